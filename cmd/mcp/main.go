@@ -3,6 +3,7 @@ package main
 import (
 	"os"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/Piszmog/pathwise/internal/db"
@@ -28,12 +29,27 @@ func main() {
 
 	dbPath := filepath.Join(dir, "db-mcp.sqlite3")
 
+	writeEnabled := false
+	if val := os.Getenv("MCP_WRITE_ENABLED"); val != "" {
+		writeEnabled, err = strconv.ParseBool(val)
+		if err != nil {
+			l.Error("failed to parse MCP_WRITE_ENABLED", "error", err)
+			return
+		}
+	}
+
+	// Writes are delegated to the primary, so a token with write access is required.
+	token := os.Getenv("DB_TOKEN_READONLY")
+	if writeEnabled {
+		token = os.Getenv("DB_TOKEN")
+	}
+
 	database, err := db.New(
 		l,
 		db.DatabaseOpts{
 			URL:           dbPath,
 			SyncURL:       os.Getenv("DB_PRIMARY_URL"),
-			Token:         os.Getenv("DB_TOKEN_READONLY"),
+			Token:         token,
 			EncryptionKey: os.Getenv("ENC_KEY"),
 			SyncInterval:  12 * time.Hour,
 		},
@@ -48,6 +64,13 @@ func main() {
 		}
 	}()
 
+	if writeEnabled {
+		if _, err = database.DB().Exec("PRAGMA foreign_keys = ON;"); err != nil {
+			l.Error("failed to enable foreign keys", "error", err)
+			return
+		}
+	}
+
 	v := os.Getenv("VERSION")
 	if v != "" {
 		version.Value = v
@@ -60,14 +83,27 @@ func main() {
 
 	toolHandlers := tool.Handler{Logger: l, Database: database}
 
+	opts := []server.Option{
+		server.AddTool(toolHandlers.NewJobApplicationsTool()),
+		server.AddTool(toolHandlers.NewJobApplicationsStatusHistoryTool()),
+		server.AddTool(toolHandlers.NewJobApplicationsNotesTool()),
+		server.AddTool(toolHandlers.NewContactsTool()),
+	}
+	if writeEnabled {
+		opts = append(
+			opts,
+			server.AddTool(toolHandlers.NewCreateJobApplicationTool()),
+			server.AddTool(toolHandlers.NewUpdateJobApplicationTool()),
+			server.AddTool(toolHandlers.NewAddJobApplicationNoteTool()),
+		)
+	}
+
 	srv := server.New(
 		"Pathwise MCP Server",
 		":"+port,
 		l,
 		database,
-		server.AddTool(toolHandlers.NewJobApplicationsTool()),
-		server.AddTool(toolHandlers.NewJobApplicationsStatusHistoryTool()),
-		server.AddTool(toolHandlers.NewJobApplicationsNotesTool()),
+		opts...,
 	)
 
 	if err = srv.Start(); err != nil {
@@ -1,17 +1,35 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"net/netip"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata"
 
+	"github.com/Piszmog/pathwise/internal/auth"
 	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/jobapplication"
 	"github.com/Piszmog/pathwise/internal/logger"
+	"github.com/Piszmog/pathwise/internal/mail"
 	"github.com/Piszmog/pathwise/internal/search"
 	"github.com/Piszmog/pathwise/internal/server"
+	"github.com/Piszmog/pathwise/internal/storage"
 	"github.com/Piszmog/pathwise/internal/ui/server/router"
+	"github.com/Piszmog/pathwise/internal/ui/utils"
 	"github.com/Piszmog/pathwise/internal/version"
 )
 
+const (
+	attachmentPurgeInterval = 5 * time.Minute
+	signinPurgeInterval     = time.Hour
+)
+
 func main() {
 	l := logger.New(os.Getenv("LOG_LEVEL"), os.Getenv("LOG_OUTPUT"))
 
@@ -39,6 +57,23 @@ func main() {
 		return
 	}
 
+	// Rebuilding the stats of every user is a maintenance step for stats that drifted from the job applications.
+	if val := os.Getenv("RECALCULATE_STATS"); val != "" {
+		recalculate, parseErr := strconv.ParseBool(val)
+		if parseErr != nil {
+			l.Error("failed to parse RECALCULATE_STATS", "error", parseErr)
+			return
+		}
+		if recalculate {
+			users, recalculateErr := jobapplication.RecalculateAllStats(context.Background(), database)
+			if recalculateErr != nil {
+				l.Error("failed to recalculate stats", "users", users, "error", recalculateErr)
+				return
+			}
+			l.Info("recalculated stats", "users", users)
+		}
+	}
+
 	v := os.Getenv("VERSION")
 	if v != "" {
 		version.Value = v
@@ -48,12 +83,218 @@ func main() {
 	if searchURL == "" {
 		searchURL = "http://localhost:8081"
 	}
-	r := router.New(l, database, search.NewClient(l, &http.Client{}, searchURL))
+
+	storageDir := os.Getenv("STORAGE_DIR")
+	if storageDir == "" {
+		storageDir = "./attachments"
+	}
+	store, err := storage.New(&http.Client{}, storage.Opts{
+		Backend: storage.Backend(os.Getenv("STORAGE_BACKEND")),
+		Dir:     storageDir,
+		S3: storage.S3Opts{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			Region:          os.Getenv("S3_REGION"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+		},
+	})
+	if err != nil {
+		l.Error("failed to create storage", "error", err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go purgeAttachments(ctx, l, database, store)
+
+	// A fixed time makes two-factor codes predictable for end-to-end tests, and freezes sign in
+	// lockouts and the expiry of reset and verification links, so it is never honored in production.
+	clock := time.Now
+	if val := os.Getenv("FIXED_TIME"); val != "" && !utils.IsProduction() {
+		fixed, parseErr := time.Parse(time.RFC3339, val)
+		if parseErr != nil {
+			l.Error("failed to parse FIXED_TIME", "error", parseErr)
+			return
+		}
+		l.Warn("using a fixed time", "time", fixed)
+		clock = func() time.Time { return fixed }
+	}
 
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
 	}
 
+	rpID := os.Getenv("WEBAUTHN_RP_ID")
+	if rpID == "" {
+		rpID = "localhost"
+	}
+	rpOrigins := []string{"http://localhost:" + port}
+	if val := os.Getenv("WEBAUTHN_RP_ORIGINS"); val != "" {
+		rpOrigins = strings.Split(val, ",")
+	}
+	wa, err := auth.NewWebAuthn(rpID, rpOrigins)
+	if err != nil {
+		l.Error("failed to create webauthn", "error", err)
+		return
+	}
+
+	appURL := os.Getenv("APP_URL")
+	if appURL == "" {
+		appURL = "http://localhost:" + port
+	}
+
+	smtpPort := 0
+	if val := os.Getenv("SMTP_PORT"); val != "" {
+		smtpPort, err = strconv.Atoi(val)
+		if err != nil {
+			l.Error("failed to parse SMTP_PORT", "error", err)
+			return
+		}
+	}
+	mailFrom := os.Getenv("MAIL_FROM")
+	if mailFrom == "" {
+		mailFrom = "Pathwise <noreply@localhost>"
+	}
+	mailDir := os.Getenv("MAIL_DIR")
+	if mailDir == "" {
+		mailDir = "./mail"
+	}
+	mailer, err := mail.New(l, mail.Opts{
+		Backend:    mail.Backend(os.Getenv("MAIL_BACKEND")),
+		From:       mailFrom,
+		Dir:        mailDir,
+		Production: utils.IsProduction(),
+		SMTP: mail.SMTPOpts{
+			Host:     os.Getenv("SMTP_HOST"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			Port:     smtpPort,
+		},
+	})
+	if err != nil {
+		l.Error("failed to create mailer", "error", err)
+		return
+	}
+
+	proxies, err := trustedProxies()
+	if err != nil {
+		l.Error("failed to parse TRUSTED_PROXIES", "error", err)
+		return
+	}
+
+	lockout, err := lockoutPolicy()
+	if err != nil {
+		l.Error("failed to parse sign in lockout policy", "error", err)
+		return
+	}
+	go purgeSignins(ctx, l, database, lockout)
+
+	r := router.New(l, database, search.NewClient(l, &http.Client{}, searchURL), store, wa, mailer, appURL, proxies, lockout, clock)
+
 	server.New(l, ":"+port, server.WithHandler(r)).StartAndWait()
 }
+
+// purgeAttachments periodically deletes the attachments of deleted job applications once their
+// deletion can no longer be undone.
+func purgeAttachments(ctx context.Context, l *slog.Logger, database db.Database, store storage.Storage) {
+	ticker := time.NewTicker(attachmentPurgeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			purged, err := jobapplication.PurgeDetachedAttachments(ctx, database.Queries(), store, now)
+			if err != nil {
+				l.ErrorContext(ctx, "failed to purge attachments", "purged", purged, "error", err)
+				continue
+			}
+			if purged > 0 {
+				l.InfoContext(ctx, "purged attachments", "purged", purged)
+			}
+		}
+	}
+}
+
+// purgeSignins periodically deletes old sign in audit entries, and the throttles of accounts and
+// addresses that no longer count towards a lockout.
+func purgeSignins(ctx context.Context, l *slog.Logger, database db.Database, policy auth.LockoutPolicy) {
+	ticker := time.NewTicker(signinPurgeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			purged, err := auth.PurgeSignins(ctx, database.Queries(), policy, now)
+			if err != nil {
+				l.ErrorContext(ctx, "failed to purge sign ins", "purged", purged, "error", err)
+				continue
+			}
+			if purged > 0 {
+				l.InfoContext(ctx, "purged sign ins", "purged", purged)
+			}
+		}
+	}
+}
+
+// trustedProxies reads the addresses and CIDR ranges of the reverse proxies in front of the app from
+// the environment.
+func trustedProxies() ([]netip.Prefix, error) {
+	val := os.Getenv("TRUSTED_PROXIES")
+	if val == "" {
+		return nil, nil
+	}
+	var proxies []netip.Prefix
+	for entry := range strings.SplitSeq(val, ",") {
+		entry = strings.TrimSpace(entry)
+		if strings.Contains(entry, "/") {
+			prefix, err := netip.ParsePrefix(entry)
+			if err != nil {
+				return nil, err
+			}
+			proxies = append(proxies, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(entry)
+		if err != nil {
+			return nil, err
+		}
+		proxies = append(proxies, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return proxies, nil
+}
+
+// lockoutPolicy reads the sign in lockout policy from the environment, keeping the default of
+// anything that is not set.
+func lockoutPolicy() (auth.LockoutPolicy, error) {
+	policy := auth.DefaultLockoutPolicy()
+	for name, value := range map[string]*int{
+		"SIGNIN_MAX_ACCOUNT_FAILURES": &policy.MaxAccountFailures,
+		"SIGNIN_MAX_IP_FAILURES":      &policy.MaxIPFailures,
+	} {
+		if val := os.Getenv(name); val != "" {
+			parsed, err := strconv.Atoi(val)
+			if err != nil {
+				return policy, fmt.Errorf("failed to parse %s: %w", name, err)
+			}
+			*value = parsed
+		}
+	}
+	for name, value := range map[string]*time.Duration{
+		"SIGNIN_FAILURE_WINDOW": &policy.FailureWindow,
+		"SIGNIN_LOCKOUT":        &policy.Lockout,
+		"SIGNIN_MAX_LOCKOUT":    &policy.MaxLockout,
+	} {
+		if val := os.Getenv(name); val != "" {
+			parsed, err := time.ParseDuration(val)
+			if err != nil {
+				return policy, fmt.Errorf("failed to parse %s: %w", name, err)
+			}
+			*value = parsed
+		}
+	}
+	return policy, nil
+}
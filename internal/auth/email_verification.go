@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/mail"
+	"github.com/google/uuid"
+)
+
+const (
+	// EmailVerificationDuration is how long the link of a verification email can be used.
+	EmailVerificationDuration = 24 * time.Hour
+	// EmailVerificationInterval is how long a user waits before another verification email is sent.
+	EmailVerificationInterval = time.Minute
+)
+
+var (
+	ErrEmailAlreadyVerified      = errors.New("email already verified")
+	ErrEmailVerificationTooSoon  = errors.New("email verification sent too soon")
+	ErrEmailVerificationNotFound = errors.New("email verification token not found")
+)
+
+// IsEmailVerified reports whether the user has confirmed they own the address of their account.
+func IsEmailVerified(ctx context.Context, q *queries.Queries, userID int64) (bool, error) {
+	verified, err := q.IsUserEmailVerified(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get email verification: %w", err)
+	}
+	return verified, nil
+}
+
+// SendEmailVerification emails the user a link that verifies the address. Earlier links keep working
+// until they expire, but a new one is only sent once every EmailVerificationInterval.
+func SendEmailVerification(ctx context.Context, q *queries.Queries, mailer mail.Mailer, appURL string, userID int64, now time.Time) error {
+	user, err := q.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	verified, err := IsEmailVerified(ctx, q, userID)
+	if err != nil {
+		return err
+	}
+	if verified {
+		return ErrEmailAlreadyVerified
+	}
+	sent, err := q.CountEmailVerificationTokensSince(ctx, queries.CountEmailVerificationTokensSinceParams{
+		UserID: userID,
+		Since:  now.Add(-EmailVerificationInterval).UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to count email verification tokens: %w", err)
+	}
+	if sent > 0 {
+		return ErrEmailVerificationTooSoon
+	}
+	if err = q.DeleteExpiredEmailVerificationTokens(ctx, now.UTC()); err != nil {
+		return fmt.Errorf("failed to delete expired email verification tokens: %w", err)
+	}
+
+	token := uuid.NewString()
+	if err = q.InsertEmailVerificationToken(ctx, queries.InsertEmailVerificationTokenParams{
+		UserID:    userID,
+		TokenHash: hashCode(token),
+		Now:       now.UTC(),
+		ExpiresAt: now.Add(EmailVerificationDuration).UTC(),
+	}); err != nil {
+		return fmt.Errorf("failed to insert email verification token: %w", err)
+	}
+
+	link, err := tokenLink(appURL, "verify-email", token)
+	if err != nil {
+		return fmt.Errorf("failed to build email verification link: %w", err)
+	}
+	return mailer.Send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "Verify your Pathwise email",
+		Body: "Welcome to Pathwise! Confirm this is your email address with the link below.\n\n" +
+			link + "\n\n" +
+			"The link expires in 24 hours. If you did not create an account, you can ignore this email.\n",
+	})
+}
+
+// VerifyEmail marks the address of the user of the token as verified. Every link sent to the user
+// stops working.
+func VerifyEmail(ctx context.Context, database db.Database, token string, now time.Time) (userID int64, err error) {
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	qtx := queries.New(tx)
+	userID, err = qtx.UseEmailVerificationToken(ctx, queries.UseEmailVerificationTokenParams{TokenHash: hashCode(token), Now: now.UTC()})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrEmailVerificationNotFound
+		}
+		return 0, fmt.Errorf("failed to use email verification token: %w", err)
+	}
+	if err = qtx.DeleteEmailVerificationTokensByUserID(ctx, userID); err != nil {
+		return 0, fmt.Errorf("failed to delete email verification tokens: %w", err)
+	}
+	if err = qtx.VerifyUserEmail(ctx, queries.VerifyUserEmailParams{Now: now.UTC(), ID: userID}); err != nil {
+		return 0, fmt.Errorf("failed to verify email: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return userID, nil
+}
@@ -0,0 +1,58 @@
+//go:build integration
+
+package auth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Piszmog/pathwise/internal/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyEmail(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+	createTestUser(t, database, 1)
+	createTestUser(t, database, 2)
+	user, err := database.Queries().GetUserByID(ctx, 1)
+	require.NoError(t, err)
+	mailer := &recordingMailer{}
+
+	verified, err := auth.IsEmailVerified(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	assert.False(t, verified)
+
+	require.NoError(t, auth.SendEmailVerification(ctx, database.Queries(), mailer, "https://example.com/app", 1, now))
+	require.Len(t, mailer.messages, 1)
+	assert.Equal(t, user.Email, mailer.messages[0].To)
+	first := linkToken(t, mailer.messages[0], "/app/verify-email")
+
+	// Another email is only sent once the interval has passed.
+	err = auth.SendEmailVerification(ctx, database.Queries(), mailer, "https://example.com/app", 1, now.Add(auth.EmailVerificationInterval/2))
+	require.ErrorIs(t, err, auth.ErrEmailVerificationTooSoon)
+	require.NoError(t, auth.SendEmailVerification(ctx, database.Queries(), mailer, "https://example.com/app", 1, now.Add(auth.EmailVerificationInterval)))
+	require.Len(t, mailer.messages, 2)
+	second := linkToken(t, mailer.messages[1], "/app/verify-email")
+
+	// Links expire, and verifying one user does not verify another.
+	_, err = auth.VerifyEmail(ctx, database, first, now.Add(auth.EmailVerificationDuration))
+	require.ErrorIs(t, err, auth.ErrEmailVerificationNotFound)
+	userID, err := auth.VerifyEmail(ctx, database, first, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), userID)
+	verified, err = auth.IsEmailVerified(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	assert.True(t, verified)
+	verified, err = auth.IsEmailVerified(ctx, database.Queries(), 2)
+	require.NoError(t, err)
+	assert.False(t, verified)
+
+	// Once verified, every link stops working and no more emails are sent.
+	_, err = auth.VerifyEmail(ctx, database, second, now)
+	require.ErrorIs(t, err, auth.ErrEmailVerificationNotFound)
+	err = auth.SendEmailVerification(ctx, database.Queries(), mailer, "https://example.com/app", 1, now.Add(auth.EmailVerificationDuration))
+	require.ErrorIs(t, err, auth.ErrEmailAlreadyVerified)
+	assert.Len(t, mailer.messages, 2)
+}
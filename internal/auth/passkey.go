@@ -0,0 +1,286 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
+)
+
+// PasskeyCeremonyDuration is how long a user has to answer the prompt of their authenticator when
+// adding a passkey or signing in with one.
+const PasskeyCeremonyDuration = 5 * time.Minute
+
+const (
+	maxPasskeyNameLength = 50
+	passkeyDisplayName   = "Pathwise"
+)
+
+var (
+	ErrPasskeyNotFound         = errors.New("passkey not found")
+	ErrInvalidPasskeyName      = errors.New("passkey name must be between 1 and 50 characters")
+	ErrPasskeyCeremonyNotFound = errors.New("passkey ceremony not found")
+	ErrPasskeyInvalid          = errors.New("passkey could not be verified")
+)
+
+// NewWebAuthn creates the relying party that passkeys are registered with. The ID is the domain
+// the app is served from and the origins are the full URLs the browser reports.
+func NewWebAuthn(rpID string, origins []string) (*webauthn.WebAuthn, error) {
+	return webauthn.New(&webauthn.Config{
+		RPID:          rpID,
+		RPDisplayName: passkeyDisplayName,
+		RPOrigins:     origins,
+	})
+}
+
+// passkeyUser is the user as seen by the authenticator. The handle is the ID of the user, so a
+// passkey found by the browser leads back to them without asking for an email.
+type passkeyUser struct {
+	email       string
+	credentials []webauthn.Credential
+	id          int64
+}
+
+func (u passkeyUser) WebAuthnID() []byte {
+	return userHandle(u.id)
+}
+
+func (u passkeyUser) WebAuthnName() string {
+	return u.email
+}
+
+func (u passkeyUser) WebAuthnDisplayName() string {
+	return u.email
+}
+
+func (u passkeyUser) WebAuthnCredentials() []webauthn.Credential {
+	return u.credentials
+}
+
+// GetPasskeys returns the passkeys of the user in the order they were added.
+func GetPasskeys(ctx context.Context, q *queries.Queries, userID int64) ([]types.Passkey, error) {
+	rows, err := q.GetPasskeysByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get passkeys: %w", err)
+	}
+	passkeys := make([]types.Passkey, len(rows))
+	for i, row := range rows {
+		passkeys[i] = types.Passkey{CreatedAt: row.CreatedAt, LastUsedAt: row.LastUsedAt.Time, Name: row.Name, ID: row.ID}
+	}
+	return passkeys, nil
+}
+
+// BeginPasskeyRegistration returns the options for the browser to create a passkey for the user.
+// The returned token identifies the ceremony until FinishPasskeyRegistration is called.
+func BeginPasskeyRegistration(ctx context.Context, q *queries.Queries, wa *webauthn.WebAuthn, userID int64, email string, now time.Time) (*protocol.CredentialCreation, string, error) {
+	user, err := getPasskeyUser(ctx, q, userID, email)
+	if err != nil {
+		return nil, "", err
+	}
+	creation, session, err := wa.BeginRegistration(
+		user,
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementRequired),
+		webauthn.WithExclusions(webauthn.Credentials(user.credentials).CredentialDescriptors()),
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to begin passkey registration: %w", err)
+	}
+	token, err := saveCeremony(ctx, q, sql.NullInt64{Int64: userID, Valid: true}, session, now)
+	if err != nil {
+		return nil, "", err
+	}
+	return creation, token, nil
+}
+
+// FinishPasskeyRegistration verifies the passkey the browser created and saves it under the name.
+func FinishPasskeyRegistration(ctx context.Context, q *queries.Queries, wa *webauthn.WebAuthn, userID int64, email string, token string, name string, body io.Reader, now time.Time) (types.Passkey, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" || utf8.RuneCountInString(name) > maxPasskeyNameLength {
+		return types.Passkey{}, ErrInvalidPasskeyName
+	}
+
+	ceremonyUserID, session, err := takeCeremony(ctx, q, token, now)
+	if err != nil {
+		return types.Passkey{}, err
+	}
+	if !ceremonyUserID.Valid || ceremonyUserID.Int64 != userID {
+		return types.Passkey{}, ErrPasskeyCeremonyNotFound
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBody(body)
+	if err != nil {
+		return types.Passkey{}, fmt.Errorf("%w: %w", ErrPasskeyInvalid, err)
+	}
+	user, err := getPasskeyUser(ctx, q, userID, email)
+	if err != nil {
+		return types.Passkey{}, err
+	}
+	credential, err := wa.CreateCredential(user, session, parsed)
+	if err != nil {
+		return types.Passkey{}, fmt.Errorf("%w: %w", ErrPasskeyInvalid, err)
+	}
+
+	b, err := json.Marshal(credential)
+	if err != nil {
+		return types.Passkey{}, fmt.Errorf("failed to marshal passkey: %w", err)
+	}
+	row, err := q.InsertPasskey(ctx, queries.InsertPasskeyParams{
+		UserID:       userID,
+		Name:         name,
+		CredentialID: credential.ID,
+		Credential:   string(b),
+		CreatedAt:    now.UTC(),
+	})
+	if err != nil {
+		return types.Passkey{}, fmt.Errorf("failed to insert passkey: %w", err)
+	}
+	return types.Passkey{CreatedAt: row.CreatedAt, LastUsedAt: row.LastUsedAt.Time, Name: row.Name, ID: row.ID}, nil
+}
+
+// BeginPasskeyLogin returns the options for the browser to sign in with any passkey it has for the
+// app. The returned token identifies the ceremony until FinishPasskeyLogin is called.
+func BeginPasskeyLogin(ctx context.Context, q *queries.Queries, wa *webauthn.WebAuthn, now time.Time) (*protocol.CredentialAssertion, string, error) {
+	assertion, session, err := wa.BeginDiscoverableLogin(webauthn.WithUserVerification(protocol.VerificationRequired))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to begin passkey login: %w", err)
+	}
+	token, err := saveCeremony(ctx, q, sql.NullInt64{}, session, now)
+	if err != nil {
+		return nil, "", err
+	}
+	return assertion, token, nil
+}
+
+// FinishPasskeyLogin verifies the passkey the browser signed in with and returns its user. The
+// authenticator already verified the user, so no two-factor code is asked for.
+func FinishPasskeyLogin(ctx context.Context, q *queries.Queries, wa *webauthn.WebAuthn, token string, body io.Reader, now time.Time) (int64, error) {
+	ceremonyUserID, session, err := takeCeremony(ctx, q, token, now)
+	if err != nil {
+		return 0, err
+	}
+	if ceremonyUserID.Valid {
+		return 0, ErrPasskeyCeremonyNotFound
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBody(body)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrPasskeyInvalid, err)
+	}
+	var lookupErr error
+	user, credential, err := wa.ValidatePasskeyLogin(func(_, handle []byte) (webauthn.User, error) {
+		if len(handle) != 8 {
+			return nil, ErrPasskeyNotFound
+		}
+		user, userErr := getPasskeyUser(ctx, q, int64(binary.BigEndian.Uint64(handle)), "")
+		if userErr != nil {
+			lookupErr = userErr
+			return nil, userErr
+		}
+		return user, nil
+	}, session, parsed)
+	if lookupErr != nil {
+		return 0, lookupErr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrPasskeyInvalid, err)
+	}
+	if credential.Authenticator.CloneWarning {
+		return 0, fmt.Errorf("%w: signature counter went backwards", ErrPasskeyInvalid)
+	}
+
+	userID := user.(passkeyUser).id
+	b, err := json.Marshal(credential)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal passkey: %w", err)
+	}
+	if err = q.UpdatePasskeyCredential(ctx, queries.UpdatePasskeyCredentialParams{
+		Credential:   string(b),
+		LastUsedAt:   now.UTC(),
+		CredentialID: credential.ID,
+		UserID:       userID,
+	}); err != nil {
+		return 0, fmt.Errorf("failed to update passkey: %w", err)
+	}
+	return userID, nil
+}
+
+// DeletePasskey removes a passkey of the user, so it can no longer be used to sign in.
+func DeletePasskey(ctx context.Context, q *queries.Queries, userID int64, id int64) error {
+	deleted, err := q.DeletePasskey(ctx, queries.DeletePasskeyParams{ID: id, UserID: userID})
+	if err != nil {
+		return fmt.Errorf("failed to delete passkey: %w", err)
+	}
+	if deleted == 0 {
+		return ErrPasskeyNotFound
+	}
+	return nil
+}
+
+// getPasskeyUser loads the stored passkeys of the user, so new ones can exclude them and signing in
+// can check against them.
+func getPasskeyUser(ctx context.Context, q *queries.Queries, userID int64, email string) (passkeyUser, error) {
+	rows, err := q.GetPasskeysByUserID(ctx, userID)
+	if err != nil {
+		return passkeyUser{}, fmt.Errorf("failed to get passkeys: %w", err)
+	}
+	user := passkeyUser{email: email, credentials: make([]webauthn.Credential, len(rows)), id: userID}
+	for i, row := range rows {
+		if err = json.Unmarshal([]byte(row.Credential), &user.credentials[i]); err != nil {
+			return passkeyUser{}, fmt.Errorf("failed to unmarshal passkey: %w", err)
+		}
+	}
+	return user, nil
+}
+
+func saveCeremony(ctx context.Context, q *queries.Queries, userID sql.NullInt64, session *webauthn.SessionData, now time.Time) (string, error) {
+	if err := q.DeleteExpiredPasskeySessions(ctx, now.UTC()); err != nil {
+		return "", fmt.Errorf("failed to delete expired passkey sessions: %w", err)
+	}
+	b, err := json.Marshal(session)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal passkey session: %w", err)
+	}
+	token := uuid.NewString()
+	if err = q.InsertPasskeySession(ctx, queries.InsertPasskeySessionParams{
+		UserID:      userID,
+		TokenHash:   hashCode(token),
+		SessionData: string(b),
+		ExpiresAt:   now.Add(PasskeyCeremonyDuration).UTC(),
+	}); err != nil {
+		return "", fmt.Errorf("failed to insert passkey session: %w", err)
+	}
+	return token, nil
+}
+
+// takeCeremony deletes the ceremony of the token and returns it, so each one can only be finished
+// once, whether or not the passkey is accepted.
+func takeCeremony(ctx context.Context, q *queries.Queries, token string, now time.Time) (sql.NullInt64, webauthn.SessionData, error) {
+	row, err := q.DeletePasskeySessionByHash(ctx, queries.DeletePasskeySessionByHashParams{TokenHash: hashCode(token), Now: now.UTC()})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sql.NullInt64{}, webauthn.SessionData{}, ErrPasskeyCeremonyNotFound
+		}
+		return sql.NullInt64{}, webauthn.SessionData{}, fmt.Errorf("failed to delete passkey session: %w", err)
+	}
+	var session webauthn.SessionData
+	if err = json.Unmarshal([]byte(row.SessionData), &session); err != nil {
+		return sql.NullInt64{}, webauthn.SessionData{}, fmt.Errorf("failed to unmarshal passkey session: %w", err)
+	}
+	return row.UserID, session, nil
+}
+
+func userHandle(userID int64) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(userID))
+}
@@ -0,0 +1,256 @@
+//go:build integration
+
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/Piszmog/pathwise/internal/auth"
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRPID   = "localhost"
+	testOrigin = "http://localhost:8080"
+)
+
+// softwareAuthenticator answers passkey ceremonies the way a platform authenticator would, with a
+// single P-256 key and no attestation.
+type softwareAuthenticator struct {
+	key        *ecdsa.PrivateKey
+	credID     []byte
+	userHandle []byte
+	counter    uint32
+}
+
+func newSoftwareAuthenticator(t *testing.T) *softwareAuthenticator {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	credID := make([]byte, 16)
+	_, err = rand.Read(credID)
+	require.NoError(t, err)
+	return &softwareAuthenticator{key: key, credID: credID}
+}
+
+func setupWebAuthn(t *testing.T) *webauthn.WebAuthn {
+	t.Helper()
+
+	wa, err := auth.NewWebAuthn(testRPID, []string{testOrigin})
+	require.NoError(t, err)
+	return wa
+}
+
+func (a *softwareAuthenticator) clientData(t *testing.T, ceremony string, challenge []byte) []byte {
+	t.Helper()
+
+	b, err := json.Marshal(map[string]string{
+		"type":      ceremony,
+		"challenge": base64.RawURLEncoding.EncodeToString(challenge),
+		"origin":    testOrigin,
+	})
+	require.NoError(t, err)
+	return b
+}
+
+func (a *softwareAuthenticator) authData(flags byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(testRPID))
+	data := append(rpIDHash[:], flags)
+	return binary.BigEndian.AppendUint32(data, a.counter)
+}
+
+// create answers a registration with a new credential for the user of the options.
+func (a *softwareAuthenticator) create(t *testing.T, creation *protocol.CredentialCreation) *bytes.Reader {
+	t.Helper()
+
+	a.userHandle = creation.Response.User.ID.(protocol.URLEncodedBase64)
+	pub, err := a.key.PublicKey.ECDH()
+	require.NoError(t, err)
+	point := pub.Bytes()
+	coseKey, err := webauthncbor.Marshal(webauthncose.EC2PublicKeyData{
+		PublicKeyData: webauthncose.PublicKeyData{
+			KeyType:   int64(webauthncose.EllipticKey),
+			Algorithm: int64(webauthncose.AlgES256),
+		},
+		Curve:  int64(webauthncose.P256),
+		XCoord: point[1:33],
+		YCoord: point[33:],
+	})
+	require.NoError(t, err)
+
+	// User present, user verified and attested credential data included.
+	authData := a.authData(0x45)
+	authData = append(authData, make([]byte, 16)...)
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(a.credID)))
+	authData = append(authData, a.credID...)
+	authData = append(authData, coseKey...)
+	attestation, err := webauthncbor.Marshal(map[string]any{"fmt": "none", "attStmt": map[string]any{}, "authData": authData})
+	require.NoError(t, err)
+
+	return a.response(t, map[string]string{
+		"clientDataJSON":    base64.RawURLEncoding.EncodeToString(a.clientData(t, "webauthn.create", creation.Response.Challenge)),
+		"attestationObject": base64.RawURLEncoding.EncodeToString(attestation),
+	})
+}
+
+// get answers a sign in by signing the challenge with the credential.
+func (a *softwareAuthenticator) get(t *testing.T, assertion *protocol.CredentialAssertion) *bytes.Reader {
+	t.Helper()
+
+	// User present and user verified.
+	authData := a.authData(0x05)
+	clientData := a.clientData(t, "webauthn.get", assertion.Response.Challenge)
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(authData, clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	require.NoError(t, err)
+
+	return a.response(t, map[string]string{
+		"clientDataJSON":    base64.RawURLEncoding.EncodeToString(clientData),
+		"authenticatorData": base64.RawURLEncoding.EncodeToString(authData),
+		"signature":         base64.RawURLEncoding.EncodeToString(signature),
+		"userHandle":        base64.RawURLEncoding.EncodeToString(a.userHandle),
+	})
+}
+
+func (a *softwareAuthenticator) response(t *testing.T, response map[string]string) *bytes.Reader {
+	t.Helper()
+
+	id := base64.RawURLEncoding.EncodeToString(a.credID)
+	b, err := json.Marshal(map[string]any{"id": id, "rawId": id, "type": "public-key", "response": response})
+	require.NoError(t, err)
+	return bytes.NewReader(b)
+}
+
+// register adds a passkey of the authenticator to the user.
+func register(t *testing.T, database db.Database, wa *webauthn.WebAuthn, a *softwareAuthenticator, userID int64) {
+	t.Helper()
+
+	creation, token, err := auth.BeginPasskeyRegistration(context.Background(), database.Queries(), wa, userID, "user@example.com", now)
+	require.NoError(t, err)
+	_, err = auth.FinishPasskeyRegistration(context.Background(), database.Queries(), wa, userID, "user@example.com", token, "Laptop", a.create(t, creation), now)
+	require.NoError(t, err)
+}
+
+// signin signs in with the passkey of the authenticator and returns the user it belongs to.
+func signin(t *testing.T, database db.Database, wa *webauthn.WebAuthn, a *softwareAuthenticator) (int64, error) {
+	t.Helper()
+
+	assertion, token, err := auth.BeginPasskeyLogin(context.Background(), database.Queries(), wa, now)
+	require.NoError(t, err)
+	return auth.FinishPasskeyLogin(context.Background(), database.Queries(), wa, token, a.get(t, assertion), now)
+}
+
+func TestPasskeyRegistration(t *testing.T) {
+	database := setupTestDB(t)
+	wa := setupWebAuthn(t)
+	ctx := context.Background()
+	createTestUser(t, database, 1)
+	createTestUser(t, database, 2)
+	a := newSoftwareAuthenticator(t)
+
+	creation, token, err := auth.BeginPasskeyRegistration(ctx, database.Queries(), wa, 1, "user@example.com", now)
+	require.NoError(t, err)
+	assert.Equal(t, protocol.ResidentKeyRequirementRequired, creation.Response.AuthenticatorSelection.ResidentKey)
+	assert.Empty(t, creation.Response.CredentialExcludeList)
+	body := a.create(t, creation)
+
+	// A name is required, and the ceremony belongs to the user that started it.
+	_, err = auth.FinishPasskeyRegistration(ctx, database.Queries(), wa, 1, "user@example.com", token, " ", body, now)
+	require.ErrorIs(t, err, auth.ErrInvalidPasskeyName)
+	_, err = auth.FinishPasskeyRegistration(ctx, database.Queries(), wa, 2, "user@example.com", token, "Laptop", body, now)
+	require.ErrorIs(t, err, auth.ErrPasskeyCeremonyNotFound)
+
+	creation, token, err = auth.BeginPasskeyRegistration(ctx, database.Queries(), wa, 1, "user@example.com", now)
+	require.NoError(t, err)
+	passkey, err := auth.FinishPasskeyRegistration(ctx, database.Queries(), wa, 1, "user@example.com", token, "  Work   laptop ", a.create(t, creation), now)
+	require.NoError(t, err)
+	assert.Equal(t, "Work laptop", passkey.Name)
+	assert.Equal(t, now, passkey.CreatedAt.UTC())
+	assert.True(t, passkey.LastUsedAt.IsZero())
+
+	// Each ceremony can only be finished once and only before it expires.
+	_, err = auth.FinishPasskeyRegistration(ctx, database.Queries(), wa, 1, "user@example.com", token, "Laptop", a.create(t, creation), now)
+	require.ErrorIs(t, err, auth.ErrPasskeyCeremonyNotFound)
+	creation, token, err = auth.BeginPasskeyRegistration(ctx, database.Queries(), wa, 1, "user@example.com", now)
+	require.NoError(t, err)
+	require.Len(t, creation.Response.CredentialExcludeList, 1)
+	assert.Equal(t, a.credID, []byte(creation.Response.CredentialExcludeList[0].CredentialID))
+	_, err = auth.FinishPasskeyRegistration(ctx, database.Queries(), wa, 1, "user@example.com", token, "Phone", newSoftwareAuthenticator(t).create(t, creation), now.Add(auth.PasskeyCeremonyDuration))
+	require.ErrorIs(t, err, auth.ErrPasskeyCeremonyNotFound)
+
+	// An answer to a different challenge is not accepted.
+	_, token, err = auth.BeginPasskeyRegistration(ctx, database.Queries(), wa, 1, "user@example.com", now)
+	require.NoError(t, err)
+	_, err = auth.FinishPasskeyRegistration(ctx, database.Queries(), wa, 1, "user@example.com", token, "Phone", newSoftwareAuthenticator(t).create(t, creation), now)
+	require.ErrorIs(t, err, auth.ErrPasskeyInvalid)
+
+	passkeys, err := auth.GetPasskeys(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	require.Len(t, passkeys, 1)
+	assert.Equal(t, passkey.ID, passkeys[0].ID)
+}
+
+func TestPasskeyLogin(t *testing.T) {
+	database := setupTestDB(t)
+	wa := setupWebAuthn(t)
+	ctx := context.Background()
+	createTestUser(t, database, 1)
+	createTestUser(t, database, 2)
+	a := newSoftwareAuthenticator(t)
+	register(t, database, wa, a, 1)
+	register(t, database, wa, newSoftwareAuthenticator(t), 2)
+
+	a.counter = 5
+	userID, err := signin(t, database, wa, a)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), userID)
+	passkeys, err := auth.GetPasskeys(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, now, passkeys[0].LastUsedAt.UTC())
+
+	// A counter that does not move forward means the key may have been copied.
+	a.counter = 3
+	_, err = signin(t, database, wa, a)
+	require.ErrorIs(t, err, auth.ErrPasskeyInvalid)
+
+	// A different key claiming the passkey is not accepted.
+	other := newSoftwareAuthenticator(t)
+	other.credID = a.credID
+	other.userHandle = a.userHandle
+	other.counter = 10
+	_, err = signin(t, database, wa, other)
+	require.ErrorIs(t, err, auth.ErrPasskeyInvalid)
+
+	// A registration ceremony cannot be used to sign in.
+	_, token, err := auth.BeginPasskeyRegistration(ctx, database.Queries(), wa, 1, "user@example.com", now)
+	require.NoError(t, err)
+	assertion, _, err := auth.BeginPasskeyLogin(ctx, database.Queries(), wa, now)
+	require.NoError(t, err)
+	a.counter = 11
+	_, err = auth.FinishPasskeyLogin(ctx, database.Queries(), wa, token, a.get(t, assertion), now)
+	require.ErrorIs(t, err, auth.ErrPasskeyCeremonyNotFound)
+
+	// A deleted passkey can no longer sign in, and only its user can delete it.
+	require.ErrorIs(t, auth.DeletePasskey(ctx, database.Queries(), 2, passkeys[0].ID), auth.ErrPasskeyNotFound)
+	require.NoError(t, auth.DeletePasskey(ctx, database.Queries(), 1, passkeys[0].ID))
+	a.counter = 12
+	_, err = signin(t, database, wa, a)
+	require.ErrorIs(t, err, auth.ErrPasskeyInvalid)
+}
@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/mail"
+	"github.com/google/uuid"
+)
+
+// PasswordResetDuration is how long the link of a password reset email can be used.
+const PasswordResetDuration = time.Hour
+
+var ErrResetTokenNotFound = errors.New("password reset token not found")
+
+// RequestPasswordReset emails the user of the address a link to choose a new password. Nothing is
+// sent for an unknown address, and no error says so, so the form does not reveal who has an
+// account.
+func RequestPasswordReset(ctx context.Context, q *queries.Queries, mailer mail.Mailer, appURL string, email string, now time.Time) error {
+	user, err := q.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if err = q.DeleteExpiredPasswordResetTokens(ctx, now.UTC()); err != nil {
+		return fmt.Errorf("failed to delete expired password reset tokens: %w", err)
+	}
+
+	token := uuid.NewString()
+	if err = q.InsertPasswordResetToken(ctx, queries.InsertPasswordResetTokenParams{
+		UserID:    user.ID,
+		TokenHash: hashCode(token),
+		ExpiresAt: now.Add(PasswordResetDuration).UTC(),
+	}); err != nil {
+		return fmt.Errorf("failed to insert password reset token: %w", err)
+	}
+
+	link, err := tokenLink(appURL, "reset-password", token)
+	if err != nil {
+		return fmt.Errorf("failed to build password reset link: %w", err)
+	}
+	return mailer.Send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "Reset your Pathwise password",
+		Body: "Someone asked to reset the password of your Pathwise account. Choose a new password with the link below.\n\n" +
+			link + "\n\n" +
+			"The link works once and expires in 1 hour. If you did not ask for this, you can ignore this email.\n",
+	})
+}
+
+// HasPasswordReset reports whether the token can still be used to reset a password.
+func HasPasswordReset(ctx context.Context, q *queries.Queries, token string, now time.Time) (bool, error) {
+	_, err := q.GetPasswordResetTokenByHash(ctx, queries.GetPasswordResetTokenByHashParams{TokenHash: hashCode(token), Now: now.UTC()})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get password reset token: %w", err)
+	}
+	return true, nil
+}
+
+// ResetPassword replaces the password of the user of the token with the hashed password. The token
+// and any other link sent to the user stop working, and every session of the user is signed out.
+func ResetPassword(ctx context.Context, database db.Database, token string, hashedPassword string, now time.Time) (userID int64, err error) {
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	qtx := queries.New(tx)
+	userID, err = qtx.UsePasswordResetToken(ctx, queries.UsePasswordResetTokenParams{TokenHash: hashCode(token), Now: now.UTC()})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrResetTokenNotFound
+		}
+		return 0, fmt.Errorf("failed to use password reset token: %w", err)
+	}
+	if err = qtx.UsePasswordResetTokensByUserID(ctx, queries.UsePasswordResetTokensByUserIDParams{Now: now.UTC(), UserID: userID}); err != nil {
+		return 0, fmt.Errorf("failed to use password reset tokens: %w", err)
+	}
+	if err = qtx.UpdateUserPassword(ctx, queries.UpdateUserPasswordParams{ID: userID, Password: hashedPassword}); err != nil {
+		return 0, fmt.Errorf("failed to update password: %w", err)
+	}
+	// Following the emailed link proves the address as much as a verification link would.
+	if err = qtx.VerifyUserEmail(ctx, queries.VerifyUserEmailParams{Now: now.UTC(), ID: userID}); err != nil {
+		return 0, fmt.Errorf("failed to verify email: %w", err)
+	}
+	if err = qtx.DeleteSessionByUserID(ctx, userID); err != nil {
+		return 0, fmt.Errorf("failed to delete sessions: %w", err)
+	}
+	// A challenge means the old password was entered, so it must not outlive it.
+	if err = qtx.DeleteTwoFactorChallengesByUserID(ctx, userID); err != nil {
+		return 0, fmt.Errorf("failed to delete two-factor challenges: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return userID, nil
+}
+
+// tokenLink is the link of an email that carries the token to the page at the path of the app.
+func tokenLink(appURL string, path string, token string) (string, error) {
+	link, err := url.JoinPath(appURL, path)
+	if err != nil {
+		return "", err
+	}
+	return link + "?" + url.Values{"token": {token}}.Encode(), nil
+}
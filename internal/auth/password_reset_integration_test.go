@@ -0,0 +1,89 @@
+//go:build integration
+
+package auth_test
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/Piszmog/pathwise/internal/auth"
+	"github.com/Piszmog/pathwise/internal/mail"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingMailer keeps the emails it is asked to send.
+type recordingMailer struct {
+	messages []mail.Message
+}
+
+func (m *recordingMailer) Send(_ context.Context, msg mail.Message) error {
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+var emailLink = regexp.MustCompile(`https?://\S+`)
+
+// linkToken returns the token of the link in the email, which must lead to the path.
+func linkToken(t *testing.T, msg mail.Message, path string) string {
+	t.Helper()
+
+	u, err := url.Parse(emailLink.FindString(msg.Body))
+	require.NoError(t, err)
+	assert.Equal(t, path, u.Path)
+	return u.Query().Get("token")
+}
+
+func TestResetPassword(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+	createTestUser(t, database, 1)
+	user, err := database.Queries().GetUserByID(ctx, 1)
+	require.NoError(t, err)
+	_, err = database.DB().ExecContext(ctx, "INSERT INTO sessions (user_id, token, expires_at, user_agent, ip_address) VALUES (1, 'session', datetime('now', '+1 day'), '', '')")
+	require.NoError(t, err)
+	mailer := &recordingMailer{}
+
+	// Unknown addresses are not told apart from known ones.
+	require.NoError(t, auth.RequestPasswordReset(ctx, database.Queries(), mailer, "https://example.com/app", "unknown@example.com", now))
+	assert.Empty(t, mailer.messages)
+
+	require.NoError(t, auth.RequestPasswordReset(ctx, database.Queries(), mailer, "https://example.com/app", user.Email, now))
+	require.NoError(t, auth.RequestPasswordReset(ctx, database.Queries(), mailer, "https://example.com/app", user.Email, now))
+	require.Len(t, mailer.messages, 2)
+	assert.Equal(t, user.Email, mailer.messages[0].To)
+	first, second := linkToken(t, mailer.messages[0], "/app/reset-password"), linkToken(t, mailer.messages[1], "/app/reset-password")
+
+	ok, err := auth.HasPasswordReset(ctx, database.Queries(), first, now)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = auth.HasPasswordReset(ctx, database.Queries(), first, now.Add(auth.PasswordResetDuration))
+	require.NoError(t, err)
+	assert.False(t, ok)
+	_, err = auth.ResetPassword(ctx, database, first, "new-hash", now.Add(auth.PasswordResetDuration))
+	require.ErrorIs(t, err, auth.ErrResetTokenNotFound)
+
+	userID, err := auth.ResetPassword(ctx, database, first, "new-hash", now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), userID)
+	user, err = database.Queries().GetUserByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "new-hash", user.Password)
+	var sessions int
+	require.NoError(t, database.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM sessions WHERE user_id = 1").Scan(&sessions))
+	assert.Zero(t, sessions)
+	verified, err := auth.IsEmailVerified(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	assert.True(t, verified)
+
+	// The link works once, and the other links sent before it stop working too.
+	_, err = auth.ResetPassword(ctx, database, first, "other-hash", now)
+	require.ErrorIs(t, err, auth.ErrResetTokenNotFound)
+	_, err = auth.ResetPassword(ctx, database, second, "other-hash", now)
+	require.ErrorIs(t, err, auth.ErrResetTokenNotFound)
+	ok, err = auth.HasPasswordReset(ctx, database.Queries(), second, now)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
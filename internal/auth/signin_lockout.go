@@ -0,0 +1,251 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+)
+
+// LockoutReset is how long an account or address has to go without a failed sign in before its
+// next lockout starts again from the shortest one.
+const LockoutReset = 24 * time.Hour
+
+// SigninAuditRetention is how long the audit entries of sign ins are kept.
+const SigninAuditRetention = 90 * 24 * time.Hour
+
+// blockedAuditInterval is how often attempts turned away by a lockout are audited for the same
+// email or address, so hammering a locked account does not grow the audit log.
+const blockedAuditInterval = time.Minute
+
+var ErrSigninLocked = errors.New("too many sign in attempts")
+
+// LockoutPolicy decides how many wrong passwords are accepted before an account or an IP address
+// has to wait to sign in again.
+type LockoutPolicy struct {
+	// MaxAccountFailures is how many wrong passwords an email can take before it is locked. Zero
+	// turns the limit off.
+	MaxAccountFailures int
+	// MaxIPFailures is how many wrong passwords an IP address can send before it is locked. It is
+	// higher than the account limit since many people can share an address. Zero turns the limit
+	// off.
+	MaxIPFailures int
+	// FailureWindow is how long a wrong password counts towards the limits after the last one.
+	FailureWindow time.Duration
+	// Lockout is how long the first lockout lasts. Each lockout after it lasts twice as long as the
+	// one before, up to MaxLockout.
+	Lockout    time.Duration
+	MaxLockout time.Duration
+}
+
+// DefaultLockoutPolicy is the policy used when none is configured.
+func DefaultLockoutPolicy() LockoutPolicy {
+	return LockoutPolicy{
+		MaxAccountFailures: 5,
+		MaxIPFailures:      20,
+		FailureWindow:      15 * time.Minute,
+		Lockout:            time.Minute,
+		MaxLockout:         time.Hour,
+	}
+}
+
+// lockoutDuration is how long the nth lockout in a row lasts.
+func (p LockoutPolicy) lockoutDuration(lockouts int64) time.Duration {
+	d := p.Lockout
+	for i := int64(1); i < lockouts && d < p.MaxLockout; i++ {
+		d *= 2
+	}
+	return min(d, max(p.Lockout, p.MaxLockout))
+}
+
+// SigninEvent is what happened to a sign in, as recorded in the audit entries.
+type SigninEvent string
+
+const (
+	// SigninSucceeded is a correct password. The user may still have to pass the two-factor step.
+	SigninSucceeded SigninEvent = "succeeded"
+	SigninFailed    SigninEvent = "failed"
+	// SigninLocked is a wrong password that locked the account or the address.
+	SigninLocked SigninEvent = "locked"
+	// SigninBlocked is an attempt that was turned away without checking the password.
+	SigninBlocked SigninEvent = "blocked"
+)
+
+const (
+	throttleAccount = "account"
+	throttleIP      = "ip"
+)
+
+// SigninAttempt is who tried to sign in and from where.
+type SigninAttempt struct {
+	Email     string
+	IPAddress string
+	UserAgent string
+	// UserID is the user of the email, or zero when it is not known.
+	UserID int64
+}
+
+// accountKey is the email the failures of an account are counted under, so changing its case does
+// not start a new count.
+func (a SigninAttempt) accountKey() string {
+	return strings.ToLower(strings.TrimSpace(a.Email))
+}
+
+// CheckSigninLockout returns ErrSigninLocked when the email or the address of the attempt is locked.
+// The attempt is recorded as blocked, unless another one was recently, and the password should not
+// be checked.
+func CheckSigninLockout(ctx context.Context, q *queries.Queries, attempt SigninAttempt, now time.Time) error {
+	locks, err := q.CountSigninLocks(ctx, queries.CountSigninLocksParams{
+		Email:     attempt.accountKey(),
+		IpAddress: attempt.IPAddress,
+		Now:       now.UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to count sign in locks: %w", err)
+	}
+	if locks == 0 {
+		return nil
+	}
+
+	recent, err := q.CountRecentSigninAudits(ctx, queries.CountRecentSigninAuditsParams{
+		Event:     string(SigninBlocked),
+		Email:     attempt.accountKey(),
+		IpAddress: attempt.IPAddress,
+		Since:     now.Add(-blockedAuditInterval).UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to count sign in audits: %w", err)
+	}
+	if recent == 0 {
+		if err = recordSignin(ctx, q, SigninBlocked, attempt, now); err != nil {
+			return err
+		}
+	}
+	return ErrSigninLocked
+}
+
+// RecordSigninFailure counts a wrong password against the email and the address of the attempt. It
+// returns ErrSigninLocked when the failure locks either of them.
+func RecordSigninFailure(ctx context.Context, database db.Database, policy LockoutPolicy, attempt SigninAttempt, now time.Time) (err error) {
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	qtx := queries.New(tx)
+	accountLocked, err := throttle(ctx, qtx, policy, throttleAccount, attempt.accountKey(), policy.MaxAccountFailures, now)
+	if err != nil {
+		return err
+	}
+	ipLocked, err := throttle(ctx, qtx, policy, throttleIP, attempt.IPAddress, policy.MaxIPFailures, now)
+	if err != nil {
+		return err
+	}
+	locked := accountLocked || ipLocked
+	event := SigninFailed
+	if locked {
+		event = SigninLocked
+	}
+	if err = recordSignin(ctx, qtx, event, attempt, now); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	if locked {
+		return ErrSigninLocked
+	}
+	return nil
+}
+
+// RecordSigninSuccess clears the failures of the email of the attempt. The failures of the address
+// are kept, otherwise signing in to an account of their own would let someone keep guessing the
+// passwords of others.
+func RecordSigninSuccess(ctx context.Context, q *queries.Queries, attempt SigninAttempt, now time.Time) error {
+	if err := q.DeleteSigninThrottle(ctx, queries.DeleteSigninThrottleParams{Kind: throttleAccount, Value: attempt.accountKey()}); err != nil {
+		return fmt.Errorf("failed to delete sign in throttle: %w", err)
+	}
+	return recordSignin(ctx, q, SigninSucceeded, attempt, now)
+}
+
+// PurgeSignins deletes the audit entries older than SigninAuditRetention, and the throttles that no
+// longer count towards a lockout. It returns how many rows were deleted.
+func PurgeSignins(ctx context.Context, q *queries.Queries, policy LockoutPolicy, now time.Time) (int64, error) {
+	audits, err := q.DeleteSigninAuditsBefore(ctx, now.Add(-SigninAuditRetention).UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete sign in audits: %w", err)
+	}
+	// Once both have passed, a throttle is treated as if it never had a failure.
+	throttles, err := q.DeleteStaleSigninThrottles(ctx, queries.DeleteStaleSigninThrottlesParams{
+		Before: now.Add(-max(LockoutReset, policy.FailureWindow)).UTC(),
+		Now:    now.UTC(),
+	})
+	if err != nil {
+		return audits, fmt.Errorf("failed to delete sign in throttles: %w", err)
+	}
+	return audits + throttles, nil
+}
+
+// throttle counts a failure against the value and reports whether it locked the value.
+func throttle(ctx context.Context, q *queries.Queries, policy LockoutPolicy, kind string, value string, maxFailures int, now time.Time) (bool, error) {
+	if maxFailures <= 0 {
+		return false, nil
+	}
+
+	current, err := q.GetSigninThrottle(ctx, queries.GetSigninThrottleParams{Kind: kind, Value: value})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return false, fmt.Errorf("failed to get sign in throttle: %w", err)
+	}
+	failures, lockouts := current.Failures, current.Lockouts
+	if now.Sub(current.UpdatedAt) >= LockoutReset {
+		lockouts = 0
+	}
+	if now.Sub(current.UpdatedAt) >= policy.FailureWindow {
+		failures = 0
+	}
+
+	failures++
+	var lockedUntil any
+	locked := failures >= int64(maxFailures)
+	if locked {
+		lockouts++
+		failures = 0
+		lockedUntil = now.Add(policy.lockoutDuration(lockouts)).UTC()
+	}
+	if err = q.UpsertSigninThrottle(ctx, queries.UpsertSigninThrottleParams{
+		Kind:        kind,
+		Value:       value,
+		Failures:    failures,
+		Lockouts:    lockouts,
+		LockedUntil: lockedUntil,
+		Now:         now.UTC(),
+	}); err != nil {
+		return false, fmt.Errorf("failed to update sign in throttle: %w", err)
+	}
+	return locked, nil
+}
+
+func recordSignin(ctx context.Context, q *queries.Queries, event SigninEvent, attempt SigninAttempt, now time.Time) error {
+	if err := q.InsertSigninAudit(ctx, queries.InsertSigninAuditParams{
+		Event:     string(event),
+		Email:     attempt.accountKey(),
+		IpAddress: attempt.IPAddress,
+		UserAgent: attempt.UserAgent,
+		UserID:    sql.NullInt64{Int64: attempt.UserID, Valid: attempt.UserID != 0},
+		Now:       now.UTC(),
+	}); err != nil {
+		return fmt.Errorf("failed to record sign in: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+//go:build integration
+
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Piszmog/pathwise/internal/auth"
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testLockoutPolicy = auth.LockoutPolicy{
+	MaxAccountFailures: 3,
+	MaxIPFailures:      5,
+	FailureWindow:      15 * time.Minute,
+	Lockout:            time.Minute,
+	MaxLockout:         4 * time.Minute,
+}
+
+// failSignin records wrong passwords for the attempt and returns the error of the last one.
+func failSignin(t *testing.T, database db.Database, attempt auth.SigninAttempt, at time.Time, times int) error {
+	t.Helper()
+
+	var err error
+	for range times {
+		err = auth.RecordSigninFailure(context.Background(), database, testLockoutPolicy, attempt, at)
+	}
+	return err
+}
+
+func TestSigninLockout_Account(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+	attempt := auth.SigninAttempt{Email: "User@Example.com", IPAddress: "192.0.2.1"}
+
+	require.NoError(t, failSignin(t, database, attempt, now, 2))
+	require.NoError(t, auth.CheckSigninLockout(ctx, database.Queries(), attempt, now))
+	require.ErrorIs(t, failSignin(t, database, attempt, now, 1), auth.ErrSigninLocked)
+
+	// The lock follows the email from any address, whatever its case, but not other emails.
+	other := auth.SigninAttempt{Email: " user@example.com", IPAddress: "192.0.2.2"}
+	require.ErrorIs(t, auth.CheckSigninLockout(ctx, database.Queries(), other, now), auth.ErrSigninLocked)
+	require.NoError(t, auth.CheckSigninLockout(ctx, database.Queries(), auth.SigninAttempt{Email: "other@example.com", IPAddress: "192.0.2.1"}, now))
+
+	// Each lockout in a row lasts twice as long as the one before, up to the longest one.
+	at := now.Add(time.Minute)
+	require.NoError(t, auth.CheckSigninLockout(ctx, database.Queries(), attempt, at))
+	for _, lockout := range []time.Duration{2 * time.Minute, 4 * time.Minute, 4 * time.Minute} {
+		require.ErrorIs(t, failSignin(t, database, attempt, at, 3), auth.ErrSigninLocked)
+		require.ErrorIs(t, auth.CheckSigninLockout(ctx, database.Queries(), attempt, at.Add(lockout-time.Second)), auth.ErrSigninLocked)
+		at = at.Add(lockout)
+		require.NoError(t, auth.CheckSigninLockout(ctx, database.Queries(), attempt, at))
+	}
+
+	// A day without failures starts again from the shortest lockout.
+	at = at.Add(auth.LockoutReset)
+	require.ErrorIs(t, failSignin(t, database, attempt, at, 3), auth.ErrSigninLocked)
+	require.NoError(t, auth.CheckSigninLockout(ctx, database.Queries(), attempt, at.Add(time.Minute)))
+}
+
+func TestSigninLockout_FailuresReset(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+	attempt := auth.SigninAttempt{Email: "user@example.com", IPAddress: "192.0.2.1"}
+
+	// Failures stop counting once the window has passed.
+	require.NoError(t, failSignin(t, database, attempt, now, 2))
+	at := now.Add(testLockoutPolicy.FailureWindow)
+	require.NoError(t, failSignin(t, database, attempt, at, 2))
+
+	// A correct password clears the failures of the account.
+	require.NoError(t, auth.RecordSigninSuccess(ctx, database.Queries(), attempt, at))
+	require.NoError(t, failSignin(t, database, attempt, at, 2))
+	require.NoError(t, auth.CheckSigninLockout(ctx, database.Queries(), attempt, at))
+}
+
+func TestSigninLockout_IP(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+	createTestUser(t, database, 1)
+
+	emails := []string{"a@example.com", "b@example.com", "c@example.com", "d@example.com"}
+	for _, email := range emails {
+		require.NoError(t, failSignin(t, database, auth.SigninAttempt{Email: email, IPAddress: "192.0.2.1"}, now, 1))
+	}
+	// A correct password does not clear the failures of the address.
+	require.NoError(t, auth.RecordSigninSuccess(ctx, database.Queries(), auth.SigninAttempt{Email: "a@example.com", IPAddress: "192.0.2.1", UserID: 1}, now))
+	err := failSignin(t, database, auth.SigninAttempt{Email: "e@example.com", IPAddress: "192.0.2.1"}, now, 1)
+	require.ErrorIs(t, err, auth.ErrSigninLocked)
+
+	blocked := auth.SigninAttempt{Email: "f@example.com", IPAddress: "192.0.2.1", UserAgent: "curl"}
+	require.ErrorIs(t, auth.CheckSigninLockout(ctx, database.Queries(), blocked, now), auth.ErrSigninLocked)
+	// Hammering the locked address is only audited once a minute, whatever the email.
+	for _, email := range []string{"f@example.com", "g@example.com"} {
+		attempt := auth.SigninAttempt{Email: email, IPAddress: "192.0.2.1"}
+		require.ErrorIs(t, auth.CheckSigninLockout(ctx, database.Queries(), attempt, now.Add(30*time.Second)), auth.ErrSigninLocked)
+	}
+	require.NoError(t, auth.CheckSigninLockout(ctx, database.Queries(), auth.SigninAttempt{Email: "f@example.com", IPAddress: "192.0.2.2"}, now))
+
+	// Every attempt leaves an audit entry.
+	rows, err := database.DB().QueryContext(ctx, "SELECT event, email, user_agent, user_id FROM signin_audits ORDER BY id")
+	require.NoError(t, err)
+	defer rows.Close()
+	type entry struct {
+		event, email, userAgent string
+		userID                  *int64
+	}
+	var entries []entry
+	for rows.Next() {
+		var e entry
+		require.NoError(t, rows.Scan(&e.event, &e.email, &e.userAgent, &e.userID))
+		entries = append(entries, e)
+	}
+	require.NoError(t, rows.Err())
+	require.Len(t, entries, 7)
+	assert.Equal(t, "failed", entries[0].event)
+	assert.Nil(t, entries[0].userID)
+	assert.Equal(t, "succeeded", entries[4].event)
+	require.NotNil(t, entries[4].userID)
+	assert.Equal(t, int64(1), *entries[4].userID)
+	assert.Equal(t, "locked", entries[5].event)
+	assert.Equal(t, entry{event: "blocked", email: "f@example.com", userAgent: "curl"}, entries[6])
+}
+
+func TestPurgeSignins(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+
+	old := auth.SigninAttempt{Email: "old@example.com", IPAddress: "192.0.2.1"}
+	require.NoError(t, failSignin(t, database, old, now, 1))
+	recent := auth.SigninAttempt{Email: "recent@example.com", IPAddress: "192.0.2.2"}
+	at := now.Add(auth.SigninAuditRetention)
+	require.NoError(t, failSignin(t, database, recent, at, 1))
+
+	purged, err := auth.PurgeSignins(ctx, database.Queries(), testLockoutPolicy, at.Add(time.Minute))
+	require.NoError(t, err)
+	// The audit entry and both throttles of the old attempt.
+	assert.Equal(t, int64(3), purged)
+
+	var audits, throttles int
+	require.NoError(t, database.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM signin_audits").Scan(&audits))
+	assert.Equal(t, 1, audits)
+	require.NoError(t, database.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM signin_throttles").Scan(&throttles))
+	assert.Equal(t, 2, throttles)
+
+	// A locked throttle is kept until its lockout ends, however long ago it was updated.
+	locked := auth.SigninAttempt{Email: "locked@example.com", IPAddress: "192.0.2.3"}
+	require.ErrorIs(t, failSignin(t, database, locked, at, 3), auth.ErrSigninLocked)
+	_, err = database.DB().ExecContext(ctx, "UPDATE signin_throttles SET updated_at = datetime(?), locked_until = datetime(?) WHERE value = ?", now.UTC(), at.Add(time.Hour).UTC(), "locked@example.com")
+	require.NoError(t, err)
+	_, err = auth.PurgeSignins(ctx, database.Queries(), testLockoutPolicy, at.Add(time.Minute))
+	require.NoError(t, err)
+	require.ErrorIs(t, auth.CheckSigninLockout(ctx, database.Queries(), locked, at.Add(time.Minute)), auth.ErrSigninLocked)
+}
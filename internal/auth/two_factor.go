@@ -0,0 +1,412 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base32"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image/png"
+	"strings"
+	"time"
+
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/google/uuid"
+	"github.com/pquerna/otp/totp"
+)
+
+const (
+	// ChallengeDuration is how long a user has to enter their code after entering their password.
+	ChallengeDuration = 10 * time.Minute
+	// MaxTwoFactorFailures is how many wrong codes a user can enter within the FailureWindow before
+	// they have to wait.
+	MaxTwoFactorFailures = 5
+	// FailureWindow is how long a wrong code counts towards MaxTwoFactorFailures.
+	FailureWindow = 15 * time.Minute
+
+	recoveryCodeCount = 10
+	totpIssuer        = "Pathwise"
+	totpPeriod        = 30
+	totpCodeLength    = 6
+	qrCodeSize        = 200
+)
+
+var (
+	ErrTwoFactorEnabled    = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled = errors.New("two-factor authentication is not enabled")
+	ErrInvalidCode         = errors.New("invalid two-factor code")
+	ErrTooManyAttempts     = errors.New("too many two-factor attempts")
+	ErrChallengeNotFound   = errors.New("two-factor challenge not found")
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GetTwoFactor returns the state of two-factor authentication of the user. A secret that was never
+// confirmed does not count as enabled.
+func GetTwoFactor(ctx context.Context, q *queries.Queries, userID int64) (types.TwoFactor, error) {
+	row, err := q.GetUserTOTPByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return types.TwoFactor{}, nil
+		}
+		return types.TwoFactor{}, fmt.Errorf("failed to get two-factor secret: %w", err)
+	}
+	if !row.EnabledAt.Valid {
+		return types.TwoFactor{}, nil
+	}
+	left, err := q.CountUnusedUserRecoveryCodes(ctx, userID)
+	if err != nil {
+		return types.TwoFactor{}, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+	return types.TwoFactor{EnabledAt: row.EnabledAt.Time, RecoveryCodesLeft: left, Enabled: true}, nil
+}
+
+// EnrollTwoFactor generates a new secret for the authenticator app of the user. The secret is
+// pending until EnableTwoFactor confirms it with a code, and starting over replaces it.
+func EnrollTwoFactor(ctx context.Context, q *queries.Queries, userID int64, email string) (types.TwoFactorEnrollment, error) {
+	key, err := totp.Generate(totp.GenerateOpts{Issuer: totpIssuer, AccountName: email})
+	if err != nil {
+		return types.TwoFactorEnrollment{}, fmt.Errorf("failed to generate two-factor secret: %w", err)
+	}
+	updated, err := q.UpsertPendingUserTOTP(ctx, queries.UpsertPendingUserTOTPParams{UserID: userID, Secret: key.Secret()})
+	if err != nil {
+		return types.TwoFactorEnrollment{}, fmt.Errorf("failed to save two-factor secret: %w", err)
+	}
+	if updated == 0 {
+		return types.TwoFactorEnrollment{}, ErrTwoFactorEnabled
+	}
+
+	img, err := key.Image(qrCodeSize, qrCodeSize)
+	if err != nil {
+		return types.TwoFactorEnrollment{}, fmt.Errorf("failed to generate QR code: %w", err)
+	}
+	var buf bytes.Buffer
+	if err = png.Encode(&buf, img); err != nil {
+		return types.TwoFactorEnrollment{}, fmt.Errorf("failed to encode QR code: %w", err)
+	}
+	return types.TwoFactorEnrollment{
+		Secret: key.Secret(),
+		QRCode: "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()),
+	}, nil
+}
+
+// EnableTwoFactor confirms the pending secret of the user with a code from their authenticator app
+// and returns the recovery codes to show once.
+func EnableTwoFactor(ctx context.Context, database db.Database, userID int64, code string, now time.Time) (codes []string, err error) {
+	if err = checkFailures(ctx, database.Queries(), userID, now); err != nil {
+		return nil, err
+	}
+	row, err := database.Queries().GetUserTOTPByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTwoFactorNotEnabled
+		}
+		return nil, fmt.Errorf("failed to get two-factor secret: %w", err)
+	}
+	if row.EnabledAt.Valid {
+		return nil, ErrTwoFactorEnabled
+	}
+	step, ok := matchTOTP(row.Secret, row.LastUsedStep, code, now)
+	if !ok {
+		return nil, recordFailure(ctx, database.Queries(), userID, now)
+	}
+
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	qtx := queries.New(tx)
+	enabled, err := qtx.EnableUserTOTP(ctx, queries.EnableUserTOTPParams{EnabledAt: now.UTC(), LastUsedStep: step, UserID: userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to enable two-factor authentication: %w", err)
+	}
+	if enabled == 0 {
+		return nil, ErrTwoFactorEnabled
+	}
+	if codes, err = replaceRecoveryCodes(ctx, qtx, userID); err != nil {
+		return nil, err
+	}
+	if err = qtx.DeleteTwoFactorFailuresByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete two-factor failures: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return codes, nil
+}
+
+// DisableTwoFactor turns off two-factor authentication of the user after checking a code from their
+// authenticator app or a recovery code.
+func DisableTwoFactor(ctx context.Context, database db.Database, userID int64, code string, now time.Time) (err error) {
+	if err = VerifyCode(ctx, database.Queries(), userID, code, now); err != nil {
+		return err
+	}
+
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	qtx := queries.New(tx)
+	if err = qtx.DeleteUserTOTPByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete two-factor secret: %w", err)
+	}
+	if err = qtx.DeleteUserRecoveryCodesByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	if err = qtx.DeleteTwoFactorChallengesByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete two-factor challenges: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the user after checking a code, so codes
+// that were used up or seen by someone else stop working.
+func RegenerateRecoveryCodes(ctx context.Context, database db.Database, userID int64, code string, now time.Time) (codes []string, err error) {
+	if err = VerifyCode(ctx, database.Queries(), userID, code, now); err != nil {
+		return nil, err
+	}
+
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	if codes, err = replaceRecoveryCodes(ctx, queries.New(tx), userID); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return codes, nil
+}
+
+// VerifyCode checks a code from the authenticator app of the user or one of their recovery codes.
+// A code from the app can only be used once and a recovery code is used up. Wrong codes count
+// towards MaxTwoFactorFailures.
+func VerifyCode(ctx context.Context, q *queries.Queries, userID int64, code string, now time.Time) error {
+	if err := checkFailures(ctx, q, userID, now); err != nil {
+		return err
+	}
+	row, err := q.GetUserTOTPByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTwoFactorNotEnabled
+		}
+		return fmt.Errorf("failed to get two-factor secret: %w", err)
+	}
+	if !row.EnabledAt.Valid {
+		return ErrTwoFactorNotEnabled
+	}
+
+	if step, ok := matchTOTP(row.Secret, row.LastUsedStep, code, now); ok {
+		// Only moving the last used step forward accepts the code, so two requests racing with the
+		// same code cannot both pass.
+		updated, updateErr := q.UpdateUserTOTPLastUsedStep(ctx, queries.UpdateUserTOTPLastUsedStepParams{LastUsedStep: step, UserID: userID})
+		if updateErr != nil {
+			return fmt.Errorf("failed to update two-factor step: %w", updateErr)
+		}
+		if updated == 1 {
+			return clearFailures(ctx, q, userID)
+		}
+	} else if normalized := normalizeRecoveryCode(code); normalized != "" {
+		used, useErr := q.UseUserRecoveryCode(ctx, queries.UseUserRecoveryCodeParams{
+			UsedAt:   now.UTC(),
+			UserID:   userID,
+			CodeHash: hashCode(normalized),
+		})
+		if useErr != nil {
+			return fmt.Errorf("failed to use recovery code: %w", useErr)
+		}
+		if used == 1 {
+			return clearFailures(ctx, q, userID)
+		}
+	}
+	return recordFailure(ctx, q, userID, now)
+}
+
+// IsTwoFactorEnabled reports whether the user has to enter a code after their password.
+func IsTwoFactorEnabled(ctx context.Context, q *queries.Queries, userID int64) (bool, error) {
+	row, err := q.GetUserTOTPByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get two-factor secret: %w", err)
+	}
+	return row.EnabledAt.Valid, nil
+}
+
+// NewChallenge starts the second sign-in step of the user once their password was checked. The
+// returned token identifies the challenge until it expires or a code is accepted.
+func NewChallenge(ctx context.Context, q *queries.Queries, userID int64, now time.Time) (string, time.Time, error) {
+	if err := q.DeleteExpiredTwoFactorChallenges(ctx, now.UTC()); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to delete expired two-factor challenges: %w", err)
+	}
+	token := uuid.NewString()
+	expiresAt := now.Add(ChallengeDuration)
+	if err := q.InsertTwoFactorChallenge(ctx, queries.InsertTwoFactorChallengeParams{
+		UserID:    userID,
+		TokenHash: hashCode(token),
+		ExpiresAt: expiresAt.UTC(),
+	}); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to insert two-factor challenge: %w", err)
+	}
+	return token, expiresAt, nil
+}
+
+// CompleteChallenge checks the code entered for a challenge and returns the user to sign in. The
+// challenge is deleted once the code is accepted or too many wrong codes were entered, so the user
+// has to enter their password again.
+func CompleteChallenge(ctx context.Context, q *queries.Queries, token string, code string, now time.Time) (int64, error) {
+	tokenHash := hashCode(token)
+	userID, err := q.GetTwoFactorChallengeByHash(ctx, queries.GetTwoFactorChallengeByHashParams{TokenHash: tokenHash, Now: now.UTC()})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrChallengeNotFound
+		}
+		return 0, fmt.Errorf("failed to get two-factor challenge: %w", err)
+	}
+
+	if err = VerifyCode(ctx, q, userID, code, now); err != nil {
+		if errors.Is(err, ErrTooManyAttempts) {
+			if deleteErr := q.DeleteTwoFactorChallengesByUserID(ctx, userID); deleteErr != nil {
+				err = errors.Join(err, fmt.Errorf("failed to delete two-factor challenges: %w", deleteErr))
+			}
+		}
+		return 0, err
+	}
+	if err = q.DeleteTwoFactorChallengeByHash(ctx, tokenHash); err != nil {
+		return 0, fmt.Errorf("failed to delete two-factor challenge: %w", err)
+	}
+	return userID, nil
+}
+
+// HasChallenge reports whether the token belongs to a challenge that has not expired.
+func HasChallenge(ctx context.Context, q *queries.Queries, token string, now time.Time) (bool, error) {
+	_, err := q.GetTwoFactorChallengeByHash(ctx, queries.GetTwoFactorChallengeByHashParams{TokenHash: hashCode(token), Now: now.UTC()})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get two-factor challenge: %w", err)
+	}
+	return true, nil
+}
+
+// matchTOTP returns the time step of the code when it matches the secret at the current step or
+// one step either side, allowing for clock drift. Steps up to the last used one are not accepted so
+// a code cannot be replayed.
+func matchTOTP(secret string, lastUsedStep int64, code string, now time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != totpCodeLength {
+		return 0, false
+	}
+	current := now.Unix() / totpPeriod
+	for step := current - 1; step <= current+1; step++ {
+		if step <= lastUsedStep {
+			continue
+		}
+		expected, err := totp.GenerateCode(secret, time.Unix(step*totpPeriod, 0))
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+func checkFailures(ctx context.Context, q *queries.Queries, userID int64, now time.Time) error {
+	failures, err := q.CountTwoFactorFailuresSince(ctx, queries.CountTwoFactorFailuresSinceParams{UserID: userID, Since: now.Add(-FailureWindow).UTC()})
+	if err != nil {
+		return fmt.Errorf("failed to count two-factor failures: %w", err)
+	}
+	if failures >= MaxTwoFactorFailures {
+		return ErrTooManyAttempts
+	}
+	return nil
+}
+
+// recordFailure counts a wrong code and returns the error to report for it, which is
+// ErrTooManyAttempts once it was the last code allowed.
+func recordFailure(ctx context.Context, q *queries.Queries, userID int64, now time.Time) error {
+	if err := q.InsertTwoFactorFailure(ctx, queries.InsertTwoFactorFailureParams{UserID: userID, CreatedAt: now.UTC()}); err != nil {
+		return fmt.Errorf("failed to record two-factor failure: %w", err)
+	}
+	if err := checkFailures(ctx, q, userID, now); err != nil {
+		return err
+	}
+	return ErrInvalidCode
+}
+
+func clearFailures(ctx context.Context, q *queries.Queries, userID int64) error {
+	if err := q.DeleteTwoFactorFailuresByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete two-factor failures: %w", err)
+	}
+	return nil
+}
+
+func replaceRecoveryCodes(ctx context.Context, qtx *queries.Queries, userID int64) ([]string, error) {
+	if err := qtx.DeleteUserRecoveryCodesByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	codes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		code := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))
+		if err := qtx.InsertUserRecoveryCode(ctx, queries.InsertUserRecoveryCodeParams{UserID: userID, CodeHash: hashCode(code)}); err != nil {
+			return nil, fmt.Errorf("failed to insert recovery code: %w", err)
+		}
+		codes[i] = code[:4] + "-" + code[4:]
+	}
+	return codes, nil
+}
+
+// normalizeRecoveryCode ignores case, spaces and dashes so a recovery code can be typed the way it
+// was written down.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, code))
+}
+
+func hashCode(code string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(code)))
+}
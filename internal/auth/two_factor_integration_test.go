@@ -0,0 +1,242 @@
+//go:build integration
+
+package auth_test
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Piszmog/pathwise/internal/auth"
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/testutil"
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2026, time.January, 15, 10, 0, 0, 0, time.UTC)
+
+func setupTestDB(t *testing.T) db.Database {
+	t.Helper()
+
+	dbFile := filepath.Join(t.TempDir(), "integration-test.sqlite3")
+	database, err := db.New(slog.New(slog.DiscardHandler), db.DatabaseOpts{URL: dbFile})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+
+	_, err = database.DB().ExecContext(context.Background(), "PRAGMA foreign_keys = ON")
+	require.NoError(t, err)
+	require.NoError(t, testutil.RunMigrations(dbFile))
+	return database
+}
+
+func createTestUser(t *testing.T, database db.Database, userID int64) {
+	t.Helper()
+
+	_, err := database.DB().ExecContext(context.Background(),
+		"INSERT INTO users (id, email, password) VALUES (?, ?, ?)",
+		userID, "test-user-"+time.Now().Format("150405.000000000")+"@example.com", "password",
+	)
+	require.NoError(t, err)
+}
+
+func code(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+
+	c, err := totp.GenerateCode(secret, at)
+	require.NoError(t, err)
+	return c
+}
+
+// enable sets up two-factor authentication for the user and returns the secret and recovery codes.
+func enable(t *testing.T, database db.Database, userID int64) (string, []string) {
+	t.Helper()
+
+	enrollment, err := auth.EnrollTwoFactor(context.Background(), database.Queries(), userID, "user@example.com")
+	require.NoError(t, err)
+	codes, err := auth.EnableTwoFactor(context.Background(), database, userID, code(t, enrollment.Secret, now), now)
+	require.NoError(t, err)
+	return enrollment.Secret, codes
+}
+
+func TestEnableTwoFactor(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+	createTestUser(t, database, 1)
+
+	tf, err := auth.GetTwoFactor(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	assert.False(t, tf.Enabled)
+	_, err = auth.EnableTwoFactor(ctx, database, 1, "123456", now)
+	require.ErrorIs(t, err, auth.ErrTwoFactorNotEnabled)
+
+	enrollment, err := auth.EnrollTwoFactor(ctx, database.Queries(), 1, "user@example.com")
+	require.NoError(t, err)
+	assert.NotEmpty(t, enrollment.Secret)
+	assert.True(t, strings.HasPrefix(enrollment.QRCode, "data:image/png;base64,"))
+
+	// A pending secret does not count as enabled, and starting over replaces it.
+	enabled, err := auth.IsTwoFactorEnabled(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	assert.False(t, enabled)
+	restarted, err := auth.EnrollTwoFactor(ctx, database.Queries(), 1, "user@example.com")
+	require.NoError(t, err)
+	assert.NotEqual(t, enrollment.Secret, restarted.Secret)
+	_, err = auth.EnableTwoFactor(ctx, database, 1, code(t, enrollment.Secret, now), now)
+	require.ErrorIs(t, err, auth.ErrInvalidCode)
+
+	codes, err := auth.EnableTwoFactor(ctx, database, 1, code(t, restarted.Secret, now), now)
+	require.NoError(t, err)
+	assert.Len(t, codes, 10)
+
+	tf, err = auth.GetTwoFactor(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	assert.True(t, tf.Enabled)
+	assert.Equal(t, now, tf.EnabledAt.UTC())
+	assert.Equal(t, int64(10), tf.RecoveryCodesLeft)
+
+	_, err = auth.EnrollTwoFactor(ctx, database.Queries(), 1, "user@example.com")
+	require.ErrorIs(t, err, auth.ErrTwoFactorEnabled)
+}
+
+func TestVerifyCode(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+	createTestUser(t, database, 1)
+	createTestUser(t, database, 2)
+	secret, codes := enable(t, database, 1)
+
+	require.ErrorIs(t, auth.VerifyCode(ctx, database.Queries(), 2, "123456", now), auth.ErrTwoFactorNotEnabled)
+
+	// The code used to enable cannot be replayed, but the next one and a drifted clock are accepted.
+	require.ErrorIs(t, auth.VerifyCode(ctx, database.Queries(), 1, code(t, secret, now), now), auth.ErrInvalidCode)
+	require.NoError(t, auth.VerifyCode(ctx, database.Queries(), 1, code(t, secret, now.Add(30*time.Second)), now))
+	require.ErrorIs(t, auth.VerifyCode(ctx, database.Queries(), 1, code(t, secret, now.Add(90*time.Second)), now), auth.ErrInvalidCode)
+	require.NoError(t, auth.VerifyCode(ctx, database.Queries(), 1, code(t, secret, now.Add(90*time.Second)), now.Add(time.Minute)))
+
+	// Recovery codes are accepted once, however they are typed.
+	recovery := strings.ToUpper(strings.ReplaceAll(codes[0], "-", " "))
+	require.NoError(t, auth.VerifyCode(ctx, database.Queries(), 1, recovery, now))
+	require.ErrorIs(t, auth.VerifyCode(ctx, database.Queries(), 1, recovery, now), auth.ErrInvalidCode)
+	tf, err := auth.GetTwoFactor(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), tf.RecoveryCodesLeft)
+
+	// Recovery codes of another user are not accepted.
+	_, otherCodes := enable(t, database, 2)
+	require.ErrorIs(t, auth.VerifyCode(ctx, database.Queries(), 1, otherCodes[0], now), auth.ErrInvalidCode)
+}
+
+func TestVerifyCode_TooManyAttempts(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+	createTestUser(t, database, 1)
+	secret, _ := enable(t, database, 1)
+
+	for range auth.MaxTwoFactorFailures - 1 {
+		require.ErrorIs(t, auth.VerifyCode(ctx, database.Queries(), 1, "000000", now), auth.ErrInvalidCode)
+	}
+	require.ErrorIs(t, auth.VerifyCode(ctx, database.Queries(), 1, "000000", now), auth.ErrTooManyAttempts)
+	require.ErrorIs(t, auth.VerifyCode(ctx, database.Queries(), 1, code(t, secret, now.Add(30*time.Second)), now), auth.ErrTooManyAttempts)
+
+	later := now.Add(auth.FailureWindow)
+	require.NoError(t, auth.VerifyCode(ctx, database.Queries(), 1, code(t, secret, later), later))
+
+	// Accepting a code clears the failures.
+	for range auth.MaxTwoFactorFailures - 1 {
+		require.ErrorIs(t, auth.VerifyCode(ctx, database.Queries(), 1, "000000", later), auth.ErrInvalidCode)
+	}
+	require.NoError(t, auth.VerifyCode(ctx, database.Queries(), 1, code(t, secret, later.Add(30*time.Second)), later))
+	require.ErrorIs(t, auth.VerifyCode(ctx, database.Queries(), 1, "000000", later), auth.ErrInvalidCode)
+}
+
+func TestCompleteChallenge(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+	createTestUser(t, database, 1)
+	secret, _ := enable(t, database, 1)
+
+	token, expiresAt, err := auth.NewChallenge(ctx, database.Queries(), 1, now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(auth.ChallengeDuration), expiresAt)
+
+	ok, err := auth.HasChallenge(ctx, database.Queries(), token, now)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = auth.HasChallenge(ctx, database.Queries(), token, expiresAt)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = auth.CompleteChallenge(ctx, database.Queries(), "unknown", code(t, secret, now.Add(30*time.Second)), now)
+	require.ErrorIs(t, err, auth.ErrChallengeNotFound)
+	_, err = auth.CompleteChallenge(ctx, database.Queries(), token, "000000", now)
+	require.ErrorIs(t, err, auth.ErrInvalidCode)
+
+	// A wrong code leaves the challenge to try again, and an accepted code uses it up.
+	userID, err := auth.CompleteChallenge(ctx, database.Queries(), token, code(t, secret, now.Add(30*time.Second)), now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), userID)
+	_, err = auth.CompleteChallenge(ctx, database.Queries(), token, code(t, secret, now.Add(-30*time.Second)), now)
+	require.ErrorIs(t, err, auth.ErrChallengeNotFound)
+
+	// Too many wrong codes end the challenge, so the password has to be entered again.
+	token, _, err = auth.NewChallenge(ctx, database.Queries(), 1, now)
+	require.NoError(t, err)
+	for range auth.MaxTwoFactorFailures - 1 {
+		_, err = auth.CompleteChallenge(ctx, database.Queries(), token, "000000", now)
+		require.ErrorIs(t, err, auth.ErrInvalidCode)
+	}
+	_, err = auth.CompleteChallenge(ctx, database.Queries(), token, "000000", now)
+	require.ErrorIs(t, err, auth.ErrTooManyAttempts)
+	ok, err = auth.HasChallenge(ctx, database.Queries(), token, now)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestRegenerateRecoveryCodes(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+	createTestUser(t, database, 1)
+	secret, codes := enable(t, database, 1)
+
+	_, err := auth.RegenerateRecoveryCodes(ctx, database, 1, "000000", now)
+	require.ErrorIs(t, err, auth.ErrInvalidCode)
+
+	regenerated, err := auth.RegenerateRecoveryCodes(ctx, database, 1, codes[0], now)
+	require.NoError(t, err)
+	assert.Len(t, regenerated, 10)
+	require.ErrorIs(t, auth.VerifyCode(ctx, database.Queries(), 1, codes[1], now), auth.ErrInvalidCode)
+	require.NoError(t, auth.VerifyCode(ctx, database.Queries(), 1, regenerated[0], now))
+
+	tf, err := auth.GetTwoFactor(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), tf.RecoveryCodesLeft)
+	require.NoError(t, auth.VerifyCode(ctx, database.Queries(), 1, code(t, secret, now.Add(30*time.Second)), now))
+}
+
+func TestDisableTwoFactor(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+	createTestUser(t, database, 1)
+	secret, _ := enable(t, database, 1)
+	token, _, err := auth.NewChallenge(ctx, database.Queries(), 1, now)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, auth.DisableTwoFactor(ctx, database, 1, "000000", now), auth.ErrInvalidCode)
+	require.NoError(t, auth.DisableTwoFactor(ctx, database, 1, code(t, secret, now.Add(30*time.Second)), now))
+
+	enabled, err := auth.IsTwoFactorEnabled(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	assert.False(t, enabled)
+	ok, err := auth.HasChallenge(ctx, database.Queries(), token, now)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	require.ErrorIs(t, auth.DisableTwoFactor(ctx, database, 1, code(t, secret, now), now), auth.ErrTwoFactorNotEnabled)
+
+	// It can be set up again afterwards.
+	enable(t, database, 1)
+}
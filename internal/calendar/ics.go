@@ -0,0 +1,120 @@
+// Package calendar writes iCalendar (RFC 5545) feeds.
+package calendar
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLineLength is the maximum number of octets of a content line, excluding the line break.
+const maxLineLength = 75
+
+const dateTimeFormat = "20060102T150405Z"
+
+// Calendar is a collection of events published as a single feed.
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+// Event is a single calendar event. All times are written in UTC.
+type Event struct {
+	Start       time.Time
+	End         time.Time
+	Stamp       time.Time
+	UID         string
+	Summary     string
+	Description string
+	URL         string
+	Canceled    bool
+}
+
+// Write writes the calendar in the iCalendar format.
+func Write(w io.Writer, cal Calendar) error {
+	bw := bufio.NewWriter(w)
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Pathwise//Pathwise//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+	}
+	if cal.Name != "" {
+		lines = append(lines, "X-WR-CALNAME:"+escapeText(cal.Name))
+	}
+	for _, e := range cal.Events {
+		lines = append(lines, eventLines(e)...)
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	for _, line := range lines {
+		if _, err := bw.WriteString(foldLine(line)); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+func eventLines(e Event) []string {
+	lines := []string{
+		"BEGIN:VEVENT",
+		"UID:" + escapeText(e.UID),
+		"DTSTAMP:" + formatTime(e.Stamp),
+		"DTSTART:" + formatTime(e.Start),
+		"DTEND:" + formatTime(e.End),
+		"SUMMARY:" + escapeText(e.Summary),
+	}
+	if e.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+escapeText(e.Description))
+	}
+	if e.URL != "" {
+		lines = append(lines, "URL:"+e.URL)
+	}
+	if e.Canceled {
+		lines = append(lines, "STATUS:CANCELLED")
+	} else {
+		lines = append(lines, "STATUS:CONFIRMED")
+	}
+	return append(lines, "END:VEVENT")
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(dateTimeFormat)
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+func escapeText(val string) string {
+	return textEscaper.Replace(val)
+}
+
+// foldLine splits the line into lines of at most maxLineLength octets, continuing each with a
+// space, and terminates it with CRLF. Lines are only split between UTF-8 characters.
+func foldLine(line string) string {
+	var sb strings.Builder
+	limit := maxLineLength
+	for len(line) > limit {
+		i := limit
+		for i > 0 && !utf8.RuneStart(line[i]) {
+			i--
+		}
+		sb.WriteString(line[:i])
+		sb.WriteString("\r\n ")
+		line = line[i:]
+		// The leading space of a continuation line counts towards its length.
+		limit = maxLineLength - 1
+	}
+	sb.WriteString(line)
+	sb.WriteString("\r\n")
+	return sb.String()
+}
@@ -0,0 +1,128 @@
+package calendar_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Piszmog/pathwise/internal/calendar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 10, 20, 9, 30, 0, 0, time.FixedZone("PDT", -7*60*60))
+	cal := calendar.Calendar{
+		Name: "Pathwise Interviews",
+		Events: []calendar.Event{
+			{
+				UID:         "interview-1@pathwise",
+				Start:       start,
+				End:         start.Add(time.Hour),
+				Stamp:       time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC),
+				Summary:     "Technical interview: Acme, Inc.",
+				Description: "Interviewers: Jane; John\nOutcome: Pending",
+				URL:         "https://acme.example.com/jobs/1",
+			},
+			{
+				UID:      "interview-2@pathwise",
+				Start:    start.AddDate(0, 0, 1),
+				End:      start.AddDate(0, 0, 1).Add(30 * time.Minute),
+				Stamp:    time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC),
+				Summary:  "Phone interview: " + strings.Repeat("Ünïcödé Company ", 10),
+				Canceled: true,
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, calendar.Write(&buf, cal))
+
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75, "line %q is too long", line)
+	}
+
+	feed := parseFeed(t, buf.String())
+	assert.Equal(t, "2.0", feed.properties["VERSION"])
+	assert.Equal(t, "Pathwise Interviews", feed.properties["X-WR-CALNAME"])
+	require.Len(t, feed.events, 2)
+
+	assert.Equal(t, "interview-1@pathwise", feed.events[0]["UID"])
+	assert.Equal(t, "20261020T163000Z", feed.events[0]["DTSTART"])
+	assert.Equal(t, "20261020T173000Z", feed.events[0]["DTEND"])
+	assert.Equal(t, "20261017T120000Z", feed.events[0]["DTSTAMP"])
+	assert.Equal(t, "Technical interview: Acme, Inc.", feed.events[0]["SUMMARY"])
+	assert.Equal(t, "Interviewers: Jane; John\nOutcome: Pending", feed.events[0]["DESCRIPTION"])
+	assert.Equal(t, "https://acme.example.com/jobs/1", feed.events[0]["URL"])
+	assert.Equal(t, "CONFIRMED", feed.events[0]["STATUS"])
+
+	assert.Equal(t, "Phone interview: "+strings.Repeat("Ünïcödé Company ", 10), feed.events[1]["SUMMARY"])
+	assert.Equal(t, "CANCELLED", feed.events[1]["STATUS"])
+	assert.NotContains(t, feed.events[1], "DESCRIPTION")
+}
+
+func TestWrite_Empty(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, calendar.Write(&buf, calendar.Calendar{}))
+
+	feed := parseFeed(t, buf.String())
+	assert.Empty(t, feed.events)
+	assert.NotContains(t, feed.properties, "X-WR-CALNAME")
+}
+
+type parsedFeed struct {
+	properties map[string]string
+	events     []map[string]string
+}
+
+// parseFeed parses the feed the way a calendar client would, unfolding lines and unescaping text.
+func parseFeed(t *testing.T, data string) parsedFeed {
+	t.Helper()
+
+	require.True(t, strings.HasSuffix(data, "\r\n"), "feed must end with CRLF")
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSuffix(data, "\r\n"), "\r\n") {
+		if strings.HasPrefix(line, " ") {
+			require.NotEmpty(t, lines, "feed cannot start with a continuation line")
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	require.Equal(t, "BEGIN:VCALENDAR", lines[0])
+	require.Equal(t, "END:VCALENDAR", lines[len(lines)-1])
+
+	feed := parsedFeed{properties: make(map[string]string)}
+	var event map[string]string
+	for _, line := range lines[1 : len(lines)-1] {
+		name, value, ok := strings.Cut(line, ":")
+		require.True(t, ok, "invalid content line %q", line)
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			require.Nil(t, event, "nested event")
+			event = make(map[string]string)
+		case name == "END" && value == "VEVENT":
+			require.NotNil(t, event, "unexpected end of event")
+			feed.events = append(feed.events, event)
+			event = nil
+		case event != nil:
+			event[name] = unescapeText(value)
+		default:
+			feed.properties[name] = unescapeText(value)
+		}
+	}
+	require.Nil(t, event, "unterminated event")
+	return feed
+}
+
+var textUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+
+func unescapeText(val string) string {
+	return textUnescaper.Replace(val)
+}
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS job_application_reminders_remind_at_idx;
+
+DROP INDEX IF EXISTS job_application_reminders_job_application_id_idx;
+
+DROP TABLE IF EXISTS job_application_reminders;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS job_application_reminders (
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	remind_at DATETIME NOT NULL,
+	dismissed_at DATETIME,
+	note TEXT NOT NULL DEFAULT '',
+	id INTEGER PRIMARY KEY,
+	job_application_id INTEGER NOT NULL,
+	FOREIGN KEY (job_application_id) REFERENCES job_applications(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS job_application_reminders_job_application_id_idx ON job_application_reminders(job_application_id);
+
+CREATE INDEX IF NOT EXISTS job_application_reminders_remind_at_idx ON job_application_reminders(remind_at) WHERE dismissed_at IS NULL;
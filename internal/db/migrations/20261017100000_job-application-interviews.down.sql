@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS calendar_feed_tokens;
+
+DROP INDEX IF EXISTS job_application_interviews_job_application_id_idx;
+
+DROP TABLE IF EXISTS job_application_interviews;
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS job_application_interviews (
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	scheduled_at DATETIME NOT NULL,
+	timezone TEXT NOT NULL DEFAULT 'UTC',
+	type TEXT CHECK (type IN ('phone', 'technical', 'onsite')) NOT NULL,
+	outcome TEXT CHECK (outcome IN ('pending', 'passed', 'failed', 'canceled')) NOT NULL DEFAULT 'pending',
+	interviewers TEXT NOT NULL DEFAULT '',
+	duration_minutes INTEGER NOT NULL DEFAULT 60,
+	id INTEGER PRIMARY KEY,
+	job_application_id INTEGER NOT NULL,
+	FOREIGN KEY (job_application_id) REFERENCES job_applications(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS job_application_interviews_job_application_id_idx ON job_application_interviews(job_application_id);
+
+CREATE TABLE IF NOT EXISTS calendar_feed_tokens (
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	token_hash TEXT NOT NULL UNIQUE,
+	id INTEGER PRIMARY KEY,
+	user_id INTEGER NOT NULL UNIQUE,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,23 @@
+ALTER TABLE job_application_stats ADD COLUMN total_accepted INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE job_application_stats ADD COLUMN total_applied INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE job_application_stats ADD COLUMN total_canceled INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE job_application_stats ADD COLUMN total_declined INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE job_application_stats ADD COLUMN total_interviewing INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE job_application_stats ADD COLUMN total_offers INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE job_application_stats ADD COLUMN total_rejected INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE job_application_stats ADD COLUMN total_watching INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE job_application_stats ADD COLUMN total_widthdrawn INTEGER NOT NULL DEFAULT 0;
+
+UPDATE job_application_stats
+SET
+	total_accepted = (SELECT COUNT(*) FROM job_applications j WHERE j.user_id = job_application_stats.user_id AND j.archived = 0 AND j.status = 'accepted'),
+	total_applied = (SELECT COUNT(*) FROM job_applications j WHERE j.user_id = job_application_stats.user_id AND j.archived = 0 AND j.status = 'applied'),
+	total_canceled = (SELECT COUNT(*) FROM job_applications j WHERE j.user_id = job_application_stats.user_id AND j.archived = 0 AND j.status = 'canceled'),
+	total_declined = (SELECT COUNT(*) FROM job_applications j WHERE j.user_id = job_application_stats.user_id AND j.archived = 0 AND j.status = 'declined'),
+	total_interviewing = (SELECT COUNT(*) FROM job_applications j WHERE j.user_id = job_application_stats.user_id AND j.archived = 0 AND j.status = 'interviewing'),
+	total_offers = (SELECT COUNT(*) FROM job_applications j WHERE j.user_id = job_application_stats.user_id AND j.archived = 0 AND j.status = 'offered'),
+	total_rejected = (SELECT COUNT(*) FROM job_applications j WHERE j.user_id = job_application_stats.user_id AND j.archived = 0 AND j.status = 'rejected'),
+	total_watching = (SELECT COUNT(*) FROM job_applications j WHERE j.user_id = job_application_stats.user_id AND j.archived = 0 AND j.status = 'watching'),
+	total_widthdrawn = (SELECT COUNT(*) FROM job_applications j WHERE j.user_id = job_application_stats.user_id AND j.archived = 0 AND j.status = 'withdrawn');
+
+DROP TABLE IF EXISTS job_application_statuses;
@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS job_application_statuses (
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	name TEXT NOT NULL,
+	position INTEGER NOT NULL,
+	terminal INTEGER NOT NULL DEFAULT 0,
+	id INTEGER PRIMARY KEY,
+	user_id INTEGER NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+	UNIQUE (user_id, name)
+);
+
+INSERT INTO job_application_statuses (user_id, name, position, terminal)
+SELECT users.id, defaults.name, defaults.position, defaults.terminal
+FROM users
+CROSS JOIN (
+	SELECT 'watching' AS name, 1 AS position, 0 AS terminal
+	UNION ALL SELECT 'applied', 2, 0
+	UNION ALL SELECT 'interviewing', 3, 0
+	UNION ALL SELECT 'offered', 4, 0
+	UNION ALL SELECT 'accepted', 5, 1
+	UNION ALL SELECT 'declined', 6, 1
+	UNION ALL SELECT 'rejected', 7, 1
+	UNION ALL SELECT 'withdrawn', 8, 1
+	UNION ALL SELECT 'canceled', 9, 1
+	UNION ALL SELECT 'closed', 10, 1
+) AS defaults;
+
+ALTER TABLE job_application_stats DROP COLUMN total_accepted;
+ALTER TABLE job_application_stats DROP COLUMN total_applied;
+ALTER TABLE job_application_stats DROP COLUMN total_canceled;
+ALTER TABLE job_application_stats DROP COLUMN total_declined;
+ALTER TABLE job_application_stats DROP COLUMN total_interviewing;
+ALTER TABLE job_application_stats DROP COLUMN total_offers;
+ALTER TABLE job_application_stats DROP COLUMN total_rejected;
+ALTER TABLE job_application_stats DROP COLUMN total_watching;
+ALTER TABLE job_application_stats DROP COLUMN total_widthdrawn;
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS job_application_notes_fts_delete;
+DROP TRIGGER IF EXISTS job_application_notes_fts_update;
+DROP TRIGGER IF EXISTS job_application_notes_fts_insert;
+DROP TRIGGER IF EXISTS job_applications_fts_delete;
+DROP TRIGGER IF EXISTS job_applications_fts_update;
+DROP TRIGGER IF EXISTS job_applications_fts_insert;
+DROP TABLE IF EXISTS job_applications_fts;
@@ -0,0 +1,55 @@
+CREATE VIRTUAL TABLE IF NOT EXISTS job_applications_fts USING fts5(
+	company,
+	title,
+	url,
+	notes,
+	tokenize = 'porter unicode61 remove_diacritics 2'
+);
+
+INSERT INTO job_applications_fts (rowid, company, title, url, notes)
+SELECT
+	j.id,
+	j.company,
+	j.title,
+	COALESCE(j.url, ''),
+	COALESCE((SELECT group_concat(n.note, char(10)) FROM job_application_notes n WHERE n.job_application_id = j.id), '')
+FROM job_applications j;
+
+CREATE TRIGGER IF NOT EXISTS job_applications_fts_insert AFTER INSERT ON job_applications
+BEGIN
+	INSERT INTO job_applications_fts (rowid, company, title, url, notes)
+	VALUES (new.id, new.company, new.title, COALESCE(new.url, ''), '');
+END;
+
+CREATE TRIGGER IF NOT EXISTS job_applications_fts_update AFTER UPDATE OF company, title, url ON job_applications
+BEGIN
+	UPDATE job_applications_fts
+	SET company = new.company, title = new.title, url = COALESCE(new.url, '')
+	WHERE rowid = new.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS job_applications_fts_delete AFTER DELETE ON job_applications
+BEGIN
+	DELETE FROM job_applications_fts WHERE rowid = old.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS job_application_notes_fts_insert AFTER INSERT ON job_application_notes
+BEGIN
+	UPDATE job_applications_fts
+	SET notes = COALESCE((SELECT group_concat(n.note, char(10)) FROM job_application_notes n WHERE n.job_application_id = new.job_application_id), '')
+	WHERE rowid = new.job_application_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS job_application_notes_fts_update AFTER UPDATE OF note ON job_application_notes
+BEGIN
+	UPDATE job_applications_fts
+	SET notes = COALESCE((SELECT group_concat(n.note, char(10)) FROM job_application_notes n WHERE n.job_application_id = new.job_application_id), '')
+	WHERE rowid = new.job_application_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS job_application_notes_fts_delete AFTER DELETE ON job_application_notes
+BEGIN
+	UPDATE job_applications_fts
+	SET notes = COALESCE((SELECT group_concat(n.note, char(10)) FROM job_application_notes n WHERE n.job_application_id = old.job_application_id), '')
+	WHERE rowid = old.job_application_id;
+END;
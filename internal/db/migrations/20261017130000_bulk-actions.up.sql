@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS job_application_bulk_actions (
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	action TEXT NOT NULL,
+	undo_data TEXT NOT NULL,
+	id INTEGER PRIMARY KEY,
+	user_id INTEGER NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS job_application_bulk_actions_user_id_idx ON job_application_bulk_actions(user_id);
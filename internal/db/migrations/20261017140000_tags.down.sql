@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS job_application_tags_tag_id_idx;
+DROP TABLE IF EXISTS job_application_tags;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS tags (
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	name TEXT NOT NULL COLLATE NOCASE,
+	color TEXT NOT NULL,
+	id INTEGER PRIMARY KEY,
+	user_id INTEGER NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+	UNIQUE (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS job_application_tags (
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	job_application_id INTEGER NOT NULL,
+	tag_id INTEGER NOT NULL,
+	PRIMARY KEY (job_application_id, tag_id),
+	FOREIGN KEY (job_application_id) REFERENCES job_applications(id) ON DELETE CASCADE,
+	FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS job_application_tags_tag_id_idx ON job_application_tags(tag_id);
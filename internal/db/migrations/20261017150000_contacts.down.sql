@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS job_application_contacts_contact_id_idx;
+DROP TABLE IF EXISTS job_application_contacts;
+DROP INDEX IF EXISTS contacts_user_id_idx;
+DROP TABLE IF EXISTS contacts;
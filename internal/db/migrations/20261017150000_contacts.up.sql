@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS contacts (
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	name TEXT NOT NULL,
+	role TEXT NOT NULL DEFAULT '',
+	email TEXT NOT NULL DEFAULT '',
+	linkedin_url TEXT NOT NULL DEFAULT '',
+	company TEXT NOT NULL DEFAULT '',
+	id INTEGER PRIMARY KEY,
+	user_id INTEGER NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS contacts_user_id_idx ON contacts(user_id);
+
+CREATE TABLE IF NOT EXISTS job_application_contacts (
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	job_application_id INTEGER NOT NULL,
+	contact_id INTEGER NOT NULL,
+	PRIMARY KEY (job_application_id, contact_id),
+	FOREIGN KEY (job_application_id) REFERENCES job_applications(id) ON DELETE CASCADE,
+	FOREIGN KEY (contact_id) REFERENCES contacts(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS job_application_contacts_contact_id_idx ON job_application_contacts(contact_id);
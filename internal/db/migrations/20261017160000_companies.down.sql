@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS job_applications_company_id_idx;
+ALTER TABLE job_applications DROP COLUMN company_id;
+DROP TABLE IF EXISTS companies;
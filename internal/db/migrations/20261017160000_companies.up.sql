@@ -0,0 +1,46 @@
+CREATE TABLE IF NOT EXISTS companies (
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	name TEXT NOT NULL,
+	normalized_name TEXT NOT NULL,
+	id INTEGER PRIMARY KEY,
+	user_id INTEGER NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+	UNIQUE (user_id, normalized_name)
+);
+
+ALTER TABLE job_applications ADD COLUMN company_id INTEGER;
+
+CREATE INDEX IF NOT EXISTS job_applications_company_id_idx ON job_applications(company_id);
+
+INSERT INTO
+	companies (user_id, name, normalized_name)
+SELECT
+	user_id,
+	company,
+	normalized_name
+FROM
+	(
+		SELECT
+			user_id,
+			company,
+			lower(replace(replace(replace(replace(company, ' ', ''), char(9), ''), char(10), ''), char(13), '')) AS normalized_name,
+			MIN(id)
+		FROM
+			job_applications
+		GROUP BY
+			user_id,
+			normalized_name
+	);
+
+UPDATE job_applications
+SET
+	company_id = (
+		SELECT
+			c.id
+		FROM
+			companies c
+		WHERE
+			c.user_id = job_applications.user_id
+			AND c.normalized_name = lower(replace(replace(replace(replace(job_applications.company, ' ', ''), char(9), ''), char(10), ''), char(13), ''))
+	);
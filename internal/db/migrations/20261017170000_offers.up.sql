@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS job_application_offers (
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	currency TEXT NOT NULL DEFAULT 'USD',
+	base_salary INTEGER NOT NULL DEFAULT 0,
+	bonus INTEGER NOT NULL DEFAULT 0,
+	sign_on_bonus INTEGER NOT NULL DEFAULT 0,
+	equity_shares INTEGER NOT NULL DEFAULT 0,
+	equity_strike_price REAL NOT NULL DEFAULT 0,
+	equity_share_price REAL NOT NULL DEFAULT 0,
+	vesting_years INTEGER NOT NULL DEFAULT 4,
+	vesting_cliff_months INTEGER NOT NULL DEFAULT 12,
+	benefits TEXT NOT NULL DEFAULT '',
+	deadline DATETIME,
+	id INTEGER PRIMARY KEY,
+	job_application_id INTEGER NOT NULL UNIQUE,
+	FOREIGN KEY (job_application_id) REFERENCES job_applications(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS exchange_rates (
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	currency TEXT NOT NULL,
+	rate REAL NOT NULL CHECK (rate > 0),
+	user_id INTEGER NOT NULL,
+	PRIMARY KEY (user_id, currency),
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+ALTER TABLE users
+ADD COLUMN display_currency TEXT NOT NULL DEFAULT 'USD';
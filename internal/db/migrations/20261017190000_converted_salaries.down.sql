@@ -0,0 +1 @@
+DROP VIEW IF EXISTS job_application_converted_salaries;
@@ -0,0 +1,17 @@
+CREATE VIEW IF NOT EXISTS job_application_converted_salaries AS
+SELECT
+	j.id AS job_application_id,
+	ROUND(j.salary_min * f.rate / t.rate) AS salary_min,
+	ROUND(j.salary_max * f.rate / t.rate) AS salary_max
+FROM job_applications j
+JOIN users u ON u.id = j.user_id
+JOIN (
+	SELECT user_id, currency, rate FROM exchange_rates
+	UNION ALL
+	SELECT id AS user_id, 'USD' AS currency, 1.0 AS rate FROM users
+) f ON f.user_id = j.user_id AND f.currency = COALESCE(NULLIF(j.salary_currency, ''), u.display_currency)
+JOIN (
+	SELECT user_id, currency, rate FROM exchange_rates
+	UNION ALL
+	SELECT id AS user_id, 'USD' AS currency, 1.0 AS rate FROM users
+) t ON t.user_id = j.user_id AND t.currency = u.display_currency;
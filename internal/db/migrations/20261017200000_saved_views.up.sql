@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS saved_views (
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	name TEXT NOT NULL COLLATE NOCASE,
+	filters TEXT NOT NULL,
+	id INTEGER PRIMARY KEY,
+	user_id INTEGER NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+	UNIQUE (user_id, name)
+);
@@ -0,0 +1 @@
+ALTER TABLE job_application_notes DROP COLUMN updated_at;
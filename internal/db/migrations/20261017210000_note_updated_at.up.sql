@@ -0,0 +1 @@
+ALTER TABLE job_application_notes ADD COLUMN updated_at DATETIME;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS job_application_attachments_job_application_id_idx;
+DROP TABLE IF EXISTS job_application_attachments;
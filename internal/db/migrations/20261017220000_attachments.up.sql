@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS job_application_attachments (
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	detached_at DATETIME,
+	name TEXT NOT NULL,
+	kind TEXT NOT NULL,
+	content_type TEXT NOT NULL,
+	size INTEGER NOT NULL,
+	storage_key TEXT NOT NULL UNIQUE,
+	id INTEGER PRIMARY KEY,
+	job_application_id INTEGER,
+	user_id INTEGER NOT NULL,
+	FOREIGN KEY (job_application_id) REFERENCES job_applications(id) ON DELETE SET NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS job_application_attachments_job_application_id_idx ON job_application_attachments(job_application_id);
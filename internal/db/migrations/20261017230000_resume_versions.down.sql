@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS job_applications_resume_version_id_idx;
+ALTER TABLE job_applications DROP COLUMN resume_version_id;
+DROP TABLE IF EXISTS resume_versions;
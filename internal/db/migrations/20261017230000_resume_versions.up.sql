@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS resume_versions (
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	label TEXT NOT NULL COLLATE NOCASE,
+	file_name TEXT,
+	content_type TEXT,
+	size INTEGER,
+	storage_key TEXT UNIQUE,
+	id INTEGER PRIMARY KEY,
+	user_id INTEGER NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+	UNIQUE (user_id, label)
+);
+
+ALTER TABLE job_applications ADD COLUMN resume_version_id INTEGER;
+
+CREATE INDEX IF NOT EXISTS job_applications_resume_version_id_idx ON job_applications(resume_version_id);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS two_factor_failures_user_id_idx;
+DROP TABLE IF EXISTS two_factor_failures;
+DROP INDEX IF EXISTS two_factor_challenges_user_id_idx;
+DROP TABLE IF EXISTS two_factor_challenges;
+DROP INDEX IF EXISTS user_recovery_codes_user_id_idx;
+DROP TABLE IF EXISTS user_recovery_codes;
+DROP TABLE IF EXISTS user_totp;
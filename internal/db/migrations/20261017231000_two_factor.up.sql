@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS user_totp (
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	secret TEXT NOT NULL,
+	enabled_at DATETIME,
+	last_used_step INTEGER NOT NULL DEFAULT 0,
+	id INTEGER PRIMARY KEY,
+	user_id INTEGER NOT NULL UNIQUE,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	used_at DATETIME,
+	code_hash TEXT NOT NULL,
+	id INTEGER PRIMARY KEY,
+	user_id INTEGER NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS user_recovery_codes_user_id_idx ON user_recovery_codes(user_id);
+
+CREATE TABLE IF NOT EXISTS two_factor_challenges (
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	expires_at DATETIME NOT NULL,
+	token_hash TEXT NOT NULL UNIQUE,
+	id INTEGER PRIMARY KEY,
+	user_id INTEGER NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS two_factor_challenges_user_id_idx ON two_factor_challenges(user_id);
+
+CREATE TABLE IF NOT EXISTS two_factor_failures (
+	created_at DATETIME NOT NULL,
+	id INTEGER PRIMARY KEY,
+	user_id INTEGER NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS two_factor_failures_user_id_idx ON two_factor_failures(user_id);
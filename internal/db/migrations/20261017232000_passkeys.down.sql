@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS passkey_sessions;
+DROP INDEX IF EXISTS passkeys_user_id_idx;
+DROP TABLE IF EXISTS passkeys;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS passkeys (
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	last_used_at DATETIME,
+	name TEXT NOT NULL,
+	credential_id BLOB NOT NULL UNIQUE,
+	credential TEXT NOT NULL,
+	id INTEGER PRIMARY KEY,
+	user_id INTEGER NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS passkeys_user_id_idx ON passkeys(user_id);
+
+CREATE TABLE IF NOT EXISTS passkey_sessions (
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	expires_at DATETIME NOT NULL,
+	token_hash TEXT NOT NULL UNIQUE,
+	session_data TEXT NOT NULL,
+	id INTEGER PRIMARY KEY,
+	user_id INTEGER,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
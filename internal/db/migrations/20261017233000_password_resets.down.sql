@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS password_reset_tokens_user_id_idx;
+DROP TABLE IF EXISTS password_reset_tokens;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS email_verification_tokens_user_id_idx;
+DROP TABLE IF EXISTS email_verification_tokens;
+
+ALTER TABLE users
+DROP COLUMN email_verified_at;
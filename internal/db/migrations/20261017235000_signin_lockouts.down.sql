@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS signin_audits_created_at_idx;
+DROP INDEX IF EXISTS signin_audits_email_idx;
+DROP INDEX IF EXISTS signin_audits_ip_address_idx;
+DROP INDEX IF EXISTS signin_audits_user_id_idx;
+DROP TABLE IF EXISTS signin_audits;
+DROP INDEX IF EXISTS signin_throttles_updated_at_idx;
+DROP TABLE IF EXISTS signin_throttles;
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS signin_throttles (
+	updated_at DATETIME NOT NULL,
+	locked_until DATETIME,
+	kind TEXT NOT NULL,
+	value TEXT NOT NULL,
+	failures INTEGER NOT NULL DEFAULT 0,
+	lockouts INTEGER NOT NULL DEFAULT 0,
+	id INTEGER PRIMARY KEY,
+	UNIQUE (kind, value)
+);
+
+CREATE TABLE IF NOT EXISTS signin_audits (
+	created_at DATETIME NOT NULL,
+	event TEXT NOT NULL,
+	email TEXT NOT NULL,
+	ip_address TEXT NOT NULL,
+	user_agent TEXT NOT NULL,
+	id INTEGER PRIMARY KEY,
+	user_id INTEGER,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS signin_throttles_updated_at_idx ON signin_throttles(updated_at);
+CREATE INDEX IF NOT EXISTS signin_audits_user_id_idx ON signin_audits(user_id);
+CREATE INDEX IF NOT EXISTS signin_audits_ip_address_idx ON signin_audits(ip_address, created_at);
+CREATE INDEX IF NOT EXISTS signin_audits_email_idx ON signin_audits(email, created_at);
+CREATE INDEX IF NOT EXISTS signin_audits_created_at_idx ON signin_audits(created_at);
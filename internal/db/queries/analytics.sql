@@ -55,3 +55,70 @@ WHERE
     WHERE h.job_application_id = ja.id
       AND h.status != 'applied'
   );
+
+-- name: GetSalaryRangesForUser :many
+SELECT
+  status,
+  salary_min,
+  salary_max,
+  salary_currency
+FROM
+  job_applications
+WHERE
+  user_id = ?
+  AND archived = false
+  AND (
+    salary_min IS NOT NULL
+    OR salary_max IS NOT NULL
+  );
+
+-- name: GetResumeVersionResponseCounts :many
+SELECT
+  rv.id,
+  rv.label,
+  COUNT(ja.id) AS applied_count,
+  COUNT(
+    CASE
+      WHEN EXISTS (
+        SELECT 1
+        FROM job_application_status_histories h
+        WHERE h.job_application_id = ja.id
+          AND h.id > ja.applied_history_id
+          AND h.status != 'applied'
+      ) THEN 1
+    END
+  ) AS responded_count,
+  COUNT(
+    CASE
+      WHEN EXISTS (
+        SELECT 1
+        FROM job_application_status_histories h
+        WHERE h.job_application_id = ja.id
+          AND h.id > ja.applied_history_id
+          AND h.status = 'interviewing'
+      ) THEN 1
+    END
+  ) AS interviewed_count
+FROM
+  resume_versions rv
+  LEFT JOIN (
+    SELECT
+      j.id,
+      j.resume_version_id,
+      MIN(h.id) AS applied_history_id
+    FROM
+      job_applications j
+      JOIN job_application_status_histories h ON h.job_application_id = j.id
+    WHERE
+      j.user_id = sqlc.arg(user_id)
+      AND j.archived = false
+      AND h.status = 'applied'
+    GROUP BY
+      j.id
+  ) ja ON ja.resume_version_id = rv.id
+WHERE
+  rv.user_id = sqlc.arg(user_id)
+GROUP BY
+  rv.id
+ORDER BY
+  rv.label ASC;
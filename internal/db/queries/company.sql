@@ -0,0 +1,121 @@
+-- name: UpsertCompany :one
+INSERT INTO
+  companies (user_id, name, normalized_name)
+VALUES
+  (?, ?, ?) ON CONFLICT (user_id, normalized_name) DO UPDATE
+SET
+  normalized_name = excluded.normalized_name RETURNING id;
+
+-- name: GetCompaniesByUserID :many
+SELECT
+  c.name,
+  c.id,
+  COUNT(j.id) AS job_applications,
+  CAST(COALESCE(SUM(j.archived = 0), 0) AS INTEGER) AS active_job_applications
+FROM
+  companies c
+  LEFT JOIN job_applications j ON j.company_id = c.id
+WHERE
+  c.user_id = ?
+GROUP BY
+  c.id
+ORDER BY
+  c.name COLLATE NOCASE;
+
+-- name: GetCompanyByIDAndUserID :one
+SELECT
+  name,
+  normalized_name,
+  id
+FROM
+  companies
+WHERE
+  id = ?
+  AND user_id = ?;
+
+-- name: GetCompanyIDByNormalizedName :one
+SELECT
+  id
+FROM
+  companies
+WHERE
+  normalized_name = ?
+  AND user_id = ?;
+
+-- name: GetJobApplicationsByCompanyID :many
+SELECT
+  j.applied_at,
+  j.updated_at,
+  j.company,
+  j.title,
+  j.status,
+  j.url,
+  j.id,
+  j.archived
+FROM
+  job_applications j
+WHERE
+  j.company_id = ?
+  AND j.user_id = ?
+ORDER BY
+  j.applied_at DESC;
+
+-- name: GetJobApplicationNotesByCompanyID :many
+SELECT
+  n.created_at,
+  n.note,
+  n.id,
+  j.title,
+  j.id AS job_application_id
+FROM
+  job_application_notes n
+  JOIN job_applications j ON j.id = n.job_application_id
+WHERE
+  j.company_id = ?
+  AND j.user_id = ?
+ORDER BY
+  n.created_at DESC;
+
+-- name: GetHNJobsByNormalizedCompany :many
+SELECT
+  hn_jobs.id,
+  hn_jobs.title,
+  hn_jobs.location,
+  hn_comments.commented_at
+FROM
+  hn_jobs
+  LEFT JOIN hn_comments ON hn_comments.id = hn_jobs.hn_comment_id
+WHERE
+  lower(replace(replace(replace(replace(hn_jobs.company, ' ', ''), char(9), ''), char(10), ''), char(13), '')) = sqlc.arg(normalized_name)
+ORDER BY
+  hn_comments.commented_at DESC
+LIMIT
+  50;
+
+-- name: MergeCompanyJobApplications :exec
+UPDATE job_applications
+SET
+  company = sqlc.arg(company),
+  company_id = sqlc.arg(target_id)
+WHERE
+  company_id = sqlc.arg(source_id)
+  AND user_id = sqlc.arg(user_id);
+
+-- name: DeleteCompany :execrows
+DELETE FROM companies
+WHERE
+  id = ?
+  AND user_id = ?;
+
+-- name: DeleteUnusedCompaniesByUserID :exec
+DELETE FROM companies
+WHERE
+  user_id = ?
+  AND NOT EXISTS (
+    SELECT
+      1
+    FROM
+      job_applications j
+    WHERE
+      j.company_id = companies.id
+  );
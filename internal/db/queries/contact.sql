@@ -0,0 +1,140 @@
+-- name: GetContactsByUserID :many
+SELECT
+  name,
+  role,
+  email,
+  linkedin_url,
+  company,
+  id
+FROM
+  contacts
+WHERE
+  user_id = ?
+ORDER BY
+  name COLLATE NOCASE ASC,
+  id ASC;
+
+-- name: GetContactByIDAndUserID :one
+SELECT
+  name,
+  role,
+  email,
+  linkedin_url,
+  company,
+  id
+FROM
+  contacts
+WHERE
+  id = ?
+  AND user_id = ?;
+
+-- name: GetContactByEmailAndUserID :one
+SELECT
+  name,
+  role,
+  email,
+  linkedin_url,
+  company,
+  id
+FROM
+  contacts
+WHERE
+  email = ? COLLATE NOCASE
+  AND user_id = ?
+ORDER BY
+  id ASC
+LIMIT
+  1;
+
+-- name: InsertContact :one
+INSERT INTO
+  contacts (user_id, name, role, email, linkedin_url, company)
+VALUES
+  (?, ?, ?, ?, ?, ?) RETURNING id;
+
+-- name: UpdateContact :execrows
+UPDATE contacts
+SET
+  name = ?,
+  role = ?,
+  email = ?,
+  linkedin_url = ?,
+  company = ?,
+  updated_at = CURRENT_TIMESTAMP
+WHERE
+  id = ?
+  AND user_id = ?;
+
+-- name: DeleteContact :execrows
+DELETE FROM contacts
+WHERE
+  id = ?
+  AND user_id = ?;
+
+-- name: DeleteJobApplicationContactsByContactID :exec
+DELETE FROM job_application_contacts
+WHERE
+  contact_id = ?;
+
+-- name: DeleteJobApplicationContactsByJobApplicationID :exec
+DELETE FROM job_application_contacts
+WHERE
+  job_application_id = ?;
+
+-- name: InsertJobApplicationContact :execrows
+INSERT INTO
+  job_application_contacts (job_application_id, contact_id)
+VALUES
+  (?, ?) ON CONFLICT (job_application_id, contact_id) DO NOTHING;
+
+-- name: DeleteJobApplicationContact :execrows
+DELETE FROM job_application_contacts
+WHERE
+  job_application_id = ?
+  AND contact_id = ?;
+
+-- name: GetContactsByJobApplicationID :many
+SELECT
+  c.name,
+  c.role,
+  c.email,
+  c.linkedin_url,
+  c.company,
+  c.id
+FROM
+  contacts c
+  JOIN job_application_contacts jc ON jc.contact_id = c.id
+WHERE
+  jc.job_application_id = ?
+ORDER BY
+  c.name COLLATE NOCASE ASC,
+  c.id ASC;
+
+-- name: GetContactJobApplicationsByUserID :many
+SELECT
+  jc.contact_id,
+  j.company,
+  j.title,
+  j.status,
+  j.archived,
+  j.id
+FROM
+  job_application_contacts jc
+  JOIN contacts c ON c.id = jc.contact_id
+  JOIN job_applications j ON j.id = jc.job_application_id
+WHERE
+  c.user_id = ?
+ORDER BY
+  j.updated_at DESC;
+
+-- name: GetAllJobApplicationContactsByUserID :many
+SELECT
+  jc.job_application_id,
+  jc.contact_id
+FROM
+  job_application_contacts jc
+  JOIN contacts c ON c.id = jc.contact_id
+WHERE
+  c.user_id = ?
+ORDER BY
+  jc.contact_id ASC;
@@ -0,0 +1,35 @@
+-- name: InsertEmailVerificationToken :exec
+INSERT INTO
+  email_verification_tokens (user_id, token_hash, created_at, expires_at)
+VALUES
+  (
+    sqlc.arg(user_id),
+    sqlc.arg(token_hash),
+    datetime(sqlc.arg(now)),
+    datetime(sqlc.arg(expires_at))
+  );
+
+-- name: CountEmailVerificationTokensSince :one
+SELECT
+  COUNT(*)
+FROM
+  email_verification_tokens
+WHERE
+  user_id = sqlc.arg(user_id)
+  AND created_at > datetime(sqlc.arg(since));
+
+-- name: UseEmailVerificationToken :one
+DELETE FROM email_verification_tokens
+WHERE
+  token_hash = sqlc.arg(token_hash)
+  AND expires_at > datetime(sqlc.arg(now)) RETURNING user_id;
+
+-- name: DeleteEmailVerificationTokensByUserID :exec
+DELETE FROM email_verification_tokens
+WHERE
+  user_id = ?;
+
+-- name: DeleteExpiredEmailVerificationTokens :exec
+DELETE FROM email_verification_tokens
+WHERE
+  expires_at <= datetime(sqlc.arg(now));
@@ -0,0 +1,25 @@
+-- name: GetExchangeRatesByUserID :many
+SELECT
+  currency,
+  rate
+FROM
+  exchange_rates
+WHERE
+  user_id = ?
+ORDER BY
+  currency;
+
+-- name: UpsertExchangeRate :exec
+INSERT INTO
+  exchange_rates (user_id, currency, rate)
+VALUES
+  (?, ?, ?) ON CONFLICT (user_id, currency) DO UPDATE
+SET
+  rate = excluded.rate,
+  updated_at = CURRENT_TIMESTAMP;
+
+-- name: DeleteExchangeRate :exec
+DELETE FROM exchange_rates
+WHERE
+  user_id = ?
+  AND currency = ?;
@@ -247,3 +247,11 @@ LIMIT
   sqlc.arg ('limit')
 OFFSET
   sqlc.arg ('offset');
+
+-- name: CountHNJobsByID :one
+SELECT
+  COUNT(*)
+FROM
+  hn_jobs
+WHERE
+  id = ?;
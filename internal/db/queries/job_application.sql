@@ -36,7 +36,7 @@ WHERE
   j.id = ?
   AND j.user_id = ?;
 
--- name: GetJobApplicationsByUserID :many
+-- name: FilterJobApplications :many
 SELECT
   j.applied_at,
   j.updated_at,
@@ -47,52 +47,176 @@ SELECT
   j.id,
   j.salary_min,
   j.salary_max,
-  j.salary_currency
+  j.salary_currency,
+  CASE
+    WHEN CAST(sqlc.arg(sort) AS TEXT) = 'salary' THEN COALESCE(cs.salary_max, cs.salary_min) IS NULL
+    ELSE 0
+  END AS sort_last,
+  CASE
+    WHEN CAST(sqlc.arg(descending) AS INTEGER) = 0 THEN CASE CAST(sqlc.arg(sort) AS TEXT)
+      WHEN 'applied' THEN j.applied_at
+      WHEN 'company' THEN LOWER(j.company)
+      WHEN 'title' THEN LOWER(j.title)
+      WHEN 'status' THEN s.position
+      WHEN 'salary' THEN COALESCE(cs.salary_max, cs.salary_min)
+      ELSE j.updated_at
+    END
+  END AS sort_asc,
+  CASE
+    WHEN CAST(sqlc.arg(descending) AS INTEGER) = 1 THEN CASE CAST(sqlc.arg(sort) AS TEXT)
+      WHEN 'applied' THEN j.applied_at
+      WHEN 'company' THEN LOWER(j.company)
+      WHEN 'title' THEN LOWER(j.title)
+      WHEN 'status' THEN s.position
+      WHEN 'salary' THEN COALESCE(cs.salary_max, cs.salary_min)
+      ELSE j.updated_at
+    END
+  END AS sort_desc
 FROM
   job_applications j
+  LEFT JOIN job_application_converted_salaries cs ON cs.job_application_id = j.id
+  LEFT JOIN job_application_statuses s ON s.user_id = j.user_id
+  AND s.name = j.status
 WHERE
-  j.user_id = ?
-  AND j.archived = ?
+  j.user_id = sqlc.arg(user_id)
+  AND j.archived = sqlc.arg(archived)
+  AND (
+    (j.company LIKE sqlc.arg(company) ESCAPE '\')
+    OR sqlc.arg(company) = ''
+  )
+  AND (
+    j.status = sqlc.arg(status)
+    OR sqlc.arg(status) = ''
+  )
+  AND (
+    CAST(sqlc.arg(tag_id) AS INTEGER) = 0
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        job_application_tags jt
+      WHERE
+        jt.job_application_id = j.id
+        AND jt.tag_id = CAST(sqlc.arg(tag_id) AS INTEGER)
+    )
+  )
+  AND (
+    CAST(sqlc.arg(applied_from) AS TEXT) = ''
+    OR datetime(j.applied_at) >= datetime(CAST(sqlc.arg(applied_from) AS TEXT))
+  )
+  AND (
+    CAST(sqlc.arg(applied_to) AS TEXT) = ''
+    OR datetime(j.applied_at) < datetime(CAST(sqlc.arg(applied_to) AS TEXT), '+1 day')
+  )
+  AND (
+    CAST(sqlc.arg(salary_min) AS INTEGER) = 0
+    OR COALESCE(cs.salary_max, cs.salary_min) >= CAST(sqlc.arg(salary_min) AS INTEGER)
+  )
+  AND (
+    CAST(sqlc.arg(salary_max) AS INTEGER) = 0
+    OR COALESCE(cs.salary_min, cs.salary_max) <= CAST(sqlc.arg(salary_max) AS INTEGER)
+  )
+  AND (
+    CAST(sqlc.arg(has_url) AS INTEGER) = 0
+    OR COALESCE(j.url, '') != ''
+  )
+  AND (
+    CAST(sqlc.arg(no_response_days) AS INTEGER) = 0
+    OR (
+      datetime(j.applied_at) <= datetime('now', '-' || CAST(sqlc.arg(no_response_days) AS INTEGER) || ' days')
+      AND (
+        SELECT
+          COUNT(*)
+        FROM
+          job_application_status_histories h
+        WHERE
+          h.job_application_id = j.id
+      ) <= 1
+    )
+  )
 ORDER BY
-  j.updated_at DESC
+  sort_last,
+  sort_asc ASC,
+  sort_desc DESC,
+  j.updated_at DESC,
+  j.id DESC
 LIMIT
-  ?
+  sqlc.arg(limit)
 OFFSET
-  ?;
+  sqlc.arg(offset);
 
--- name: CountJobApplicationsByUserID :one
+-- name: CountFilterJobApplications :one
 SELECT
   COUNT(*)
 FROM
-  job_applications
+  job_applications j
+  LEFT JOIN job_application_converted_salaries cs ON cs.job_application_id = j.id
 WHERE
-  user_id = ?
-  AND archived = ?;
+  j.user_id = sqlc.arg(user_id)
+  AND j.archived = sqlc.arg(archived)
+  AND (
+    (j.company LIKE sqlc.arg(company) ESCAPE '\')
+    OR sqlc.arg(company) = ''
+  )
+  AND (
+    j.status = sqlc.arg(status)
+    OR sqlc.arg(status) = ''
+  )
+  AND (
+    CAST(sqlc.arg(tag_id) AS INTEGER) = 0
+    OR EXISTS (
+      SELECT
+        1
+      FROM
+        job_application_tags jt
+      WHERE
+        jt.job_application_id = j.id
+        AND jt.tag_id = CAST(sqlc.arg(tag_id) AS INTEGER)
+    )
+  )
+  AND (
+    CAST(sqlc.arg(applied_from) AS TEXT) = ''
+    OR datetime(j.applied_at) >= datetime(CAST(sqlc.arg(applied_from) AS TEXT))
+  )
+  AND (
+    CAST(sqlc.arg(applied_to) AS TEXT) = ''
+    OR datetime(j.applied_at) < datetime(CAST(sqlc.arg(applied_to) AS TEXT), '+1 day')
+  )
+  AND (
+    CAST(sqlc.arg(salary_min) AS INTEGER) = 0
+    OR COALESCE(cs.salary_max, cs.salary_min) >= CAST(sqlc.arg(salary_min) AS INTEGER)
+  )
+  AND (
+    CAST(sqlc.arg(salary_max) AS INTEGER) = 0
+    OR COALESCE(cs.salary_min, cs.salary_max) <= CAST(sqlc.arg(salary_max) AS INTEGER)
+  )
+  AND (
+    CAST(sqlc.arg(has_url) AS INTEGER) = 0
+    OR COALESCE(j.url, '') != ''
+  )
+  AND (
+    CAST(sqlc.arg(no_response_days) AS INTEGER) = 0
+    OR (
+      datetime(j.applied_at) <= datetime('now', '-' || CAST(sqlc.arg(no_response_days) AS INTEGER) || ' days')
+      AND (
+        SELECT
+          COUNT(*)
+        FROM
+          job_application_status_histories h
+        WHERE
+          h.job_application_id = j.id
+      ) <= 1
+    )
+  );
 
--- name: GetJobApplicationsByUserIDAndCompany :many
+-- name: CountJobApplicationsByUserID :one
 SELECT
-  j.applied_at,
-  j.updated_at,
-  j.company,
-  j.title,
-  j.status,
-  j.url,
-  j.id,
-  j.salary_min,
-  j.salary_max,
-  j.salary_currency
+  COUNT(*)
 FROM
-  job_applications j
+  job_applications
 WHERE
-  j.company LIKE ?
-  AND j.user_id = ?
-  AND j.archived = ?
-ORDER BY
-  j.updated_at DESC
-LIMIT
-  ?
-OFFSET
-  ?;
+  user_id = ?
+  AND archived = ?;
 
 -- name: CountJobApplicationsByUserIDAndCompany :one
 SELECT
@@ -104,31 +228,6 @@ WHERE
   AND user_id = ?
   AND archived = ?;
 
--- name: GetJobApplicationsByUserIDAndStatus :many
-SELECT
-  j.applied_at,
-  j.updated_at,
-  j.company,
-  j.title,
-  j.status,
-  j.url,
-  j.id,
-  j.salary_min,
-  j.salary_max,
-  j.salary_currency
-FROM
-  job_applications j
-WHERE
-  j.status = ?
-  AND j.user_id = ?
-  AND j.archived = ?
-ORDER BY
-  j.updated_at DESC
-LIMIT
-  ?
-OFFSET
-  ?;
-
 -- name: CountJobApplicationsByUserIDAndStatus :one
 SELECT
   COUNT(*)
@@ -139,32 +238,6 @@ WHERE
   AND user_id = ?
   AND archived = ?;
 
--- name: GetJobApplicationsByUserIDAndCompanyAndStatus :many
-SELECT
-  j.applied_at,
-  j.updated_at,
-  j.company,
-  j.title,
-  j.status,
-  j.url,
-  j.id,
-  j.salary_min,
-  j.salary_max,
-  j.salary_currency
-FROM
-  job_applications j
-WHERE
-  j.company LIKE ?
-  AND j.status = ?
-  AND j.user_id = ?
-  AND j.archived = ?
-ORDER BY
-  j.updated_at DESC
-LIMIT
-  ?
-OFFSET
-  ?;
-
 -- name: CountJobApplicationsByUserIDAndCompanyAndStatus :one
 SELECT
   COUNT(*)
@@ -182,7 +255,7 @@ SELECT
 FROM
   job_applications
 WHERE
-  company = ?
+  company_id = ?
   AND user_id = ?
   AND archived = ?;
 
@@ -190,6 +263,7 @@ WHERE
 INSERT INTO
   job_applications (
     company,
+    company_id,
     title,
     url,
     user_id,
@@ -198,12 +272,13 @@ INSERT INTO
     salary_currency
   )
 VALUES
-  (?, ?, ?, ?, ?, ?, ?) RETURNING id;
+  (?, ?, ?, ?, ?, ?, ?, ?) RETURNING id;
 
 -- name: UpdateJobApplication :exec
 UPDATE job_applications
 SET
   company = ?,
+  company_id = ?,
   title = ?,
   STATUS = ?,
   url = ?,
@@ -261,16 +336,28 @@ FROM
   job_applications j
   LEFT JOIN job_application_status_histories h 
     ON h.job_application_id = j.id
-    AND h.status IN ('interviewing', 'offered', 'rejected', 'accepted', 'declined')
+    AND h.status NOT IN ('watching', 'applied', 'withdrawn', 'canceled', 'closed')
 WHERE
   j.user_id = ?
   AND j.archived = 0
 GROUP BY
   j.id;
 
+-- name: HasJobApplicationHeardBack :one
+SELECT
+  EXISTS (
+    SELECT
+      1
+    FROM
+      job_application_status_histories
+    WHERE
+      job_application_id = ?
+      AND status NOT IN ('watching', 'applied', 'withdrawn', 'canceled', 'closed')
+  );
+
 -- name: CountJobApplicationCompanies :one
 SELECT
-  COUNT(DISTINCT company)
+  COUNT(DISTINCT company_id)
 FROM
   job_applications j
 WHERE
@@ -288,10 +375,101 @@ SELECT
   j.archived,
   j.salary_min,
   j.salary_max,
-  j.salary_currency
+  j.salary_currency,
+  CAST(
+    COALESCE(
+      (
+        SELECT
+          group_concat(t.name, ', ')
+        FROM
+          (
+            SELECT
+              t.name
+            FROM
+              tags t
+              JOIN job_application_tags jt ON jt.tag_id = t.id
+            WHERE
+              jt.job_application_id = j.id
+            ORDER BY
+              t.name
+          ) t
+      ),
+      ''
+    ) AS TEXT
+  ) AS tags
 FROM
   job_applications j
 WHERE
   j.user_id = ?
 ORDER BY
   j.applied_at DESC;
+
+-- name: InsertImportedJobApplication :one
+INSERT INTO
+  job_applications (
+    company,
+    company_id,
+    title,
+    url,
+    status,
+    user_id,
+    salary_min,
+    salary_max,
+    salary_currency,
+    applied_at,
+    updated_at
+  )
+VALUES
+  (?, ?, ?, ?, ?, ?, ?, ?, ?, datetime(sqlc.arg(applied_at)), datetime(sqlc.arg(updated_at))) RETURNING id;
+
+-- name: GetJobApplicationKeysByUserID :many
+SELECT
+  j.company,
+  j.title,
+  j.url
+FROM
+  job_applications j
+WHERE
+  j.user_id = ?;
+
+-- name: GetJobApplicationsForExport :many
+SELECT
+  j.id,
+  j.created_at,
+  j.applied_at,
+  j.updated_at,
+  j.company,
+  j.title,
+  j.status,
+  j.url,
+  j.archived,
+  j.salary_min,
+  j.salary_max,
+  j.salary_currency,
+  j.resume_version_id
+FROM
+  job_applications j
+WHERE
+  j.user_id = ?
+ORDER BY
+  j.id;
+
+-- name: InsertJobApplicationFromArchive :one
+INSERT INTO
+  job_applications (
+    company,
+    company_id,
+    title,
+    url,
+    status,
+    archived,
+    user_id,
+    salary_min,
+    salary_max,
+    salary_currency,
+    applied_at,
+    updated_at,
+    created_at
+  )
+VALUES
+  (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, datetime(sqlc.arg(applied_at)), datetime(sqlc.arg(updated_at)), datetime(sqlc.arg(created_at))) RETURNING id;
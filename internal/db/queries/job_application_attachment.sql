@@ -0,0 +1,117 @@
+-- name: GetJobApplicationAttachmentsByJobApplicationID :many
+SELECT
+  *
+FROM
+  job_application_attachments
+WHERE
+  job_application_id = ?
+ORDER BY
+  created_at DESC;
+
+-- name: GetJobApplicationAttachmentByIDAndUserID :one
+SELECT
+  *
+FROM
+  job_application_attachments
+WHERE
+  id = ?
+  AND job_application_id = ?
+  AND user_id = ?;
+
+-- name: InsertJobApplicationAttachment :one
+INSERT INTO
+  job_application_attachments (
+    name,
+    kind,
+    content_type,
+    size,
+    storage_key,
+    job_application_id,
+    user_id,
+    created_at
+  )
+VALUES
+  (
+    sqlc.arg(name),
+    sqlc.arg(kind),
+    sqlc.arg(content_type),
+    sqlc.arg(size),
+    sqlc.arg(storage_key),
+    sqlc.arg(job_application_id),
+    sqlc.arg(user_id),
+    datetime(sqlc.arg(created_at))
+  ) RETURNING *;
+
+-- name: DeleteJobApplicationAttachment :execrows
+DELETE FROM job_application_attachments
+WHERE
+  id = ?
+  AND user_id = ?;
+
+-- name: GetAllJobApplicationAttachmentsByUserID :many
+SELECT
+  a.*
+FROM
+  job_application_attachments a
+  JOIN job_applications ja ON a.job_application_id = ja.id
+WHERE
+  ja.user_id = ?
+ORDER BY
+  a.id ASC;
+
+-- name: GetStorageKeysByUserID :many
+SELECT
+  storage_key
+FROM
+  job_application_attachments
+WHERE
+  user_id = ?;
+
+-- name: DeleteJobApplicationAttachmentsByUserID :exec
+DELETE FROM job_application_attachments
+WHERE
+  user_id = ?;
+
+-- name: DetachJobApplicationAttachmentsByJobApplicationID :exec
+UPDATE job_application_attachments
+SET
+  job_application_id = NULL,
+  detached_at = CURRENT_TIMESTAMP
+WHERE
+  job_application_id = ?;
+
+-- name: GetJobApplicationAttachmentIDsForSnapshot :many
+SELECT
+  id
+FROM
+  job_application_attachments
+WHERE
+  job_application_id = ?
+ORDER BY
+  id;
+
+-- name: ReattachJobApplicationAttachment :exec
+UPDATE job_application_attachments
+SET
+  job_application_id = sqlc.arg(job_application_id),
+  detached_at = NULL
+WHERE
+  id = sqlc.arg(id)
+  AND user_id = sqlc.arg(user_id)
+  AND job_application_id IS NULL;
+
+-- name: GetDetachedJobApplicationAttachments :many
+SELECT
+  id,
+  storage_key
+FROM
+  job_application_attachments
+WHERE
+  job_application_id IS NULL
+  AND COALESCE(detached_at, created_at) < datetime(sqlc.arg(detached_before));
+
+-- name: DeleteDetachedJobApplicationAttachment :exec
+DELETE FROM job_application_attachments
+WHERE
+  id = ?
+  AND job_application_id IS NULL;
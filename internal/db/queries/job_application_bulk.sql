@@ -0,0 +1,270 @@
+-- name: InsertJobApplicationBulkAction :one
+INSERT INTO
+  job_application_bulk_actions (user_id, action, undo_data, created_at)
+VALUES
+  (sqlc.arg(user_id), sqlc.arg(action), sqlc.arg(undo_data), datetime(sqlc.arg(created_at))) RETURNING id;
+
+-- name: GetJobApplicationBulkActionByIDAndUserID :one
+SELECT
+  b.created_at,
+  b.action,
+  b.undo_data,
+  b.id,
+  b.user_id
+FROM
+  job_application_bulk_actions b
+WHERE
+  b.id = ?
+  AND b.user_id = ?;
+
+-- name: DeleteJobApplicationBulkAction :exec
+DELETE FROM job_application_bulk_actions
+WHERE
+  id = ?;
+
+-- name: DeleteExpiredJobApplicationBulkActions :exec
+DELETE FROM job_application_bulk_actions
+WHERE
+  user_id = sqlc.arg(user_id)
+  AND created_at < datetime(sqlc.arg(created_before));
+
+-- name: GetJobApplicationForSnapshot :one
+SELECT
+  *
+FROM
+  job_applications
+WHERE
+  id = ?
+  AND user_id = ?;
+
+-- name: GetJobApplicationStatusHistoriesForSnapshot :many
+SELECT
+  *
+FROM
+  job_application_status_histories
+WHERE
+  job_application_id = ?
+ORDER BY
+  id ASC;
+
+-- name: GetJobApplicationNotesForSnapshot :many
+SELECT
+  *
+FROM
+  job_application_notes
+WHERE
+  job_application_id = ?
+ORDER BY
+  id ASC;
+
+-- name: GetJobApplicationRemindersForSnapshot :many
+SELECT
+  *
+FROM
+  job_application_reminders
+WHERE
+  job_application_id = ?
+ORDER BY
+  id ASC;
+
+-- name: GetJobApplicationInterviewsForSnapshot :many
+SELECT
+  *
+FROM
+  job_application_interviews
+WHERE
+  job_application_id = ?
+ORDER BY
+  id ASC;
+
+-- name: GetUserHNJobsForSnapshot :many
+SELECT
+  *
+FROM
+  user_hn_jobs
+WHERE
+  job_application_id = ?;
+
+-- name: GetLastJobApplicationStatusHistoryID :one
+SELECT
+  id
+FROM
+  job_application_status_histories
+WHERE
+  job_application_id = ?
+ORDER BY
+  id DESC
+LIMIT
+  1;
+
+-- name: DeleteJobApplicationStatusHistory :exec
+DELETE FROM job_application_status_histories
+WHERE
+  id = ?
+  AND job_application_id = ?;
+
+-- name: RestoreJobApplicationStatus :exec
+UPDATE job_applications
+SET
+  status = sqlc.arg(status),
+  updated_at = datetime(sqlc.arg(updated_at))
+WHERE
+  id = sqlc.arg(id)
+  AND user_id = sqlc.arg(user_id);
+
+-- name: RestoreJobApplicationReminder :exec
+UPDATE job_application_reminders
+SET
+  dismissed_at = NULL
+WHERE
+  id = ?
+  AND job_application_id = ?;
+
+-- name: DeleteJobApplicationNote :exec
+DELETE FROM job_application_notes
+WHERE
+  id = ?
+  AND job_application_id = ?;
+
+-- name: DeleteJobApplicationByIDAndUserID :execrows
+DELETE FROM job_applications
+WHERE
+  id = ?
+  AND user_id = ?;
+
+-- name: DeleteJobApplicationStatusHistoriesByJobApplicationID :exec
+DELETE FROM job_application_status_histories
+WHERE
+  job_application_id = ?;
+
+-- name: DeleteJobApplicationNotesByJobApplicationID :exec
+DELETE FROM job_application_notes
+WHERE
+  job_application_id = ?;
+
+-- name: DeleteJobApplicationRemindersByJobApplicationID :exec
+DELETE FROM job_application_reminders
+WHERE
+  job_application_id = ?;
+
+-- name: DeleteJobApplicationInterviewsByJobApplicationID :exec
+DELETE FROM job_application_interviews
+WHERE
+  job_application_id = ?;
+
+-- name: DeleteUserHNJobsByJobApplicationID :exec
+DELETE FROM user_hn_jobs
+WHERE
+  job_application_id = ?;
+
+-- name: InsertJobApplicationReminderFromSnapshot :exec
+INSERT INTO
+  job_application_reminders (
+    job_application_id,
+    remind_at,
+    dismissed_at,
+    note,
+    created_at,
+    updated_at
+  )
+VALUES
+  (
+    sqlc.arg(job_application_id),
+    datetime(sqlc.arg(remind_at)),
+    datetime(sqlc.narg(dismissed_at)),
+    sqlc.arg(note),
+    datetime(sqlc.arg(created_at)),
+    datetime(sqlc.arg(updated_at))
+  );
+
+-- name: InsertJobApplicationInterviewFromSnapshot :exec
+INSERT INTO
+  job_application_interviews (
+    job_application_id,
+    scheduled_at,
+    timezone,
+    type,
+    outcome,
+    interviewers,
+    duration_minutes,
+    created_at,
+    updated_at
+  )
+VALUES
+  (
+    sqlc.arg(job_application_id),
+    datetime(sqlc.arg(scheduled_at)),
+    sqlc.arg(timezone),
+    sqlc.arg(type),
+    sqlc.arg(outcome),
+    sqlc.arg(interviewers),
+    sqlc.arg(duration_minutes),
+    datetime(sqlc.arg(created_at)),
+    datetime(sqlc.arg(updated_at))
+  );
+
+-- name: InsertUserHNJobFromSnapshot :exec
+INSERT INTO
+  user_hn_jobs (user_id, hn_job_id, job_application_id, created_at)
+VALUES
+  (sqlc.arg(user_id), sqlc.arg(hn_job_id), sqlc.arg(job_application_id), datetime(sqlc.arg(created_at)));
+
+-- name: GetJobApplicationTagIDsForSnapshot :many
+SELECT
+  tag_id
+FROM
+  job_application_tags
+WHERE
+  job_application_id = ?
+ORDER BY
+  tag_id;
+
+-- name: InsertJobApplicationTagFromSnapshot :exec
+INSERT INTO
+  job_application_tags (job_application_id, tag_id)
+SELECT
+  sqlc.arg(job_application_id),
+  t.id
+FROM
+  tags t
+WHERE
+  t.id = sqlc.arg(tag_id)
+  AND t.user_id = sqlc.arg(user_id) ON CONFLICT (job_application_id, tag_id) DO NOTHING;
+
+-- name: GetJobApplicationContactIDsForSnapshot :many
+SELECT
+  contact_id
+FROM
+  job_application_contacts
+WHERE
+  job_application_id = ?
+ORDER BY
+  contact_id;
+
+-- name: InsertJobApplicationContactFromSnapshot :exec
+INSERT INTO
+  job_application_contacts (job_application_id, contact_id)
+SELECT
+  sqlc.arg(job_application_id),
+  c.id
+FROM
+  contacts c
+WHERE
+  c.id = sqlc.arg(contact_id)
+  AND c.user_id = sqlc.arg(user_id) ON CONFLICT (job_application_id, contact_id) DO NOTHING;
+
+-- name: RestoreJobApplicationResumeVersion :exec
+UPDATE job_applications
+SET
+  resume_version_id = (
+    SELECT
+      rv.id
+    FROM
+      resume_versions rv
+    WHERE
+      rv.id = sqlc.arg(resume_version_id)
+      AND rv.user_id = sqlc.arg(user_id)
+  )
+WHERE
+  job_applications.id = sqlc.arg(id)
+  AND job_applications.user_id = sqlc.arg(user_id);
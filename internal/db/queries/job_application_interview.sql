@@ -0,0 +1,128 @@
+-- name: InsertJobApplicationInterview :one
+INSERT INTO
+  job_application_interviews (
+    job_application_id,
+    scheduled_at,
+    timezone,
+    type,
+    interviewers,
+    duration_minutes
+  )
+VALUES
+  (
+    sqlc.arg(job_application_id),
+    datetime(sqlc.arg(scheduled_at)),
+    sqlc.arg(timezone),
+    sqlc.arg(type),
+    sqlc.arg(interviewers),
+    sqlc.arg(duration_minutes)
+  ) RETURNING created_at,
+  scheduled_at,
+  timezone,
+  type,
+  outcome,
+  interviewers,
+  duration_minutes,
+  id,
+  job_application_id;
+
+-- name: GetJobApplicationInterviewsByJobApplicationID :many
+SELECT
+  i.created_at,
+  i.scheduled_at,
+  i.timezone,
+  i.type,
+  i.outcome,
+  i.interviewers,
+  i.duration_minutes,
+  i.id,
+  i.job_application_id
+FROM
+  job_application_interviews i
+WHERE
+  i.job_application_id = ?
+ORDER BY
+  i.scheduled_at ASC;
+
+-- name: UpdateJobApplicationInterviewOutcome :one
+UPDATE job_application_interviews
+SET
+  outcome = sqlc.arg(outcome),
+  updated_at = CURRENT_TIMESTAMP
+WHERE
+  job_application_interviews.id = sqlc.arg(id)
+  AND job_application_interviews.job_application_id IN (
+    SELECT
+      ja.id
+    FROM
+      job_applications ja
+    WHERE
+      ja.user_id = sqlc.arg(user_id)
+  ) RETURNING created_at,
+  scheduled_at,
+  timezone,
+  type,
+  outcome,
+  interviewers,
+  duration_minutes,
+  id,
+  job_application_id;
+
+-- name: GetJobApplicationInterviewsForCalendarByUserID :many
+SELECT
+  i.updated_at,
+  i.scheduled_at,
+  i.type,
+  i.outcome,
+  i.interviewers,
+  i.duration_minutes,
+  i.id,
+  ja.company,
+  ja.title,
+  ja.url
+FROM
+  job_application_interviews i
+  JOIN job_applications ja ON i.job_application_id = ja.id
+WHERE
+  ja.user_id = ?
+ORDER BY
+  i.scheduled_at ASC;
+
+-- name: InsertCalendarFeedToken :one
+INSERT INTO
+  calendar_feed_tokens (user_id, token_hash)
+VALUES
+  (?, ?) RETURNING id,
+  created_at;
+
+-- name: GetCalendarFeedTokenByHash :one
+SELECT
+  user_id
+FROM
+  calendar_feed_tokens
+WHERE
+  token_hash = ?;
+
+-- name: GetCalendarFeedTokenByUserID :one
+SELECT
+  created_at
+FROM
+  calendar_feed_tokens
+WHERE
+  user_id = ?;
+
+-- name: DeleteCalendarFeedTokenByUserID :exec
+DELETE FROM calendar_feed_tokens
+WHERE
+  user_id = ?;
+
+-- name: GetAllJobApplicationInterviewsByUserID :many
+SELECT
+  i.*
+FROM
+  job_application_interviews i
+  JOIN job_applications ja ON i.job_application_id = ja.id
+WHERE
+  ja.user_id = ?
+ORDER BY
+  i.id ASC;
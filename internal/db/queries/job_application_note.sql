@@ -1,6 +1,7 @@
 -- name: GetJobApplicationNotesByJobApplicationID :many
 SELECT
   n.created_at,
+  n.updated_at,
   n.note,
   n.job_application_id,
   n.id
@@ -16,13 +17,52 @@ INSERT INTO
   job_application_notes (note, job_application_id)
 VALUES
   (?, ?) RETURNING created_at,
+  updated_at,
   note,
   job_application_id,
   id;
 
+-- name: UpdateJobApplicationNote :one
+UPDATE job_application_notes
+SET
+  note = sqlc.arg(note),
+  updated_at = CURRENT_TIMESTAMP
+WHERE
+  job_application_notes.id = sqlc.arg(id)
+  AND job_application_notes.job_application_id = sqlc.arg(job_application_id)
+  AND EXISTS (
+    SELECT
+      1
+    FROM
+      job_applications j
+    WHERE
+      j.id = job_application_notes.job_application_id
+      AND j.user_id = sqlc.arg(user_id)
+  ) RETURNING created_at,
+  updated_at,
+  note,
+  job_application_id,
+  id;
+
+-- name: DeleteJobApplicationNoteByIDAndUserID :execrows
+DELETE FROM job_application_notes
+WHERE
+  job_application_notes.id = sqlc.arg(id)
+  AND job_application_notes.job_application_id = sqlc.arg(job_application_id)
+  AND EXISTS (
+    SELECT
+      1
+    FROM
+      job_applications j
+    WHERE
+      j.id = job_application_notes.job_application_id
+      AND j.user_id = sqlc.arg(user_id)
+  );
+
 -- name: GetJobApplicationNotesByJobApplicationIDAndUserID :many
 SELECT
   n.created_at,
+  n.updated_at,
   n.note,
   n.job_application_id,
   n.id
@@ -36,6 +76,7 @@ WHERE
 -- name: GetAllJobApplicationNotesByUserID :many
 SELECT
   n.created_at,
+  n.updated_at,
   n.note,
   n.job_application_id,
   n.id
@@ -45,3 +86,13 @@ FROM
 WHERE
   ja.user_id = ?;
 
+-- name: InsertJobApplicationNoteWithCreatedAt :exec
+INSERT INTO
+  job_application_notes (job_application_id, note, created_at, updated_at)
+VALUES
+  (
+    sqlc.arg(job_application_id),
+    sqlc.arg(note),
+    datetime(sqlc.arg(created_at)),
+    datetime(sqlc.narg(updated_at))
+  );
@@ -0,0 +1,152 @@
+-- name: UpsertJobApplicationOffer :exec
+INSERT INTO
+  job_application_offers (
+    job_application_id,
+    currency,
+    base_salary,
+    bonus,
+    sign_on_bonus,
+    equity_shares,
+    equity_strike_price,
+    equity_share_price,
+    vesting_years,
+    vesting_cliff_months,
+    benefits,
+    deadline
+  )
+VALUES
+  (
+    sqlc.arg(job_application_id),
+    sqlc.arg(currency),
+    sqlc.arg(base_salary),
+    sqlc.arg(bonus),
+    sqlc.arg(sign_on_bonus),
+    sqlc.arg(equity_shares),
+    sqlc.arg(equity_strike_price),
+    sqlc.arg(equity_share_price),
+    sqlc.arg(vesting_years),
+    sqlc.arg(vesting_cliff_months),
+    sqlc.arg(benefits),
+    datetime(sqlc.narg(deadline))
+  ) ON CONFLICT (job_application_id) DO UPDATE
+SET
+  currency = excluded.currency,
+  base_salary = excluded.base_salary,
+  bonus = excluded.bonus,
+  sign_on_bonus = excluded.sign_on_bonus,
+  equity_shares = excluded.equity_shares,
+  equity_strike_price = excluded.equity_strike_price,
+  equity_share_price = excluded.equity_share_price,
+  vesting_years = excluded.vesting_years,
+  vesting_cliff_months = excluded.vesting_cliff_months,
+  benefits = excluded.benefits,
+  deadline = excluded.deadline,
+  updated_at = CURRENT_TIMESTAMP;
+
+-- name: GetJobApplicationOfferByJobApplicationID :one
+SELECT
+  *
+FROM
+  job_application_offers
+WHERE
+  job_application_id = ?;
+
+-- name: GetJobApplicationOffersByUserID :many
+SELECT
+  o.currency,
+  o.base_salary,
+  o.bonus,
+  o.sign_on_bonus,
+  o.equity_shares,
+  o.equity_strike_price,
+  o.equity_share_price,
+  o.vesting_years,
+  o.vesting_cliff_months,
+  o.benefits,
+  o.deadline,
+  o.id,
+  o.job_application_id,
+  j.company,
+  j.title,
+  j.status
+FROM
+  job_application_offers o
+  JOIN job_applications j ON j.id = o.job_application_id
+WHERE
+  j.user_id = ?
+  AND j.archived = 0
+ORDER BY
+  j.company COLLATE NOCASE,
+  j.title COLLATE NOCASE;
+
+-- name: DeleteJobApplicationOffer :execrows
+DELETE FROM job_application_offers
+WHERE
+  job_application_id = sqlc.arg(job_application_id)
+  AND EXISTS (
+    SELECT
+      1
+    FROM
+      job_applications j
+    WHERE
+      j.id = job_application_offers.job_application_id
+      AND j.user_id = sqlc.arg(user_id)
+  );
+
+-- name: GetJobApplicationOffersForSnapshot :many
+SELECT
+  *
+FROM
+  job_application_offers
+WHERE
+  job_application_id = ?;
+
+-- name: DeleteJobApplicationOffersByJobApplicationID :exec
+DELETE FROM job_application_offers
+WHERE
+  job_application_id = ?;
+
+-- name: InsertJobApplicationOfferFromSnapshot :exec
+INSERT INTO
+  job_application_offers (
+    job_application_id,
+    currency,
+    base_salary,
+    bonus,
+    sign_on_bonus,
+    equity_shares,
+    equity_strike_price,
+    equity_share_price,
+    vesting_years,
+    vesting_cliff_months,
+    benefits,
+    deadline,
+    created_at,
+    updated_at
+  )
+VALUES
+  (
+    sqlc.arg(job_application_id),
+    sqlc.arg(currency),
+    sqlc.arg(base_salary),
+    sqlc.arg(bonus),
+    sqlc.arg(sign_on_bonus),
+    sqlc.arg(equity_shares),
+    sqlc.arg(equity_strike_price),
+    sqlc.arg(equity_share_price),
+    sqlc.arg(vesting_years),
+    sqlc.arg(vesting_cliff_months),
+    sqlc.arg(benefits),
+    datetime(sqlc.narg(deadline)),
+    datetime(sqlc.arg(created_at)),
+    datetime(sqlc.arg(updated_at))
+  );
+
+-- name: GetAllJobApplicationOffersByUserID :many
+SELECT
+  o.*
+FROM
+  job_application_offers o
+  JOIN job_applications ja ON o.job_application_id = ja.id
+WHERE
+  ja.user_id = ?;
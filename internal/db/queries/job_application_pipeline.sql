@@ -0,0 +1,70 @@
+-- name: GetJobApplicationStatusesByUserID :many
+SELECT
+  name,
+  position,
+  terminal,
+  id
+FROM
+  job_application_statuses
+WHERE
+  user_id = ?
+ORDER BY
+  position ASC,
+  id ASC;
+
+-- name: InsertDefaultJobApplicationStatus :exec
+INSERT INTO
+  job_application_statuses (user_id, name, position, terminal)
+VALUES
+  (?, ?, ?, ?) ON CONFLICT (user_id, name) DO NOTHING;
+
+-- name: InsertJobApplicationStatus :one
+INSERT INTO
+  job_application_statuses (user_id, name, position, terminal)
+VALUES
+  (
+    sqlc.arg(user_id),
+    sqlc.arg(name),
+    (
+      SELECT
+        COALESCE(MAX(s.position), 0) + 1
+      FROM
+        job_application_statuses s
+      WHERE
+        s.user_id = sqlc.arg(user_id)
+    ),
+    sqlc.arg(terminal)
+  ) RETURNING id;
+
+-- name: UpdateJobApplicationStatusPosition :exec
+UPDATE job_application_statuses
+SET
+  position = ?,
+  updated_at = CURRENT_TIMESTAMP
+WHERE
+  id = ?
+  AND user_id = ?;
+
+-- name: UpdateJobApplicationStatusTerminal :execrows
+UPDATE job_application_statuses
+SET
+  terminal = ?,
+  updated_at = CURRENT_TIMESTAMP
+WHERE
+  id = ?
+  AND user_id = ?;
+
+-- name: DeleteJobApplicationStatus :exec
+DELETE FROM job_application_statuses
+WHERE
+  id = ?
+  AND user_id = ?;
+
+-- name: CountJobApplicationsByStatus :one
+SELECT
+  COUNT(*)
+FROM
+  job_applications
+WHERE
+  user_id = ?
+  AND status = ?;
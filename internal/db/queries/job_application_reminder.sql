@@ -0,0 +1,100 @@
+-- name: InsertJobApplicationReminder :one
+INSERT INTO
+  job_application_reminders (job_application_id, remind_at, note)
+VALUES
+  (sqlc.arg(job_application_id), datetime(sqlc.arg(remind_at)), sqlc.arg(note)) RETURNING created_at,
+  remind_at,
+  dismissed_at,
+  note,
+  id,
+  job_application_id;
+
+-- name: GetJobApplicationRemindersByJobApplicationID :many
+SELECT
+  r.created_at,
+  r.remind_at,
+  r.dismissed_at,
+  r.note,
+  r.id,
+  r.job_application_id
+FROM
+  job_application_reminders r
+WHERE
+  r.job_application_id = ?
+ORDER BY
+  r.remind_at ASC;
+
+-- name: GetDueJobApplicationRemindersByUserID :many
+SELECT
+  r.created_at,
+  r.remind_at,
+  r.note,
+  r.id,
+  r.job_application_id,
+  ja.company,
+  ja.title,
+  ja.status
+FROM
+  job_application_reminders r
+  JOIN job_applications ja ON r.job_application_id = ja.id
+WHERE
+  ja.user_id = ?
+  AND ja.archived = 0
+  AND r.dismissed_at IS NULL
+  AND r.remind_at < datetime(sqlc.arg(due_before))
+ORDER BY
+  r.remind_at ASC;
+
+-- name: SnoozeJobApplicationReminder :execrows
+UPDATE job_application_reminders
+SET
+  remind_at = datetime(sqlc.arg(remind_at)),
+  updated_at = CURRENT_TIMESTAMP
+WHERE
+  job_application_reminders.id = sqlc.arg(id)
+  AND job_application_reminders.dismissed_at IS NULL
+  AND job_application_reminders.job_application_id IN (
+    SELECT
+      ja.id
+    FROM
+      job_applications ja
+    WHERE
+      ja.user_id = sqlc.arg(user_id)
+  );
+
+-- name: DismissJobApplicationReminder :execrows
+UPDATE job_application_reminders
+SET
+  dismissed_at = CURRENT_TIMESTAMP,
+  updated_at = CURRENT_TIMESTAMP
+WHERE
+  job_application_reminders.id = sqlc.arg(id)
+  AND job_application_reminders.dismissed_at IS NULL
+  AND job_application_reminders.job_application_id IN (
+    SELECT
+      ja.id
+    FROM
+      job_applications ja
+    WHERE
+      ja.user_id = sqlc.arg(user_id)
+  );
+
+-- name: DismissJobApplicationRemindersByJobApplicationID :execrows
+UPDATE job_application_reminders
+SET
+  dismissed_at = CURRENT_TIMESTAMP,
+  updated_at = CURRENT_TIMESTAMP
+WHERE
+  job_application_id = ?
+  AND dismissed_at IS NULL;
+
+-- name: GetAllJobApplicationRemindersByUserID :many
+SELECT
+  r.*
+FROM
+  job_application_reminders r
+  JOIN job_applications ja ON r.job_application_id = ja.id
+WHERE
+  ja.user_id = ?
+ORDER BY
+  r.id ASC;
@@ -2,9 +2,7 @@
 SELECT
   average_time_to_hear_back,
   total_applications,
-  total_companies,
-  total_interviewing,
-  total_rejected
+  total_companies
 FROM
   job_application_stats
 WHERE
@@ -15,28 +13,6 @@ UPDATE job_application_stats
 SET
   total_applications = total_applications + 1,
   total_companies = total_companies + ?,
-  total_applied = total_applied + 1,
-  updated_at = CURRENT_TIMESTAMP
-WHERE
-  user_id = ?;
-
--- name: UpdateJobApplicationStat :exec
-UPDATE job_application_stats
-SET
-  total_companies = total_companies + ?,
-  average_time_to_hear_back = CASE
-    WHEN average_time_to_hear_back > 0 THEN (average_time_to_hear_back + ?) / 2
-    ELSE ?
-  END,
-  total_accepted = total_accepted + ?,
-  total_applied = total_applied + ?,
-  total_canceled = total_canceled + ?,
-  total_declined = total_declined + ?,
-  total_interviewing = total_interviewing + ?,
-  total_offers = total_offers + ?,
-  total_rejected = total_rejected + ?,
-  total_watching = total_watching + ?,
-  total_widthdrawn = total_widthdrawn + ?,
   updated_at = CURRENT_TIMESTAMP
 WHERE
   user_id = ?;
@@ -53,15 +29,15 @@ SET
   total_applications = ?,
   total_companies = ?,
   average_time_to_hear_back = ?,
-  total_accepted = ?,
-  total_applied = ?,
-  total_canceled = ?,
-  total_declined = ?,
-  total_interviewing = ?,
-  total_offers = ?,
-  total_rejected = ?,
-  total_watching = ?,
-  total_widthdrawn = ?,
+  updated_at = CURRENT_TIMESTAMP
+WHERE
+  user_id = ?;
+
+-- name: DecrementJobApplicationStat :exec
+UPDATE job_application_stats
+SET
+  total_applications = MAX(total_applications - 1, 0),
+  total_companies = MAX(total_companies - ?, 0),
   updated_at = CURRENT_TIMESTAMP
 WHERE
   user_id = ?;
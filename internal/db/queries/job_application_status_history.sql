@@ -70,3 +70,9 @@ FROM
   JOIN job_applications ja ON h.job_application_id = ja.id
 WHERE
   ja.user_id = ?;
+
+-- name: InsertJobApplicationStatusHistoryWithCreatedAt :exec
+INSERT INTO
+  job_application_status_histories (status, job_application_id, created_at)
+VALUES
+  (?, ?, datetime(sqlc.arg(created_at)));
@@ -0,0 +1,105 @@
+-- name: GetTagsByUserID :many
+SELECT
+  name,
+  color,
+  id
+FROM
+  tags
+WHERE
+  user_id = ?
+ORDER BY
+  name ASC;
+
+-- name: GetTagByIDAndUserID :one
+SELECT
+  name,
+  color,
+  id
+FROM
+  tags
+WHERE
+  id = ?
+  AND user_id = ?;
+
+-- name: InsertTag :one
+INSERT INTO
+  tags (user_id, name, color)
+VALUES
+  (?, ?, ?) RETURNING id;
+
+-- name: UpdateTag :execrows
+UPDATE tags
+SET
+  name = ?,
+  color = ?,
+  updated_at = CURRENT_TIMESTAMP
+WHERE
+  id = ?
+  AND user_id = ?;
+
+-- name: DeleteTag :execrows
+DELETE FROM tags
+WHERE
+  id = ?
+  AND user_id = ?;
+
+-- name: DeleteJobApplicationTagsByTagID :exec
+DELETE FROM job_application_tags
+WHERE
+  tag_id = ?;
+
+-- name: DeleteJobApplicationTagsByJobApplicationID :exec
+DELETE FROM job_application_tags
+WHERE
+  job_application_id = ?;
+
+-- name: InsertJobApplicationTag :execrows
+INSERT INTO
+  job_application_tags (job_application_id, tag_id)
+VALUES
+  (?, ?) ON CONFLICT (job_application_id, tag_id) DO NOTHING;
+
+-- name: DeleteJobApplicationTag :exec
+DELETE FROM job_application_tags
+WHERE
+  job_application_id = ?
+  AND tag_id = ?;
+
+-- name: GetTagsByJobApplicationID :many
+SELECT
+  t.name,
+  t.color,
+  t.id
+FROM
+  tags t
+  JOIN job_application_tags jt ON jt.tag_id = t.id
+WHERE
+  jt.job_application_id = ?
+ORDER BY
+  t.name ASC;
+
+-- name: GetTagsByJobApplicationIDs :many
+SELECT
+  jt.job_application_id,
+  t.name,
+  t.color,
+  t.id
+FROM
+  tags t
+  JOIN job_application_tags jt ON jt.tag_id = t.id
+WHERE
+  jt.job_application_id IN (sqlc.slice ('ids'))
+ORDER BY
+  t.name ASC;
+
+-- name: GetAllJobApplicationTagsByUserID :many
+SELECT
+  jt.job_application_id,
+  t.name
+FROM
+  job_application_tags jt
+  JOIN tags t ON t.id = jt.tag_id
+WHERE
+  t.user_id = ?
+ORDER BY
+  t.name ASC;
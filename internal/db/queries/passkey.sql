@@ -0,0 +1,68 @@
+-- name: GetPasskeysByUserID :many
+SELECT
+  created_at,
+  last_used_at,
+  name,
+  credential,
+  id
+FROM
+  passkeys
+WHERE
+  user_id = ?
+ORDER BY
+  created_at ASC,
+  id ASC;
+
+-- name: InsertPasskey :one
+INSERT INTO
+  passkeys (user_id, name, credential_id, credential, created_at)
+VALUES
+  (
+    sqlc.arg(user_id),
+    sqlc.arg(name),
+    sqlc.arg(credential_id),
+    sqlc.arg(credential),
+    datetime(sqlc.arg(created_at))
+  ) RETURNING created_at,
+  last_used_at,
+  name,
+  id;
+
+-- name: UpdatePasskeyCredential :exec
+UPDATE passkeys
+SET
+  credential = sqlc.arg(credential),
+  last_used_at = datetime(sqlc.arg(last_used_at)),
+  updated_at = CURRENT_TIMESTAMP
+WHERE
+  credential_id = sqlc.arg(credential_id)
+  AND user_id = sqlc.arg(user_id);
+
+-- name: DeletePasskey :execrows
+DELETE FROM passkeys
+WHERE
+  id = ?
+  AND user_id = ?;
+
+-- name: InsertPasskeySession :exec
+INSERT INTO
+  passkey_sessions (user_id, token_hash, session_data, expires_at)
+VALUES
+  (
+    sqlc.arg(user_id),
+    sqlc.arg(token_hash),
+    sqlc.arg(session_data),
+    datetime(sqlc.arg(expires_at))
+  );
+
+-- name: DeletePasskeySessionByHash :one
+DELETE FROM passkey_sessions
+WHERE
+  token_hash = sqlc.arg(token_hash)
+  AND expires_at > datetime(sqlc.arg(now)) RETURNING user_id,
+  session_data;
+
+-- name: DeleteExpiredPasskeySessions :exec
+DELETE FROM passkey_sessions
+WHERE
+  expires_at <= datetime(sqlc.arg(now));
@@ -0,0 +1,37 @@
+-- name: InsertPasswordResetToken :exec
+INSERT INTO
+  password_reset_tokens (user_id, token_hash, expires_at)
+VALUES
+  (sqlc.arg(user_id), sqlc.arg(token_hash), datetime(sqlc.arg(expires_at)));
+
+-- name: GetPasswordResetTokenByHash :one
+SELECT
+  user_id
+FROM
+  password_reset_tokens
+WHERE
+  token_hash = sqlc.arg(token_hash)
+  AND used_at IS NULL
+  AND expires_at > datetime(sqlc.arg(now));
+
+-- name: UsePasswordResetToken :one
+UPDATE password_reset_tokens
+SET
+  used_at = datetime(sqlc.arg(now))
+WHERE
+  token_hash = sqlc.arg(token_hash)
+  AND used_at IS NULL
+  AND expires_at > datetime(sqlc.arg(now)) RETURNING user_id;
+
+-- name: UsePasswordResetTokensByUserID :exec
+UPDATE password_reset_tokens
+SET
+  used_at = datetime(sqlc.arg(now))
+WHERE
+  user_id = sqlc.arg(user_id)
+  AND used_at IS NULL;
+
+-- name: DeleteExpiredPasswordResetTokens :exec
+DELETE FROM password_reset_tokens
+WHERE
+  expires_at <= datetime(sqlc.arg(now));
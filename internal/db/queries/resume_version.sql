@@ -0,0 +1,77 @@
+-- name: GetResumeVersionsByUserID :many
+SELECT
+  *
+FROM
+  resume_versions
+WHERE
+  user_id = ?
+ORDER BY
+  label ASC;
+
+-- name: GetResumeVersionByIDAndUserID :one
+SELECT
+  *
+FROM
+  resume_versions
+WHERE
+  id = ?
+  AND user_id = ?;
+
+-- name: GetResumeVersionByLabelAndUserID :one
+SELECT
+  id
+FROM
+  resume_versions
+WHERE
+  label = ?
+  AND user_id = ?;
+
+-- name: InsertResumeVersion :one
+INSERT INTO
+  resume_versions (user_id, label, file_name, content_type, size, storage_key)
+VALUES
+  (?, ?, ?, ?, ?, ?) RETURNING *;
+
+-- name: DeleteResumeVersion :execrows
+DELETE FROM resume_versions
+WHERE
+  id = ?
+  AND user_id = ?;
+
+-- name: ClearJobApplicationResumeVersions :exec
+UPDATE job_applications
+SET
+  resume_version_id = NULL
+WHERE
+  resume_version_id = ?;
+
+-- name: GetResumeVersionStorageKeysByUserID :many
+SELECT
+  storage_key
+FROM
+  resume_versions
+WHERE
+  user_id = ?
+  AND storage_key IS NOT NULL;
+
+-- name: DeleteResumeVersionsByUserID :exec
+DELETE FROM resume_versions
+WHERE
+  user_id = ?;
+
+-- name: GetJobApplicationResumeVersionID :one
+SELECT
+  resume_version_id
+FROM
+  job_applications
+WHERE
+  id = ?
+  AND user_id = ?;
+
+-- name: SetJobApplicationResumeVersion :execrows
+UPDATE job_applications
+SET
+  resume_version_id = ?
+WHERE
+  id = ?
+  AND user_id = ?;
@@ -0,0 +1,34 @@
+-- name: GetSavedViewsByUserID :many
+SELECT
+  name,
+  filters,
+  id
+FROM
+  saved_views
+WHERE
+  user_id = ?
+ORDER BY
+  name ASC;
+
+-- name: GetSavedViewByNameAndUserID :one
+SELECT
+  name,
+  filters,
+  id
+FROM
+  saved_views
+WHERE
+  name = ?
+  AND user_id = ?;
+
+-- name: InsertSavedView :one
+INSERT INTO
+  saved_views (user_id, name, filters)
+VALUES
+  (?, ?, ?) RETURNING id;
+
+-- name: DeleteSavedView :execrows
+DELETE FROM saved_views
+WHERE
+  id = ?
+  AND user_id = ?;
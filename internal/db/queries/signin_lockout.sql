@@ -0,0 +1,108 @@
+-- name: GetSigninThrottle :one
+SELECT
+  updated_at,
+  locked_until,
+  failures,
+  lockouts
+FROM
+  signin_throttles
+WHERE
+  kind = sqlc.arg(kind)
+  AND value = sqlc.arg(value);
+
+-- name: UpsertSigninThrottle :exec
+INSERT INTO
+  signin_throttles (
+    kind,
+    value,
+    failures,
+    lockouts,
+    locked_until,
+    updated_at
+  )
+VALUES
+  (
+    sqlc.arg(kind),
+    sqlc.arg(value),
+    sqlc.arg(failures),
+    sqlc.arg(lockouts),
+    datetime(sqlc.narg(locked_until)),
+    datetime(sqlc.arg(now))
+  ) ON CONFLICT (kind, value) DO
+UPDATE
+SET
+  failures = excluded.failures,
+  lockouts = excluded.lockouts,
+  locked_until = excluded.locked_until,
+  updated_at = excluded.updated_at;
+
+-- name: CountSigninLocks :one
+SELECT
+  COUNT(*)
+FROM
+  signin_throttles
+WHERE
+  (
+    (
+      kind = 'account'
+      AND value = sqlc.arg(email)
+    )
+    OR (
+      kind = 'ip'
+      AND value = sqlc.arg(ip_address)
+    )
+  )
+  AND locked_until > datetime(sqlc.arg(now));
+
+-- name: DeleteSigninThrottle :exec
+DELETE FROM signin_throttles
+WHERE
+  kind = sqlc.arg(kind)
+  AND value = sqlc.arg(value);
+
+-- name: InsertSigninAudit :exec
+INSERT INTO
+  signin_audits (
+    event,
+    email,
+    ip_address,
+    user_agent,
+    user_id,
+    created_at
+  )
+VALUES
+  (
+    sqlc.arg(event),
+    sqlc.arg(email),
+    sqlc.arg(ip_address),
+    sqlc.arg(user_agent),
+    sqlc.narg(user_id),
+    datetime(sqlc.arg(now))
+  );
+
+-- name: CountRecentSigninAudits :one
+SELECT
+  COUNT(*)
+FROM
+  signin_audits
+WHERE
+  event = sqlc.arg(event)
+  AND (
+    email = sqlc.arg(email)
+    OR ip_address = sqlc.arg(ip_address)
+  )
+  AND created_at > datetime(sqlc.arg(since));
+
+-- name: DeleteSigninAuditsBefore :execrows
+DELETE FROM signin_audits
+WHERE
+  created_at < datetime(sqlc.arg(before));
+
+-- name: DeleteStaleSigninThrottles :execrows
+DELETE FROM signin_throttles
+WHERE
+  updated_at < datetime(sqlc.arg(before))
+  AND (
+    locked_until IS NULL
+    OR locked_until <= datetime(sqlc.arg(now))
+  );
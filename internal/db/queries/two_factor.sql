@@ -0,0 +1,125 @@
+-- name: GetUserTOTPByUserID :one
+SELECT
+  enabled_at,
+  secret,
+  last_used_step
+FROM
+  user_totp
+WHERE
+  user_id = ?;
+
+-- name: UpsertPendingUserTOTP :execrows
+INSERT INTO
+  user_totp (user_id, secret)
+VALUES
+  (?, ?) ON CONFLICT (user_id) DO
+UPDATE
+SET
+  secret = excluded.secret,
+  last_used_step = 0,
+  updated_at = CURRENT_TIMESTAMP
+WHERE
+  user_totp.enabled_at IS NULL;
+
+-- name: EnableUserTOTP :execrows
+UPDATE user_totp
+SET
+  enabled_at = datetime(sqlc.arg(enabled_at)),
+  last_used_step = sqlc.arg(last_used_step),
+  updated_at = CURRENT_TIMESTAMP
+WHERE
+  user_id = sqlc.arg(user_id)
+  AND enabled_at IS NULL;
+
+-- name: UpdateUserTOTPLastUsedStep :execrows
+UPDATE user_totp
+SET
+  last_used_step = sqlc.arg(last_used_step),
+  updated_at = CURRENT_TIMESTAMP
+WHERE
+  user_id = sqlc.arg(user_id)
+  AND last_used_step < sqlc.arg(last_used_step);
+
+-- name: DeleteUserTOTPByUserID :exec
+DELETE FROM user_totp
+WHERE
+  user_id = ?;
+
+-- name: InsertUserRecoveryCode :exec
+INSERT INTO
+  user_recovery_codes (user_id, code_hash)
+VALUES
+  (?, ?);
+
+-- name: UseUserRecoveryCode :execrows
+UPDATE user_recovery_codes
+SET
+  used_at = datetime(sqlc.arg(used_at))
+WHERE
+  user_id = sqlc.arg(user_id)
+  AND code_hash = sqlc.arg(code_hash)
+  AND used_at IS NULL;
+
+-- name: CountUnusedUserRecoveryCodes :one
+SELECT
+  COUNT(*)
+FROM
+  user_recovery_codes
+WHERE
+  user_id = ?
+  AND used_at IS NULL;
+
+-- name: DeleteUserRecoveryCodesByUserID :exec
+DELETE FROM user_recovery_codes
+WHERE
+  user_id = ?;
+
+-- name: InsertTwoFactorChallenge :exec
+INSERT INTO
+  two_factor_challenges (user_id, token_hash, expires_at)
+VALUES
+  (sqlc.arg(user_id), sqlc.arg(token_hash), datetime(sqlc.arg(expires_at)));
+
+-- name: GetTwoFactorChallengeByHash :one
+SELECT
+  user_id
+FROM
+  two_factor_challenges
+WHERE
+  token_hash = sqlc.arg(token_hash)
+  AND expires_at > datetime(sqlc.arg(now));
+
+-- name: DeleteTwoFactorChallengeByHash :exec
+DELETE FROM two_factor_challenges
+WHERE
+  token_hash = ?;
+
+-- name: DeleteTwoFactorChallengesByUserID :exec
+DELETE FROM two_factor_challenges
+WHERE
+  user_id = ?;
+
+-- name: DeleteExpiredTwoFactorChallenges :exec
+DELETE FROM two_factor_challenges
+WHERE
+  expires_at <= datetime(sqlc.arg(now));
+
+-- name: InsertTwoFactorFailure :exec
+INSERT INTO
+  two_factor_failures (user_id, created_at)
+VALUES
+  (sqlc.arg(user_id), datetime(sqlc.arg(created_at)));
+
+-- name: CountTwoFactorFailuresSince :one
+SELECT
+  COUNT(*)
+FROM
+  two_factor_failures
+WHERE
+  user_id = sqlc.arg(user_id)
+  AND created_at > datetime(sqlc.arg(since));
+
+-- name: DeleteTwoFactorFailuresByUserID :exec
+DELETE FROM two_factor_failures
+WHERE
+  user_id = ?;
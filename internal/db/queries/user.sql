@@ -35,3 +35,42 @@ SET
   password = ?
 WHERE
   id = ?;
+
+-- name: GetUserIDs :many
+SELECT
+  id
+FROM
+  users
+ORDER BY
+  id;
+
+-- name: GetUserDisplayCurrency :one
+SELECT
+  display_currency
+FROM
+  users
+WHERE
+  id = ?;
+
+-- name: UpdateUserDisplayCurrency :exec
+UPDATE users
+SET
+  display_currency = ?
+WHERE
+  id = ?;
+
+-- name: IsUserEmailVerified :one
+SELECT
+  email_verified_at IS NOT NULL AS verified
+FROM
+  users
+WHERE
+  id = ?;
+
+-- name: VerifyUserEmail :exec
+UPDATE users
+SET
+  email_verified_at = datetime(sqlc.arg(now))
+WHERE
+  id = sqlc.arg(id)
+  AND email_verified_at IS NULL;
@@ -14,8 +14,25 @@ INSERT INTO user_hn_jobs (
     job_application_id
 ) VALUES (?, ?, ?);
 
+-- name: InsertUserHNJobIfMissing :exec
+INSERT INTO user_hn_jobs (
+    user_id,
+    hn_job_id,
+    job_application_id
+) VALUES (?, ?, ?)
+ON CONFLICT (user_id, hn_job_id) DO NOTHING;
+
 -- name: DeleteUserHNJob :exec
 DELETE FROM user_hn_jobs
 WHERE 
     user_id = ? 
     AND hn_job_id = ?;
+
+-- name: GetUserHNJobsByUserID :many
+SELECT
+    hn_job_id,
+    job_application_id
+FROM
+    user_hn_jobs
+WHERE
+    user_id = ?;
@@ -0,0 +1,1156 @@
+package jobapplication
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/storage"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/google/uuid"
+)
+
+// ArchiveVersion is the version of the archive format written by Export. Fields may be added
+// without changing the version, but removing or changing the meaning of a field requires a new
+// version. Version 2 added the data linked to job applications besides notes and attachments, such
+// as tags, contacts and offers. Archives of version 1 are still read.
+const ArchiveVersion = 2
+
+var (
+	ErrUnsupportedArchiveVersion = errors.New("unsupported archive version")
+	ErrInvalidArchive            = errors.New("invalid archive")
+)
+
+// Archive is the full set of job application data of a user.
+type Archive struct {
+	Version         int                     `json:"version"`
+	ExportedAt      time.Time               `json:"exported_at"`
+	Pipeline        []ArchivePipelineStatus `json:"pipeline,omitempty"`
+	Tags            []ArchiveTag            `json:"tags,omitempty"`
+	Contacts        []ArchiveContact        `json:"contacts,omitempty"`
+	Companies       []ArchiveCompany        `json:"companies,omitempty"`
+	ExchangeRates   []ArchiveExchangeRate   `json:"exchange_rates,omitempty"`
+	SavedViews      []ArchiveSavedView      `json:"saved_views,omitempty"`
+	ResumeVersions  []ArchiveResumeVersion  `json:"resume_versions,omitempty"`
+	JobApplications []ArchiveJobApplication `json:"job_applications"`
+}
+
+// ArchivePipelineStatus is a stage of the status pipeline, in pipeline order.
+type ArchivePipelineStatus struct {
+	Name     string `json:"name"`
+	Terminal bool   `json:"terminal"`
+}
+
+// ArchiveTag is a tag job applications can be labeled with.
+type ArchiveTag struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// ArchiveContact is a person job applications can be linked to.
+type ArchiveContact struct {
+	// ID identifies the contact within the archive. It is not the ID of the contact in the database.
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Role        string `json:"role,omitempty"`
+	Email       string `json:"email,omitempty"`
+	LinkedInURL string `json:"linkedin_url,omitempty"`
+	Company     string `json:"company,omitempty"`
+}
+
+// ArchiveCompany is a company job applications are grouped by.
+type ArchiveCompany struct {
+	Name string `json:"name"`
+}
+
+// ArchiveExchangeRate is how much one unit of a currency is worth in the base currency.
+type ArchiveExchangeRate struct {
+	Currency string  `json:"currency"`
+	Rate     float64 `json:"rate"`
+}
+
+// ArchiveSavedView is a named set of filters. The filters are encoded as a query string, without
+// the tag, as tag IDs differ between users.
+type ArchiveSavedView struct {
+	Name    string `json:"name"`
+	Filters string `json:"filters"`
+	// Tag is the name of the tag of the archive the view is filtered by.
+	Tag string `json:"tag,omitempty"`
+}
+
+// ArchiveResumeVersion is a version of the resume sent with job applications. The content of the
+// file is encoded as base64, and both are empty when the version has no file.
+type ArchiveResumeVersion struct {
+	Label    string `json:"label"`
+	FileName string `json:"file_name,omitempty"`
+	Content  []byte `json:"content,omitempty"`
+}
+
+// ArchiveJobApplication is a job application with its timeline.
+type ArchiveJobApplication struct {
+	Company        string                 `json:"company"`
+	Title          string                 `json:"title"`
+	URL            string                 `json:"url,omitempty"`
+	Status         string                 `json:"status"`
+	Archived       bool                   `json:"archived"`
+	SalaryMin      *int64                 `json:"salary_min,omitempty"`
+	SalaryMax      *int64                 `json:"salary_max,omitempty"`
+	SalaryCurrency string                 `json:"salary_currency,omitempty"`
+	AppliedAt      time.Time              `json:"applied_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+	CreatedAt      time.Time              `json:"created_at"`
+	StatusHistory  []ArchiveStatusHistory `json:"status_history"`
+	Notes          []ArchiveNote          `json:"notes"`
+	Attachments    []ArchiveAttachment    `json:"attachments,omitempty"`
+	HNJobIDs       []string               `json:"hn_job_ids,omitempty"`
+	Reminders      []ArchiveReminder      `json:"reminders,omitempty"`
+	Interviews     []ArchiveInterview     `json:"interviews,omitempty"`
+	Offer          *ArchiveOffer          `json:"offer,omitempty"`
+	// Tags are the names of tags of the archive.
+	Tags []string `json:"tags,omitempty"`
+	// ContactIDs are the IDs of contacts of the archive.
+	ContactIDs []int64 `json:"contact_ids,omitempty"`
+	// ResumeVersion is the label of a resume version of the archive.
+	ResumeVersion string `json:"resume_version,omitempty"`
+}
+
+// ArchiveStatusHistory is a status change of a job application.
+type ArchiveStatusHistory struct {
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ArchiveNote is a note of a job application.
+type ArchiveNote struct {
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+	// UpdatedAt is when the note was last edited. It is nil when the note was never edited.
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// ArchiveAttachment is a file attached to a job application. The content is encoded as base64.
+type ArchiveAttachment struct {
+	Name      string    `json:"name"`
+	Kind      string    `json:"kind"`
+	CreatedAt time.Time `json:"created_at"`
+	Content   []byte    `json:"content"`
+}
+
+// ArchiveReminder is a reminder to follow up on a job application.
+type ArchiveReminder struct {
+	RemindAt    time.Time  `json:"remind_at"`
+	DismissedAt *time.Time `json:"dismissed_at,omitempty"`
+	Note        string     `json:"note,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// ArchiveInterview is an interview round of a job application.
+type ArchiveInterview struct {
+	ScheduledAt     time.Time `json:"scheduled_at"`
+	Timezone        string    `json:"timezone"`
+	Type            string    `json:"type"`
+	Outcome         string    `json:"outcome"`
+	Interviewers    string    `json:"interviewers,omitempty"`
+	DurationMinutes int64     `json:"duration_minutes"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// ArchiveOffer is the offer of a job application.
+type ArchiveOffer struct {
+	Currency           string  `json:"currency"`
+	BaseSalary         int64   `json:"base_salary"`
+	Bonus              int64   `json:"bonus"`
+	SignOnBonus        int64   `json:"sign_on_bonus"`
+	EquityShares       int64   `json:"equity_shares"`
+	EquityStrikePrice  float64 `json:"equity_strike_price"`
+	EquitySharePrice   float64 `json:"equity_share_price"`
+	VestingYears       int64   `json:"vesting_years"`
+	VestingCliffMonths int64   `json:"vesting_cliff_months"`
+	Benefits           string  `json:"benefits,omitempty"`
+	// Deadline is formatted with OfferDeadlineLayout. It is empty when the offer has no deadline.
+	Deadline  string    `json:"deadline,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ArchiveImportResult is the outcome of importing an archive.
+type ArchiveImportResult struct {
+	Imported int
+	Skipped  int
+	Errors   []string
+}
+
+// Export builds the archive of all the job applications of the user, including archived ones, with
+// the contents of their attachments and the data of the user they link to.
+func Export(ctx context.Context, q *queries.Queries, store storage.Storage, userID int64) (Archive, error) {
+	jobs, err := q.GetJobApplicationsForExport(ctx, userID)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get job applications: %w", err)
+	}
+	histories, err := q.GetAllJobApplicationStatusHistoryByUserID(ctx, userID)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get status histories: %w", err)
+	}
+	notes, err := q.GetAllJobApplicationNotesByUserID(ctx, userID)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get notes: %w", err)
+	}
+	attachments, err := q.GetAllJobApplicationAttachmentsByUserID(ctx, userID)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	hnJobs, err := q.GetUserHNJobsByUserID(ctx, userID)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get hn jobs: %w", err)
+	}
+	reminders, err := q.GetAllJobApplicationRemindersByUserID(ctx, userID)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get reminders: %w", err)
+	}
+	interviews, err := q.GetAllJobApplicationInterviewsByUserID(ctx, userID)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get interviews: %w", err)
+	}
+	offers, err := q.GetAllJobApplicationOffersByUserID(ctx, userID)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get offers: %w", err)
+	}
+	jobTags, err := q.GetAllJobApplicationTagsByUserID(ctx, userID)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get job tags: %w", err)
+	}
+	jobContacts, err := q.GetAllJobApplicationContactsByUserID(ctx, userID)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get job contacts: %w", err)
+	}
+	pipeline, err := GetPipeline(ctx, q, userID)
+	if err != nil {
+		return Archive{}, err
+	}
+
+	archive := Archive{
+		Version:         ArchiveVersion,
+		ExportedAt:      time.Now().UTC().Truncate(time.Second),
+		Pipeline:        make([]ArchivePipelineStatus, 0, len(pipeline)),
+		JobApplications: make([]ArchiveJobApplication, 0, len(jobs)),
+	}
+	for _, s := range pipeline {
+		archive.Pipeline = append(archive.Pipeline, ArchivePipelineStatus{Name: s.Name.String(), Terminal: s.Terminal})
+	}
+	contactIDs, resumeLabels, err := exportUserData(ctx, q, store, userID, &archive)
+	if err != nil {
+		return Archive{}, err
+	}
+
+	// Entries are kept in insertion order so entries created at the same time keep their order.
+	sort.Slice(histories, func(i, j int) bool { return histories[i].ID < histories[j].ID })
+	sort.Slice(notes, func(i, j int) bool { return notes[i].ID < notes[j].ID })
+
+	historiesByJob := make(map[int64][]ArchiveStatusHistory)
+	for _, h := range histories {
+		historiesByJob[h.JobApplicationID] = append(historiesByJob[h.JobApplicationID], ArchiveStatusHistory{
+			Status:    h.Status,
+			CreatedAt: h.CreatedAt.UTC(),
+		})
+	}
+	notesByJob := make(map[int64][]ArchiveNote)
+	for _, n := range notes {
+		note := ArchiveNote{
+			Note:      n.Note,
+			CreatedAt: n.CreatedAt.UTC(),
+		}
+		if n.UpdatedAt.Valid {
+			updatedAt := n.UpdatedAt.Time.UTC()
+			note.UpdatedAt = &updatedAt
+		}
+		notesByJob[n.JobApplicationID] = append(notesByJob[n.JobApplicationID], note)
+	}
+	attachmentsByJob := make(map[int64][]ArchiveAttachment)
+	for _, a := range attachments {
+		content, readErr := readAttachment(ctx, store, a.StorageKey)
+		if errors.Is(readErr, storage.ErrNotFound) {
+			// An attachment without content cannot be imported, so it is left out.
+			continue
+		} else if readErr != nil {
+			return Archive{}, readErr
+		}
+		attachmentsByJob[a.JobApplicationID.Int64] = append(attachmentsByJob[a.JobApplicationID.Int64], ArchiveAttachment{
+			Name:      a.Name,
+			Kind:      a.Kind,
+			CreatedAt: a.CreatedAt.UTC(),
+			Content:   content,
+		})
+	}
+	hnJobsByJob := make(map[int64][]string)
+	for _, h := range hnJobs {
+		hnJobsByJob[h.JobApplicationID] = append(hnJobsByJob[h.JobApplicationID], h.HnJobID)
+	}
+	remindersByJob := make(map[int64][]ArchiveReminder)
+	for _, r := range reminders {
+		reminder := ArchiveReminder{
+			RemindAt:  r.RemindAt.UTC(),
+			Note:      r.Note,
+			CreatedAt: r.CreatedAt.UTC(),
+			UpdatedAt: r.UpdatedAt.UTC(),
+		}
+		if r.DismissedAt.Valid {
+			dismissedAt := r.DismissedAt.Time.UTC()
+			reminder.DismissedAt = &dismissedAt
+		}
+		remindersByJob[r.JobApplicationID] = append(remindersByJob[r.JobApplicationID], reminder)
+	}
+	interviewsByJob := make(map[int64][]ArchiveInterview)
+	for _, i := range interviews {
+		interviewsByJob[i.JobApplicationID] = append(interviewsByJob[i.JobApplicationID], ArchiveInterview{
+			ScheduledAt:     i.ScheduledAt.UTC(),
+			Timezone:        i.Timezone,
+			Type:            i.Type,
+			Outcome:         i.Outcome,
+			Interviewers:    i.Interviewers,
+			DurationMinutes: i.DurationMinutes,
+			CreatedAt:       i.CreatedAt.UTC(),
+			UpdatedAt:       i.UpdatedAt.UTC(),
+		})
+	}
+	offersByJob := make(map[int64]*ArchiveOffer, len(offers))
+	for _, o := range offers {
+		offersByJob[o.JobApplicationID] = &ArchiveOffer{
+			Currency:           o.Currency,
+			BaseSalary:         o.BaseSalary,
+			Bonus:              o.Bonus,
+			SignOnBonus:        o.SignOnBonus,
+			EquityShares:       o.EquityShares,
+			EquityStrikePrice:  o.EquityStrikePrice,
+			EquitySharePrice:   o.EquitySharePrice,
+			VestingYears:       o.VestingYears,
+			VestingCliffMonths: o.VestingCliffMonths,
+			Benefits:           o.Benefits,
+			Deadline:           formatOfferDeadline(o.Deadline),
+			CreatedAt:          o.CreatedAt.UTC(),
+			UpdatedAt:          o.UpdatedAt.UTC(),
+		}
+	}
+	tagsByJob := make(map[int64][]string)
+	for _, t := range jobTags {
+		tagsByJob[t.JobApplicationID] = append(tagsByJob[t.JobApplicationID], t.Name)
+	}
+	contactsByJob := make(map[int64][]int64)
+	for _, c := range jobContacts {
+		contactsByJob[c.JobApplicationID] = append(contactsByJob[c.JobApplicationID], contactIDs[c.ContactID])
+	}
+
+	for _, j := range jobs {
+		app := ArchiveJobApplication{
+			Company:        j.Company,
+			Title:          j.Title,
+			URL:            j.Url.String,
+			Status:         j.Status,
+			Archived:       j.Archived == 1,
+			SalaryCurrency: j.SalaryCurrency.String,
+			AppliedAt:      j.AppliedAt.UTC(),
+			UpdatedAt:      j.UpdatedAt.UTC(),
+			CreatedAt:      j.CreatedAt.UTC(),
+			StatusHistory:  historiesByJob[j.ID],
+			Notes:          notesByJob[j.ID],
+			Attachments:    attachmentsByJob[j.ID],
+			HNJobIDs:       hnJobsByJob[j.ID],
+			Reminders:      remindersByJob[j.ID],
+			Interviews:     interviewsByJob[j.ID],
+			Offer:          offersByJob[j.ID],
+			Tags:           tagsByJob[j.ID],
+			ContactIDs:     contactsByJob[j.ID],
+			ResumeVersion:  resumeLabels[j.ResumeVersionID.Int64],
+		}
+		if j.SalaryMin.Valid {
+			app.SalaryMin = &j.SalaryMin.Int64
+		}
+		if j.SalaryMax.Valid {
+			app.SalaryMax = &j.SalaryMax.Int64
+		}
+		if app.StatusHistory == nil {
+			app.StatusHistory = []ArchiveStatusHistory{}
+		}
+		if app.Notes == nil {
+			app.Notes = []ArchiveNote{}
+		}
+		slices.Sort(app.ContactIDs)
+		archive.JobApplications = append(archive.JobApplications, app)
+	}
+	return archive, nil
+}
+
+// exportUserData adds the data of the user job applications link to to the archive. It returns the
+// archive IDs of the contacts and the labels of the resume versions, by their IDs.
+func exportUserData(ctx context.Context, q *queries.Queries, store storage.Storage, userID int64, archive *Archive) (map[int64]int64, map[int64]string, error) {
+	tags, err := q.GetTagsByUserID(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+	contacts, err := q.GetContactsByUserID(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get contacts: %w", err)
+	}
+	companies, err := q.GetCompaniesByUserID(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get companies: %w", err)
+	}
+	rates, err := q.GetExchangeRatesByUserID(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get exchange rates: %w", err)
+	}
+	views, err := q.GetSavedViewsByUserID(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get saved views: %w", err)
+	}
+	versions, err := q.GetResumeVersionsByUserID(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get resume versions: %w", err)
+	}
+
+	tagNames := make(map[int64]string, len(tags))
+	for _, t := range tags {
+		tagNames[t.ID] = t.Name
+		archive.Tags = append(archive.Tags, ArchiveTag{Name: t.Name, Color: t.Color})
+	}
+	// Contacts are numbered in order so the same contacts get the same IDs in every export.
+	contactIDs := make(map[int64]int64, len(contacts))
+	for i, c := range contacts {
+		contactIDs[c.ID] = int64(i + 1)
+		archive.Contacts = append(archive.Contacts, ArchiveContact{
+			ID:          int64(i + 1),
+			Name:        c.Name,
+			Role:        c.Role,
+			Email:       c.Email,
+			LinkedInURL: c.LinkedinUrl,
+			Company:     c.Company,
+		})
+	}
+	for _, c := range companies {
+		archive.Companies = append(archive.Companies, ArchiveCompany{Name: c.Name})
+	}
+	for _, r := range rates {
+		archive.ExchangeRates = append(archive.ExchangeRates, ArchiveExchangeRate{Currency: r.Currency, Rate: r.Rate})
+	}
+	for _, v := range views {
+		view, parseErr := toSavedView(v.Name, v.Filters, v.ID)
+		if parseErr != nil {
+			return nil, nil, parseErr
+		}
+		// A view filtered by a tag deleted since matches nothing, so the filter is kept with no tag.
+		tag := tagNames[view.Filters.Tag]
+		view.Filters.Tag = 0
+		archive.SavedViews = append(archive.SavedViews, ArchiveSavedView{Name: v.Name, Filters: view.Filters.Query().Encode(), Tag: tag})
+	}
+	resumeLabels := make(map[int64]string, len(versions))
+	for _, v := range versions {
+		resumeLabels[v.ID] = v.Label
+		version := ArchiveResumeVersion{Label: v.Label}
+		if v.StorageKey.Valid {
+			content, readErr := readAttachment(ctx, store, v.StorageKey.String)
+			if readErr != nil && !errors.Is(readErr, storage.ErrNotFound) {
+				return nil, nil, readErr
+			}
+			// Like attachments, a file without content is left out, but the version is kept.
+			if readErr == nil {
+				version.FileName = v.FileName.String
+				version.Content = content
+			}
+		}
+		archive.ResumeVersions = append(archive.ResumeVersions, version)
+	}
+	return contactIDs, resumeLabels, nil
+}
+
+func readAttachment(ctx context.Context, store storage.Storage, key string) ([]byte, error) {
+	r, err := store.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get stored attachment: %w", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stored attachment: %w", err)
+	}
+	return content, nil
+}
+
+// ReadArchive decodes the archive and checks that its version is supported.
+func ReadArchive(r io.Reader) (Archive, error) {
+	var archive Archive
+	if err := json.NewDecoder(r).Decode(&archive); err != nil {
+		return Archive{}, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+	if archive.Version < 1 || archive.Version > ArchiveVersion {
+		return Archive{}, fmt.Errorf("%w: %d", ErrUnsupportedArchiveVersion, archive.Version)
+	}
+	return archive, nil
+}
+
+// Validate returns a message for each problem found in the archive. Job applications may only use
+// the statuses of the default pipeline and of the pipeline of the archive, and the tags, contacts and
+// resume versions of the archive.
+func (a Archive) Validate() []string {
+	var messages []string
+	statuses := make(map[string]bool, len(types.DefaultPipeline)+len(a.Pipeline))
+	for _, s := range types.DefaultPipeline {
+		statuses[s.Name.String()] = true
+	}
+	for _, s := range a.Pipeline {
+		if s.Name == "" || NormalizeStatus(s.Name).String() != s.Name || utf8.RuneCountInString(s.Name) > maxStatusNameLength {
+			messages = append(messages, fmt.Sprintf("pipeline: invalid status %q", s.Name))
+			continue
+		}
+		statuses[s.Name] = true
+	}
+
+	// Tags and resume labels are unique regardless of case.
+	tags := make(map[string]bool, len(a.Tags))
+	for _, t := range a.Tags {
+		err := validateTag(t.Name, types.TagColor(t.Color))
+		if err == nil && NormalizeTagName(t.Name) != t.Name {
+			err = ErrInvalidTagName
+		}
+		if err == nil && tags[strings.ToLower(t.Name)] {
+			err = ErrDuplicateTag
+		}
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("tag %q: %s", t.Name, err))
+			continue
+		}
+		tags[strings.ToLower(t.Name)] = true
+	}
+	contacts := make(map[int64]bool, len(a.Contacts))
+	for _, c := range a.Contacts {
+		if contacts[c.ID] {
+			messages = append(messages, fmt.Sprintf("contact %d: duplicate ID", c.ID))
+			continue
+		}
+		contacts[c.ID] = true
+		if err := c.newContact().validate(); err != nil {
+			messages = append(messages, fmt.Sprintf("contact %d: %s", c.ID, err))
+		}
+	}
+	for _, c := range a.Companies {
+		if strings.TrimSpace(c.Name) == "" {
+			messages = append(messages, "company: missing name")
+		}
+	}
+	for _, r := range a.ExchangeRates {
+		if _, ok := types.GetCurrency(r.Currency); !ok || r.Currency == types.BaseCurrency {
+			messages = append(messages, fmt.Sprintf("exchange rate %q: %s", r.Currency, ErrInvalidCurrency))
+		} else if !(r.Rate > 0) || math.IsInf(r.Rate, 0) {
+			messages = append(messages, fmt.Sprintf("exchange rate %q: %s", r.Currency, ErrInvalidExchangeRate))
+		}
+	}
+	for _, v := range a.SavedViews {
+		if v.Name == "" || normalizeViewName(v.Name) != v.Name || utf8.RuneCountInString(v.Name) > maxViewNameLength {
+			messages = append(messages, fmt.Sprintf("saved view %q: %s", v.Name, ErrInvalidViewName))
+		} else if _, err := toSavedView(v.Name, v.Filters, 0); err != nil {
+			messages = append(messages, fmt.Sprintf("saved view %q: invalid filters", v.Name))
+		} else if v.Tag != "" && !tags[strings.ToLower(v.Tag)] {
+			messages = append(messages, fmt.Sprintf("saved view %q: unknown tag %q", v.Name, v.Tag))
+		}
+	}
+	resumes := make(map[string]bool, len(a.ResumeVersions))
+	for _, v := range a.ResumeVersions {
+		if v.Label == "" || strings.Join(strings.Fields(v.Label), " ") != v.Label || utf8.RuneCountInString(v.Label) > maxResumeLabelLength {
+			messages = append(messages, fmt.Sprintf("resume version %q: %s", v.Label, ErrInvalidResumeLabel))
+			continue
+		}
+		if resumes[strings.ToLower(v.Label)] {
+			messages = append(messages, fmt.Sprintf("resume version %q: %s", v.Label, ErrDuplicateResume))
+			continue
+		}
+		resumes[strings.ToLower(v.Label)] = true
+		if v.FileName == "" && len(v.Content) == 0 {
+			continue
+		}
+		name, _, err := validateAttachment(v.FileName, types.AttachmentKindResume, int64(len(v.Content)))
+		if err == nil {
+			err = sniffAttachment(name, v.Content)
+		}
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("resume version %q: invalid file: %s", v.Label, err))
+		}
+	}
+
+	for i, app := range a.JobApplications {
+		prefix := fmt.Sprintf("job application %d", i+1)
+		if app.Company == "" || app.Title == "" {
+			messages = append(messages, prefix+": missing company or title")
+		}
+		if !statuses[app.Status] {
+			messages = append(messages, fmt.Sprintf("%s: invalid status %q", prefix, app.Status))
+		}
+		if app.SalaryMin != nil && app.SalaryMax != nil && *app.SalaryMin > *app.SalaryMax {
+			messages = append(messages, prefix+": min salary is greater than max salary")
+		}
+		if app.AppliedAt.IsZero() {
+			messages = append(messages, prefix+": missing applied date")
+		}
+		for _, h := range app.StatusHistory {
+			if !statuses[h.Status] {
+				messages = append(messages, fmt.Sprintf("%s: invalid status history status %q", prefix, h.Status))
+			}
+		}
+		for _, a := range app.Attachments {
+			name, _, err := validateAttachment(a.Name, types.AttachmentKind(a.Kind), int64(len(a.Content)))
+			if err == nil {
+				err = sniffAttachment(name, a.Content)
+			}
+			if err != nil {
+				messages = append(messages, fmt.Sprintf("%s: invalid attachment %q: %s", prefix, a.Name, err))
+			}
+		}
+		for _, r := range app.Reminders {
+			if r.RemindAt.IsZero() {
+				messages = append(messages, prefix+": missing reminder date")
+			}
+		}
+		for _, interview := range app.Interviews {
+			if err := interview.validate(); err != nil {
+				messages = append(messages, fmt.Sprintf("%s: invalid interview: %s", prefix, err))
+			}
+		}
+		if app.Offer != nil {
+			if _, err := app.Offer.newOffer().deadline(); err != nil {
+				messages = append(messages, fmt.Sprintf("%s: invalid offer: %s", prefix, err))
+			}
+		}
+		for _, t := range app.Tags {
+			if !tags[strings.ToLower(t)] {
+				messages = append(messages, fmt.Sprintf("%s: unknown tag %q", prefix, t))
+			}
+		}
+		for _, id := range app.ContactIDs {
+			if !contacts[id] {
+				messages = append(messages, fmt.Sprintf("%s: unknown contact %d", prefix, id))
+			}
+		}
+		if app.ResumeVersion != "" && !resumes[strings.ToLower(app.ResumeVersion)] {
+			messages = append(messages, fmt.Sprintf("%s: unknown resume version %q", prefix, app.ResumeVersion))
+		}
+	}
+	return messages
+}
+
+func (c ArchiveContact) newContact() NewContact {
+	return NewContact{
+		Name:        c.Name,
+		Role:        c.Role,
+		Email:       c.Email,
+		LinkedInURL: c.LinkedInURL,
+		Company:     c.Company,
+	}.normalize()
+}
+
+func (i ArchiveInterview) validate() error {
+	if types.ToInterviewType(i.Type) == "" {
+		return ErrInvalidInterviewType
+	}
+	if types.ToInterviewOutcome(i.Outcome) == "" {
+		return ErrInvalidInterviewOutcome
+	}
+	if i.DurationMinutes < 1 || i.DurationMinutes > 24*60 {
+		return ErrInvalidInterviewDuration
+	}
+	if _, err := time.LoadLocation(i.Timezone); i.Timezone == "" || err != nil {
+		return ErrInvalidTimezone
+	}
+	if i.ScheduledAt.IsZero() {
+		return ErrInvalidInterviewTime
+	}
+	return nil
+}
+
+func (o ArchiveOffer) newOffer() NewOffer {
+	return NewOffer{
+		Currency:           o.Currency,
+		BaseSalary:         o.BaseSalary,
+		Bonus:              o.Bonus,
+		SignOnBonus:        o.SignOnBonus,
+		EquityShares:       o.EquityShares,
+		EquityStrikePrice:  o.EquityStrikePrice,
+		EquitySharePrice:   o.EquitySharePrice,
+		VestingYears:       o.VestingYears,
+		VestingCliffMonths: o.VestingCliffMonths,
+		Benefits:           strings.TrimSpace(o.Benefits),
+		Deadline:           o.Deadline,
+	}
+}
+
+// ImportArchive inserts the job applications of the archive in a single transaction, skipping the
+// ones the user already has, and rebuilds the stats of the user. The tags, contacts, companies,
+// exchange rates, saved views and resume versions of the archive are added unless the user already
+// has them. Nothing is imported when the
+// archive is invalid, and the stored attachments are deleted again when the import fails.
+func ImportArchive(ctx context.Context, database db.Database, store storage.Storage, userID int64, archive Archive) (result ArchiveImportResult, err error) {
+	if messages := archive.Validate(); len(messages) > 0 {
+		return ArchiveImportResult{Errors: messages}, nil
+	}
+
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return ArchiveImportResult{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	var storageKeys []string
+	defer func() {
+		if err == nil {
+			return
+		}
+		for _, key := range storageKeys {
+			if deleteErr := store.Delete(ctx, key); deleteErr != nil {
+				err = errors.Join(err, fmt.Errorf("failed to delete stored attachment: %w", deleteErr))
+			}
+		}
+	}()
+
+	qtx := queries.New(tx)
+	keys, err := getApplicationKeys(ctx, qtx, userID)
+	if err != nil {
+		return ArchiveImportResult{}, err
+	}
+	if err = addArchivePipelineStatuses(ctx, qtx, userID, archive); err != nil {
+		return ArchiveImportResult{}, err
+	}
+	refs, err := importUserData(ctx, qtx, store, userID, archive, &storageKeys)
+	if err != nil {
+		return ArchiveImportResult{}, err
+	}
+
+	for _, app := range archive.JobApplications {
+		key := newApplicationKey(app.Company, app.Title, app.URL)
+		if keys[key] {
+			result.Skipped++
+			continue
+		}
+		keys[key] = true
+
+		if err = insertArchiveJobApplication(ctx, qtx, store, userID, app, refs, &storageKeys); err != nil {
+			return ArchiveImportResult{}, err
+		}
+		result.Imported++
+	}
+
+	if result.Imported > 0 {
+		if err = RecalculateStats(ctx, qtx, userID); err != nil {
+			return ArchiveImportResult{}, fmt.Errorf("failed to recalculate stats: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return ArchiveImportResult{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return result, nil
+}
+
+// addArchivePipelineStatuses appends the statuses used by the archive that are missing from the
+// pipeline of the user, in the order of the pipeline of the archive.
+func addArchivePipelineStatuses(ctx context.Context, qtx *queries.Queries, userID int64, archive Archive) error {
+	pipeline, err := GetPipeline(ctx, qtx, userID)
+	if err != nil {
+		return err
+	}
+
+	used := make(map[string]bool)
+	for _, app := range archive.JobApplications {
+		used[app.Status] = true
+		for _, h := range app.StatusHistory {
+			used[h.Status] = true
+		}
+	}
+
+	candidates := make([]ArchivePipelineStatus, 0, len(archive.Pipeline)+len(types.DefaultPipeline))
+	candidates = append(candidates, archive.Pipeline...)
+	for _, s := range types.DefaultPipeline {
+		if used[s.Name.String()] {
+			candidates = append(candidates, ArchivePipelineStatus{Name: s.Name.String(), Terminal: s.Terminal})
+		}
+	}
+	for _, c := range candidates {
+		if pipeline.Contains(types.JobApplicationStatus(c.Name)) {
+			continue
+		}
+		s, addErr := AddPipelineStatus(ctx, qtx, userID, c.Name, c.Terminal)
+		if addErr != nil {
+			return fmt.Errorf("failed to add pipeline status %q: %w", c.Name, addErr)
+		}
+		pipeline = append(pipeline, s)
+	}
+	return nil
+}
+
+// archiveRefs are the IDs of the data of the user the job applications of an archive link to. Tags
+// and resume versions are keyed by their lowered name and label, and contacts by their archive ID.
+type archiveRefs struct {
+	tags           map[string]int64
+	contacts       map[int64]int64
+	resumeVersions map[string]int64
+}
+
+// importUserData adds the data of the archive the user does not have yet, and adds the keys of the
+// resume files it stored to storageKeys.
+func importUserData(ctx context.Context, qtx *queries.Queries, store storage.Storage, userID int64, archive Archive, storageKeys *[]string) (archiveRefs, error) {
+	refs := archiveRefs{
+		tags:           make(map[string]int64),
+		contacts:       make(map[int64]int64, len(archive.Contacts)),
+		resumeVersions: make(map[string]int64),
+	}
+
+	tags, err := qtx.GetTagsByUserID(ctx, userID)
+	if err != nil {
+		return archiveRefs{}, fmt.Errorf("failed to get tags: %w", err)
+	}
+	for _, t := range tags {
+		refs.tags[strings.ToLower(t.Name)] = t.ID
+	}
+	for _, t := range archive.Tags {
+		if _, ok := refs.tags[strings.ToLower(t.Name)]; ok {
+			continue
+		}
+		id, insertErr := qtx.InsertTag(ctx, queries.InsertTagParams{UserID: userID, Name: t.Name, Color: t.Color})
+		if insertErr != nil {
+			return archiveRefs{}, fmt.Errorf("failed to insert tag: %w", insertErr)
+		}
+		refs.tags[strings.ToLower(t.Name)] = id
+	}
+
+	// Contacts have nothing unique about them, so only a contact with the same details is reused.
+	contacts, err := qtx.GetContactsByUserID(ctx, userID)
+	if err != nil {
+		return archiveRefs{}, fmt.Errorf("failed to get contacts: %w", err)
+	}
+	existing := make(map[NewContact]int64, len(contacts))
+	for _, c := range contacts {
+		existing[NewContact{Name: c.Name, Role: c.Role, Email: c.Email, LinkedInURL: c.LinkedinUrl, Company: c.Company}] = c.ID
+	}
+	for _, c := range archive.Contacts {
+		contact := c.newContact()
+		if id, ok := existing[contact]; ok {
+			refs.contacts[c.ID] = id
+			continue
+		}
+		inserted, insertErr := insertContact(ctx, qtx, userID, contact)
+		if insertErr != nil {
+			return archiveRefs{}, insertErr
+		}
+		existing[contact] = inserted.ID
+		refs.contacts[c.ID] = inserted.ID
+	}
+
+	for _, c := range archive.Companies {
+		if _, err = companyIDTx(ctx, qtx, userID, c.Name); err != nil {
+			return archiveRefs{}, err
+		}
+	}
+
+	rates, err := qtx.GetExchangeRatesByUserID(ctx, userID)
+	if err != nil {
+		return archiveRefs{}, fmt.Errorf("failed to get exchange rates: %w", err)
+	}
+	currencies := make(map[string]bool, len(rates))
+	for _, r := range rates {
+		currencies[r.Currency] = true
+	}
+	for _, r := range archive.ExchangeRates {
+		if currencies[r.Currency] {
+			continue
+		}
+		if err = qtx.UpsertExchangeRate(ctx, queries.UpsertExchangeRateParams{UserID: userID, Currency: r.Currency, Rate: r.Rate}); err != nil {
+			return archiveRefs{}, fmt.Errorf("failed to save exchange rate: %w", err)
+		}
+		currencies[r.Currency] = true
+	}
+
+	for _, v := range archive.SavedViews {
+		_, getErr := qtx.GetSavedViewByNameAndUserID(ctx, queries.GetSavedViewByNameAndUserIDParams{Name: v.Name, UserID: userID})
+		if getErr == nil {
+			continue
+		} else if !errors.Is(getErr, sql.ErrNoRows) {
+			return archiveRefs{}, fmt.Errorf("failed to check view name: %w", getErr)
+		}
+		view, parseErr := toSavedView(v.Name, v.Filters, 0)
+		if parseErr != nil {
+			return archiveRefs{}, parseErr
+		}
+		view.Filters.Tag = refs.tags[strings.ToLower(v.Tag)]
+		if _, err = qtx.InsertSavedView(ctx, queries.InsertSavedViewParams{UserID: userID, Name: v.Name, Filters: view.Filters.Query().Encode()}); err != nil {
+			return archiveRefs{}, fmt.Errorf("failed to insert view: %w", err)
+		}
+	}
+
+	for _, v := range archive.ResumeVersions {
+		id, getErr := qtx.GetResumeVersionByLabelAndUserID(ctx, queries.GetResumeVersionByLabelAndUserIDParams{Label: v.Label, UserID: userID})
+		if getErr == nil {
+			refs.resumeVersions[strings.ToLower(v.Label)] = id
+			continue
+		} else if !errors.Is(getErr, sql.ErrNoRows) {
+			return archiveRefs{}, fmt.Errorf("failed to check resume label: %w", getErr)
+		}
+
+		params := queries.InsertResumeVersionParams{UserID: userID, Label: v.Label}
+		if v.FileName != "" {
+			name, contentType, validateErr := validateAttachment(v.FileName, types.AttachmentKindResume, int64(len(v.Content)))
+			if validateErr != nil {
+				return archiveRefs{}, validateErr
+			}
+			params.FileName = sql.NullString{String: name, Valid: true}
+			params.ContentType = sql.NullString{String: contentType, Valid: true}
+			params.Size = sql.NullInt64{Int64: int64(len(v.Content)), Valid: true}
+			params.StorageKey = sql.NullString{String: "resumes/" + uuid.NewString(), Valid: true}
+			if err = store.Put(ctx, params.StorageKey.String, bytes.NewReader(v.Content), params.Size.Int64, contentType); err != nil {
+				return archiveRefs{}, fmt.Errorf("failed to store resume: %w", err)
+			}
+			*storageKeys = append(*storageKeys, params.StorageKey.String)
+		}
+		row, insertErr := qtx.InsertResumeVersion(ctx, params)
+		if insertErr != nil {
+			return archiveRefs{}, fmt.Errorf("failed to insert resume version: %w", insertErr)
+		}
+		refs.resumeVersions[strings.ToLower(v.Label)] = row.ID
+	}
+	return refs, nil
+}
+
+// insertArchiveJobApplication inserts a job application of an archive and adds the keys of the
+// attachments it stored to storageKeys.
+func insertArchiveJobApplication(ctx context.Context, qtx *queries.Queries, store storage.Storage, userID int64, app ArchiveJobApplication, refs archiveRefs, storageKeys *[]string) error {
+	archived := int64(0)
+	if app.Archived {
+		archived = 1
+	}
+	updatedAt := app.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = app.AppliedAt
+	}
+	createdAt := app.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = app.AppliedAt
+	}
+
+	companyID, err := companyIDTx(ctx, qtx, userID, app.Company)
+	if err != nil {
+		return err
+	}
+	jobID, err := qtx.InsertJobApplicationFromArchive(ctx, queries.InsertJobApplicationFromArchiveParams{
+		Company:        app.Company,
+		CompanyID:      companyID,
+		Title:          app.Title,
+		Url:            db.NewNullString(app.URL),
+		Status:         app.Status,
+		Archived:       archived,
+		UserID:         userID,
+		SalaryMin:      toNullInt64(app.SalaryMin),
+		SalaryMax:      toNullInt64(app.SalaryMax),
+		SalaryCurrency: db.NewNullString(app.SalaryCurrency),
+		AppliedAt:      app.AppliedAt.UTC(),
+		UpdatedAt:      updatedAt.UTC(),
+		CreatedAt:      createdAt.UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert job: %w", err)
+	}
+
+	history := app.StatusHistory
+	if len(history) == 0 {
+		history = []ArchiveStatusHistory{{Status: app.Status, CreatedAt: app.AppliedAt}}
+	}
+	for _, h := range history {
+		err = qtx.InsertJobApplicationStatusHistoryWithCreatedAt(ctx, queries.InsertJobApplicationStatusHistoryWithCreatedAtParams{
+			Status:           h.Status,
+			JobApplicationID: jobID,
+			CreatedAt:        h.CreatedAt.UTC(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert job status history: %w", err)
+		}
+	}
+
+	for _, n := range app.Notes {
+		var updatedAt any
+		if n.UpdatedAt != nil {
+			updatedAt = n.UpdatedAt.UTC()
+		}
+		err = qtx.InsertJobApplicationNoteWithCreatedAt(ctx, queries.InsertJobApplicationNoteWithCreatedAtParams{
+			JobApplicationID: jobID,
+			Note:             n.Note,
+			CreatedAt:        n.CreatedAt.UTC(),
+			UpdatedAt:        updatedAt,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert note: %w", err)
+		}
+	}
+
+	for _, a := range app.Attachments {
+		name, contentType, validateErr := validateAttachment(a.Name, types.AttachmentKind(a.Kind), int64(len(a.Content)))
+		if validateErr != nil {
+			return validateErr
+		}
+		createdAt := a.CreatedAt
+		if createdAt.IsZero() {
+			createdAt = app.AppliedAt
+		}
+		attachment, insertErr := insertAttachment(ctx, qtx, store, userID, jobID, queries.InsertJobApplicationAttachmentParams{
+			Name:        name,
+			Kind:        a.Kind,
+			ContentType: contentType,
+			Size:        int64(len(a.Content)),
+			CreatedAt:   createdAt.UTC(),
+		}, bytes.NewReader(a.Content))
+		if insertErr != nil {
+			return insertErr
+		}
+		*storageKeys = append(*storageKeys, attachment.StorageKey)
+	}
+
+	// Listings are shared between users, so links are only restored when this instance has the listing.
+	// A listing the user already linked to another application keeps that link.
+	for _, hnJobID := range app.HNJobIDs {
+		count, countErr := qtx.CountHNJobsByID(ctx, hnJobID)
+		if countErr != nil {
+			return fmt.Errorf("failed to count hn job: %w", countErr)
+		}
+		if count == 0 {
+			continue
+		}
+		err = qtx.InsertUserHNJobIfMissing(ctx, queries.InsertUserHNJobIfMissingParams{UserID: userID, HnJobID: hnJobID, JobApplicationID: jobID})
+		if err != nil {
+			return fmt.Errorf("failed to insert user hn job: %w", err)
+		}
+	}
+
+	for _, r := range app.Reminders {
+		var dismissedAt any
+		if r.DismissedAt != nil {
+			dismissedAt = r.DismissedAt.UTC()
+		}
+		createdAt := orTime(r.CreatedAt, app.AppliedAt)
+		err = qtx.InsertJobApplicationReminderFromSnapshot(ctx, queries.InsertJobApplicationReminderFromSnapshotParams{
+			JobApplicationID: jobID,
+			RemindAt:         r.RemindAt.UTC(),
+			DismissedAt:      dismissedAt,
+			Note:             r.Note,
+			CreatedAt:        createdAt.UTC(),
+			UpdatedAt:        orTime(r.UpdatedAt, createdAt).UTC(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert reminder: %w", err)
+		}
+	}
+	for _, i := range app.Interviews {
+		createdAt := orTime(i.CreatedAt, app.AppliedAt)
+		err = qtx.InsertJobApplicationInterviewFromSnapshot(ctx, queries.InsertJobApplicationInterviewFromSnapshotParams{
+			JobApplicationID: jobID,
+			ScheduledAt:      i.ScheduledAt.UTC(),
+			Timezone:         i.Timezone,
+			Type:             i.Type,
+			Outcome:          i.Outcome,
+			Interviewers:     i.Interviewers,
+			DurationMinutes:  i.DurationMinutes,
+			CreatedAt:        createdAt.UTC(),
+			UpdatedAt:        orTime(i.UpdatedAt, createdAt).UTC(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert interview: %w", err)
+		}
+	}
+	if app.Offer != nil {
+		offer := app.Offer.newOffer()
+		deadline, deadlineErr := offer.deadline()
+		if deadlineErr != nil {
+			return deadlineErr
+		}
+		createdAt := orTime(app.Offer.CreatedAt, app.AppliedAt)
+		err = qtx.InsertJobApplicationOfferFromSnapshot(ctx, queries.InsertJobApplicationOfferFromSnapshotParams{
+			JobApplicationID:   jobID,
+			Currency:           offer.Currency,
+			BaseSalary:         offer.BaseSalary,
+			Bonus:              offer.Bonus,
+			SignOnBonus:        offer.SignOnBonus,
+			EquityShares:       offer.EquityShares,
+			EquityStrikePrice:  offer.EquityStrikePrice,
+			EquitySharePrice:   offer.EquitySharePrice,
+			VestingYears:       offer.VestingYears,
+			VestingCliffMonths: offer.VestingCliffMonths,
+			Benefits:           offer.Benefits,
+			Deadline:           deadline,
+			CreatedAt:          createdAt.UTC(),
+			UpdatedAt:          orTime(app.Offer.UpdatedAt, createdAt).UTC(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert offer: %w", err)
+		}
+	}
+
+	for _, t := range app.Tags {
+		err = qtx.InsertJobApplicationTagFromSnapshot(ctx, queries.InsertJobApplicationTagFromSnapshotParams{
+			JobApplicationID: jobID,
+			TagID:            refs.tags[strings.ToLower(t)],
+			UserID:           userID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert tag: %w", err)
+		}
+	}
+	for _, id := range app.ContactIDs {
+		err = qtx.InsertJobApplicationContactFromSnapshot(ctx, queries.InsertJobApplicationContactFromSnapshotParams{
+			JobApplicationID: jobID,
+			ContactID:        refs.contacts[id],
+			UserID:           userID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert contact: %w", err)
+		}
+	}
+	if app.ResumeVersion != "" {
+		err = qtx.RestoreJobApplicationResumeVersion(ctx, queries.RestoreJobApplicationResumeVersionParams{
+			ResumeVersionID: refs.resumeVersions[strings.ToLower(app.ResumeVersion)],
+			UserID:          userID,
+			ID:              jobID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set resume version: %w", err)
+		}
+	}
+	return nil
+}
+
+// orTime returns the time, or the fallback when the time is missing from the archive.
+func orTime(t time.Time, fallback time.Time) time.Time {
+	if t.IsZero() {
+		return fallback
+	}
+	return t
+}
+
+func toNullInt64(val *int64) sql.NullInt64 {
+	if val == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *val, Valid: true}
+}
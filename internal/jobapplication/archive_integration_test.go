@@ -0,0 +1,338 @@
+//go:build integration
+
+package jobapplication_test
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/storage"
+	"github.com/Piszmog/pathwise/internal/testutil"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchive_RoundTrip(t *testing.T) {
+	database := setupTestDB(t)
+	store := setupTestStorage(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	createTestUser(t, database.DB(), 1)
+	createTestUser(t, database.DB(), 2)
+	insertTestHNJob(t, database.DB(), "hn-1")
+
+	acmeID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{
+		Company:        "Acme",
+		Title:          "Engineer",
+		URL:            "https://acme.example.com",
+		SalaryMin:      sql.NullInt64{Int64: 100000, Valid: true},
+		SalaryMax:      sql.NullInt64{Int64: 150000, Valid: true},
+		SalaryCurrency: sql.NullString{String: "USD", Valid: true},
+	})
+	require.NoError(t, err)
+	globexID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Globex", Title: "Manager"})
+	require.NoError(t, err)
+
+	// Backdate the timeline so the stats depend on the imported timestamps.
+	_, err = database.DB().ExecContext(ctx, "UPDATE job_applications SET applied_at = '2025-01-01 10:00:00', created_at = '2025-01-01 10:00:00' WHERE id = ?", acmeID)
+	require.NoError(t, err)
+	_, err = database.DB().ExecContext(ctx, "UPDATE job_application_status_histories SET created_at = '2025-01-01 10:00:00' WHERE job_application_id = ?", acmeID)
+	require.NoError(t, err)
+	_, err = jobapplication.Update(ctx, database, 1, jobapplication.UpdatedJobApplication{
+		ID:             acmeID,
+		Company:        "Acme",
+		Title:          "Engineer",
+		URL:            "https://acme.example.com",
+		Status:         types.JobApplicationStatusInterviewing,
+		SalaryMin:      sql.NullInt64{Int64: 100000, Valid: true},
+		SalaryMax:      sql.NullInt64{Int64: 150000, Valid: true},
+		SalaryCurrency: sql.NullString{String: "USD", Valid: true},
+	})
+	require.NoError(t, err)
+	_, err = jobapplication.AddNote(ctx, database, 1, acmeID, "first note")
+	require.NoError(t, err)
+	_, err = jobapplication.AddNote(ctx, database, 1, acmeID, "second note")
+	require.NoError(t, err)
+	_, err = database.DB().ExecContext(ctx, "INSERT INTO user_hn_jobs (user_id, hn_job_id, job_application_id) VALUES (1, 'hn-1', ?)", acmeID)
+	require.NoError(t, err)
+	_, err = database.DB().ExecContext(ctx, "UPDATE job_applications SET archived = 1 WHERE id = ?", globexID)
+	require.NoError(t, err)
+	require.NoError(t, jobapplication.RecalculateStats(ctx, database.Queries(), 1))
+
+	_, err = jobapplication.AddReminder(ctx, database, 1, acmeID, now.AddDate(0, 0, 7), "Follow up", now)
+	require.NoError(t, err)
+	_, err = jobapplication.AddInterview(ctx, database, 1, acmeID, jobapplication.NewInterview{
+		ScheduledAt:     "2026-10-20T09:30",
+		Timezone:        "Europe/Berlin",
+		Type:            types.InterviewTypeTechnical,
+		Interviewers:    "Jane",
+		DurationMinutes: 45,
+	})
+	require.NoError(t, err)
+	remote, err := jobapplication.AddTag(ctx, database.Queries(), 1, "Remote", types.TagColorBlue)
+	require.NoError(t, err)
+	_, err = jobapplication.AddTag(ctx, database.Queries(), 1, "Unused", types.TagColorRed)
+	require.NoError(t, err)
+	require.NoError(t, jobapplication.SetJobApplicationTags(ctx, database, 1, acmeID, []int64{remote.ID}))
+	_, err = jobapplication.AddJobApplicationContact(ctx, database, 1, acmeID, jobapplication.NewContact{Name: "Jane", Role: "Recruiter", Email: "jane@acme.example.com"})
+	require.NoError(t, err)
+	_, err = jobapplication.AddContact(ctx, database.Queries(), 1, jobapplication.NewContact{Name: "Bob", Company: "Globex"})
+	require.NoError(t, err)
+	resume, err := jobapplication.AddResumeVersion(ctx, database.Queries(), store, 1, "Backend", resumeFile("resume.txt", "backend resume"))
+	require.NoError(t, err)
+	_, err = jobapplication.AddResumeVersion(ctx, database.Queries(), store, 1, "Draft", nil)
+	require.NoError(t, err)
+	require.NoError(t, jobapplication.SetJobApplicationResumeVersion(ctx, database.Queries(), 1, acmeID, resume.ID))
+	require.NoError(t, jobapplication.SaveExchangeRates(ctx, database, 1, []types.ExchangeRate{{Currency: "EUR", Rate: 1.1}}))
+	_, err = jobapplication.SaveView(ctx, database.Queries(), 1, "Remote interviews", types.FilterOpts{Status: types.JobApplicationStatusInterviewing, Tag: remote.ID})
+	require.NoError(t, err)
+
+	initechID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Initech", Title: "Developer"})
+	require.NoError(t, err)
+	_, err = jobapplication.Update(ctx, database, 1, jobapplication.UpdatedJobApplication{ID: initechID, Company: "Initech", Title: "Developer", Status: types.JobApplicationStatusOffered})
+	require.NoError(t, err)
+	require.NoError(t, jobapplication.SaveOffer(ctx, database, 1, initechID, jobapplication.NewOffer{
+		Currency:           "EUR",
+		BaseSalary:         90000,
+		Bonus:              5000,
+		VestingYears:       4,
+		VestingCliffMonths: 12,
+		Benefits:           "Lunch",
+		Deadline:           "2026-11-01",
+	}))
+
+	exported, err := jobapplication.Export(ctx, database.Queries(), store, 1)
+	require.NoError(t, err)
+	require.Len(t, exported.JobApplications, 3)
+	assert.Equal(t, jobapplication.ArchiveVersion, exported.Version)
+	assert.Equal(t, []string{"hn-1"}, exported.JobApplications[0].HNJobIDs)
+	assert.True(t, exported.JobApplications[1].Archived)
+	assert.Len(t, exported.JobApplications[0].Reminders, 1)
+	assert.Len(t, exported.JobApplications[0].Interviews, 1)
+	assert.Equal(t, []string{"Remote"}, exported.JobApplications[0].Tags)
+	assert.Equal(t, []int64{2}, exported.JobApplications[0].ContactIDs)
+	assert.Equal(t, "Backend", exported.JobApplications[0].ResumeVersion)
+	require.NotNil(t, exported.JobApplications[2].Offer)
+	assert.Equal(t, "2026-11-01", exported.JobApplications[2].Offer.Deadline)
+	assert.Len(t, exported.Tags, 2)
+	assert.Len(t, exported.Contacts, 2)
+	assert.Len(t, exported.Companies, 3)
+	assert.Equal(t, []jobapplication.ArchiveExchangeRate{{Currency: "EUR", Rate: 1.1}}, exported.ExchangeRates)
+	assert.Equal(t, []jobapplication.ArchiveSavedView{{Name: "Remote interviews", Filters: "status=interviewing", Tag: "Remote"}}, exported.SavedViews)
+	require.Len(t, exported.ResumeVersions, 2)
+	assert.Equal(t, []byte("backend resume"), exported.ResumeVersions[0].Content)
+
+	importStore := setupTestStorage(t)
+	result, err := jobapplication.ImportArchive(ctx, database, importStore, 2, exported)
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, 3, result.Imported)
+
+	imported, err := jobapplication.Export(ctx, database.Queries(), importStore, 2)
+	require.NoError(t, err)
+	imported.ExportedAt = exported.ExportedAt
+	assert.Equal(t, exported, imported)
+	assert.Equal(t, getTestStats(t, database.DB(), 1), getTestStats(t, database.DB(), 2))
+
+	// The saved view is filtered by the tag of the user it was imported for.
+	views, err := jobapplication.GetViews(ctx, database.Queries(), 2)
+	require.NoError(t, err)
+	require.Len(t, views, 1)
+	assert.Equal(t, int64(1), views[0].Count)
+
+	// Importing the same archive again does not create duplicates.
+	result, err = jobapplication.ImportArchive(ctx, database, importStore, 2, exported)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Imported)
+	assert.Equal(t, 3, result.Skipped)
+
+	imported, err = jobapplication.Export(ctx, database.Queries(), importStore, 2)
+	require.NoError(t, err)
+	imported.ExportedAt = exported.ExportedAt
+	assert.Equal(t, exported, imported)
+}
+
+func TestArchive_ImportVersion1(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+
+	archive, err := jobapplication.ReadArchive(strings.NewReader(`{
+		"version": 1,
+		"exported_at": "2025-02-01T00:00:00Z",
+		"job_applications": [
+			{
+				"company": "Acme",
+				"title": "Engineer",
+				"status": "applied",
+				"archived": false,
+				"applied_at": "2025-01-01T10:00:00Z",
+				"updated_at": "2025-01-01T10:00:00Z",
+				"created_at": "2025-01-01T10:00:00Z",
+				"status_history": [{"status": "applied", "created_at": "2025-01-01T10:00:00Z"}],
+				"notes": [{"note": "first note", "created_at": "2025-01-02T10:00:00Z"}]
+			}
+		]
+	}`))
+	require.NoError(t, err)
+
+	result, err := jobapplication.ImportArchive(ctx, database, setupTestStorage(t), 1, archive)
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, 1, result.Imported)
+
+	exported, err := jobapplication.Export(ctx, database.Queries(), setupTestStorage(t), 1)
+	require.NoError(t, err)
+	require.Len(t, exported.JobApplications, 1)
+	assert.Equal(t, "Acme", exported.JobApplications[0].Company)
+	assert.Len(t, exported.JobApplications[0].Notes, 1)
+	assert.Equal(t, []jobapplication.ArchiveCompany{{Name: "Acme"}}, exported.Companies)
+}
+
+func TestArchive_ImportLinkedHNJob(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+	insertTestHNJob(t, database.DB(), "hn-1")
+	jobID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+	_, err = database.DB().ExecContext(ctx, "INSERT INTO user_hn_jobs (user_id, hn_job_id, job_application_id) VALUES (1, 'hn-1', ?)", jobID)
+	require.NoError(t, err)
+
+	// An edited copy of the application is not a duplicate, but its listing is already linked.
+	exported, err := jobapplication.Export(ctx, database.Queries(), setupTestStorage(t), 1)
+	require.NoError(t, err)
+	require.Len(t, exported.JobApplications, 1)
+	exported.JobApplications[0].Company = "Acme Corp"
+	exported.JobApplications[0].Title = "Senior Engineer"
+	exported.JobApplications[0].URL = "https://acme.example.com"
+
+	result, err := jobapplication.ImportArchive(ctx, database, setupTestStorage(t), 1, exported)
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, 1, result.Imported)
+
+	linkedID, err := database.Queries().CheckUserHasAddedHNJob(ctx, queries.CheckUserHasAddedHNJobParams{UserID: 1, HnJobID: "hn-1"})
+	require.NoError(t, err)
+	assert.Equal(t, jobID, linkedID)
+}
+
+func TestArchive_ImportInvalid(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+
+	archive := jobapplication.Archive{
+		Version: jobapplication.ArchiveVersion,
+		JobApplications: []jobapplication.ArchiveJobApplication{
+			{Company: "Acme", Title: "Engineer", Status: "applied", AppliedAt: time.Now()},
+			{Company: "Globex", Status: "unknown", AppliedAt: time.Now()},
+			{Company: "Initech", Title: "Developer", Status: "applied", AppliedAt: time.Now(), Tags: []string{"Remote"}, ContactIDs: []int64{1}},
+		},
+	}
+	result, err := jobapplication.ImportArchive(ctx, database, setupTestStorage(t), 1, archive)
+	require.NoError(t, err)
+	assert.Len(t, result.Errors, 4)
+	assert.Equal(t, 0, result.Imported)
+
+	var count int
+	require.NoError(t, database.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM job_applications WHERE user_id = 1").Scan(&count))
+	assert.Equal(t, 0, count)
+}
+
+func setupTestDB(t *testing.T) db.Database {
+	t.Helper()
+
+	dbFile := filepath.Join(t.TempDir(), "integration-test.sqlite3")
+	database, err := db.New(slog.New(slog.DiscardHandler), db.DatabaseOpts{URL: dbFile})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+
+	_, err = database.DB().ExecContext(context.Background(), "PRAGMA foreign_keys = ON")
+	require.NoError(t, err)
+	require.NoError(t, testutil.RunMigrations(dbFile))
+	return database
+}
+
+func setupTestStorage(t *testing.T) storage.Storage {
+	t.Helper()
+
+	store, err := storage.NewLocal(t.TempDir())
+	require.NoError(t, err)
+	return store
+}
+
+func createTestUser(t *testing.T, db *sql.DB, userID int64) {
+	t.Helper()
+
+	_, err := db.ExecContext(context.Background(),
+		"INSERT INTO users (id, email, password) VALUES (?, ?, ?)",
+		userID, "test-user-"+time.Now().Format("150405.000000000")+"@example.com", "password",
+	)
+	require.NoError(t, err)
+	_, err = db.ExecContext(context.Background(), "INSERT INTO job_application_stats (user_id) VALUES (?)", userID)
+	require.NoError(t, err)
+	require.NoError(t, jobapplication.SeedPipeline(context.Background(), queries.New(db), userID))
+}
+
+func insertTestHNJob(t *testing.T, db *sql.DB, id string) {
+	t.Helper()
+
+	ctx := context.Background()
+	_, err := db.ExecContext(ctx, "INSERT INTO hn_stories (id, posted_at, title) VALUES (1, CURRENT_TIMESTAMP, 'Who is hiring?')")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO hn_comments (id, commented_at, value, hn_story_id) VALUES (1, CURRENT_TIMESTAMP, 'Acme is hiring', 1)")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO hn_jobs (id, company, company_description, title, hn_comment_id) VALUES (?, 'Acme', 'Acme', 'Engineer', 1)", id)
+	require.NoError(t, err)
+}
+
+type testStats struct {
+	TotalApplications     int64
+	TotalCompanies        int64
+	AverageTimeToHearBack int64
+	TotalInterviewing     int64
+	TotalRejected         int64
+	TotalApplied          int64
+	TotalCanceled         int64
+	TotalDeclined         int64
+	TotalAccepted         int64
+	TotalWatching         int64
+	TotalOffers           int64
+	TotalWithdrawn        int64
+}
+
+func getTestStats(t *testing.T, db *sql.DB, userID int64) testStats {
+	t.Helper()
+
+	var s testStats
+	// The stats only hold the totals, so the per status counts are taken from the job applications
+	// the stats are built from.
+	countStatus := func(status string) string {
+		return "(SELECT COUNT(*) FROM job_applications j WHERE j.user_id = s.user_id AND j.archived = 0 AND j.status = '" + status + "')"
+	}
+	err := db.QueryRowContext(context.Background(),
+		`SELECT s.total_applications, s.total_companies, s.average_time_to_hear_back, `+
+			countStatus("interviewing")+`, `+countStatus("rejected")+`, `+countStatus("applied")+`, `+
+			countStatus("canceled")+`, `+countStatus("declined")+`, `+countStatus("accepted")+`, `+
+			countStatus("watching")+`, `+countStatus("offered")+`, `+countStatus("withdrawn")+`
+		FROM job_application_stats s WHERE s.user_id = ?`,
+		userID,
+	).Scan(&s.TotalApplications, &s.TotalCompanies, &s.AverageTimeToHearBack, &s.TotalInterviewing, &s.TotalRejected,
+		&s.TotalApplied, &s.TotalCanceled, &s.TotalDeclined, &s.TotalAccepted, &s.TotalWatching, &s.TotalOffers, &s.TotalWithdrawn)
+	require.NoError(t, err)
+	return s
+}
@@ -0,0 +1,277 @@
+package jobapplication
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/storage"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/google/uuid"
+)
+
+// MaxAttachmentSize is the maximum size of an attachment in bytes.
+const MaxAttachmentSize = 10 << 20
+
+const maxAttachmentNameLength = 255
+
+// detachedAttachmentRetention is how long the attachments of deleted job applications are kept, so
+// undoing a bulk delete can attach them again.
+const detachedAttachmentRetention = BulkUndoWindow + time.Minute
+
+var (
+	ErrInvalidAttachmentName = errors.New("invalid attachment name")
+	ErrInvalidAttachmentKind = errors.New("invalid attachment kind")
+	ErrAttachmentType        = errors.New("attachment type is not allowed")
+	ErrAttachmentEmpty       = errors.New("attachment is empty")
+	ErrAttachmentTooLarge    = errors.New("attachment is too large")
+	ErrAttachmentNotFound    = errors.New("attachment not found")
+)
+
+// attachmentType is a file type attachments may have.
+type attachmentType struct {
+	// contentType is what the attachment is served as.
+	contentType string
+	// sniffed are the content types http.DetectContentType may detect for the content, so a file
+	// cannot pretend to be another type by its extension.
+	sniffed []string
+}
+
+// attachmentTypes are the allowed file types by extension. Office documents are detected as ZIP or
+// binary content.
+var attachmentTypes = map[string]attachmentType{
+	".pdf":  {contentType: "application/pdf", sniffed: []string{"application/pdf"}},
+	".doc":  {contentType: "application/msword", sniffed: []string{"application/octet-stream"}},
+	".docx": {contentType: "application/vnd.openxmlformats-officedocument.wordprocessingml.document", sniffed: []string{"application/zip"}},
+	".odt":  {contentType: "application/vnd.oasis.opendocument.text", sniffed: []string{"application/zip"}},
+	".rtf":  {contentType: "application/rtf", sniffed: []string{"text/plain"}},
+	".txt":  {contentType: "text/plain; charset=utf-8", sniffed: []string{"text/plain"}},
+	".md":   {contentType: "text/markdown; charset=utf-8", sniffed: []string{"text/plain"}},
+	".png":  {contentType: "image/png", sniffed: []string{"image/png"}},
+	".jpg":  {contentType: "image/jpeg", sniffed: []string{"image/jpeg"}},
+	".jpeg": {contentType: "image/jpeg", sniffed: []string{"image/jpeg"}},
+}
+
+// AttachmentUpload is a file uploaded to a job application.
+type AttachmentUpload struct {
+	Name    string
+	Kind    types.AttachmentKind
+	Size    int64
+	Content io.Reader
+}
+
+// AddAttachment stores the file and attaches it to a job application of the user. Only PDFs, office
+// documents, text files and images up to MaxAttachmentSize are allowed.
+func AddAttachment(ctx context.Context, database db.Database, store storage.Storage, userID int64, jobID int64, upload AttachmentUpload) (queries.JobApplicationAttachment, error) {
+	name, contentType, err := validateAttachment(upload.Name, upload.Kind, upload.Size)
+	if err != nil {
+		return queries.JobApplicationAttachment{}, err
+	}
+	content, err := checkAttachmentContent(name, upload.Content)
+	if err != nil {
+		return queries.JobApplicationAttachment{}, err
+	}
+
+	if _, err = database.Queries().GetJobApplicationByIDAndUserID(ctx, queries.GetJobApplicationByIDAndUserIDParams{ID: jobID, UserID: userID}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return queries.JobApplicationAttachment{}, ErrNotFound
+		}
+		return queries.JobApplicationAttachment{}, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	return insertAttachment(ctx, database.Queries(), store, userID, jobID, queries.InsertJobApplicationAttachmentParams{
+		Name:        name,
+		Kind:        upload.Kind.String(),
+		ContentType: contentType,
+		Size:        upload.Size,
+		CreatedAt:   time.Now().UTC(),
+	}, content)
+}
+
+// insertAttachment stores the content under a new key and inserts the attachment. The content is
+// deleted again when the attachment cannot be inserted.
+func insertAttachment(ctx context.Context, q *queries.Queries, store storage.Storage, userID int64, jobID int64, params queries.InsertJobApplicationAttachmentParams, content io.Reader) (queries.JobApplicationAttachment, error) {
+	params.StorageKey = "attachments/" + uuid.NewString()
+	params.JobApplicationID = sql.NullInt64{Int64: jobID, Valid: true}
+	params.UserID = userID
+	if err := store.Put(ctx, params.StorageKey, content, params.Size, params.ContentType); err != nil {
+		return queries.JobApplicationAttachment{}, fmt.Errorf("failed to store attachment: %w", err)
+	}
+
+	a, err := q.InsertJobApplicationAttachment(ctx, params)
+	if err != nil {
+		err = fmt.Errorf("failed to insert attachment: %w", err)
+		if deleteErr := store.Delete(ctx, params.StorageKey); deleteErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to delete stored attachment: %w", deleteErr))
+		}
+		return queries.JobApplicationAttachment{}, err
+	}
+	return a, nil
+}
+
+// OpenAttachment returns an attachment of a job application of the user with its content. The
+// content must be closed.
+func OpenAttachment(ctx context.Context, q *queries.Queries, store storage.Storage, userID int64, jobID int64, attachmentID int64) (queries.JobApplicationAttachment, io.ReadCloser, error) {
+	a, err := getAttachment(ctx, q, userID, jobID, attachmentID)
+	if err != nil {
+		return queries.JobApplicationAttachment{}, nil, err
+	}
+	content, err := store.Get(ctx, a.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return queries.JobApplicationAttachment{}, nil, ErrAttachmentNotFound
+		}
+		return queries.JobApplicationAttachment{}, nil, fmt.Errorf("failed to get stored attachment: %w", err)
+	}
+	return a, content, nil
+}
+
+// DeleteAttachment deletes an attachment of a job application of the user along with its content.
+func DeleteAttachment(ctx context.Context, database db.Database, store storage.Storage, userID int64, jobID int64, attachmentID int64) (err error) {
+	a, err := getAttachment(ctx, database.Queries(), userID, jobID, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	// The content is deleted before the attachment is committed as deleted, so an attachment is never
+	// left without its content.
+	qtx := queries.New(tx)
+	deleted, err := qtx.DeleteJobApplicationAttachment(ctx, queries.DeleteJobApplicationAttachmentParams{ID: a.ID, UserID: userID})
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+	if deleted == 0 {
+		return ErrAttachmentNotFound
+	}
+	if err = store.Delete(ctx, a.StorageKey); err != nil {
+		return fmt.Errorf("failed to delete stored attachment: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// DeleteAllAttachments deletes every attachment of the user along with their contents, including
+// the attachments of deleted job applications.
+func DeleteAllAttachments(ctx context.Context, q *queries.Queries, store storage.Storage, userID int64) error {
+	keys, err := q.GetStorageKeysByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get attachments: %w", err)
+	}
+	for _, key := range keys {
+		if err = store.Delete(ctx, key); err != nil {
+			return fmt.Errorf("failed to delete stored attachment: %w", err)
+		}
+	}
+	if err = q.DeleteJobApplicationAttachmentsByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete attachments: %w", err)
+	}
+	return nil
+}
+
+// PurgeDetachedAttachments deletes the attachments of job applications that were deleted long
+// enough ago that the deletion can no longer be undone, and returns how many were deleted.
+func PurgeDetachedAttachments(ctx context.Context, q *queries.Queries, store storage.Storage, now time.Time) (int, error) {
+	detached, err := q.GetDetachedJobApplicationAttachments(ctx, now.Add(-detachedAttachmentRetention).UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to get detached attachments: %w", err)
+	}
+	for i, a := range detached {
+		if err = store.Delete(ctx, a.StorageKey); err != nil {
+			return i, fmt.Errorf("failed to delete stored attachment: %w", err)
+		}
+		if err = q.DeleteDetachedJobApplicationAttachment(ctx, a.ID); err != nil {
+			return i, fmt.Errorf("failed to delete attachment: %w", err)
+		}
+	}
+	return len(detached), nil
+}
+
+func getAttachment(ctx context.Context, q *queries.Queries, userID int64, jobID int64, attachmentID int64) (queries.JobApplicationAttachment, error) {
+	a, err := q.GetJobApplicationAttachmentByIDAndUserID(ctx, queries.GetJobApplicationAttachmentByIDAndUserIDParams{
+		ID:               attachmentID,
+		JobApplicationID: sql.NullInt64{Int64: jobID, Valid: true},
+		UserID:           userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return queries.JobApplicationAttachment{}, ErrAttachmentNotFound
+		}
+		return queries.JobApplicationAttachment{}, fmt.Errorf("failed to get attachment: %w", err)
+	}
+	return a, nil
+}
+
+// validateAttachment checks the name, kind and size of an attachment and returns the cleaned name
+// with the content type of the attachment.
+func validateAttachment(name string, kind types.AttachmentKind, size int64) (string, string, error) {
+	// Browsers may send the full path of the file on the device of the user.
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, `\`, "/")))
+	if name == "" || name == "." || name == "/" || !utf8.ValidString(name) || utf8.RuneCountInString(name) > maxAttachmentNameLength {
+		return "", "", ErrInvalidAttachmentName
+	}
+	if types.ToAttachmentKind(kind.String()) == "" {
+		return "", "", ErrInvalidAttachmentKind
+	}
+	t, ok := attachmentTypes[strings.ToLower(filepath.Ext(name))]
+	if !ok {
+		return "", "", ErrAttachmentType
+	}
+	if size <= 0 {
+		return "", "", ErrAttachmentEmpty
+	}
+	if size > MaxAttachmentSize {
+		return "", "", ErrAttachmentTooLarge
+	}
+	return name, t.contentType, nil
+}
+
+// checkAttachmentContent checks that the content matches the file type of the name and returns the
+// full content to store.
+func checkAttachmentContent(name string, content io.Reader) (io.Reader, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	head = head[:n]
+	if err = sniffAttachment(name, head); err != nil {
+		return nil, err
+	}
+	return io.MultiReader(bytes.NewReader(head), content), nil
+}
+
+// sniffAttachment checks that the start of the content matches the file type of the name.
+func sniffAttachment(name string, head []byte) error {
+	if len(head) == 0 {
+		return ErrAttachmentEmpty
+	}
+	sniffed, _, _ := strings.Cut(http.DetectContentType(head), ";")
+	if !slices.Contains(attachmentTypes[strings.ToLower(filepath.Ext(name))].sniffed, sniffed) {
+		return ErrAttachmentType
+	}
+	return nil
+}
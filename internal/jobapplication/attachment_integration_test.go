@@ -0,0 +1,241 @@
+//go:build integration
+
+package jobapplication_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/storage"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPDF = "%PDF-1.7\n1 0 obj << /Type /Catalog >> endobj\n%%EOF\n"
+
+func pdfUpload(name string) jobapplication.AttachmentUpload {
+	return jobapplication.AttachmentUpload{
+		Name:    name,
+		Kind:    types.AttachmentKindResume,
+		Size:    int64(len(testPDF)),
+		Content: strings.NewReader(testPDF),
+	}
+}
+
+func readStored(t *testing.T, store storage.Storage, key string) string {
+	t.Helper()
+
+	r, err := store.Get(context.Background(), key)
+	require.NoError(t, err)
+	defer func() { _ = r.Close() }()
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestAttachments(t *testing.T) {
+	database := setupTestDB(t)
+	store := setupTestStorage(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+	createTestUser(t, database.DB(), 2)
+	jobID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+
+	a, err := jobapplication.AddAttachment(ctx, database, store, 1, jobID, pdfUpload(`C:\Users\me\Resume 2026.pdf`))
+	require.NoError(t, err)
+	assert.Equal(t, "Resume 2026.pdf", a.Name)
+	assert.Equal(t, "application/pdf", a.ContentType)
+	assert.Equal(t, int64(len(testPDF)), a.Size)
+	assert.Equal(t, jobID, a.JobApplicationID.Int64)
+	assert.Equal(t, testPDF, readStored(t, store, a.StorageKey))
+
+	opened, content, err := jobapplication.OpenAttachment(ctx, database.Queries(), store, 1, jobID, a.ID)
+	require.NoError(t, err)
+	b, err := io.ReadAll(content)
+	require.NoError(t, err)
+	require.NoError(t, content.Close())
+	assert.Equal(t, a.ID, opened.ID)
+	assert.Equal(t, testPDF, string(b))
+
+	_, _, err = jobapplication.OpenAttachment(ctx, database.Queries(), store, 2, jobID, a.ID)
+	require.ErrorIs(t, err, jobapplication.ErrAttachmentNotFound)
+	_, err = jobapplication.AddAttachment(ctx, database, store, 2, jobID, pdfUpload("resume.pdf"))
+	require.ErrorIs(t, err, jobapplication.ErrNotFound)
+	require.ErrorIs(t, jobapplication.DeleteAttachment(ctx, database, store, 2, jobID, a.ID), jobapplication.ErrAttachmentNotFound)
+
+	require.NoError(t, jobapplication.DeleteAttachment(ctx, database, store, 1, jobID, a.ID))
+	_, err = store.Get(ctx, a.StorageKey)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+	require.ErrorIs(t, jobapplication.DeleteAttachment(ctx, database, store, 1, jobID, a.ID), jobapplication.ErrAttachmentNotFound)
+}
+
+func TestAttachments_Validation(t *testing.T) {
+	database := setupTestDB(t)
+	store := setupTestStorage(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+	jobID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+
+	html := "<!DOCTYPE html><html><script>alert(1)</script></html>"
+	tests := []struct {
+		name     string
+		upload   jobapplication.AttachmentUpload
+		expected error
+	}{
+		{
+			name:     "disallowed extension",
+			upload:   jobapplication.AttachmentUpload{Name: "setup.exe", Kind: types.AttachmentKindOther, Size: int64(len(testPDF)), Content: strings.NewReader(testPDF)},
+			expected: jobapplication.ErrAttachmentType,
+		},
+		{
+			name:     "content does not match extension",
+			upload:   jobapplication.AttachmentUpload{Name: "resume.pdf", Kind: types.AttachmentKindResume, Size: int64(len(html)), Content: strings.NewReader(html)},
+			expected: jobapplication.ErrAttachmentType,
+		},
+		{
+			name:     "html as text",
+			upload:   jobapplication.AttachmentUpload{Name: "notes.txt", Kind: types.AttachmentKindOther, Size: int64(len(html)), Content: strings.NewReader(html)},
+			expected: jobapplication.ErrAttachmentType,
+		},
+		{
+			name:     "too large",
+			upload:   jobapplication.AttachmentUpload{Name: "resume.pdf", Kind: types.AttachmentKindResume, Size: jobapplication.MaxAttachmentSize + 1, Content: strings.NewReader(testPDF)},
+			expected: jobapplication.ErrAttachmentTooLarge,
+		},
+		{
+			name:     "empty",
+			upload:   jobapplication.AttachmentUpload{Name: "resume.pdf", Kind: types.AttachmentKindResume, Content: strings.NewReader("")},
+			expected: jobapplication.ErrAttachmentEmpty,
+		},
+		{
+			name:     "invalid kind",
+			upload:   jobapplication.AttachmentUpload{Name: "resume.pdf", Kind: "portfolio", Size: int64(len(testPDF)), Content: strings.NewReader(testPDF)},
+			expected: jobapplication.ErrInvalidAttachmentKind,
+		},
+		{
+			name:     "missing name",
+			upload:   jobapplication.AttachmentUpload{Name: " ", Kind: types.AttachmentKindResume, Size: int64(len(testPDF)), Content: strings.NewReader(testPDF)},
+			expected: jobapplication.ErrInvalidAttachmentName,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jobapplication.AddAttachment(ctx, database, store, 1, jobID, tt.upload)
+			require.ErrorIs(t, err, tt.expected)
+		})
+	}
+
+	text := "Thank you for considering my application."
+	a, err := jobapplication.AddAttachment(ctx, database, store, 1, jobID, jobapplication.AttachmentUpload{
+		Name:    "cover.txt",
+		Kind:    types.AttachmentKindCoverLetter,
+		Size:    int64(len(text)),
+		Content: strings.NewReader(text),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "text/plain; charset=utf-8", a.ContentType)
+
+	var count int
+	require.NoError(t, database.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM job_application_attachments").Scan(&count))
+	assert.Equal(t, 1, count)
+}
+
+func TestAttachments_DeleteJobApplication(t *testing.T) {
+	database := setupTestDB(t)
+	store := setupTestStorage(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+	acmeID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+	globexID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Globex", Title: "Manager"})
+	require.NoError(t, err)
+	acme, err := jobapplication.AddAttachment(ctx, database, store, 1, acmeID, pdfUpload("acme.pdf"))
+	require.NoError(t, err)
+	globex, err := jobapplication.AddAttachment(ctx, database, store, 1, globexID, pdfUpload("globex.pdf"))
+	require.NoError(t, err)
+
+	// Undoing a bulk delete attaches the attachments to the restored job application.
+	now := time.Now()
+	result, err := jobapplication.BulkDelete(ctx, database, 1, []int64{acmeID}, now)
+	require.NoError(t, err)
+	_, err = jobapplication.UndoBulkAction(ctx, database, 1, result.ActionID, now)
+	require.NoError(t, err)
+	var restoredID int64
+	require.NoError(t, database.DB().QueryRowContext(ctx, "SELECT job_application_id FROM job_application_attachments WHERE id = ?", acme.ID).Scan(&restoredID))
+	assert.NotEqual(t, acmeID, restoredID)
+	_, content, err := jobapplication.OpenAttachment(ctx, database.Queries(), store, 1, restoredID, acme.ID)
+	require.NoError(t, err)
+	require.NoError(t, content.Close())
+
+	// Attachments of deleted job applications are kept until the deletion can no longer be undone.
+	_, err = jobapplication.Delete(ctx, database, 1, globexID)
+	require.NoError(t, err)
+	purged, err := jobapplication.PurgeDetachedAttachments(ctx, database.Queries(), store, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 0, purged)
+	assert.Equal(t, testPDF, readStored(t, store, globex.StorageKey))
+
+	purged, err = jobapplication.PurgeDetachedAttachments(ctx, database.Queries(), store, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	_, err = store.Get(ctx, globex.StorageKey)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+	assert.Equal(t, testPDF, readStored(t, store, acme.StorageKey))
+
+	require.NoError(t, jobapplication.DeleteAllAttachments(ctx, database.Queries(), store, 1))
+	_, err = store.Get(ctx, acme.StorageKey)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+	var count int
+	require.NoError(t, database.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM job_application_attachments").Scan(&count))
+	assert.Equal(t, 0, count)
+}
+
+func TestAttachments_Archive(t *testing.T) {
+	database := setupTestDB(t)
+	store := setupTestStorage(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+	createTestUser(t, database.DB(), 2)
+	jobID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+	_, err = jobapplication.AddAttachment(ctx, database, store, 1, jobID, pdfUpload("resume.pdf"))
+	require.NoError(t, err)
+
+	exported, err := jobapplication.Export(ctx, database.Queries(), store, 1)
+	require.NoError(t, err)
+	require.Len(t, exported.JobApplications, 1)
+	require.Len(t, exported.JobApplications[0].Attachments, 1)
+	assert.Equal(t, "resume.pdf", exported.JobApplications[0].Attachments[0].Name)
+	assert.Equal(t, "resume", exported.JobApplications[0].Attachments[0].Kind)
+	assert.Equal(t, testPDF, string(exported.JobApplications[0].Attachments[0].Content))
+
+	result, err := jobapplication.ImportArchive(ctx, database, store, 2, exported)
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, 1, result.Imported)
+
+	imported, err := jobapplication.Export(ctx, database.Queries(), store, 2)
+	require.NoError(t, err)
+	require.Len(t, imported.JobApplications, 1)
+	assert.Equal(t, exported.JobApplications[0].Attachments, imported.JobApplications[0].Attachments)
+
+	// Attachments are validated like uploads, so nothing is imported when one is not allowed.
+	exported.JobApplications[0].Company = "Globex"
+	exported.JobApplications[0].Attachments[0].Content = []byte("<html></html>")
+	result, err = jobapplication.ImportArchive(ctx, database, store, 2, exported)
+	require.NoError(t, err)
+	require.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0], "resume.pdf")
+	assert.Equal(t, 0, result.Imported)
+}
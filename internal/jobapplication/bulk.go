@@ -0,0 +1,696 @@
+package jobapplication
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+// BulkUndoWindow is how long a bulk action can be undone after it ran.
+const BulkUndoWindow = 30 * time.Second
+
+// MaxBulkJobs is the maximum number of job applications a single bulk action can change.
+const MaxBulkJobs = 100
+
+var (
+	ErrNoJobsSelected  = errors.New("no job applications selected")
+	ErrTooManyJobs     = errors.New("too many job applications selected")
+	ErrUndoExpired     = errors.New("bulk action can no longer be undone")
+	ErrUnknownBulkUndo = errors.New("unknown bulk action")
+)
+
+// BulkAction is the kind of change a bulk action made.
+type BulkAction string
+
+const (
+	BulkActionStatus BulkAction = "status"
+	BulkActionDelete BulkAction = "delete"
+	BulkActionNote   BulkAction = "note"
+	BulkActionTag    BulkAction = "tag"
+)
+
+// BulkResult describes a bulk action that ran.
+type BulkResult struct {
+	ActionID int64
+	Action   BulkAction
+	Count    int
+}
+
+// BulkUndoResult describes a bulk action that was undone.
+type BulkUndoResult struct {
+	Action BulkAction
+	// Skipped is the number of job applications that changed after the bulk action, and were left as
+	// they are.
+	Skipped int
+}
+
+type bulkStatusUndo struct {
+	// Status is the status the bulk action moved the job applications to.
+	Status string          `json:"status"`
+	Jobs   []bulkStatusJob `json:"jobs"`
+}
+
+type bulkStatusJob struct {
+	ID          int64     `json:"id"`
+	Status      string    `json:"status"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	HistoryID   int64     `json:"history_id"`
+	ReminderIDs []int64   `json:"reminder_ids,omitempty"`
+}
+
+type bulkNoteUndo struct {
+	Notes []bulkNote `json:"notes"`
+}
+
+type bulkNote struct {
+	ID               int64 `json:"id"`
+	JobApplicationID int64 `json:"job_application_id"`
+}
+
+type bulkTagUndo struct {
+	TagID           int64   `json:"tag_id"`
+	JobApplications []int64 `json:"job_applications"`
+}
+
+type bulkDeleteUndo struct {
+	Jobs []jobApplicationSnapshot `json:"jobs"`
+}
+
+// jobApplicationSnapshot is a job application with everything that references it, so a deleted
+// job application can be restored.
+type jobApplicationSnapshot struct {
+	Job             queries.JobApplication                `json:"job"`
+	StatusHistories []queries.JobApplicationStatusHistory `json:"status_histories"`
+	Notes           []queries.JobApplicationNote          `json:"notes"`
+	Reminders       []queries.JobApplicationReminder      `json:"reminders"`
+	Interviews      []queries.JobApplicationInterview     `json:"interviews"`
+	Offers          []queries.JobApplicationOffer         `json:"offers"`
+	HNJobs          []queries.UserHnJob                   `json:"hn_jobs"`
+	TagIDs          []int64                               `json:"tag_ids"`
+	ContactIDs      []int64                               `json:"contact_ids"`
+	AttachmentIDs   []int64                               `json:"attachment_ids,omitempty"`
+}
+
+// BulkUpdateStatus moves the job applications of the user to the status. Job applications already
+// at the status are left as is.
+func BulkUpdateStatus(ctx context.Context, database db.Database, userID int64, jobIDs []int64, status types.JobApplicationStatus, now time.Time) (result BulkResult, err error) {
+	if jobIDs, err = validateBulkJobIDs(jobIDs); err != nil {
+		return BulkResult{}, err
+	}
+
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return BulkResult{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	qtx := queries.New(tx)
+	undo := bulkStatusUndo{Status: status.String()}
+	for _, jobID := range jobIDs {
+		job, getErr := qtx.GetJobApplicationForSnapshot(ctx, queries.GetJobApplicationForSnapshotParams{ID: jobID, UserID: userID})
+		if getErr != nil {
+			if errors.Is(getErr, sql.ErrNoRows) {
+				return BulkResult{}, ErrNotFound
+			}
+			return BulkResult{}, fmt.Errorf("failed to get job: %w", getErr)
+		}
+		if job.Archived == 1 {
+			return BulkResult{}, ErrNotFound
+		}
+		reminders, getErr := qtx.GetJobApplicationRemindersForSnapshot(ctx, jobID)
+		if getErr != nil {
+			return BulkResult{}, fmt.Errorf("failed to get reminders: %w", getErr)
+		}
+
+		updateResult, updateErr := UpdateTx(ctx, qtx, userID, UpdatedJobApplication{
+			ID:             job.ID,
+			Company:        job.Company,
+			Title:          job.Title,
+			URL:            job.Url.String,
+			Status:         status,
+			SalaryMin:      job.SalaryMin,
+			SalaryMax:      job.SalaryMax,
+			SalaryCurrency: job.SalaryCurrency,
+		})
+		if updateErr != nil {
+			return BulkResult{}, updateErr
+		}
+		if !updateResult.StatusChanged {
+			continue
+		}
+
+		historyID, getErr := qtx.GetLastJobApplicationStatusHistoryID(ctx, jobID)
+		if getErr != nil {
+			return BulkResult{}, fmt.Errorf("failed to get status history: %w", getErr)
+		}
+		undoJob := bulkStatusJob{ID: job.ID, Status: job.Status, UpdatedAt: job.UpdatedAt, HistoryID: historyID}
+		if updateResult.RemindersCleared {
+			for _, r := range reminders {
+				if !r.DismissedAt.Valid {
+					undoJob.ReminderIDs = append(undoJob.ReminderIDs, r.ID)
+				}
+			}
+		}
+		undo.Jobs = append(undo.Jobs, undoJob)
+	}
+
+	result, err = insertBulkAction(ctx, qtx, userID, BulkActionStatus, undo, len(undo.Jobs), now)
+	if err != nil {
+		return BulkResult{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return BulkResult{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return result, nil
+}
+
+// BulkAddNote adds the same note to each of the job applications of the user.
+func BulkAddNote(ctx context.Context, database db.Database, userID int64, jobIDs []int64, note string, now time.Time) (result BulkResult, err error) {
+	if note == "" {
+		return BulkResult{}, ErrMissingNote
+	}
+	if jobIDs, err = validateBulkJobIDs(jobIDs); err != nil {
+		return BulkResult{}, err
+	}
+
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return BulkResult{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	qtx := queries.New(tx)
+	var undo bulkNoteUndo
+	for _, jobID := range jobIDs {
+		job, getErr := qtx.GetJobApplicationForSnapshot(ctx, queries.GetJobApplicationForSnapshotParams{ID: jobID, UserID: userID})
+		if getErr != nil {
+			if errors.Is(getErr, sql.ErrNoRows) {
+				return BulkResult{}, ErrNotFound
+			}
+			return BulkResult{}, fmt.Errorf("failed to get job: %w", getErr)
+		}
+		if job.Archived == 1 {
+			return BulkResult{}, ErrNotFound
+		}
+
+		n, insertErr := qtx.InsertJobApplicationNote(ctx, queries.InsertJobApplicationNoteParams{JobApplicationID: jobID, Note: note})
+		if insertErr != nil {
+			return BulkResult{}, fmt.Errorf("failed to insert note: %w", insertErr)
+		}
+		undo.Notes = append(undo.Notes, bulkNote{ID: n.ID, JobApplicationID: jobID})
+	}
+
+	result, err = insertBulkAction(ctx, qtx, userID, BulkActionNote, undo, len(undo.Notes), now)
+	if err != nil {
+		return BulkResult{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return BulkResult{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return result, nil
+}
+
+// BulkAddTag adds the tag to each of the job applications of the user. Job applications that
+// already have the tag are left as is.
+func BulkAddTag(ctx context.Context, database db.Database, userID int64, jobIDs []int64, tagID int64, now time.Time) (result BulkResult, err error) {
+	if jobIDs, err = validateBulkJobIDs(jobIDs); err != nil {
+		return BulkResult{}, err
+	}
+
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return BulkResult{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	qtx := queries.New(tx)
+	if _, err = qtx.GetTagByIDAndUserID(ctx, queries.GetTagByIDAndUserIDParams{ID: tagID, UserID: userID}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return BulkResult{}, ErrTagNotFound
+		}
+		return BulkResult{}, fmt.Errorf("failed to get tag: %w", err)
+	}
+
+	undo := bulkTagUndo{TagID: tagID}
+	for _, jobID := range jobIDs {
+		job, getErr := qtx.GetJobApplicationForSnapshot(ctx, queries.GetJobApplicationForSnapshotParams{ID: jobID, UserID: userID})
+		if getErr != nil {
+			if errors.Is(getErr, sql.ErrNoRows) {
+				return BulkResult{}, ErrNotFound
+			}
+			return BulkResult{}, fmt.Errorf("failed to get job: %w", getErr)
+		}
+		if job.Archived == 1 {
+			return BulkResult{}, ErrNotFound
+		}
+
+		added, insertErr := qtx.InsertJobApplicationTag(ctx, queries.InsertJobApplicationTagParams{JobApplicationID: jobID, TagID: tagID})
+		if insertErr != nil {
+			return BulkResult{}, fmt.Errorf("failed to add tag: %w", insertErr)
+		}
+		// Only the tags added by the bulk action are removed on undo.
+		if added > 0 {
+			undo.JobApplications = append(undo.JobApplications, jobID)
+		}
+	}
+
+	result, err = insertBulkAction(ctx, qtx, userID, BulkActionTag, undo, len(jobIDs), now)
+	if err != nil {
+		return BulkResult{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return BulkResult{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return result, nil
+}
+
+// BulkDelete deletes the job applications of the user along with their timelines.
+func BulkDelete(ctx context.Context, database db.Database, userID int64, jobIDs []int64, now time.Time) (result BulkResult, err error) {
+	if jobIDs, err = validateBulkJobIDs(jobIDs); err != nil {
+		return BulkResult{}, err
+	}
+
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return BulkResult{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	qtx := queries.New(tx)
+	var undo bulkDeleteUndo
+	for _, jobID := range jobIDs {
+		snapshot, snapshotErr := getJobApplicationSnapshot(ctx, qtx, userID, jobID)
+		if snapshotErr != nil {
+			return BulkResult{}, snapshotErr
+		}
+		if err = deleteTx(ctx, qtx, userID, jobID); err != nil {
+			return BulkResult{}, err
+		}
+		undo.Jobs = append(undo.Jobs, snapshot)
+	}
+
+	if err = RecalculateStats(ctx, qtx, userID); err != nil {
+		return BulkResult{}, fmt.Errorf("failed to recalculate stats: %w", err)
+	}
+
+	result, err = insertBulkAction(ctx, qtx, userID, BulkActionDelete, undo, len(undo.Jobs), now)
+	if err != nil {
+		return BulkResult{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return BulkResult{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return result, nil
+}
+
+// UndoBulkAction reverts a bulk action of the user that ran within the undo window. Deleted job
+// applications are restored with new IDs, and job applications whose status changed again after a
+// bulk status change are skipped.
+func UndoBulkAction(ctx context.Context, database db.Database, userID int64, actionID int64, now time.Time) (result BulkUndoResult, err error) {
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return BulkUndoResult{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	qtx := queries.New(tx)
+	bulkAction, err := qtx.GetJobApplicationBulkActionByIDAndUserID(ctx, queries.GetJobApplicationBulkActionByIDAndUserIDParams{ID: actionID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return BulkUndoResult{}, ErrNotFound
+		}
+		return BulkUndoResult{}, fmt.Errorf("failed to get bulk action: %w", err)
+	}
+	if now.Sub(bulkAction.CreatedAt) > BulkUndoWindow {
+		return BulkUndoResult{}, ErrUndoExpired
+	}
+
+	result.Action = BulkAction(bulkAction.Action)
+	switch result.Action {
+	case BulkActionStatus:
+		result.Skipped, err = undoBulkStatus(ctx, qtx, userID, bulkAction.UndoData)
+	case BulkActionNote:
+		err = undoBulkNote(ctx, qtx, bulkAction.UndoData)
+	case BulkActionTag:
+		err = undoBulkTag(ctx, qtx, bulkAction.UndoData)
+	case BulkActionDelete:
+		err = undoBulkDelete(ctx, qtx, userID, bulkAction.UndoData)
+	default:
+		err = ErrUnknownBulkUndo
+	}
+	if err != nil {
+		return BulkUndoResult{}, err
+	}
+
+	if err = qtx.DeleteJobApplicationBulkAction(ctx, bulkAction.ID); err != nil {
+		return BulkUndoResult{}, fmt.Errorf("failed to delete bulk action: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return BulkUndoResult{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return result, nil
+}
+
+// validateBulkJobIDs checks the number of selected job applications and drops duplicates.
+func validateBulkJobIDs(jobIDs []int64) ([]int64, error) {
+	jobIDs = slices.Compact(slices.Sorted(slices.Values(jobIDs)))
+	if len(jobIDs) == 0 {
+		return nil, ErrNoJobsSelected
+	}
+	if len(jobIDs) > MaxBulkJobs {
+		return nil, ErrTooManyJobs
+	}
+	return jobIDs, nil
+}
+
+func insertBulkAction(ctx context.Context, qtx *queries.Queries, userID int64, action BulkAction, undo any, count int, now time.Time) (BulkResult, error) {
+	if err := qtx.DeleteExpiredJobApplicationBulkActions(ctx, queries.DeleteExpiredJobApplicationBulkActionsParams{
+		UserID:        userID,
+		CreatedBefore: now.Add(-BulkUndoWindow).UTC(),
+	}); err != nil {
+		return BulkResult{}, fmt.Errorf("failed to delete expired bulk actions: %w", err)
+	}
+
+	undoData, err := json.Marshal(undo)
+	if err != nil {
+		return BulkResult{}, fmt.Errorf("failed to encode undo data: %w", err)
+	}
+	id, err := qtx.InsertJobApplicationBulkAction(ctx, queries.InsertJobApplicationBulkActionParams{
+		UserID:    userID,
+		Action:    string(action),
+		UndoData:  string(undoData),
+		CreatedAt: now.UTC(),
+	})
+	if err != nil {
+		return BulkResult{}, fmt.Errorf("failed to insert bulk action: %w", err)
+	}
+	return BulkResult{ActionID: id, Action: action, Count: count}, nil
+}
+
+// undoBulkStatus moves the job applications back to their previous statuses and returns how many
+// were skipped. A job application that is no longer at the status of the bulk action was changed
+// since, and undoing would overwrite that change.
+func undoBulkStatus(ctx context.Context, qtx *queries.Queries, userID int64, data string) (int, error) {
+	var undo bulkStatusUndo
+	if err := json.Unmarshal([]byte(data), &undo); err != nil {
+		return 0, fmt.Errorf("failed to decode undo data: %w", err)
+	}
+	skipped := 0
+	for _, job := range undo.Jobs {
+		current, err := qtx.GetJobApplicationForSnapshot(ctx, queries.GetJobApplicationForSnapshotParams{ID: job.ID, UserID: userID})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("failed to get job: %w", err)
+		}
+		if err != nil || current.Status != undo.Status {
+			skipped++
+			continue
+		}
+
+		if err = qtx.RestoreJobApplicationStatus(ctx, queries.RestoreJobApplicationStatusParams{
+			Status:    job.Status,
+			UpdatedAt: job.UpdatedAt.UTC(),
+			ID:        job.ID,
+			UserID:    userID,
+		}); err != nil {
+			return 0, fmt.Errorf("failed to restore status: %w", err)
+		}
+		if err = qtx.DeleteJobApplicationStatusHistory(ctx, queries.DeleteJobApplicationStatusHistoryParams{ID: job.HistoryID, JobApplicationID: job.ID}); err != nil {
+			return 0, fmt.Errorf("failed to delete status history: %w", err)
+		}
+		for _, reminderID := range job.ReminderIDs {
+			if err = qtx.RestoreJobApplicationReminder(ctx, queries.RestoreJobApplicationReminderParams{ID: reminderID, JobApplicationID: job.ID}); err != nil {
+				return 0, fmt.Errorf("failed to restore reminder: %w", err)
+			}
+		}
+	}
+	if err := RecalculateStats(ctx, qtx, userID); err != nil {
+		return 0, fmt.Errorf("failed to recalculate stats: %w", err)
+	}
+	return skipped, nil
+}
+
+func undoBulkNote(ctx context.Context, qtx *queries.Queries, data string) error {
+	var undo bulkNoteUndo
+	if err := json.Unmarshal([]byte(data), &undo); err != nil {
+		return fmt.Errorf("failed to decode undo data: %w", err)
+	}
+	for _, n := range undo.Notes {
+		if err := qtx.DeleteJobApplicationNote(ctx, queries.DeleteJobApplicationNoteParams{ID: n.ID, JobApplicationID: n.JobApplicationID}); err != nil {
+			return fmt.Errorf("failed to delete note: %w", err)
+		}
+	}
+	return nil
+}
+
+func undoBulkTag(ctx context.Context, qtx *queries.Queries, data string) error {
+	var undo bulkTagUndo
+	if err := json.Unmarshal([]byte(data), &undo); err != nil {
+		return fmt.Errorf("failed to decode undo data: %w", err)
+	}
+	for _, jobID := range undo.JobApplications {
+		if err := qtx.DeleteJobApplicationTag(ctx, queries.DeleteJobApplicationTagParams{JobApplicationID: jobID, TagID: undo.TagID}); err != nil {
+			return fmt.Errorf("failed to remove tag: %w", err)
+		}
+	}
+	return nil
+}
+
+func undoBulkDelete(ctx context.Context, qtx *queries.Queries, userID int64, data string) error {
+	var undo bulkDeleteUndo
+	if err := json.Unmarshal([]byte(data), &undo); err != nil {
+		return fmt.Errorf("failed to decode undo data: %w", err)
+	}
+	for _, snapshot := range undo.Jobs {
+		if err := restoreJobApplicationSnapshot(ctx, qtx, userID, snapshot); err != nil {
+			return err
+		}
+	}
+	if err := RecalculateStats(ctx, qtx, userID); err != nil {
+		return fmt.Errorf("failed to recalculate stats: %w", err)
+	}
+	return nil
+}
+
+func getJobApplicationSnapshot(ctx context.Context, qtx *queries.Queries, userID int64, jobID int64) (jobApplicationSnapshot, error) {
+	job, err := qtx.GetJobApplicationForSnapshot(ctx, queries.GetJobApplicationForSnapshotParams{ID: jobID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return jobApplicationSnapshot{}, ErrNotFound
+		}
+		return jobApplicationSnapshot{}, fmt.Errorf("failed to get job: %w", err)
+	}
+	snapshot := jobApplicationSnapshot{Job: job}
+	if snapshot.StatusHistories, err = qtx.GetJobApplicationStatusHistoriesForSnapshot(ctx, jobID); err != nil {
+		return jobApplicationSnapshot{}, fmt.Errorf("failed to get status histories: %w", err)
+	}
+	if snapshot.Notes, err = qtx.GetJobApplicationNotesForSnapshot(ctx, jobID); err != nil {
+		return jobApplicationSnapshot{}, fmt.Errorf("failed to get notes: %w", err)
+	}
+	if snapshot.Reminders, err = qtx.GetJobApplicationRemindersForSnapshot(ctx, jobID); err != nil {
+		return jobApplicationSnapshot{}, fmt.Errorf("failed to get reminders: %w", err)
+	}
+	if snapshot.Interviews, err = qtx.GetJobApplicationInterviewsForSnapshot(ctx, jobID); err != nil {
+		return jobApplicationSnapshot{}, fmt.Errorf("failed to get interviews: %w", err)
+	}
+	if snapshot.Offers, err = qtx.GetJobApplicationOffersForSnapshot(ctx, jobID); err != nil {
+		return jobApplicationSnapshot{}, fmt.Errorf("failed to get offers: %w", err)
+	}
+	if snapshot.HNJobs, err = qtx.GetUserHNJobsForSnapshot(ctx, jobID); err != nil {
+		return jobApplicationSnapshot{}, fmt.Errorf("failed to get hn jobs: %w", err)
+	}
+	if snapshot.TagIDs, err = qtx.GetJobApplicationTagIDsForSnapshot(ctx, jobID); err != nil {
+		return jobApplicationSnapshot{}, fmt.Errorf("failed to get tags: %w", err)
+	}
+	if snapshot.ContactIDs, err = qtx.GetJobApplicationContactIDsForSnapshot(ctx, jobID); err != nil {
+		return jobApplicationSnapshot{}, fmt.Errorf("failed to get contacts: %w", err)
+	}
+	if snapshot.AttachmentIDs, err = qtx.GetJobApplicationAttachmentIDsForSnapshot(ctx, sql.NullInt64{Int64: jobID, Valid: true}); err != nil {
+		return jobApplicationSnapshot{}, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	return snapshot, nil
+}
+
+func restoreJobApplicationSnapshot(ctx context.Context, qtx *queries.Queries, userID int64, snapshot jobApplicationSnapshot) error {
+	job := snapshot.Job
+	// The company may have been merged or removed since the snapshot, so it is looked up by name.
+	companyID, err := companyIDTx(ctx, qtx, userID, job.Company)
+	if err != nil {
+		return err
+	}
+	jobID, err := qtx.InsertJobApplicationFromArchive(ctx, queries.InsertJobApplicationFromArchiveParams{
+		Company:        job.Company,
+		CompanyID:      companyID,
+		Title:          job.Title,
+		Url:            job.Url,
+		Status:         job.Status,
+		Archived:       job.Archived,
+		UserID:         userID,
+		SalaryMin:      job.SalaryMin,
+		SalaryMax:      job.SalaryMax,
+		SalaryCurrency: job.SalaryCurrency,
+		AppliedAt:      job.AppliedAt.UTC(),
+		UpdatedAt:      job.UpdatedAt.UTC(),
+		CreatedAt:      job.CreatedAt.UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore job: %w", err)
+	}
+	// A resume version deleted since the snapshot is not restored.
+	if job.ResumeVersionID.Valid {
+		if err = qtx.RestoreJobApplicationResumeVersion(ctx, queries.RestoreJobApplicationResumeVersionParams{
+			ResumeVersionID: job.ResumeVersionID.Int64,
+			UserID:          userID,
+			ID:              jobID,
+		}); err != nil {
+			return fmt.Errorf("failed to restore resume version: %w", err)
+		}
+	}
+
+	for _, h := range snapshot.StatusHistories {
+		if err = qtx.InsertJobApplicationStatusHistoryWithCreatedAt(ctx, queries.InsertJobApplicationStatusHistoryWithCreatedAtParams{
+			Status:           h.Status,
+			JobApplicationID: jobID,
+			CreatedAt:        h.CreatedAt.UTC(),
+		}); err != nil {
+			return fmt.Errorf("failed to restore status history: %w", err)
+		}
+	}
+	for _, n := range snapshot.Notes {
+		var updatedAt any
+		if n.UpdatedAt.Valid {
+			updatedAt = n.UpdatedAt.Time.UTC()
+		}
+		if err = qtx.InsertJobApplicationNoteWithCreatedAt(ctx, queries.InsertJobApplicationNoteWithCreatedAtParams{
+			JobApplicationID: jobID,
+			Note:             n.Note,
+			CreatedAt:        n.CreatedAt.UTC(),
+			UpdatedAt:        updatedAt,
+		}); err != nil {
+			return fmt.Errorf("failed to restore note: %w", err)
+		}
+	}
+	for _, r := range snapshot.Reminders {
+		var dismissedAt any
+		if r.DismissedAt.Valid {
+			dismissedAt = r.DismissedAt.Time.UTC()
+		}
+		if err = qtx.InsertJobApplicationReminderFromSnapshot(ctx, queries.InsertJobApplicationReminderFromSnapshotParams{
+			JobApplicationID: jobID,
+			RemindAt:         r.RemindAt.UTC(),
+			DismissedAt:      dismissedAt,
+			Note:             r.Note,
+			CreatedAt:        r.CreatedAt.UTC(),
+			UpdatedAt:        r.UpdatedAt.UTC(),
+		}); err != nil {
+			return fmt.Errorf("failed to restore reminder: %w", err)
+		}
+	}
+	for _, i := range snapshot.Interviews {
+		if err = qtx.InsertJobApplicationInterviewFromSnapshot(ctx, queries.InsertJobApplicationInterviewFromSnapshotParams{
+			JobApplicationID: jobID,
+			ScheduledAt:      i.ScheduledAt.UTC(),
+			Timezone:         i.Timezone,
+			Type:             i.Type,
+			Outcome:          i.Outcome,
+			Interviewers:     i.Interviewers,
+			DurationMinutes:  i.DurationMinutes,
+			CreatedAt:        i.CreatedAt.UTC(),
+			UpdatedAt:        i.UpdatedAt.UTC(),
+		}); err != nil {
+			return fmt.Errorf("failed to restore interview: %w", err)
+		}
+	}
+	for _, o := range snapshot.Offers {
+		if err = qtx.InsertJobApplicationOfferFromSnapshot(ctx, queries.InsertJobApplicationOfferFromSnapshotParams{
+			JobApplicationID:   jobID,
+			Currency:           o.Currency,
+			BaseSalary:         o.BaseSalary,
+			Bonus:              o.Bonus,
+			SignOnBonus:        o.SignOnBonus,
+			EquityShares:       o.EquityShares,
+			EquityStrikePrice:  o.EquityStrikePrice,
+			EquitySharePrice:   o.EquitySharePrice,
+			VestingYears:       o.VestingYears,
+			VestingCliffMonths: o.VestingCliffMonths,
+			Benefits:           o.Benefits,
+			Deadline:           o.Deadline,
+			CreatedAt:          o.CreatedAt.UTC(),
+			UpdatedAt:          o.UpdatedAt.UTC(),
+		}); err != nil {
+			return fmt.Errorf("failed to restore offer: %w", err)
+		}
+	}
+	for _, hn := range snapshot.HNJobs {
+		if err = qtx.InsertUserHNJobFromSnapshot(ctx, queries.InsertUserHNJobFromSnapshotParams{
+			UserID:           userID,
+			HnJobID:          hn.HnJobID,
+			JobApplicationID: jobID,
+			CreatedAt:        hn.CreatedAt.UTC(),
+		}); err != nil {
+			return fmt.Errorf("failed to restore hn job: %w", err)
+		}
+	}
+	// Tags deleted since the snapshot are not restored.
+	for _, tagID := range snapshot.TagIDs {
+		if err = qtx.InsertJobApplicationTagFromSnapshot(ctx, queries.InsertJobApplicationTagFromSnapshotParams{
+			JobApplicationID: jobID,
+			TagID:            tagID,
+			UserID:           userID,
+		}); err != nil {
+			return fmt.Errorf("failed to restore tag: %w", err)
+		}
+	}
+	// Likewise, contacts deleted since the snapshot are not restored.
+	for _, contactID := range snapshot.ContactIDs {
+		if err = qtx.InsertJobApplicationContactFromSnapshot(ctx, queries.InsertJobApplicationContactFromSnapshotParams{
+			JobApplicationID: jobID,
+			ContactID:        contactID,
+			UserID:           userID,
+		}); err != nil {
+			return fmt.Errorf("failed to restore contact: %w", err)
+		}
+	}
+	for _, attachmentID := range snapshot.AttachmentIDs {
+		if err = qtx.ReattachJobApplicationAttachment(ctx, queries.ReattachJobApplicationAttachmentParams{
+			JobApplicationID: sql.NullInt64{Int64: jobID, Valid: true},
+			ID:               attachmentID,
+			UserID:           userID,
+		}); err != nil {
+			return fmt.Errorf("failed to restore attachment: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+//go:build integration
+
+package jobapplication_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkUpdateStatus(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	createTestUser(t, database.DB(), 1)
+	createTestUser(t, database.DB(), 2)
+	acmeID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+	globexID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Globex", Title: "Engineer"})
+	require.NoError(t, err)
+	otherID, err := jobapplication.Create(ctx, database, 2, jobapplication.NewJobApplication{Company: "Initech", Title: "Engineer"})
+	require.NoError(t, err)
+	_, err = jobapplication.AddReminder(ctx, database, 1, acmeID, now, "", now)
+	require.NoError(t, err)
+
+	_, err = jobapplication.BulkUpdateStatus(ctx, database, 1, []int64{acmeID, otherID}, types.JobApplicationStatusRejected, now)
+	require.ErrorIs(t, err, jobapplication.ErrNotFound)
+	_, err = jobapplication.BulkUpdateStatus(ctx, database, 1, []int64{acmeID, globexID}, "take-home", now)
+	require.ErrorIs(t, err, jobapplication.ErrInvalidStatus)
+	_, err = jobapplication.BulkUpdateStatus(ctx, database, 1, nil, types.JobApplicationStatusRejected, now)
+	require.ErrorIs(t, err, jobapplication.ErrNoJobsSelected)
+	// Failed bulk actions leave every job application as it was.
+	assert.Equal(t, int64(2), getTestStats(t, database.DB(), 1).TotalApplied)
+
+	result, err := jobapplication.BulkUpdateStatus(ctx, database, 1, []int64{acmeID, globexID, acmeID}, types.JobApplicationStatusRejected, now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Count)
+	assert.Equal(t, jobapplication.BulkActionStatus, result.Action)
+
+	stats := getTestStats(t, database.DB(), 1)
+	assert.Equal(t, int64(0), stats.TotalApplied)
+	assert.Equal(t, int64(2), stats.TotalRejected)
+	histories, err := database.Queries().CountJobApplicationStatusHistoriesByJobApplicationID(ctx, acmeID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), histories)
+	due, err := jobapplication.GetDueReminders(ctx, database.Queries(), 1, now)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+
+	_, err = jobapplication.UndoBulkAction(ctx, database, 2, result.ActionID, now)
+	require.ErrorIs(t, err, jobapplication.ErrNotFound)
+	undone, err := jobapplication.UndoBulkAction(ctx, database, 1, result.ActionID, now.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, jobapplication.BulkUndoResult{Action: jobapplication.BulkActionStatus}, undone)
+
+	stats = getTestStats(t, database.DB(), 1)
+	assert.Equal(t, int64(2), stats.TotalApplied)
+	assert.Equal(t, int64(0), stats.TotalRejected)
+	histories, err = database.Queries().CountJobApplicationStatusHistoriesByJobApplicationID(ctx, acmeID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), histories)
+	due, err = jobapplication.GetDueReminders(ctx, database.Queries(), 1, now)
+	require.NoError(t, err)
+	assert.Len(t, due, 1)
+
+	_, err = jobapplication.UndoBulkAction(ctx, database, 1, result.ActionID, now)
+	require.ErrorIs(t, err, jobapplication.ErrNotFound)
+
+	result, err = jobapplication.BulkUpdateStatus(ctx, database, 1, []int64{acmeID}, types.JobApplicationStatusInterviewing, now)
+	require.NoError(t, err)
+	_, err = jobapplication.UndoBulkAction(ctx, database, 1, result.ActionID, now.Add(jobapplication.BulkUndoWindow+time.Second))
+	require.ErrorIs(t, err, jobapplication.ErrUndoExpired)
+}
+
+func TestBulkUpdateStatus_UndoSkipsChangedJobs(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	createTestUser(t, database.DB(), 1)
+	acmeID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+	globexID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Globex", Title: "Engineer"})
+	require.NoError(t, err)
+
+	result, err := jobapplication.BulkUpdateStatus(ctx, database, 1, []int64{acmeID, globexID}, types.JobApplicationStatusInterviewing, now)
+	require.NoError(t, err)
+	// Acme hears back within the undo window.
+	_, err = jobapplication.UpdateStatus(ctx, database, 1, acmeID, types.JobApplicationStatusOffered)
+	require.NoError(t, err)
+
+	undone, err := jobapplication.UndoBulkAction(ctx, database, 1, result.ActionID, now.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, jobapplication.BulkUndoResult{Action: jobapplication.BulkActionStatus, Skipped: 1}, undone)
+
+	acme, err := database.Queries().GetJobApplicationForSnapshot(ctx, queries.GetJobApplicationForSnapshotParams{ID: acmeID, UserID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, types.JobApplicationStatusOffered.String(), acme.Status)
+	histories, err := database.Queries().CountJobApplicationStatusHistoriesByJobApplicationID(ctx, acmeID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), histories)
+
+	globex, err := database.Queries().GetJobApplicationForSnapshot(ctx, queries.GetJobApplicationForSnapshotParams{ID: globexID, UserID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, types.JobApplicationStatusApplied.String(), globex.Status)
+	histories, err = database.Queries().CountJobApplicationStatusHistoriesByJobApplicationID(ctx, globexID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), histories)
+}
+
+func TestBulkAddNote(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	createTestUser(t, database.DB(), 1)
+	acmeID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+	globexID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Globex", Title: "Engineer"})
+	require.NoError(t, err)
+
+	_, err = jobapplication.BulkAddNote(ctx, database, 1, []int64{acmeID, globexID}, "", now)
+	require.ErrorIs(t, err, jobapplication.ErrMissingNote)
+
+	result, err := jobapplication.BulkAddNote(ctx, database, 1, []int64{acmeID, globexID}, "Sent a thank you email", now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Count)
+	for _, jobID := range []int64{acmeID, globexID} {
+		notes, getErr := database.Queries().GetJobApplicationNotesByJobApplicationID(ctx, jobID)
+		require.NoError(t, getErr)
+		require.Len(t, notes, 1)
+		assert.Equal(t, "Sent a thank you email", notes[0].Note)
+	}
+
+	_, err = jobapplication.UndoBulkAction(ctx, database, 1, result.ActionID, now)
+	require.NoError(t, err)
+	for _, jobID := range []int64{acmeID, globexID} {
+		notes, getErr := database.Queries().GetJobApplicationNotesByJobApplicationID(ctx, jobID)
+		require.NoError(t, getErr)
+		assert.Empty(t, notes)
+	}
+}
+
+func TestBulkDelete(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	createTestUser(t, database.DB(), 1)
+	acmeID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer", URL: "https://acme.com"})
+	require.NoError(t, err)
+	globexID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Globex", Title: "Engineer"})
+	require.NoError(t, err)
+	_, err = jobapplication.Update(ctx, database, 1, jobapplication.UpdatedJobApplication{ID: acmeID, Company: "Acme", Title: "Engineer", URL: "https://acme.com", Status: types.JobApplicationStatusInterviewing})
+	require.NoError(t, err)
+	_, err = jobapplication.AddNote(ctx, database, 1, acmeID, "Talked to the hiring manager")
+	require.NoError(t, err)
+	_, err = jobapplication.AddReminder(ctx, database, 1, acmeID, now.AddDate(0, 0, 7), "Follow up", now)
+	require.NoError(t, err)
+	_, err = jobapplication.AddInterview(ctx, database, 1, acmeID, jobapplication.NewInterview{
+		ScheduledAt:     "2026-10-20T09:30",
+		Timezone:        "UTC",
+		Type:            types.InterviewTypeTechnical,
+		DurationMinutes: 45,
+	})
+	require.NoError(t, err)
+
+	result, err := jobapplication.BulkDelete(ctx, database, 1, []int64{acmeID, globexID}, now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Count)
+
+	stats := getTestStats(t, database.DB(), 1)
+	assert.Equal(t, int64(0), stats.TotalApplications)
+	assert.Equal(t, int64(0), stats.TotalCompanies)
+	_, err = database.Queries().GetJobApplicationByID(ctx, acmeID)
+	require.Error(t, err)
+	var count int
+	require.NoError(t, database.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM job_application_notes WHERE job_application_id = ?", acmeID).Scan(&count))
+	assert.Zero(t, count)
+	results, _, err := jobapplication.Search(ctx, database, 1, "acme", false, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	_, err = jobapplication.UndoBulkAction(ctx, database, 1, result.ActionID, now)
+	require.NoError(t, err)
+
+	stats = getTestStats(t, database.DB(), 1)
+	assert.Equal(t, int64(2), stats.TotalApplications)
+	assert.Equal(t, int64(2), stats.TotalCompanies)
+	assert.Equal(t, int64(1), stats.TotalInterviewing)
+
+	results, _, err = jobapplication.Search(ctx, database, 1, "hiring manager", false, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	restored := results[0].Job
+	assert.Equal(t, "Acme", restored.Company)
+	assert.Equal(t, "https://acme.com", restored.URL)
+	assert.Equal(t, types.JobApplicationStatusInterviewing, restored.Status)
+
+	histories, err := database.Queries().GetJobApplicationStatusHistoriesByJobApplicationID(ctx, restored.ID)
+	require.NoError(t, err)
+	assert.Len(t, histories, 2)
+	reminders, err := database.Queries().GetJobApplicationRemindersByJobApplicationID(ctx, restored.ID)
+	require.NoError(t, err)
+	require.Len(t, reminders, 1)
+	assert.Equal(t, "Follow up", reminders[0].Note)
+	assert.False(t, reminders[0].DismissedAt.Valid)
+	interviews, err := database.Queries().GetJobApplicationInterviewsByJobApplicationID(ctx, restored.ID)
+	require.NoError(t, err)
+	require.Len(t, interviews, 1)
+	assert.Equal(t, int64(45), interviews[0].DurationMinutes)
+}
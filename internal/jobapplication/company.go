@@ -0,0 +1,202 @@
+package jobapplication
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+var (
+	ErrCompanyNotFound = errors.New("company not found")
+	ErrSameCompany     = errors.New("cannot merge a company into itself")
+)
+
+// normalizeCompanyName returns the key companies are matched by, ignoring case and whitespace. Only
+// ASCII letters are lowered so the key matches the one computed in SQL, where lower() does the same.
+func normalizeCompanyName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			return -1
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return r
+		}
+	}, name)
+}
+
+// companyIDTx returns the ID of the company of the user with the name, creating the company when it
+// does not exist yet.
+func companyIDTx(ctx context.Context, qtx *queries.Queries, userID int64, name string) (sql.NullInt64, error) {
+	id, err := qtx.UpsertCompany(ctx, queries.UpsertCompanyParams{
+		UserID:         userID,
+		Name:           strings.TrimSpace(name),
+		NormalizedName: normalizeCompanyName(name),
+	})
+	if err != nil {
+		return sql.NullInt64{}, fmt.Errorf("failed to upsert company: %w", err)
+	}
+	return sql.NullInt64{Int64: id, Valid: true}, nil
+}
+
+// GetCompanies returns the companies of the user ordered by name.
+func GetCompanies(ctx context.Context, q *queries.Queries, userID int64) ([]types.Company, error) {
+	rows, err := q.GetCompaniesByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get companies: %w", err)
+	}
+	companies := make([]types.Company, len(rows))
+	for i, row := range rows {
+		companies[i] = types.Company{
+			Name:               row.Name,
+			ID:                 row.ID,
+			Applications:       row.JobApplications,
+			ActiveApplications: row.ActiveJobApplications,
+		}
+	}
+	return companies, nil
+}
+
+// GetCompany returns every job application and note of a company of the user, along with the job
+// listings posted under the same company name.
+func GetCompany(ctx context.Context, q *queries.Queries, userID int64, companyID int64) (types.CompanyDetails, error) {
+	company, err := q.GetCompanyByIDAndUserID(ctx, queries.GetCompanyByIDAndUserIDParams{ID: companyID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return types.CompanyDetails{}, ErrCompanyNotFound
+		}
+		return types.CompanyDetails{}, fmt.Errorf("failed to get company: %w", err)
+	}
+	id := sql.NullInt64{Int64: companyID, Valid: true}
+
+	jobs, err := q.GetJobApplicationsByCompanyID(ctx, queries.GetJobApplicationsByCompanyIDParams{CompanyID: id, UserID: userID})
+	if err != nil {
+		return types.CompanyDetails{}, fmt.Errorf("failed to get job applications: %w", err)
+	}
+	notes, err := q.GetJobApplicationNotesByCompanyID(ctx, queries.GetJobApplicationNotesByCompanyIDParams{CompanyID: id, UserID: userID})
+	if err != nil {
+		return types.CompanyDetails{}, fmt.Errorf("failed to get notes: %w", err)
+	}
+	listings, err := q.GetHNJobsByNormalizedCompany(ctx, company.NormalizedName)
+	if err != nil {
+		return types.CompanyDetails{}, fmt.Errorf("failed to get job listings: %w", err)
+	}
+	companies, err := GetCompanies(ctx, q, userID)
+	if err != nil {
+		return types.CompanyDetails{}, err
+	}
+
+	details := types.CompanyDetails{
+		JobApplications: make([]types.JobApplication, len(jobs)),
+		Notes:           make([]types.CompanyNote, len(notes)),
+		Listings:        make([]types.CompanyListing, len(listings)),
+	}
+	for _, c := range companies {
+		if c.ID == companyID {
+			details.Company = c
+		} else {
+			details.MergeTargets = append(details.MergeTargets, c)
+		}
+	}
+	for i, j := range jobs {
+		details.JobApplications[i] = types.JobApplication{
+			AppliedAt: j.AppliedAt,
+			UpdatedAt: j.UpdatedAt,
+			Company:   j.Company,
+			Title:     j.Title,
+			URL:       j.Url.String,
+			Status:    types.JobApplicationStatus(j.Status),
+			ID:        j.ID,
+			UserID:    userID,
+			Archived:  j.Archived == 1,
+		}
+	}
+	for i, n := range notes {
+		details.Notes[i] = types.CompanyNote{
+			CreatedAt:        n.CreatedAt,
+			Note:             n.Note,
+			Title:            n.Title,
+			ID:               n.ID,
+			JobApplicationID: n.JobApplicationID,
+		}
+	}
+	for i, l := range listings {
+		details.Listings[i] = types.CompanyListing{
+			PostedAt: l.CommentedAt.Time,
+			ID:       l.ID,
+			Title:    l.Title,
+			Location: l.Location.String,
+		}
+	}
+	return details, nil
+}
+
+// GetCompanyIDByName returns the ID of the company of the user matching the name, or zero when the
+// user has not applied to the company.
+func GetCompanyIDByName(ctx context.Context, q *queries.Queries, userID int64, name string) (int64, error) {
+	id, err := q.GetCompanyIDByNormalizedName(ctx, queries.GetCompanyIDByNormalizedNameParams{NormalizedName: normalizeCompanyName(name), UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get company: %w", err)
+	}
+	return id, nil
+}
+
+// MergeCompanies moves the job applications of the source company to the target company, renaming
+// them to the name of the target, and deletes the source company.
+func MergeCompanies(ctx context.Context, database db.Database, userID int64, sourceID int64, targetID int64) (err error) {
+	if sourceID == targetID {
+		return ErrSameCompany
+	}
+
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	qtx := queries.New(tx)
+	target, err := qtx.GetCompanyByIDAndUserID(ctx, queries.GetCompanyByIDAndUserIDParams{ID: targetID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrCompanyNotFound
+		}
+		return fmt.Errorf("failed to get company: %w", err)
+	}
+	if err = qtx.MergeCompanyJobApplications(ctx, queries.MergeCompanyJobApplicationsParams{
+		Company:  target.Name,
+		TargetID: sql.NullInt64{Int64: targetID, Valid: true},
+		SourceID: sql.NullInt64{Int64: sourceID, Valid: true},
+		UserID:   userID,
+	}); err != nil {
+		return fmt.Errorf("failed to move job applications: %w", err)
+	}
+	deleted, err := qtx.DeleteCompany(ctx, queries.DeleteCompanyParams{ID: sourceID, UserID: userID})
+	if err != nil {
+		return fmt.Errorf("failed to delete company: %w", err)
+	}
+	if deleted == 0 {
+		return ErrCompanyNotFound
+	}
+	if err = RecalculateStats(ctx, qtx, userID); err != nil {
+		return fmt.Errorf("failed to recalculate stats: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
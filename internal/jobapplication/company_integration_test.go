@@ -0,0 +1,132 @@
+//go:build integration
+
+package jobapplication_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompanies(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+	createTestUser(t, database.DB(), 2)
+	insertTestHNJob(t, database.DB(), "hn-1")
+
+	acmeID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme Inc", Title: "Engineer"})
+	require.NoError(t, err)
+	_, err = jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: " acme  inc", Title: "Manager"})
+	require.NoError(t, err)
+	_, err = jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Globex", Title: "Engineer"})
+	require.NoError(t, err)
+	_, err = jobapplication.Create(ctx, database, 2, jobapplication.NewJobApplication{Company: "Acme Inc", Title: "Engineer"})
+	require.NoError(t, err)
+	_, err = jobapplication.AddNote(ctx, database, 1, acmeID, "Great team")
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2), getTestStats(t, database.DB(), 1).TotalCompanies)
+
+	companies, err := jobapplication.GetCompanies(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	require.Len(t, companies, 2)
+	acme := companies[0]
+	assert.Equal(t, "Acme Inc", acme.Name)
+	assert.Equal(t, int64(2), acme.Applications)
+	assert.Equal(t, int64(2), acme.ActiveApplications)
+
+	id, err := jobapplication.GetCompanyIDByName(ctx, database.Queries(), 1, "ACME inc")
+	require.NoError(t, err)
+	assert.Equal(t, acme.ID, id)
+	id, err = jobapplication.GetCompanyIDByName(ctx, database.Queries(), 1, "Initech")
+	require.NoError(t, err)
+	assert.Zero(t, id)
+
+	details, err := jobapplication.GetCompany(ctx, database.Queries(), 1, acme.ID)
+	require.NoError(t, err)
+	assert.Equal(t, acme, details.Company)
+	assert.Len(t, details.JobApplications, 2)
+	require.Len(t, details.Notes, 1)
+	assert.Equal(t, "Great team", details.Notes[0].Note)
+	assert.Equal(t, acmeID, details.Notes[0].JobApplicationID)
+	assert.Equal(t, []types.Company{companies[1]}, details.MergeTargets)
+
+	_, err = jobapplication.GetCompany(ctx, database.Queries(), 2, acme.ID)
+	require.ErrorIs(t, err, jobapplication.ErrCompanyNotFound)
+
+	// The job listing is posted by "Acme", which only the renamed company matches.
+	_, err = jobapplication.Update(ctx, database, 1, jobapplication.UpdatedJobApplication{ID: acmeID, Company: "ACME", Title: "Engineer", Status: types.JobApplicationStatusApplied})
+	require.NoError(t, err)
+	id, err = jobapplication.GetCompanyIDByName(ctx, database.Queries(), 1, "Acme")
+	require.NoError(t, err)
+	details, err = jobapplication.GetCompany(ctx, database.Queries(), 1, id)
+	require.NoError(t, err)
+	require.Len(t, details.Listings, 1)
+	assert.Equal(t, "hn-1", details.Listings[0].ID)
+	assert.Equal(t, int64(3), getTestStats(t, database.DB(), 1).TotalCompanies)
+}
+
+func TestMergeCompanies(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+	createTestUser(t, database.DB(), 2)
+
+	acmeID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+	_, err = jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme Corporation", Title: "Manager"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), getTestStats(t, database.DB(), 1).TotalCompanies)
+
+	source, err := jobapplication.GetCompanyIDByName(ctx, database.Queries(), 1, "Acme")
+	require.NoError(t, err)
+	target, err := jobapplication.GetCompanyIDByName(ctx, database.Queries(), 1, "Acme Corporation")
+	require.NoError(t, err)
+
+	require.ErrorIs(t, jobapplication.MergeCompanies(ctx, database, 1, source, source), jobapplication.ErrSameCompany)
+	require.ErrorIs(t, jobapplication.MergeCompanies(ctx, database, 2, source, target), jobapplication.ErrCompanyNotFound)
+	require.ErrorIs(t, jobapplication.MergeCompanies(ctx, database, 1, source, target+100), jobapplication.ErrCompanyNotFound)
+
+	require.NoError(t, jobapplication.MergeCompanies(ctx, database, 1, source, target))
+
+	companies, err := jobapplication.GetCompanies(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	require.Len(t, companies, 1)
+	assert.Equal(t, target, companies[0].ID)
+	assert.Equal(t, int64(2), companies[0].Applications)
+	assert.Equal(t, int64(1), getTestStats(t, database.DB(), 1).TotalCompanies)
+
+	job, err := database.Queries().GetJobApplicationByID(ctx, acmeID)
+	require.NoError(t, err)
+	assert.Equal(t, "Acme Corporation", job.Company)
+}
+
+func TestCompanies_RemovedWhenUnused(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+
+	acmeID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+	globexID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Globex", Title: "Engineer"})
+	require.NoError(t, err)
+
+	_, err = jobapplication.Update(ctx, database, 1, jobapplication.UpdatedJobApplication{ID: acmeID, Company: "Initech", Title: "Engineer", Status: types.JobApplicationStatusApplied})
+	require.NoError(t, err)
+	_, err = jobapplication.Delete(ctx, database, 1, globexID)
+	require.NoError(t, err)
+
+	companies, err := jobapplication.GetCompanies(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	require.Len(t, companies, 1)
+	assert.Equal(t, "Initech", companies[0].Name)
+	assert.Equal(t, int64(1), getTestStats(t, database.DB(), 1).TotalCompanies)
+}
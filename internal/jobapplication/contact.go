@@ -0,0 +1,348 @@
+package jobapplication
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+const maxContactNameLength = 100
+
+var (
+	ErrInvalidContactName  = errors.New("contact name must be between 1 and 100 characters")
+	ErrInvalidContactEmail = errors.New("invalid contact email")
+	ErrInvalidLinkedInURL  = errors.New("invalid LinkedIn URL")
+	ErrContactNotFound     = errors.New("contact not found")
+)
+
+// NewContact is the details of a contact to add or update.
+type NewContact struct {
+	Name        string
+	Role        string
+	Email       string
+	LinkedInURL string
+	Company     string
+}
+
+func (c NewContact) normalize() NewContact {
+	return NewContact{
+		Name:        strings.TrimSpace(c.Name),
+		Role:        strings.TrimSpace(c.Role),
+		Email:       strings.TrimSpace(c.Email),
+		LinkedInURL: strings.TrimSpace(c.LinkedInURL),
+		Company:     strings.TrimSpace(c.Company),
+	}
+}
+
+func (c NewContact) validate() error {
+	if c.Name == "" || utf8.RuneCountInString(c.Name) > maxContactNameLength {
+		return ErrInvalidContactName
+	}
+	if c.Email != "" && !isEmail(c.Email) {
+		return ErrInvalidContactEmail
+	}
+	if c.LinkedInURL != "" {
+		u, err := url.Parse(c.LinkedInURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return ErrInvalidLinkedInURL
+		}
+		host := strings.ToLower(u.Hostname())
+		if host != "linkedin.com" && !strings.HasSuffix(host, ".linkedin.com") {
+			return ErrInvalidLinkedInURL
+		}
+	}
+	return nil
+}
+
+// isEmail reports whether the value is a bare email address, without a display name.
+func isEmail(val string) bool {
+	addr, err := mail.ParseAddress(val)
+	return err == nil && addr.Address == val
+}
+
+// GetContacts returns the contacts of the user ordered by name, along with the job applications
+// each contact is linked to.
+func GetContacts(ctx context.Context, q *queries.Queries, userID int64) ([]types.Contact, error) {
+	rows, err := q.GetContactsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contacts: %w", err)
+	}
+	links, err := q.GetContactJobApplicationsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contact job applications: %w", err)
+	}
+	jobs := make(map[int64][]types.ContactJobApplication, len(rows))
+	for _, link := range links {
+		jobs[link.ContactID] = append(jobs[link.ContactID], types.ContactJobApplication{
+			Company:  link.Company,
+			Title:    link.Title,
+			Status:   types.JobApplicationStatus(link.Status),
+			ID:       link.ID,
+			Archived: link.Archived == 1,
+		})
+	}
+
+	contacts := make([]types.Contact, len(rows))
+	for i, row := range rows {
+		contacts[i] = types.Contact{
+			Name:            row.Name,
+			Role:            row.Role,
+			Email:           row.Email,
+			LinkedInURL:     row.LinkedinUrl,
+			Company:         row.Company,
+			ID:              row.ID,
+			JobApplications: jobs[row.ID],
+		}
+	}
+	return contacts, nil
+}
+
+// AddContact creates a contact for the user.
+func AddContact(ctx context.Context, q *queries.Queries, userID int64, contact NewContact) (types.Contact, error) {
+	contact = contact.normalize()
+	if err := contact.validate(); err != nil {
+		return types.Contact{}, err
+	}
+	return insertContact(ctx, q, userID, contact)
+}
+
+func insertContact(ctx context.Context, q *queries.Queries, userID int64, contact NewContact) (types.Contact, error) {
+	id, err := q.InsertContact(ctx, queries.InsertContactParams{
+		UserID:      userID,
+		Name:        contact.Name,
+		Role:        contact.Role,
+		Email:       contact.Email,
+		LinkedinUrl: contact.LinkedInURL,
+		Company:     contact.Company,
+	})
+	if err != nil {
+		return types.Contact{}, fmt.Errorf("failed to insert contact: %w", err)
+	}
+	return types.Contact{
+		Name:        contact.Name,
+		Role:        contact.Role,
+		Email:       contact.Email,
+		LinkedInURL: contact.LinkedInURL,
+		Company:     contact.Company,
+		ID:          id,
+	}, nil
+}
+
+// UpdateContact replaces the details of a contact of the user.
+func UpdateContact(ctx context.Context, q *queries.Queries, userID int64, contactID int64, contact NewContact) error {
+	contact = contact.normalize()
+	if err := contact.validate(); err != nil {
+		return err
+	}
+
+	updated, err := q.UpdateContact(ctx, queries.UpdateContactParams{
+		Name:        contact.Name,
+		Role:        contact.Role,
+		Email:       contact.Email,
+		LinkedinUrl: contact.LinkedInURL,
+		Company:     contact.Company,
+		ID:          contactID,
+		UserID:      userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update contact: %w", err)
+	}
+	if updated == 0 {
+		return ErrContactNotFound
+	}
+	return nil
+}
+
+// DeleteContact deletes a contact of the user and unlinks it from its job applications.
+func DeleteContact(ctx context.Context, database db.Database, userID int64, contactID int64) (err error) {
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	qtx := queries.New(tx)
+	deleted, err := qtx.DeleteContact(ctx, queries.DeleteContactParams{ID: contactID, UserID: userID})
+	if err != nil {
+		return fmt.Errorf("failed to delete contact: %w", err)
+	}
+	if deleted == 0 {
+		return ErrContactNotFound
+	}
+	if err = qtx.DeleteJobApplicationContactsByContactID(ctx, contactID); err != nil {
+		return fmt.Errorf("failed to unlink contact: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetJobApplicationContacts returns the contacts linked to a job application of the user ordered by name.
+func GetJobApplicationContacts(ctx context.Context, q *queries.Queries, userID int64, jobID int64) ([]types.Contact, error) {
+	if err := checkJobApplication(ctx, q, userID, jobID); err != nil {
+		return nil, err
+	}
+
+	rows, err := q.GetContactsByJobApplicationID(ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job application contacts: %w", err)
+	}
+	contacts := make([]types.Contact, len(rows))
+	for i, row := range rows {
+		contacts[i] = types.Contact{
+			Name:        row.Name,
+			Role:        row.Role,
+			Email:       row.Email,
+			LinkedInURL: row.LinkedinUrl,
+			Company:     row.Company,
+			ID:          row.ID,
+		}
+	}
+	return contacts, nil
+}
+
+// LinkContact links an existing contact of the user to a job application of the user.
+func LinkContact(ctx context.Context, database db.Database, userID int64, jobID int64, contactID int64) (err error) {
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	qtx := queries.New(tx)
+	if err = checkJobApplication(ctx, qtx, userID, jobID); err != nil {
+		return err
+	}
+	if _, err = qtx.GetContactByIDAndUserID(ctx, queries.GetContactByIDAndUserIDParams{ID: contactID, UserID: userID}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrContactNotFound
+		}
+		return fmt.Errorf("failed to get contact: %w", err)
+	}
+	if _, err = qtx.InsertJobApplicationContact(ctx, queries.InsertJobApplicationContactParams{JobApplicationID: jobID, ContactID: contactID}); err != nil {
+		return fmt.Errorf("failed to link contact: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// AddJobApplicationContact creates a contact for the user and links it to a job application of the
+// user. The contact works at the company of the job application unless another company is given.
+func AddJobApplicationContact(ctx context.Context, database db.Database, userID int64, jobID int64, contact NewContact) (created types.Contact, err error) {
+	contact = contact.normalize()
+	if err = contact.validate(); err != nil {
+		return types.Contact{}, err
+	}
+
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return types.Contact{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	qtx := queries.New(tx)
+	job, err := qtx.GetJobApplicationByIDAndUserID(ctx, queries.GetJobApplicationByIDAndUserIDParams{ID: jobID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return types.Contact{}, ErrNotFound
+		}
+		return types.Contact{}, fmt.Errorf("failed to get job: %w", err)
+	}
+	if contact.Company == "" {
+		contact.Company = job.Company
+	}
+
+	created, err = insertContact(ctx, qtx, userID, contact)
+	if err != nil {
+		return types.Contact{}, err
+	}
+	if _, err = qtx.InsertJobApplicationContact(ctx, queries.InsertJobApplicationContactParams{JobApplicationID: jobID, ContactID: created.ID}); err != nil {
+		return types.Contact{}, fmt.Errorf("failed to link contact: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return types.Contact{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return created, nil
+}
+
+// UnlinkContact removes a contact from a job application of the user. The contact itself is kept.
+func UnlinkContact(ctx context.Context, q *queries.Queries, userID int64, jobID int64, contactID int64) error {
+	if err := checkJobApplication(ctx, q, userID, jobID); err != nil {
+		return err
+	}
+	deleted, err := q.DeleteJobApplicationContact(ctx, queries.DeleteJobApplicationContactParams{JobApplicationID: jobID, ContactID: contactID})
+	if err != nil {
+		return fmt.Errorf("failed to unlink contact: %w", err)
+	}
+	if deleted == 0 {
+		return ErrContactNotFound
+	}
+	return nil
+}
+
+// AddListingContactTx links the contact email of a job listing to a newly added job application.
+// An existing contact with the email is reused. Emails that cannot be parsed, such as obfuscated
+// ones, are skipped.
+func AddListingContactTx(ctx context.Context, qtx *queries.Queries, userID int64, jobID int64, company string, email string) error {
+	email = strings.TrimSpace(email)
+	if !isEmail(email) {
+		return nil
+	}
+
+	var contactID int64
+	existing, err := qtx.GetContactByEmailAndUserID(ctx, queries.GetContactByEmailAndUserIDParams{Email: email, UserID: userID})
+	switch {
+	case err == nil:
+		contactID = existing.ID
+	case errors.Is(err, sql.ErrNoRows):
+		created, insertErr := insertContact(ctx, qtx, userID, NewContact{Name: email, Email: email, Company: strings.TrimSpace(company)})
+		if insertErr != nil {
+			return insertErr
+		}
+		contactID = created.ID
+	default:
+		return fmt.Errorf("failed to get contact: %w", err)
+	}
+
+	if _, err = qtx.InsertJobApplicationContact(ctx, queries.InsertJobApplicationContactParams{JobApplicationID: jobID, ContactID: contactID}); err != nil {
+		return fmt.Errorf("failed to link contact: %w", err)
+	}
+	return nil
+}
+
+func checkJobApplication(ctx context.Context, q *queries.Queries, userID int64, jobID int64) error {
+	if _, err := q.GetJobApplicationByIDAndUserID(ctx, queries.GetJobApplicationByIDAndUserIDParams{ID: jobID, UserID: userID}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to get job: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+//go:build integration
+
+package jobapplication_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContacts(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+	createTestUser(t, database.DB(), 2)
+
+	bob, err := jobapplication.AddContact(ctx, database.Queries(), 1, jobapplication.NewContact{
+		Name:        "  Bob  ",
+		Role:        "Recruiter",
+		Email:       "bob@acme.com",
+		LinkedInURL: "https://www.linkedin.com/in/bob",
+		Company:     "Acme",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Bob", bob.Name)
+
+	tests := []struct {
+		name     string
+		contact  jobapplication.NewContact
+		expected error
+	}{
+		{name: "missing name", contact: jobapplication.NewContact{Name: "  "}, expected: jobapplication.ErrInvalidContactName},
+		{name: "invalid email", contact: jobapplication.NewContact{Name: "Alice", Email: "alice"}, expected: jobapplication.ErrInvalidContactEmail},
+		{name: "email with display name", contact: jobapplication.NewContact{Name: "Alice", Email: "Alice <alice@acme.com>"}, expected: jobapplication.ErrInvalidContactEmail},
+		{name: "not a LinkedIn URL", contact: jobapplication.NewContact{Name: "Alice", LinkedInURL: "https://example.com/in/alice"}, expected: jobapplication.ErrInvalidLinkedInURL},
+		{name: "LinkedIn URL without scheme", contact: jobapplication.NewContact{Name: "Alice", LinkedInURL: "javascript://linkedin.com"}, expected: jobapplication.ErrInvalidLinkedInURL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, addErr := jobapplication.AddContact(ctx, database.Queries(), 1, tt.contact)
+			require.ErrorIs(t, addErr, tt.expected)
+		})
+	}
+
+	alice, err := jobapplication.AddContact(ctx, database.Queries(), 1, jobapplication.NewContact{Name: "alice"})
+	require.NoError(t, err)
+	require.NoError(t, jobapplication.UpdateContact(ctx, database.Queries(), 1, alice.ID, jobapplication.NewContact{Name: "Alice", Role: "Hiring Manager"}))
+	require.ErrorIs(t, jobapplication.UpdateContact(ctx, database.Queries(), 2, alice.ID, jobapplication.NewContact{Name: "Alice"}), jobapplication.ErrContactNotFound)
+	require.ErrorIs(t, jobapplication.UpdateContact(ctx, database.Queries(), 1, alice.ID, jobapplication.NewContact{Name: ""}), jobapplication.ErrInvalidContactName)
+
+	acmeID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+	require.NoError(t, jobapplication.LinkContact(ctx, database, 1, acmeID, bob.ID))
+	// Linking twice is a no-op.
+	require.NoError(t, jobapplication.LinkContact(ctx, database, 1, acmeID, bob.ID))
+	require.ErrorIs(t, jobapplication.LinkContact(ctx, database, 2, acmeID, bob.ID), jobapplication.ErrNotFound)
+	other, err := jobapplication.AddContact(ctx, database.Queries(), 2, jobapplication.NewContact{Name: "Carol"})
+	require.NoError(t, err)
+	require.ErrorIs(t, jobapplication.LinkContact(ctx, database, 1, acmeID, other.ID), jobapplication.ErrContactNotFound)
+
+	dave, err := jobapplication.AddJobApplicationContact(ctx, database, 1, acmeID, jobapplication.NewContact{Name: "Dave"})
+	require.NoError(t, err)
+	assert.Equal(t, "Acme", dave.Company)
+
+	linked, err := jobapplication.GetJobApplicationContacts(ctx, database.Queries(), 1, acmeID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Bob", "Dave"}, contactNames(linked))
+	_, err = jobapplication.GetJobApplicationContacts(ctx, database.Queries(), 2, acmeID)
+	require.ErrorIs(t, err, jobapplication.ErrNotFound)
+
+	contacts, err := jobapplication.GetContacts(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Alice", "Bob", "Dave"}, contactNames(contacts))
+	assert.Equal(t, "Hiring Manager", contacts[0].Role)
+	assert.Empty(t, contacts[0].JobApplications)
+	assert.Equal(t, []types.ContactJobApplication{
+		{Company: "Acme", Title: "Engineer", Status: types.JobApplicationStatusApplied, ID: acmeID},
+	}, contacts[1].JobApplications)
+
+	require.NoError(t, jobapplication.UnlinkContact(ctx, database.Queries(), 1, acmeID, dave.ID))
+	require.ErrorIs(t, jobapplication.UnlinkContact(ctx, database.Queries(), 1, acmeID, dave.ID), jobapplication.ErrContactNotFound)
+
+	require.ErrorIs(t, jobapplication.DeleteContact(ctx, database, 2, bob.ID), jobapplication.ErrContactNotFound)
+	require.NoError(t, jobapplication.DeleteContact(ctx, database, 1, bob.ID))
+	linked, err = jobapplication.GetJobApplicationContacts(ctx, database.Queries(), 1, acmeID)
+	require.NoError(t, err)
+	assert.Empty(t, linked)
+}
+
+func TestAddListingContactTx(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+	existing, err := jobapplication.AddContact(ctx, database.Queries(), 1, jobapplication.NewContact{Name: "Jane", Email: "jane@acme.com"})
+	require.NoError(t, err)
+	firstID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+	secondID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Globex", Title: "Engineer"})
+	require.NoError(t, err)
+	thirdID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Initech", Title: "Engineer"})
+	require.NoError(t, err)
+
+	// An existing contact with the same email is reused.
+	require.NoError(t, jobapplication.AddListingContactTx(ctx, database.Queries(), 1, firstID, "Acme", "JANE@acme.com"))
+	// A new contact is named after the email and works at the company of the listing.
+	require.NoError(t, jobapplication.AddListingContactTx(ctx, database.Queries(), 1, secondID, "Globex", " jobs@globex.com "))
+	// Obfuscated emails are skipped.
+	require.NoError(t, jobapplication.AddListingContactTx(ctx, database.Queries(), 1, thirdID, "Initech", "jobs [at] initech [dot] com"))
+
+	first, err := jobapplication.GetJobApplicationContacts(ctx, database.Queries(), 1, firstID)
+	require.NoError(t, err)
+	require.Len(t, first, 1)
+	assert.Equal(t, existing.ID, first[0].ID)
+
+	second, err := jobapplication.GetJobApplicationContacts(ctx, database.Queries(), 1, secondID)
+	require.NoError(t, err)
+	require.Len(t, second, 1)
+	assert.Equal(t, "jobs@globex.com", second[0].Name)
+	assert.Equal(t, "jobs@globex.com", second[0].Email)
+	assert.Equal(t, "Globex", second[0].Company)
+
+	third, err := jobapplication.GetJobApplicationContacts(ctx, database.Queries(), 1, thirdID)
+	require.NoError(t, err)
+	assert.Empty(t, third)
+}
+
+func TestDelete_KeepsContacts(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	createTestUser(t, database.DB(), 1)
+	acmeID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+	globexID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Globex", Title: "Engineer"})
+	require.NoError(t, err)
+	jane, err := jobapplication.AddJobApplicationContact(ctx, database, 1, acmeID, jobapplication.NewContact{Name: "Jane"})
+	require.NoError(t, err)
+	require.NoError(t, jobapplication.LinkContact(ctx, database, 1, globexID, jane.ID))
+
+	// Bulk deleting and undoing restores the links.
+	result, err := jobapplication.BulkDelete(ctx, database, 1, []int64{acmeID}, now)
+	require.NoError(t, err)
+	var links int
+	require.NoError(t, database.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM job_application_contacts WHERE job_application_id = ?", acmeID).Scan(&links))
+	assert.Zero(t, links)
+	_, err = jobapplication.UndoBulkAction(ctx, database, 1, result.ActionID, now.Add(time.Second))
+	require.NoError(t, err)
+	contacts, err := jobapplication.GetContacts(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	require.Len(t, contacts, 1)
+	companies := make([]string, 0, len(contacts[0].JobApplications))
+	var restoredID int64
+	for _, job := range contacts[0].JobApplications {
+		companies = append(companies, job.Company)
+		if job.Company == "Acme" {
+			restoredID = job.ID
+		}
+	}
+	assert.ElementsMatch(t, []string{"Acme", "Globex"}, companies)
+
+	// Hard deleting unlinks the contact but keeps it.
+	_, err = jobapplication.Delete(ctx, database, 1, restoredID)
+	require.NoError(t, err)
+	contacts, err = jobapplication.GetContacts(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	require.Len(t, contacts, 1)
+	require.Len(t, contacts[0].JobApplications, 1)
+	assert.Equal(t, globexID, contacts[0].JobApplications[0].ID)
+}
+
+func contactNames(contacts []types.Contact) []string {
+	names := make([]string, len(contacts))
+	for i, contact := range contacts {
+		names[i] = contact.Name
+	}
+	return names
+}
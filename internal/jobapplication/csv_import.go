@@ -0,0 +1,443 @@
+package jobapplication
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+var (
+	ErrEmptyCSV           = errors.New("the CSV file is empty")
+	ErrMissingCSVMapping  = errors.New("the company and job title columns must be mapped")
+	ErrDuplicateCSVColumn = errors.New("a field can only be mapped to one column")
+)
+
+// CSVField is a job application field a CSV column can be mapped to.
+type CSVField string
+
+const (
+	CSVFieldIgnore      CSVField = ""
+	CSVFieldCompany     CSVField = "company"
+	CSVFieldTitle       CSVField = "title"
+	CSVFieldStatus      CSVField = "status"
+	CSVFieldSalaryMin   CSVField = "salary_min"
+	CSVFieldSalaryMax   CSVField = "salary_max"
+	CSVFieldCurrency    CSVField = "salary_currency"
+	CSVFieldURL         CSVField = "url"
+	CSVFieldAppliedAt   CSVField = "applied_at"
+	CSVFieldLastUpdated CSVField = "updated_at"
+)
+
+// CSVFields are the fields a CSV column can be mapped to, in the order of the CSV export.
+var CSVFields = []CSVField{
+	CSVFieldCompany,
+	CSVFieldTitle,
+	CSVFieldStatus,
+	CSVFieldSalaryMin,
+	CSVFieldSalaryMax,
+	CSVFieldCurrency,
+	CSVFieldURL,
+	CSVFieldAppliedAt,
+	CSVFieldLastUpdated,
+}
+
+// Header returns the column header used by the CSV export for the field.
+func (f CSVField) Header() string {
+	switch f {
+	case CSVFieldCompany:
+		return "Company"
+	case CSVFieldTitle:
+		return "Job Title"
+	case CSVFieldStatus:
+		return "Status"
+	case CSVFieldSalaryMin:
+		return "Min Salary"
+	case CSVFieldSalaryMax:
+		return "Max Salary"
+	case CSVFieldCurrency:
+		return "Currency"
+	case CSVFieldURL:
+		return "URL"
+	case CSVFieldAppliedAt:
+		return "Applied Date"
+	case CSVFieldLastUpdated:
+		return "Last Updated"
+	default:
+		return "Ignore"
+	}
+}
+
+// ToCSVField converts the value to a CSVField. Unknown values are ignored.
+func ToCSVField(val string) CSVField {
+	for _, f := range CSVFields {
+		if string(f) == val {
+			return f
+		}
+	}
+	return CSVFieldIgnore
+}
+
+var csvHeaderAliases = map[string]CSVField{
+	"company":      CSVFieldCompany,
+	"job title":    CSVFieldTitle,
+	"title":        CSVFieldTitle,
+	"status":       CSVFieldStatus,
+	"min salary":   CSVFieldSalaryMin,
+	"salary min":   CSVFieldSalaryMin,
+	"max salary":   CSVFieldSalaryMax,
+	"salary max":   CSVFieldSalaryMax,
+	"currency":     CSVFieldCurrency,
+	"url":          CSVFieldURL,
+	"link":         CSVFieldURL,
+	"applied date": CSVFieldAppliedAt,
+	"applied at":   CSVFieldAppliedAt,
+	"applied":      CSVFieldAppliedAt,
+	"last updated": CSVFieldLastUpdated,
+	"updated at":   CSVFieldLastUpdated,
+}
+
+var csvDateLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	time.RFC3339,
+}
+
+// CSVFile is the parsed content of an uploaded CSV file.
+type CSVFile struct {
+	Header  []string
+	Records [][]string
+}
+
+// ReadCSV reads the header and records of the CSV.
+func ReadCSV(r io.Reader) (CSVFile, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return CSVFile{}, fmt.Errorf("failed to read csv: %w", err)
+	}
+	if len(records) == 0 {
+		return CSVFile{}, ErrEmptyCSV
+	}
+	header := records[0]
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	return CSVFile{Header: header, Records: records[1:]}, nil
+}
+
+// DetectCSVMapping maps the header columns to fields based on the column names. Columns with
+// unknown names are ignored.
+func DetectCSVMapping(header []string) []CSVField {
+	mapping := make([]CSVField, len(header))
+	seen := make(map[CSVField]bool)
+	for i, h := range header {
+		field := csvHeaderAliases[strings.ToLower(strings.TrimSpace(h))]
+		if field == CSVFieldIgnore || seen[field] {
+			continue
+		}
+		seen[field] = true
+		mapping[i] = field
+	}
+	return mapping
+}
+
+// CSVImportRow is a single record of the CSV and the result of validating it.
+type CSVImportRow struct {
+	// Line is the line number of the record in the file, including the header.
+	Line      int
+	App       NewJobApplication
+	Status    types.JobApplicationStatus
+	AppliedAt time.Time
+	UpdatedAt time.Time
+	Duplicate bool
+	Errors    []string
+}
+
+// Importable returns true when the row is valid and not a duplicate.
+func (r CSVImportRow) Importable() bool {
+	return len(r.Errors) == 0 && !r.Duplicate
+}
+
+// CSVImportResult is the outcome of previewing or importing a CSV.
+type CSVImportResult struct {
+	Rows     []CSVImportRow
+	Imported int
+}
+
+// Importable returns the number of rows that can be imported.
+func (r CSVImportResult) Importable() int {
+	count := 0
+	for _, row := range r.Rows {
+		if row.Importable() {
+			count++
+		}
+	}
+	return count
+}
+
+// Skipped returns the rows that are invalid or duplicates.
+func (r CSVImportResult) Skipped() []CSVImportRow {
+	var rows []CSVImportRow
+	for _, row := range r.Rows {
+		if !row.Importable() {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func validateCSVMapping(mapping []CSVField) error {
+	seen := make(map[CSVField]bool)
+	for _, f := range mapping {
+		if f == CSVFieldIgnore {
+			continue
+		}
+		if seen[f] {
+			return ErrDuplicateCSVColumn
+		}
+		seen[f] = true
+	}
+	if !seen[CSVFieldCompany] || !seen[CSVFieldTitle] {
+		return ErrMissingCSVMapping
+	}
+	return nil
+}
+
+// ParseCSVRows converts the records to rows using the mapping. Rows that fail validation, including
+// rows with a status outside of the pipeline, have their errors set.
+func ParseCSVRows(records [][]string, mapping []CSVField, pipeline types.Pipeline, now time.Time) ([]CSVImportRow, error) {
+	if err := validateCSVMapping(mapping); err != nil {
+		return nil, err
+	}
+
+	rows := make([]CSVImportRow, 0, len(records))
+	for i, record := range records {
+		row := CSVImportRow{Line: i + 2, Status: types.JobApplicationStatusApplied}
+		var appliedAt, updatedAt string
+		for col, field := range mapping {
+			if col >= len(record) {
+				break
+			}
+			val := strings.TrimSpace(record[col])
+			switch field {
+			case CSVFieldCompany:
+				row.App.Company = val
+			case CSVFieldTitle:
+				row.App.Title = val
+			case CSVFieldURL:
+				row.App.URL = val
+			case CSVFieldStatus:
+				if val != "" {
+					row.Status = NormalizeStatus(val)
+					if !pipeline.Contains(row.Status) {
+						row.Status = ""
+						row.Errors = append(row.Errors, fmt.Sprintf("invalid status %q", val))
+					}
+				}
+			case CSVFieldSalaryMin:
+				row.App.SalaryMin = parseCSVSalary(&row, "min salary", val)
+			case CSVFieldSalaryMax:
+				row.App.SalaryMax = parseCSVSalary(&row, "max salary", val)
+			case CSVFieldCurrency:
+				if val != "" {
+					row.App.SalaryCurrency = sql.NullString{String: strings.ToUpper(val), Valid: true}
+				}
+			case CSVFieldAppliedAt:
+				appliedAt = val
+			case CSVFieldLastUpdated:
+				updatedAt = val
+			case CSVFieldIgnore:
+			}
+		}
+
+		row.AppliedAt = parseCSVDate(&row, "applied date", appliedAt, now)
+		row.UpdatedAt = parseCSVDate(&row, "last updated", updatedAt, row.AppliedAt)
+		if row.UpdatedAt.Before(row.AppliedAt) {
+			row.UpdatedAt = row.AppliedAt
+		}
+
+		if row.App.Company == "" {
+			row.Errors = append(row.Errors, "missing company")
+		}
+		if row.App.Title == "" {
+			row.Errors = append(row.Errors, "missing job title")
+		}
+		if errors.Is(validateSalary(row.App.SalaryMin, row.App.SalaryMax), ErrInvalidSalaryRange) {
+			row.Errors = append(row.Errors, "min salary is greater than max salary")
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func parseCSVSalary(row *CSVImportRow, name string, val string) sql.NullInt64 {
+	if val == "" {
+		return sql.NullInt64{}
+	}
+	salary, err := strconv.ParseInt(strings.ReplaceAll(val, ",", ""), 10, 64)
+	if err != nil {
+		row.Errors = append(row.Errors, fmt.Sprintf("invalid %s %q", name, val))
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: salary, Valid: true}
+}
+
+func parseCSVDate(row *CSVImportRow, name string, val string, fallback time.Time) time.Time {
+	if val == "" {
+		return fallback
+	}
+	for _, layout := range csvDateLayouts {
+		if t, err := time.Parse(layout, val); err == nil {
+			return t.UTC()
+		}
+	}
+	row.Errors = append(row.Errors, fmt.Sprintf("invalid %s %q", name, val))
+	return fallback
+}
+
+// markCSVDuplicates flags rows that match an existing job application of the user, or an earlier
+// row of the file, by company, title and URL.
+func markCSVDuplicates(ctx context.Context, q *queries.Queries, userID int64, rows []CSVImportRow) error {
+	keys, err := getApplicationKeys(ctx, q, userID)
+	if err != nil {
+		return err
+	}
+	for i := range rows {
+		if len(rows[i].Errors) > 0 {
+			continue
+		}
+		key := newApplicationKey(rows[i].App.Company, rows[i].App.Title, rows[i].App.URL)
+		rows[i].Duplicate = keys[key]
+		keys[key] = true
+	}
+	return nil
+}
+
+// PreviewCSV validates the records and flags duplicates without writing anything.
+func PreviewCSV(ctx context.Context, database db.Database, userID int64, records [][]string, mapping []CSVField) (CSVImportResult, error) {
+	pipeline, err := GetPipeline(ctx, database.Queries(), userID)
+	if err != nil {
+		return CSVImportResult{}, err
+	}
+	rows, err := ParseCSVRows(records, mapping, pipeline, time.Now().UTC().Truncate(time.Second))
+	if err != nil {
+		return CSVImportResult{}, err
+	}
+	if err = markCSVDuplicates(ctx, database.Queries(), userID, rows); err != nil {
+		return CSVImportResult{}, err
+	}
+	return CSVImportResult{Rows: rows}, nil
+}
+
+// ImportCSV inserts the valid, non-duplicate records in a single transaction and rebuilds the
+// stats of the user.
+func ImportCSV(ctx context.Context, database db.Database, userID int64, records [][]string, mapping []CSVField) (result CSVImportResult, err error) {
+	pipeline, err := GetPipeline(ctx, database.Queries(), userID)
+	if err != nil {
+		return CSVImportResult{}, err
+	}
+	rows, err := ParseCSVRows(records, mapping, pipeline, time.Now().UTC().Truncate(time.Second))
+	if err != nil {
+		return CSVImportResult{}, err
+	}
+
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return CSVImportResult{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	qtx := queries.New(tx)
+	if err = markCSVDuplicates(ctx, qtx, userID, rows); err != nil {
+		return CSVImportResult{}, err
+	}
+
+	result = CSVImportResult{Rows: rows}
+	for _, row := range rows {
+		if !row.Importable() {
+			continue
+		}
+		if err = insertCSVRow(ctx, qtx, userID, row); err != nil {
+			return CSVImportResult{}, fmt.Errorf("failed to import line %d: %w", row.Line, err)
+		}
+		result.Imported++
+	}
+
+	if result.Imported > 0 {
+		if err = RecalculateStats(ctx, qtx, userID); err != nil {
+			return CSVImportResult{}, fmt.Errorf("failed to recalculate stats: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return CSVImportResult{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return result, nil
+}
+
+func insertCSVRow(ctx context.Context, qtx *queries.Queries, userID int64, row CSVImportRow) error {
+	companyID, err := companyIDTx(ctx, qtx, userID, row.App.Company)
+	if err != nil {
+		return err
+	}
+	jobID, err := qtx.InsertImportedJobApplication(ctx, queries.InsertImportedJobApplicationParams{
+		Company:        row.App.Company,
+		CompanyID:      companyID,
+		Title:          row.App.Title,
+		Url:            db.NewNullString(row.App.URL),
+		Status:         row.Status.String(),
+		UserID:         userID,
+		SalaryMin:      row.App.SalaryMin,
+		SalaryMax:      row.App.SalaryMax,
+		SalaryCurrency: row.App.SalaryCurrency,
+		AppliedAt:      row.AppliedAt,
+		UpdatedAt:      row.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert job: %w", err)
+	}
+
+	// Applications start as applied (or watching), so the timeline shows when the current
+	// status was reached.
+	initialStatus := types.JobApplicationStatusApplied
+	if row.Status == types.JobApplicationStatusWatching {
+		initialStatus = types.JobApplicationStatusWatching
+	}
+	err = qtx.InsertJobApplicationStatusHistoryWithCreatedAt(ctx, queries.InsertJobApplicationStatusHistoryWithCreatedAtParams{
+		Status:           initialStatus.String(),
+		JobApplicationID: jobID,
+		CreatedAt:        row.AppliedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert job status history: %w", err)
+	}
+
+	if row.Status != initialStatus {
+		err = qtx.InsertJobApplicationStatusHistoryWithCreatedAt(ctx, queries.InsertJobApplicationStatusHistoryWithCreatedAtParams{
+			Status:           row.Status.String(),
+			JobApplicationID: jobID,
+			CreatedAt:        row.UpdatedAt,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert job status history: %w", err)
+		}
+	}
+	return nil
+}
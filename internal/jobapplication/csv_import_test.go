@@ -0,0 +1,214 @@
+package jobapplication_test
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCSV(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name            string
+		input           string
+		expectedHeader  []string
+		expectedRecords int
+		expectedErr     error
+	}{
+		{
+			name:            "export format",
+			input:           "Company,Job Title,Status\nAcme,Engineer,applied\n",
+			expectedHeader:  []string{"Company", "Job Title", "Status"},
+			expectedRecords: 1,
+		},
+		{
+			name:            "byte order mark",
+			input:           "\ufeffCompany,Job Title\nAcme,Engineer\n",
+			expectedHeader:  []string{"Company", "Job Title"},
+			expectedRecords: 1,
+		},
+		{
+			name:            "uneven rows",
+			input:           "Company,Job Title,Status\nAcme,Engineer\n",
+			expectedHeader:  []string{"Company", "Job Title", "Status"},
+			expectedRecords: 1,
+		},
+		{
+			name:        "empty",
+			input:       "",
+			expectedErr: jobapplication.ErrEmptyCSV,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			actual, err := jobapplication.ReadCSV(strings.NewReader(test.input))
+			if test.expectedErr != nil {
+				require.ErrorIs(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedHeader, actual.Header)
+			assert.Len(t, actual.Records, test.expectedRecords)
+		})
+	}
+}
+
+func TestDetectCSVMapping(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		header   []string
+		expected []jobapplication.CSVField
+	}{
+		{
+			name:   "export header",
+			header: []string{"Company", "Job Title", "Status", "Min Salary", "Max Salary", "Currency", "URL", "Applied Date", "Last Updated"},
+			expected: []jobapplication.CSVField{
+				jobapplication.CSVFieldCompany,
+				jobapplication.CSVFieldTitle,
+				jobapplication.CSVFieldStatus,
+				jobapplication.CSVFieldSalaryMin,
+				jobapplication.CSVFieldSalaryMax,
+				jobapplication.CSVFieldCurrency,
+				jobapplication.CSVFieldURL,
+				jobapplication.CSVFieldAppliedAt,
+				jobapplication.CSVFieldLastUpdated,
+			},
+		},
+		{
+			name:     "case and whitespace",
+			header:   []string{" company ", "TITLE"},
+			expected: []jobapplication.CSVField{jobapplication.CSVFieldCompany, jobapplication.CSVFieldTitle},
+		},
+		{
+			name:     "unknown and repeated columns",
+			header:   []string{"Company", "Notes", "Company"},
+			expected: []jobapplication.CSVField{jobapplication.CSVFieldCompany, jobapplication.CSVFieldIgnore, jobapplication.CSVFieldIgnore},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, jobapplication.DetectCSVMapping(test.header))
+		})
+	}
+}
+
+func TestParseCSVRows(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	exportMapping := jobapplication.DetectCSVMapping([]string{"Company", "Job Title", "Status", "Min Salary", "Max Salary", "Currency", "URL", "Applied Date", "Last Updated"})
+	tests := []struct {
+		name        string
+		records     [][]string
+		mapping     []jobapplication.CSVField
+		expected    []jobapplication.CSVImportRow
+		expectedErr error
+	}{
+		{
+			name:    "valid row",
+			records: [][]string{{"Acme", "Engineer", "Interviewing", "100,000", "150000", "usd", "https://acme.com", "2025-01-02", "2025-01-10 12:00:00"}},
+			mapping: exportMapping,
+			expected: []jobapplication.CSVImportRow{
+				{
+					Line: 2,
+					App: jobapplication.NewJobApplication{
+						Company:        "Acme",
+						Title:          "Engineer",
+						URL:            "https://acme.com",
+						SalaryMin:      sql.NullInt64{Int64: 100000, Valid: true},
+						SalaryMax:      sql.NullInt64{Int64: 150000, Valid: true},
+						SalaryCurrency: sql.NullString{String: "USD", Valid: true},
+					},
+					Status:    types.JobApplicationStatusInterviewing,
+					AppliedAt: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+					UpdatedAt: time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			name:    "defaults",
+			records: [][]string{{"Acme", "Engineer"}},
+			mapping: exportMapping,
+			expected: []jobapplication.CSVImportRow{
+				{
+					Line:      2,
+					App:       jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"},
+					Status:    types.JobApplicationStatusApplied,
+					AppliedAt: now,
+					UpdatedAt: now,
+				},
+			},
+		},
+		{
+			name:    "row errors",
+			records: [][]string{{"", "Engineer", "hired", "abc", "", "", "", "01/02/2025", ""}},
+			mapping: exportMapping,
+			expected: []jobapplication.CSVImportRow{
+				{
+					Line:      2,
+					App:       jobapplication.NewJobApplication{Title: "Engineer"},
+					AppliedAt: now,
+					UpdatedAt: now,
+					Errors: []string{
+						`invalid status "hired"`,
+						`invalid min salary "abc"`,
+						`invalid applied date "01/02/2025"`,
+						"missing company",
+					},
+				},
+			},
+		},
+		{
+			name:    "invalid salary range",
+			records: [][]string{{"Acme", "Engineer", "", "200", "100"}},
+			mapping: exportMapping,
+			expected: []jobapplication.CSVImportRow{
+				{
+					Line: 2,
+					App: jobapplication.NewJobApplication{
+						Company:   "Acme",
+						Title:     "Engineer",
+						SalaryMin: sql.NullInt64{Int64: 200, Valid: true},
+						SalaryMax: sql.NullInt64{Int64: 100, Valid: true},
+					},
+					Status:    types.JobApplicationStatusApplied,
+					AppliedAt: now,
+					UpdatedAt: now,
+					Errors:    []string{"min salary is greater than max salary"},
+				},
+			},
+		},
+		{
+			name:        "missing title mapping",
+			records:     [][]string{{"Acme", "Engineer"}},
+			mapping:     []jobapplication.CSVField{jobapplication.CSVFieldCompany, jobapplication.CSVFieldIgnore},
+			expectedErr: jobapplication.ErrMissingCSVMapping,
+		},
+		{
+			name:        "duplicate mapping",
+			records:     [][]string{{"Acme", "Engineer", "Other"}},
+			mapping:     []jobapplication.CSVField{jobapplication.CSVFieldCompany, jobapplication.CSVFieldTitle, jobapplication.CSVFieldTitle},
+			expectedErr: jobapplication.ErrDuplicateCSVColumn,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			actual, err := jobapplication.ParseCSVRows(test.records, test.mapping, types.DefaultPipeline, now)
+			if test.expectedErr != nil {
+				require.ErrorIs(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package jobapplication
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+var ErrInvalidExchangeRatesFile = errors.New("invalid exchange rates file")
+
+// GetDisplayCurrency returns the currency the user wants amounts shown in.
+func GetDisplayCurrency(ctx context.Context, q *queries.Queries, userID int64) (string, error) {
+	currency, err := q.GetUserDisplayCurrency(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get display currency: %w", err)
+	}
+	return currency, nil
+}
+
+// SetDisplayCurrency changes the currency the user wants amounts shown in.
+func SetDisplayCurrency(ctx context.Context, q *queries.Queries, userID int64, currency string) error {
+	if _, ok := types.GetCurrency(currency); !ok {
+		return ErrInvalidCurrency
+	}
+	if err := q.UpdateUserDisplayCurrency(ctx, queries.UpdateUserDisplayCurrencyParams{DisplayCurrency: currency, ID: userID}); err != nil {
+		return fmt.Errorf("failed to update display currency: %w", err)
+	}
+	return nil
+}
+
+// ReadExchangeRates reads exchange rates from a CSV file with a currency code and its value in the
+// types.BaseCurrency on each row, such as "EUR,1.08". A header row and rows of the base currency
+// are skipped.
+func ReadExchangeRates(r io.Reader) ([]types.ExchangeRate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	var rates []types.ExchangeRate
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidExchangeRatesFile, err)
+		}
+
+		currency := strings.ToUpper(strings.TrimSpace(record[0]))
+		if line == 1 && currency == "CURRENCY" {
+			continue
+		}
+		if currency == types.BaseCurrency {
+			continue
+		}
+		// A rate of zero deletes the rate when saved, which a file must never do.
+		rate, err := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+		if err != nil || !(rate > 0) || math.IsInf(rate, 0) {
+			return nil, fmt.Errorf("%w: line %d has an invalid rate", ErrInvalidExchangeRatesFile, line)
+		}
+		rates = append(rates, types.ExchangeRate{Currency: currency, Rate: rate})
+	}
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("%w: no exchange rates", ErrInvalidExchangeRatesFile)
+	}
+	return rates, nil
+}
@@ -0,0 +1,78 @@
+package jobapplication_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadExchangeRates(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		input    string
+		expected []types.ExchangeRate
+		err      error
+		errMsg   string
+	}{
+		{
+			name:     "rates",
+			input:    "EUR,1.08\ngbp, 1.25\n",
+			expected: []types.ExchangeRate{{Currency: "EUR", Rate: 1.08}, {Currency: "GBP", Rate: 1.25}},
+		},
+		{
+			name:     "header and base currency",
+			input:    "currency,rate\nUSD,1\nJPY,0.0067\n",
+			expected: []types.ExchangeRate{{Currency: "JPY", Rate: 0.0067}},
+		},
+		{
+			name:  "invalid rate",
+			input: "EUR,abc\n",
+			err:   jobapplication.ErrInvalidExchangeRatesFile,
+		},
+		{
+			name:   "zero rate",
+			input:  "EUR,1.08\nGBP,0\n",
+			err:    jobapplication.ErrInvalidExchangeRatesFile,
+			errMsg: "line 2",
+		},
+		{
+			name:   "negative rate",
+			input:  "EUR,-1\n",
+			err:    jobapplication.ErrInvalidExchangeRatesFile,
+			errMsg: "line 1",
+		},
+		{
+			name:  "not a number",
+			input: "EUR,NaN\n",
+			err:   jobapplication.ErrInvalidExchangeRatesFile,
+		},
+		{
+			name:  "missing column",
+			input: "EUR\n",
+			err:   jobapplication.ErrInvalidExchangeRatesFile,
+		},
+		{
+			name:  "empty",
+			input: "currency,rate\n",
+			err:   jobapplication.ErrInvalidExchangeRatesFile,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rates, err := jobapplication.ReadExchangeRates(strings.NewReader(tt.input))
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rates)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package jobapplication
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+)
+
+// Delete permanently deletes a job application of the user along with its notes, status history,
+// reminders, interviews, offer, tags, attachments and linked HN jobs, and takes it out of the stats.
+// Linked contacts are unlinked but kept.
+func Delete(ctx context.Context, database db.Database, userID int64, jobID int64) (job queries.JobApplication, err error) {
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return queries.JobApplication{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	qtx := queries.New(tx)
+	job, err = qtx.GetJobApplicationForSnapshot(ctx, queries.GetJobApplicationForSnapshotParams{ID: jobID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return queries.JobApplication{}, ErrNotFound
+		}
+		return queries.JobApplication{}, fmt.Errorf("failed to get job: %w", err)
+	}
+	heardBack, err := qtx.HasJobApplicationHeardBack(ctx, jobID)
+	if err != nil {
+		return queries.JobApplication{}, fmt.Errorf("failed to check if heard back: %w", err)
+	}
+
+	if err = deleteTx(ctx, qtx, userID, jobID); err != nil {
+		return queries.JobApplication{}, err
+	}
+
+	// Archived job applications are not part of the stats.
+	if job.Archived == 0 {
+		if err = removeFromStats(ctx, qtx, userID, job.CompanyID, heardBack == 1); err != nil {
+			return queries.JobApplication{}, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return queries.JobApplication{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return job, nil
+}
+
+// removeFromStats takes a deleted job application out of the stats of the user. The average time to
+// hear back cannot be decremented, so the stats are rebuilt instead when the job application counted
+// towards it.
+func removeFromStats(ctx context.Context, qtx *queries.Queries, userID int64, companyID sql.NullInt64, heardBack bool) error {
+	if heardBack {
+		if err := RecalculateStats(ctx, qtx, userID); err != nil {
+			return fmt.Errorf("failed to recalculate stats: %w", err)
+		}
+		return nil
+	}
+
+	companyCount, err := qtx.CountJobApplicationCompany(ctx, queries.CountJobApplicationCompanyParams{UserID: userID, CompanyID: companyID})
+	if err != nil {
+		return fmt.Errorf("failed to count company: %w", err)
+	}
+	companyDecrement := int64(0)
+	if companyCount == 0 {
+		companyDecrement = 1
+	}
+	if err = qtx.DecrementJobApplicationStat(ctx, queries.DecrementJobApplicationStatParams{UserID: userID, TotalCompanies: companyDecrement}); err != nil {
+		return fmt.Errorf("failed to decrement stats: %w", err)
+	}
+	return nil
+}
+
+// deleteTx deletes a job application of the user with everything that references it. The rows
+// referencing the job application are deleted explicitly since foreign keys are only enforced on
+// connections that enabled them.
+func deleteTx(ctx context.Context, qtx *queries.Queries, userID int64, jobID int64) error {
+	deleted, err := qtx.DeleteJobApplicationByIDAndUserID(ctx, queries.DeleteJobApplicationByIDAndUserIDParams{ID: jobID, UserID: userID})
+	if err != nil {
+		return fmt.Errorf("failed to delete job: %w", err)
+	}
+	if deleted == 0 {
+		return ErrNotFound
+	}
+
+	if err = qtx.DeleteJobApplicationStatusHistoriesByJobApplicationID(ctx, jobID); err != nil {
+		return fmt.Errorf("failed to delete status histories: %w", err)
+	}
+	if err = qtx.DeleteJobApplicationNotesByJobApplicationID(ctx, jobID); err != nil {
+		return fmt.Errorf("failed to delete notes: %w", err)
+	}
+	if err = qtx.DeleteJobApplicationRemindersByJobApplicationID(ctx, jobID); err != nil {
+		return fmt.Errorf("failed to delete reminders: %w", err)
+	}
+	if err = qtx.DeleteJobApplicationInterviewsByJobApplicationID(ctx, jobID); err != nil {
+		return fmt.Errorf("failed to delete interviews: %w", err)
+	}
+	if err = qtx.DeleteJobApplicationOffersByJobApplicationID(ctx, jobID); err != nil {
+		return fmt.Errorf("failed to delete offers: %w", err)
+	}
+	if err = qtx.DeleteUserHNJobsByJobApplicationID(ctx, jobID); err != nil {
+		return fmt.Errorf("failed to delete hn jobs: %w", err)
+	}
+	if err = qtx.DeleteJobApplicationTagsByJobApplicationID(ctx, jobID); err != nil {
+		return fmt.Errorf("failed to delete tags: %w", err)
+	}
+	if err = qtx.DeleteJobApplicationContactsByJobApplicationID(ctx, jobID); err != nil {
+		return fmt.Errorf("failed to unlink contacts: %w", err)
+	}
+	// Attachments are only detached, so undoing a bulk delete can attach them again. Their contents
+	// are deleted later by PurgeDetachedAttachments.
+	if err = qtx.DetachJobApplicationAttachmentsByJobApplicationID(ctx, sql.NullInt64{Int64: jobID, Valid: true}); err != nil {
+		return fmt.Errorf("failed to detach attachments: %w", err)
+	}
+	if err = qtx.DeleteUnusedCompaniesByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete unused companies: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+//go:build integration
+
+package jobapplication_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelete(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+	createTestUser(t, database.DB(), 2)
+	insertTestHNJob(t, database.DB(), "hn-1")
+
+	acmeID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+	acmeManagerID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Manager"})
+	require.NoError(t, err)
+	globexID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Globex", Title: "Engineer"})
+	require.NoError(t, err)
+	_, err = jobapplication.AddNote(ctx, database, 1, acmeID, "Talked to the hiring manager")
+	require.NoError(t, err)
+	_, err = database.DB().ExecContext(ctx, "INSERT INTO user_hn_jobs (user_id, hn_job_id, job_application_id) VALUES (1, 'hn-1', ?)", acmeID)
+	require.NoError(t, err)
+
+	_, err = jobapplication.Delete(ctx, database, 2, acmeID)
+	require.ErrorIs(t, err, jobapplication.ErrNotFound)
+
+	job, err := jobapplication.Delete(ctx, database, 1, acmeID)
+	require.NoError(t, err)
+	assert.Equal(t, "Acme", job.Company)
+
+	// Another application for the company is left, so the company still counts.
+	stats := getTestStats(t, database.DB(), 1)
+	assert.Equal(t, int64(2), stats.TotalApplications)
+	assert.Equal(t, int64(2), stats.TotalCompanies)
+	for _, table := range []string{"job_application_notes", "job_application_status_histories", "user_hn_jobs"} {
+		var count int
+		require.NoError(t, database.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table+" WHERE job_application_id = ?", acmeID).Scan(&count))
+		assert.Zero(t, count, table)
+	}
+	_, err = jobapplication.Delete(ctx, database, 1, acmeID)
+	require.ErrorIs(t, err, jobapplication.ErrNotFound)
+
+	_, err = jobapplication.Delete(ctx, database, 1, acmeManagerID)
+	require.NoError(t, err)
+	stats = getTestStats(t, database.DB(), 1)
+	assert.Equal(t, int64(1), stats.TotalApplications)
+	assert.Equal(t, int64(1), stats.TotalCompanies)
+
+	// Deleting a job application that heard back rebuilds the average time to hear back.
+	_, err = database.DB().ExecContext(ctx, "UPDATE job_applications SET applied_at = datetime('now', '-4 days') WHERE id = ?", globexID)
+	require.NoError(t, err)
+	initechID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Initech", Title: "Engineer"})
+	require.NoError(t, err)
+	_, err = jobapplication.UpdateStatus(ctx, database, 1, globexID, types.JobApplicationStatusInterviewing)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), getTestStats(t, database.DB(), 1).AverageTimeToHearBack)
+
+	_, err = jobapplication.Delete(ctx, database, 1, globexID)
+	require.NoError(t, err)
+	stats = getTestStats(t, database.DB(), 1)
+	assert.Equal(t, int64(1), stats.TotalApplications)
+	assert.Equal(t, int64(1), stats.TotalCompanies)
+	assert.Zero(t, stats.AverageTimeToHearBack)
+
+	// Archived job applications are not part of the stats.
+	_, err = database.DB().ExecContext(ctx, "UPDATE job_applications SET archived = 1 WHERE id = ?", initechID)
+	require.NoError(t, err)
+	require.NoError(t, jobapplication.RecalculateStats(ctx, database.Queries(), 1))
+	job, err = jobapplication.Delete(ctx, database, 1, initechID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), job.Archived)
+	stats = getTestStats(t, database.DB(), 1)
+	assert.Zero(t, stats.TotalApplications)
+	assert.Zero(t, stats.TotalCompanies)
+}
+
+func TestRecalculateAllStats(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+	createTestUser(t, database.DB(), 2)
+	for _, userID := range []int64{1, 2} {
+		_, err := jobapplication.Create(ctx, database, userID, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+		require.NoError(t, err)
+	}
+	_, err := database.DB().ExecContext(ctx, "UPDATE job_application_stats SET total_applications = 42, total_companies = 7")
+	require.NoError(t, err)
+
+	users, err := jobapplication.RecalculateAllStats(ctx, database)
+	require.NoError(t, err)
+	assert.Equal(t, 2, users)
+	for _, userID := range []int64{1, 2} {
+		stats := getTestStats(t, database.DB(), userID)
+		assert.Equal(t, int64(1), stats.TotalApplications)
+		assert.Equal(t, int64(1), stats.TotalCompanies)
+	}
+}
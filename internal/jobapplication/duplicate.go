@@ -0,0 +1,38 @@
+package jobapplication
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Piszmog/pathwise/internal/db/queries"
+)
+
+// applicationKey identifies a job application when checking for duplicates. Company and title are
+// compared case-insensitively.
+type applicationKey struct {
+	company string
+	title   string
+	url     string
+}
+
+func newApplicationKey(company string, title string, url string) applicationKey {
+	return applicationKey{
+		company: strings.ToLower(strings.TrimSpace(company)),
+		title:   strings.ToLower(strings.TrimSpace(title)),
+		url:     strings.TrimSpace(url),
+	}
+}
+
+func getApplicationKeys(ctx context.Context, q *queries.Queries, userID int64) (map[applicationKey]bool, error) {
+	existing, err := q.GetJobApplicationKeysByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get existing job applications: %w", err)
+	}
+
+	keys := make(map[applicationKey]bool, len(existing))
+	for _, e := range existing {
+		keys[newApplicationKey(e.Company, e.Title, e.Url.String)] = true
+	}
+	return keys, nil
+}
@@ -0,0 +1,80 @@
+package jobapplication
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+// FilterJobApplications returns a page of the user's job applications matching the filters, in the
+// sort order of the filters. Salaries are compared and sorted in the display currency of the user,
+// leaving out salaries in currencies without an exchange rate.
+func FilterJobApplications(ctx context.Context, q *queries.Queries, userID int64, opts types.FilterOpts, page int64, perPage int64) ([]types.JobApplication, error) {
+	rows, err := q.FilterJobApplications(ctx, queries.FilterJobApplicationsParams{
+		UserID:         userID,
+		Archived:       boolToInt64(opts.IsArchived),
+		Company:        companyPattern(opts.Company),
+		Status:         opts.Status.String(),
+		TagID:          opts.Tag,
+		AppliedFrom:    opts.AppliedFrom,
+		AppliedTo:      opts.AppliedTo,
+		SalaryMin:      opts.SalaryMin,
+		SalaryMax:      opts.SalaryMax,
+		HasUrl:         boolToInt64(opts.HasURL),
+		NoResponseDays: opts.NoResponseDays,
+		Sort:           string(opts.Sort),
+		Descending:     boolToInt64(opts.IsDescending()),
+		Limit:          perPage,
+		Offset:         page * perPage,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter job applications: %w", err)
+	}
+
+	jobs := make([]types.JobApplication, len(rows))
+	for i, row := range rows {
+		jobs[i] = types.JobApplication{
+			ID:             row.ID,
+			Company:        row.Company,
+			Title:          row.Title,
+			URL:            row.Url.String,
+			Status:         types.JobApplicationStatus(row.Status),
+			AppliedAt:      row.AppliedAt,
+			UpdatedAt:      row.UpdatedAt,
+			SalaryMin:      row.SalaryMin,
+			SalaryMax:      row.SalaryMax,
+			SalaryCurrency: row.SalaryCurrency,
+		}
+	}
+	return WithTags(ctx, q, jobs)
+}
+
+func countFilterParams(userID int64, opts types.FilterOpts) queries.CountFilterJobApplicationsParams {
+	return queries.CountFilterJobApplicationsParams{
+		UserID:         userID,
+		Archived:       boolToInt64(opts.IsArchived),
+		Company:        companyPattern(opts.Company),
+		Status:         opts.Status.String(),
+		TagID:          opts.Tag,
+		AppliedFrom:    opts.AppliedFrom,
+		AppliedTo:      opts.AppliedTo,
+		SalaryMin:      opts.SalaryMin,
+		SalaryMax:      opts.SalaryMax,
+		HasUrl:         boolToInt64(opts.HasURL),
+		NoResponseDays: opts.NoResponseDays,
+	}
+}
+
+// likeEscaper escapes the LIKE wildcards so the text the user typed only matches itself. The
+// queries use a backslash as the escape character.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func companyPattern(company string) string {
+	if company == "" {
+		return ""
+	}
+	return "%" + likeEscaper.Replace(company) + "%"
+}
@@ -0,0 +1,91 @@
+//go:build integration
+
+package jobapplication_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterJobApplications(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+	createTestUser(t, database.DB(), 2)
+
+	create := func(company string, title string, url string, appliedAt string, salaryMax int64, currency string) int64 {
+		job := jobapplication.NewJobApplication{Company: company, Title: title, URL: url}
+		if salaryMax > 0 {
+			job.SalaryMax = sql.NullInt64{Int64: salaryMax, Valid: true}
+			job.SalaryCurrency = sql.NullString{String: currency, Valid: true}
+		}
+		id, err := jobapplication.Create(ctx, database, 1, job)
+		require.NoError(t, err)
+		_, err = database.DB().ExecContext(ctx, "UPDATE job_applications SET applied_at = ? WHERE id = ?", appliedAt, id)
+		require.NoError(t, err)
+		return id
+	}
+	create("Acme", "Backend Engineer", "https://acme.com", "2026-09-01 10:00:00", 150000, "USD")
+	globexID := create("globex", "Frontend Engineer", "", "2026-09-15 10:00:00", 120000, "EUR")
+	create("Initech", "Data Engineer", "https://initech.com", "2026-10-01 10:00:00", 0, "")
+	hooliID := create("Hooli", "Platform Engineer", "https://hooli.com", "2026-10-10 10:00:00", 130000, "USD")
+	_, err := jobapplication.UpdateStatus(ctx, database, 1, hooliID, types.JobApplicationStatusInterviewing)
+	require.NoError(t, err)
+	_, err = jobapplication.Create(ctx, database, 2, jobapplication.NewJobApplication{Company: "Other", Title: "Engineer"})
+	require.NoError(t, err)
+
+	filter := func(t *testing.T, opts types.FilterOpts) []string {
+		t.Helper()
+		jobs, filterErr := jobapplication.FilterJobApplications(ctx, database.Queries(), 1, opts, 0, 10)
+		require.NoError(t, filterErr)
+		companies := make([]string, len(jobs))
+		for i, job := range jobs {
+			companies[i] = job.Company
+		}
+		return companies
+	}
+
+	tests := []struct {
+		name     string
+		opts     types.FilterOpts
+		expected []string
+	}{
+		{name: "last updated", expected: []string{"Hooli", "Initech", "globex", "Acme"}},
+		{name: "applied date", opts: types.FilterOpts{Sort: types.JobSortApplied}, expected: []string{"Hooli", "Initech", "globex", "Acme"}},
+		{name: "applied date ascending", opts: types.FilterOpts{Sort: types.JobSortApplied, Order: "asc"}, expected: []string{"Acme", "globex", "Initech", "Hooli"}},
+		{name: "company", opts: types.FilterOpts{Sort: types.JobSortCompany}, expected: []string{"Acme", "globex", "Hooli", "Initech"}},
+		{name: "title descending", opts: types.FilterOpts{Sort: types.JobSortTitle, Order: "desc"}, expected: []string{"Hooli", "globex", "Initech", "Acme"}},
+		{name: "status", opts: types.FilterOpts{Sort: types.JobSortStatus, Order: "desc"}, expected: []string{"Hooli", "Initech", "globex", "Acme"}},
+		{name: "salary without exchange rate", opts: types.FilterOpts{Sort: types.JobSortSalary}, expected: []string{"Acme", "Hooli", "Initech", "globex"}},
+		{name: "applied range", opts: types.FilterOpts{AppliedFrom: "2026-09-15", AppliedTo: "2026-10-01"}, expected: []string{"Initech", "globex"}},
+		{name: "has url", opts: types.FilterOpts{HasURL: true, Sort: types.JobSortCompany}, expected: []string{"Acme", "Hooli", "Initech"}},
+		{name: "salary range", opts: types.FilterOpts{SalaryMin: 140000}, expected: []string{"Acme"}},
+		{name: "no response", opts: types.FilterOpts{NoResponseDays: 1, Sort: types.JobSortCompany}, expected: []string{"Acme", "globex", "Initech"}},
+		{name: "company", opts: types.FilterOpts{Company: "LOB"}, expected: []string{"globex"}},
+		{name: "company wildcards", opts: types.FilterOpts{Company: "a_m"}, expected: []string{}},
+		{name: "company escape character", opts: types.FilterOpts{Company: `\`}, expected: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, filter(t, tt.opts))
+		})
+	}
+
+	// Salaries are converted to the display currency once there is an exchange rate.
+	require.NoError(t, jobapplication.SaveExchangeRates(ctx, database, 1, []types.ExchangeRate{{Currency: "EUR", Rate: 1.5}}))
+	assert.Equal(t, []string{"globex", "Acme", "Hooli", "Initech"}, filter(t, types.FilterOpts{Sort: types.JobSortSalary}))
+	assert.Equal(t, []string{"Hooli", "Acme"}, filter(t, types.FilterOpts{Sort: types.JobSortSalary, Order: "asc", SalaryMax: 160000}))
+
+	require.NoError(t, jobapplication.SetDisplayCurrency(ctx, database.Queries(), 1, "EUR"))
+	jobs, err := jobapplication.FilterJobApplications(ctx, database.Queries(), 1, types.FilterOpts{SalaryMin: 120000, SalaryMax: 120000}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, globexID, jobs[0].ID)
+}
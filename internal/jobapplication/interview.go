@@ -0,0 +1,144 @@
+package jobapplication
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Piszmog/pathwise/internal/calendar"
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+// InterviewTimeLayout is the layout of the local date and time an interview is scheduled at.
+const InterviewTimeLayout = "2006-01-02T15:04"
+
+var (
+	ErrNotInterviewing          = errors.New("interviews can only be scheduled while interviewing")
+	ErrInvalidInterviewType     = errors.New("invalid interview type")
+	ErrInvalidInterviewOutcome  = errors.New("invalid interview outcome")
+	ErrInvalidInterviewTime     = errors.New("invalid interview date and time")
+	ErrInvalidInterviewDuration = errors.New("interview duration must be between 1 and 1440 minutes")
+	ErrInvalidTimezone          = errors.New("invalid timezone")
+	ErrInterviewNotFound        = errors.New("interview not found")
+)
+
+type NewInterview struct {
+	// ScheduledAt is the local date and time of the interview in the timezone, formatted with
+	// InterviewTimeLayout.
+	ScheduledAt     string
+	Timezone        string
+	Type            types.InterviewType
+	Interviewers    string
+	DurationMinutes int64
+}
+
+// scheduledAt validates the interview and returns when it is scheduled.
+func (i NewInterview) scheduledAt() (time.Time, error) {
+	if i.Type == "" {
+		return time.Time{}, ErrInvalidInterviewType
+	}
+	if i.DurationMinutes < 1 || i.DurationMinutes > 24*60 {
+		return time.Time{}, ErrInvalidInterviewDuration
+	}
+	if i.Timezone == "" {
+		return time.Time{}, ErrInvalidTimezone
+	}
+	loc, err := time.LoadLocation(i.Timezone)
+	if err != nil {
+		return time.Time{}, ErrInvalidTimezone
+	}
+	scheduledAt, err := time.ParseInLocation(InterviewTimeLayout, i.ScheduledAt, loc)
+	if err != nil {
+		return time.Time{}, ErrInvalidInterviewTime
+	}
+	return scheduledAt, nil
+}
+
+// AddInterview records an interview round for a job application owned by the user that is
+// currently interviewing.
+func AddInterview(ctx context.Context, database db.Database, userID int64, jobID int64, interview NewInterview) (queries.InsertJobApplicationInterviewRow, error) {
+	scheduledAt, err := interview.scheduledAt()
+	if err != nil {
+		return queries.InsertJobApplicationInterviewRow{}, err
+	}
+
+	job, err := database.Queries().GetJobApplicationByIDAndUserID(ctx, queries.GetJobApplicationByIDAndUserIDParams{ID: jobID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return queries.InsertJobApplicationInterviewRow{}, ErrNotFound
+		}
+		return queries.InsertJobApplicationInterviewRow{}, fmt.Errorf("failed to get job: %w", err)
+	}
+	if types.JobApplicationStatus(job.Status) != types.JobApplicationStatusInterviewing {
+		return queries.InsertJobApplicationInterviewRow{}, ErrNotInterviewing
+	}
+
+	row, err := database.Queries().InsertJobApplicationInterview(ctx, queries.InsertJobApplicationInterviewParams{
+		JobApplicationID: jobID,
+		ScheduledAt:      scheduledAt.UTC(),
+		Timezone:         interview.Timezone,
+		Type:             interview.Type.String(),
+		Interviewers:     strings.TrimSpace(interview.Interviewers),
+		DurationMinutes:  interview.DurationMinutes,
+	})
+	if err != nil {
+		return queries.InsertJobApplicationInterviewRow{}, fmt.Errorf("failed to insert interview: %w", err)
+	}
+	return row, nil
+}
+
+// UpdateInterviewOutcome records the outcome of an interview owned by the user.
+func UpdateInterviewOutcome(ctx context.Context, database db.Database, userID int64, interviewID int64, outcome types.InterviewOutcome) (queries.UpdateJobApplicationInterviewOutcomeRow, error) {
+	if outcome == "" {
+		return queries.UpdateJobApplicationInterviewOutcomeRow{}, ErrInvalidInterviewOutcome
+	}
+	row, err := database.Queries().UpdateJobApplicationInterviewOutcome(ctx, queries.UpdateJobApplicationInterviewOutcomeParams{
+		Outcome: outcome.String(),
+		ID:      interviewID,
+		UserID:  userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return queries.UpdateJobApplicationInterviewOutcomeRow{}, ErrInterviewNotFound
+		}
+		return queries.UpdateJobApplicationInterviewOutcomeRow{}, fmt.Errorf("failed to update interview outcome: %w", err)
+	}
+	return row, nil
+}
+
+// InterviewCalendar returns the interviews of the user as a calendar for subscribing to.
+func InterviewCalendar(ctx context.Context, q *queries.Queries, userID int64) (calendar.Calendar, error) {
+	interviews, err := q.GetJobApplicationInterviewsForCalendarByUserID(ctx, userID)
+	if err != nil {
+		return calendar.Calendar{}, fmt.Errorf("failed to get interviews: %w", err)
+	}
+
+	cal := calendar.Calendar{
+		Name:   "Pathwise Interviews",
+		Events: make([]calendar.Event, len(interviews)),
+	}
+	for i, interview := range interviews {
+		outcome := types.ToInterviewOutcome(interview.Outcome)
+		description := "Outcome: " + outcome.PrettyString()
+		if interview.Interviewers != "" {
+			description = "Interviewers: " + interview.Interviewers + "\n" + description
+		}
+		cal.Events[i] = calendar.Event{
+			Start:       interview.ScheduledAt,
+			End:         interview.ScheduledAt.Add(time.Duration(interview.DurationMinutes) * time.Minute),
+			Stamp:       interview.UpdatedAt,
+			UID:         "interview-" + strconv.FormatInt(interview.ID, 10) + "@pathwise",
+			Summary:     types.ToInterviewType(interview.Type).PrettyString() + " interview: " + interview.Company + " - " + interview.Title,
+			Description: description,
+			URL:         interview.Url.String,
+			Canceled:    outcome == types.InterviewOutcomeCanceled,
+		}
+	}
+	return cal, nil
+}
@@ -0,0 +1,139 @@
+//go:build integration
+
+package jobapplication_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Piszmog/pathwise/internal/calendar"
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterviews(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+	createTestUser(t, database.DB(), 2)
+	jobID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme, Inc.", Title: "Engineer", URL: "https://acme.example.com"})
+	require.NoError(t, err)
+
+	interview := jobapplication.NewInterview{
+		ScheduledAt:     "2026-10-20T09:30",
+		Timezone:        "America/New_York",
+		Type:            types.InterviewTypeTechnical,
+		Interviewers:    " Jane Doe; John Smith ",
+		DurationMinutes: 45,
+	}
+	_, err = jobapplication.AddInterview(ctx, database, 1, jobID, interview)
+	require.ErrorIs(t, err, jobapplication.ErrNotInterviewing)
+
+	_, err = jobapplication.Update(ctx, database, 1, jobapplication.UpdatedJobApplication{
+		ID:      jobID,
+		Company: "Acme, Inc.",
+		Title:   "Engineer",
+		URL:     "https://acme.example.com",
+		Status:  types.JobApplicationStatusInterviewing,
+	})
+	require.NoError(t, err)
+
+	_, err = jobapplication.AddInterview(ctx, database, 2, jobID, interview)
+	require.ErrorIs(t, err, jobapplication.ErrNotFound)
+
+	invalid := interview
+	invalid.Timezone = "Mars/Olympus_Mons"
+	_, err = jobapplication.AddInterview(ctx, database, 1, jobID, invalid)
+	require.ErrorIs(t, err, jobapplication.ErrInvalidTimezone)
+	invalid = interview
+	invalid.ScheduledAt = "tomorrow"
+	_, err = jobapplication.AddInterview(ctx, database, 1, jobID, invalid)
+	require.ErrorIs(t, err, jobapplication.ErrInvalidInterviewTime)
+	invalid = interview
+	invalid.Type = types.ToInterviewType("coffee")
+	_, err = jobapplication.AddInterview(ctx, database, 1, jobID, invalid)
+	require.ErrorIs(t, err, jobapplication.ErrInvalidInterviewType)
+	invalid = interview
+	invalid.DurationMinutes = 0
+	_, err = jobapplication.AddInterview(ctx, database, 1, jobID, invalid)
+	require.ErrorIs(t, err, jobapplication.ErrInvalidInterviewDuration)
+
+	technical, err := jobapplication.AddInterview(ctx, database, 1, jobID, interview)
+	require.NoError(t, err)
+	assert.Equal(t, "2026-10-20T13:30:00Z", technical.ScheduledAt.UTC().Format("2006-01-02T15:04:05Z"))
+	assert.Equal(t, "America/New_York", technical.Timezone)
+	assert.Equal(t, "Jane Doe; John Smith", technical.Interviewers)
+	assert.Equal(t, types.InterviewOutcomePending.String(), technical.Outcome)
+
+	onsite, err := jobapplication.AddInterview(ctx, database, 1, jobID, jobapplication.NewInterview{
+		ScheduledAt:     "2026-10-27T14:00",
+		Timezone:        "UTC",
+		Type:            types.InterviewTypeOnsite,
+		DurationMinutes: 240,
+	})
+	require.NoError(t, err)
+
+	_, err = jobapplication.UpdateInterviewOutcome(ctx, database, 2, onsite.ID, types.InterviewOutcomeCanceled)
+	require.ErrorIs(t, err, jobapplication.ErrInterviewNotFound)
+	_, err = jobapplication.UpdateInterviewOutcome(ctx, database, 1, onsite.ID, types.ToInterviewOutcome("maybe"))
+	require.ErrorIs(t, err, jobapplication.ErrInvalidInterviewOutcome)
+	updated, err := jobapplication.UpdateInterviewOutcome(ctx, database, 1, onsite.ID, types.InterviewOutcomeCanceled)
+	require.NoError(t, err)
+	assert.Equal(t, types.InterviewOutcomeCanceled.String(), updated.Outcome)
+
+	interviews, err := database.Queries().GetJobApplicationInterviewsByJobApplicationID(ctx, jobID)
+	require.NoError(t, err)
+	require.Len(t, interviews, 2)
+	assert.Equal(t, technical.ID, interviews[0].ID)
+	assert.Equal(t, onsite.ID, interviews[1].ID)
+
+	cal, err := jobapplication.InterviewCalendar(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, calendar.Write(&buf, cal))
+	events := parseEvents(t, buf.String())
+	require.Len(t, events, 2)
+
+	assert.Equal(t, "20261020T133000Z", events[0]["DTSTART"])
+	assert.Equal(t, "20261020T141500Z", events[0]["DTEND"])
+	assert.Equal(t, `Technical interview: Acme\, Inc. - Engineer`, events[0]["SUMMARY"])
+	assert.Equal(t, `Interviewers: Jane Doe\; John Smith\nOutcome: Pending`, events[0]["DESCRIPTION"])
+	assert.Equal(t, "https://acme.example.com", events[0]["URL"])
+	assert.Equal(t, "CONFIRMED", events[0]["STATUS"])
+
+	assert.Equal(t, "20261027T140000Z", events[1]["DTSTART"])
+	assert.Equal(t, "20261027T180000Z", events[1]["DTEND"])
+	assert.Equal(t, "CANCELLED", events[1]["STATUS"])
+
+	cal, err = jobapplication.InterviewCalendar(ctx, database.Queries(), 2)
+	require.NoError(t, err)
+	assert.Empty(t, cal.Events)
+}
+
+// parseEvents returns the properties of the events in the feed, with values left escaped.
+func parseEvents(t *testing.T, feed string) []map[string]string {
+	t.Helper()
+
+	unfolded := strings.ReplaceAll(feed, "\r\n ", "")
+	var events []map[string]string
+	var event map[string]string
+	for _, line := range strings.Split(strings.TrimSuffix(unfolded, "\r\n"), "\r\n") {
+		name, value, ok := strings.Cut(line, ":")
+		require.True(t, ok, "invalid content line %q", line)
+		switch {
+		case line == "BEGIN:VEVENT":
+			event = make(map[string]string)
+		case line == "END:VEVENT":
+			events = append(events, event)
+			event = nil
+		case event != nil:
+			event[name] = value
+		}
+	}
+	return events
+}
@@ -0,0 +1,342 @@
+package jobapplication
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+var (
+	ErrMissingCompanyOrTitle = errors.New("company and title are required")
+	ErrInvalidStatus         = errors.New("invalid job application status")
+	ErrInvalidSalaryRange    = errors.New("minimum salary cannot be greater than maximum salary")
+	ErrMissingNote           = errors.New("note is required")
+	ErrNotFound              = errors.New("job application not found")
+	ErrNoteNotFound          = errors.New("note not found")
+)
+
+// NewJobApplication is the data needed to create a job application.
+type NewJobApplication struct {
+	Company        string
+	Title          string
+	URL            string
+	SalaryMin      sql.NullInt64
+	SalaryMax      sql.NullInt64
+	SalaryCurrency sql.NullString
+}
+
+func (j NewJobApplication) validate() error {
+	if j.Company == "" || j.Title == "" {
+		return ErrMissingCompanyOrTitle
+	}
+	return validateSalary(j.SalaryMin, j.SalaryMax)
+}
+
+// UpdatedJobApplication is the full set of values a job application is updated to.
+type UpdatedJobApplication struct {
+	ID             int64
+	Company        string
+	Title          string
+	URL            string
+	Status         types.JobApplicationStatus
+	SalaryMin      sql.NullInt64
+	SalaryMax      sql.NullInt64
+	SalaryCurrency sql.NullString
+}
+
+func (j UpdatedJobApplication) validate() error {
+	if j.Company == "" || j.Title == "" {
+		return ErrMissingCompanyOrTitle
+	}
+	if j.Status == "" {
+		return ErrInvalidStatus
+	}
+	return validateSalary(j.SalaryMin, j.SalaryMax)
+}
+
+// UpdateResult describes the side effects of updating a job application.
+type UpdateResult struct {
+	Previous         queries.GetJobApplicationByIDAndUserIDRow
+	StatusChanged    bool
+	StatsChanged     bool
+	RemindersCleared bool
+}
+
+func validateSalary(salaryMin sql.NullInt64, salaryMax sql.NullInt64) error {
+	if salaryMin.Valid && salaryMax.Valid && salaryMin.Int64 > salaryMax.Int64 {
+		return ErrInvalidSalaryRange
+	}
+	return nil
+}
+
+// Create inserts a new job application along with its initial status history and stats.
+func Create(ctx context.Context, database db.Database, userID int64, app NewJobApplication) (id int64, err error) {
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	id, err = CreateTx(ctx, queries.New(tx), userID, app)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return id, nil
+}
+
+// CreateTx inserts a new job application using an existing transaction.
+func CreateTx(ctx context.Context, qtx *queries.Queries, userID int64, app NewJobApplication) (int64, error) {
+	if err := app.validate(); err != nil {
+		return 0, err
+	}
+
+	companyID, err := companyIDTx(ctx, qtx, userID, app.Company)
+	if err != nil {
+		return 0, err
+	}
+	companyCount, err := qtx.CountJobApplicationCompany(ctx, queries.CountJobApplicationCompanyParams{UserID: userID, CompanyID: companyID})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count company: %w", err)
+	}
+
+	jobID, err := qtx.InsertJobApplication(ctx, queries.InsertJobApplicationParams{
+		Company:        app.Company,
+		CompanyID:      companyID,
+		Title:          app.Title,
+		Url:            db.NewNullString(app.URL),
+		UserID:         userID,
+		SalaryMin:      app.SalaryMin,
+		SalaryMax:      app.SalaryMax,
+		SalaryCurrency: app.SalaryCurrency,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert job: %w", err)
+	}
+	if err = qtx.InsertJobApplicationStatusHistory(ctx, jobID); err != nil {
+		return 0, fmt.Errorf("failed to insert job status history: %w", err)
+	}
+
+	companyIncrement := int64(0)
+	if companyCount == 0 {
+		companyIncrement = 1
+	}
+	if err = qtx.IncrementNewJobApplicationStat(ctx, queries.IncrementNewJobApplicationStatParams{UserID: userID, TotalCompanies: companyIncrement}); err != nil {
+		return 0, fmt.Errorf("failed to increment new job application stat: %w", err)
+	}
+	return jobID, nil
+}
+
+// Update updates a job application, recording a status history entry when the status changes and
+// recalculating the stats when they are affected.
+func Update(ctx context.Context, database db.Database, userID int64, app UpdatedJobApplication) (result UpdateResult, err error) {
+	if err = app.validate(); err != nil {
+		return UpdateResult{}, err
+	}
+
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return UpdateResult{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	result, err = UpdateTx(ctx, queries.New(tx), userID, app)
+	if err != nil {
+		return UpdateResult{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return UpdateResult{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return result, nil
+}
+
+// UpdateStatus moves a job application to another status, keeping the rest of its values.
+func UpdateStatus(ctx context.Context, database db.Database, userID int64, jobID int64, status types.JobApplicationStatus) (result UpdateResult, err error) {
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return UpdateResult{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	qtx := queries.New(tx)
+	job, err := qtx.GetJobApplicationByIDAndUserID(ctx, queries.GetJobApplicationByIDAndUserIDParams{ID: jobID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return UpdateResult{}, ErrNotFound
+		}
+		return UpdateResult{}, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	result, err = UpdateTx(ctx, qtx, userID, UpdatedJobApplication{
+		ID:             job.ID,
+		Company:        job.Company,
+		Title:          job.Title,
+		URL:            job.Url.String,
+		Status:         status,
+		SalaryMin:      job.SalaryMin,
+		SalaryMax:      job.SalaryMax,
+		SalaryCurrency: job.SalaryCurrency,
+	})
+	if err != nil {
+		return UpdateResult{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return UpdateResult{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return result, nil
+}
+
+// UpdateTx updates a job application using an existing transaction.
+func UpdateTx(ctx context.Context, qtx *queries.Queries, userID int64, app UpdatedJobApplication) (UpdateResult, error) {
+	if err := app.validate(); err != nil {
+		return UpdateResult{}, err
+	}
+
+	job, err := qtx.GetJobApplicationByIDAndUserID(ctx, queries.GetJobApplicationByIDAndUserIDParams{ID: app.ID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return UpdateResult{}, ErrNotFound
+		}
+		return UpdateResult{}, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	pipeline, err := GetPipeline(ctx, qtx, userID)
+	if err != nil {
+		return UpdateResult{}, err
+	}
+	if !pipeline.Contains(app.Status) {
+		return UpdateResult{}, ErrInvalidStatus
+	}
+
+	previousStatus := types.JobApplicationStatus(job.Status)
+	companyID, err := companyIDTx(ctx, qtx, userID, app.Company)
+	if err != nil {
+		return UpdateResult{}, err
+	}
+
+	err = qtx.UpdateJobApplication(ctx, queries.UpdateJobApplicationParams{
+		ID:             job.ID,
+		Company:        app.Company,
+		CompanyID:      companyID,
+		Title:          app.Title,
+		Url:            db.NewNullString(app.URL),
+		Status:         app.Status.String(),
+		SalaryMin:      app.SalaryMin,
+		SalaryMax:      app.SalaryMax,
+		SalaryCurrency: app.SalaryCurrency,
+		UserID:         userID,
+	})
+	if err != nil {
+		return UpdateResult{}, fmt.Errorf("failed to update job: %w", err)
+	}
+
+	result := UpdateResult{Previous: job}
+	if previousStatus != app.Status {
+		result.StatusChanged = true
+		err = qtx.InsertJobApplicationStatusHistoryWithStatus(ctx, queries.InsertJobApplicationStatusHistoryWithStatusParams{JobApplicationID: job.ID, Status: app.Status.String()})
+		if err != nil {
+			return UpdateResult{}, fmt.Errorf("failed to insert job status history: %w", err)
+		}
+
+		if clearsReminders(pipeline, app.Status) {
+			cleared, clearErr := qtx.DismissJobApplicationRemindersByJobApplicationID(ctx, job.ID)
+			if clearErr != nil {
+				return UpdateResult{}, fmt.Errorf("failed to clear reminders: %w", clearErr)
+			}
+			result.RemindersCleared = cleared > 0
+		}
+	}
+
+	if job.Company != app.Company {
+		if err = qtx.DeleteUnusedCompaniesByUserID(ctx, userID); err != nil {
+			return UpdateResult{}, fmt.Errorf("failed to delete unused companies: %w", err)
+		}
+	}
+	if result.StatusChanged || job.Company != app.Company {
+		result.StatsChanged = true
+		if err = RecalculateStats(ctx, qtx, userID); err != nil {
+			return UpdateResult{}, fmt.Errorf("failed to recalculate stats: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// AddNote adds a note to a job application owned by the user.
+func AddNote(ctx context.Context, database db.Database, userID int64, jobID int64, note string) (queries.InsertJobApplicationNoteRow, error) {
+	if note == "" {
+		return queries.InsertJobApplicationNoteRow{}, ErrMissingNote
+	}
+
+	if _, err := database.Queries().GetJobApplicationByIDAndUserID(ctx, queries.GetJobApplicationByIDAndUserIDParams{ID: jobID, UserID: userID}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return queries.InsertJobApplicationNoteRow{}, ErrNotFound
+		}
+		return queries.InsertJobApplicationNoteRow{}, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	n, err := database.Queries().InsertJobApplicationNote(ctx, queries.InsertJobApplicationNoteParams{JobApplicationID: jobID, Note: note})
+	if err != nil {
+		return queries.InsertJobApplicationNoteRow{}, fmt.Errorf("failed to insert note: %w", err)
+	}
+	return n, nil
+}
+
+// UpdateNote changes the text of a note of a job application owned by the user and marks it as
+// edited.
+func UpdateNote(ctx context.Context, database db.Database, userID int64, jobID int64, noteID int64, note string) (queries.UpdateJobApplicationNoteRow, error) {
+	if note == "" {
+		return queries.UpdateJobApplicationNoteRow{}, ErrMissingNote
+	}
+
+	n, err := database.Queries().UpdateJobApplicationNote(ctx, queries.UpdateJobApplicationNoteParams{
+		Note:             note,
+		ID:               noteID,
+		JobApplicationID: jobID,
+		UserID:           userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return queries.UpdateJobApplicationNoteRow{}, ErrNoteNotFound
+		}
+		return queries.UpdateJobApplicationNoteRow{}, fmt.Errorf("failed to update note: %w", err)
+	}
+	return n, nil
+}
+
+// DeleteNote deletes a note of a job application owned by the user.
+func DeleteNote(ctx context.Context, database db.Database, userID int64, jobID int64, noteID int64) error {
+	deleted, err := database.Queries().DeleteJobApplicationNoteByIDAndUserID(ctx, queries.DeleteJobApplicationNoteByIDAndUserIDParams{
+		ID:               noteID,
+		JobApplicationID: jobID,
+		UserID:           userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete note: %w", err)
+	}
+	if deleted == 0 {
+		return ErrNoteNotFound
+	}
+	return nil
+}
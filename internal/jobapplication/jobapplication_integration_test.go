@@ -0,0 +1,50 @@
+//go:build integration
+
+package jobapplication_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateStatus(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+	createTestUser(t, database.DB(), 2)
+
+	jobID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer", URL: "https://acme.com"})
+	require.NoError(t, err)
+
+	result, err := jobapplication.UpdateStatus(ctx, database, 1, jobID, types.JobApplicationStatusInterviewing)
+	require.NoError(t, err)
+	assert.True(t, result.StatusChanged)
+	assert.True(t, result.StatsChanged)
+	assert.Equal(t, types.JobApplicationStatusApplied.String(), result.Previous.Status)
+
+	job, err := database.Queries().GetJobApplicationByID(ctx, jobID)
+	require.NoError(t, err)
+	assert.Equal(t, types.JobApplicationStatusInterviewing.String(), job.Status)
+	assert.Equal(t, "Acme", job.Company)
+	assert.Equal(t, "Engineer", job.Title)
+	assert.Equal(t, "https://acme.com", job.Url.String)
+
+	histories, err := database.Queries().CountJobApplicationStatusHistoriesByJobApplicationID(ctx, jobID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), histories)
+
+	stats := getTestStats(t, database.DB(), 1)
+	assert.Equal(t, int64(1), stats.TotalInterviewing)
+	assert.Equal(t, int64(0), stats.TotalApplied)
+
+	_, err = jobapplication.UpdateStatus(ctx, database, 1, jobID, "take-home")
+	require.ErrorIs(t, err, jobapplication.ErrInvalidStatus)
+	_, err = jobapplication.UpdateStatus(ctx, database, 2, jobID, types.JobApplicationStatusRejected)
+	require.ErrorIs(t, err, jobapplication.ErrNotFound)
+}
@@ -0,0 +1,62 @@
+//go:build integration
+
+package jobapplication_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotes(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+	createTestUser(t, database.DB(), 2)
+	jobID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+	otherJobID, err := jobapplication.Create(ctx, database, 2, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+
+	note, err := jobapplication.AddNote(ctx, database, 1, jobID, "Call recruiter")
+	require.NoError(t, err)
+	assert.False(t, note.UpdatedAt.Valid)
+
+	_, err = jobapplication.UpdateNote(ctx, database, 1, jobID, note.ID, "")
+	require.ErrorIs(t, err, jobapplication.ErrMissingNote)
+	_, err = jobapplication.UpdateNote(ctx, database, 2, jobID, note.ID, "Mine now")
+	require.ErrorIs(t, err, jobapplication.ErrNoteNotFound)
+	_, err = jobapplication.UpdateNote(ctx, database, 2, otherJobID, note.ID, "Mine now")
+	require.ErrorIs(t, err, jobapplication.ErrNoteNotFound)
+
+	updated, err := jobapplication.UpdateNote(ctx, database, 1, jobID, note.ID, "- [x] Call recruiter")
+	require.NoError(t, err)
+	assert.Equal(t, "- [x] Call recruiter", updated.Note)
+	assert.True(t, updated.UpdatedAt.Valid)
+	assert.Equal(t, note.CreatedAt, updated.CreatedAt)
+
+	// Edits are searchable.
+	results, _, err := jobapplication.Search(ctx, database, 1, "recruiter", false, 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+
+	// Edits survive an export.
+	archive, err := jobapplication.Export(ctx, database.Queries(), setupTestStorage(t), 1)
+	require.NoError(t, err)
+	require.Len(t, archive.JobApplications, 1)
+	require.Len(t, archive.JobApplications[0].Notes, 1)
+	assert.NotNil(t, archive.JobApplications[0].Notes[0].UpdatedAt)
+
+	require.ErrorIs(t, jobapplication.DeleteNote(ctx, database, 2, jobID, note.ID), jobapplication.ErrNoteNotFound)
+	require.NoError(t, jobapplication.DeleteNote(ctx, database, 1, jobID, note.ID))
+	require.ErrorIs(t, jobapplication.DeleteNote(ctx, database, 1, jobID, note.ID), jobapplication.ErrNoteNotFound)
+
+	notes, err := database.Queries().GetJobApplicationNotesByJobApplicationIDAndUserID(ctx, queries.GetJobApplicationNotesByJobApplicationIDAndUserIDParams{JobApplicationID: jobID, UserID: 1})
+	require.NoError(t, err)
+	assert.Empty(t, notes)
+}
@@ -0,0 +1,305 @@
+package jobapplication
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+// OfferDeadlineLayout is the layout of the date an offer has to be answered by.
+const OfferDeadlineLayout = "2006-01-02"
+
+const maxVestingYears = 10
+
+var (
+	ErrNotOffered          = errors.New("offers can only be recorded for offered job applications")
+	ErrInvalidOfferAmount  = errors.New("offer amounts cannot be negative")
+	ErrInvalidVesting      = errors.New("vesting must be between 1 and 10 years with a cliff within it")
+	ErrInvalidCurrency     = errors.New("invalid currency")
+	ErrInvalidDeadline     = errors.New("invalid offer deadline")
+	ErrOfferNotFound       = errors.New("offer not found")
+	ErrInvalidExchangeRate = errors.New("exchange rates must be greater than zero")
+)
+
+// NewOffer is the data needed to record the offer of a job application.
+type NewOffer struct {
+	Currency           string
+	BaseSalary         int64
+	Bonus              int64
+	SignOnBonus        int64
+	EquityShares       int64
+	EquityStrikePrice  float64
+	EquitySharePrice   float64
+	VestingYears       int64
+	VestingCliffMonths int64
+	Benefits           string
+	// Deadline is the date the offer has to be answered by, formatted with OfferDeadlineLayout. It
+	// is optional.
+	Deadline string
+}
+
+// deadline validates the offer and returns the date the offer has to be answered by.
+func (o NewOffer) deadline() (sql.NullTime, error) {
+	if _, ok := types.GetCurrency(o.Currency); !ok {
+		return sql.NullTime{}, ErrInvalidCurrency
+	}
+	if o.BaseSalary < 0 || o.Bonus < 0 || o.SignOnBonus < 0 || o.EquityShares < 0 || o.EquityStrikePrice < 0 || o.EquitySharePrice < 0 {
+		return sql.NullTime{}, ErrInvalidOfferAmount
+	}
+	if o.VestingYears < 1 || o.VestingYears > maxVestingYears || o.VestingCliffMonths < 0 || o.VestingCliffMonths > o.VestingYears*12 {
+		return sql.NullTime{}, ErrInvalidVesting
+	}
+	if o.Deadline == "" {
+		return sql.NullTime{}, nil
+	}
+	deadline, err := time.Parse(OfferDeadlineLayout, o.Deadline)
+	if err != nil {
+		return sql.NullTime{}, ErrInvalidDeadline
+	}
+	return sql.NullTime{Time: deadline, Valid: true}, nil
+}
+
+// SaveOffer records the offer of a job application owned by the user that is currently offered,
+// replacing the offer recorded before.
+func SaveOffer(ctx context.Context, database db.Database, userID int64, jobID int64, offer NewOffer) error {
+	deadline, err := offer.deadline()
+	if err != nil {
+		return err
+	}
+
+	job, err := database.Queries().GetJobApplicationByIDAndUserID(ctx, queries.GetJobApplicationByIDAndUserIDParams{ID: jobID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to get job: %w", err)
+	}
+	if types.JobApplicationStatus(job.Status) != types.JobApplicationStatusOffered {
+		return ErrNotOffered
+	}
+
+	err = database.Queries().UpsertJobApplicationOffer(ctx, queries.UpsertJobApplicationOfferParams{
+		JobApplicationID:   jobID,
+		Currency:           offer.Currency,
+		BaseSalary:         offer.BaseSalary,
+		Bonus:              offer.Bonus,
+		SignOnBonus:        offer.SignOnBonus,
+		EquityShares:       offer.EquityShares,
+		EquityStrikePrice:  offer.EquityStrikePrice,
+		EquitySharePrice:   offer.EquitySharePrice,
+		VestingYears:       offer.VestingYears,
+		VestingCliffMonths: offer.VestingCliffMonths,
+		Benefits:           strings.TrimSpace(offer.Benefits),
+		Deadline:           deadline,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save offer: %w", err)
+	}
+	return nil
+}
+
+// DeleteOffer removes the offer of a job application owned by the user.
+func DeleteOffer(ctx context.Context, q *queries.Queries, userID int64, jobID int64) error {
+	deleted, err := q.DeleteJobApplicationOffer(ctx, queries.DeleteJobApplicationOfferParams{JobApplicationID: jobID, UserID: userID})
+	if err != nil {
+		return fmt.Errorf("failed to delete offer: %w", err)
+	}
+	if deleted == 0 {
+		return ErrOfferNotFound
+	}
+	return nil
+}
+
+// GetOffer returns the offer of a job application. The returned bool is false when no offer has
+// been recorded.
+func GetOffer(ctx context.Context, q *queries.Queries, jobID int64) (types.Offer, bool, error) {
+	row, err := q.GetJobApplicationOfferByJobApplicationID(ctx, jobID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return types.Offer{}, false, nil
+		}
+		return types.Offer{}, false, fmt.Errorf("failed to get offer: %w", err)
+	}
+	offer := types.Offer{
+		Currency:           row.Currency,
+		Benefits:           row.Benefits,
+		Deadline:           formatOfferDeadline(row.Deadline),
+		BaseSalary:         row.BaseSalary,
+		Bonus:              row.Bonus,
+		SignOnBonus:        row.SignOnBonus,
+		EquityShares:       row.EquityShares,
+		EquityStrikePrice:  row.EquityStrikePrice,
+		EquitySharePrice:   row.EquitySharePrice,
+		VestingYears:       row.VestingYears,
+		VestingCliffMonths: row.VestingCliffMonths,
+		ID:                 row.ID,
+		JobApplicationID:   row.JobApplicationID,
+	}
+	offer.FirstYear, offer.FourYear = OfferCompensation(offer)
+	return offer, true, nil
+}
+
+func formatOfferDeadline(deadline sql.NullTime) string {
+	if !deadline.Valid {
+		return ""
+	}
+	return deadline.Time.UTC().Format(OfferDeadlineLayout)
+}
+
+// CompareOffers returns the offers of the user's active job applications with their total
+// compensation converted to the currency.
+func CompareOffers(ctx context.Context, q *queries.Queries, userID int64, currency string) (types.OfferComparison, error) {
+	if _, ok := types.GetCurrency(currency); !ok {
+		return types.OfferComparison{}, ErrInvalidCurrency
+	}
+
+	rates, err := GetExchangeRates(ctx, q, userID)
+	if err != nil {
+		return types.OfferComparison{}, err
+	}
+	rows, err := q.GetJobApplicationOffersByUserID(ctx, userID)
+	if err != nil {
+		return types.OfferComparison{}, fmt.Errorf("failed to get offers: %w", err)
+	}
+
+	comparison := types.OfferComparison{
+		Currency: currency,
+		Offers:   make([]types.ComparedOffer, len(rows)),
+		Rates:    rates,
+	}
+	for i, row := range rows {
+		offer := types.Offer{
+			Company:            row.Company,
+			Title:              row.Title,
+			Currency:           row.Currency,
+			Benefits:           row.Benefits,
+			Deadline:           formatOfferDeadline(row.Deadline),
+			Status:             types.JobApplicationStatus(row.Status),
+			BaseSalary:         row.BaseSalary,
+			Bonus:              row.Bonus,
+			SignOnBonus:        row.SignOnBonus,
+			EquityShares:       row.EquityShares,
+			EquityStrikePrice:  row.EquityStrikePrice,
+			EquitySharePrice:   row.EquitySharePrice,
+			VestingYears:       row.VestingYears,
+			VestingCliffMonths: row.VestingCliffMonths,
+			ID:                 row.ID,
+			JobApplicationID:   row.JobApplicationID,
+		}
+		offer.FirstYear, offer.FourYear = OfferCompensation(offer)
+
+		compared := types.ComparedOffer{Offer: offer}
+		compared.ConvertedFirstYear, compared.Converted = ConvertAmount(offer.FirstYear, offer.Currency, currency, rates)
+		compared.ConvertedFourYear, _ = ConvertAmount(offer.FourYear, offer.Currency, currency, rates)
+		comparison.Offers[i] = compared
+	}
+	return comparison, nil
+}
+
+// OfferCompensation returns the total compensation of the first year and of the first four years
+// of an offer. The sign-on bonus is paid once and the equity is valued at the share price less the
+// strike price, vesting monthly once the cliff is reached.
+func OfferCompensation(offer types.Offer) (firstYear int64, fourYear int64) {
+	annual := float64(offer.BaseSalary + offer.Bonus)
+	valuePerShare := math.Max(offer.EquitySharePrice-offer.EquityStrikePrice, 0)
+	equity := func(months int64) float64 {
+		if offer.VestingYears <= 0 || months < offer.VestingCliffMonths {
+			return 0
+		}
+		vestingMonths := offer.VestingYears * 12
+		return float64(offer.EquityShares) * float64(min(months, vestingMonths)) / float64(vestingMonths) * valuePerShare
+	}
+
+	firstYear = int64(math.Round(annual + float64(offer.SignOnBonus) + equity(12)))
+	fourYear = int64(math.Round(4*annual + float64(offer.SignOnBonus) + equity(48)))
+	return firstYear, fourYear
+}
+
+// ConvertAmount converts an amount between currencies using exchange rates relative to the
+// types.BaseCurrency. The returned bool is false when either currency has no rate.
+func ConvertAmount(amount int64, from string, to string, rates []types.ExchangeRate) (int64, bool) {
+	if from == to {
+		return amount, true
+	}
+	rate := func(currency string) (float64, bool) {
+		if currency == types.BaseCurrency {
+			return 1, true
+		}
+		for _, r := range rates {
+			if r.Currency == currency {
+				return r.Rate, true
+			}
+		}
+		return 0, false
+	}
+	fromRate, ok := rate(from)
+	if !ok {
+		return 0, false
+	}
+	toRate, ok := rate(to)
+	if !ok {
+		return 0, false
+	}
+	return int64(math.Round(float64(amount) * fromRate / toRate)), true
+}
+
+// GetExchangeRates returns the exchange rates the user configured.
+func GetExchangeRates(ctx context.Context, q *queries.Queries, userID int64) ([]types.ExchangeRate, error) {
+	rows, err := q.GetExchangeRatesByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exchange rates: %w", err)
+	}
+	rates := make([]types.ExchangeRate, len(rows))
+	for i, row := range rows {
+		rates[i] = types.ExchangeRate{Currency: row.Currency, Rate: row.Rate}
+	}
+	return rates, nil
+}
+
+// SaveExchangeRates sets the exchange rates of the user. A rate of zero removes the rate of the
+// currency.
+func SaveExchangeRates(ctx context.Context, database db.Database, userID int64, rates []types.ExchangeRate) (err error) {
+	for _, r := range rates {
+		if _, ok := types.GetCurrency(r.Currency); !ok || r.Currency == types.BaseCurrency {
+			return ErrInvalidCurrency
+		}
+		if r.Rate < 0 || math.IsNaN(r.Rate) || math.IsInf(r.Rate, 0) {
+			return ErrInvalidExchangeRate
+		}
+	}
+
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	qtx := queries.New(tx)
+	for _, r := range rates {
+		if r.Rate == 0 {
+			err = qtx.DeleteExchangeRate(ctx, queries.DeleteExchangeRateParams{UserID: userID, Currency: r.Currency})
+		} else {
+			err = qtx.UpsertExchangeRate(ctx, queries.UpsertExchangeRateParams{UserID: userID, Currency: r.Currency, Rate: r.Rate})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to save exchange rate: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+//go:build integration
+
+package jobapplication_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOffers(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+	createTestUser(t, database.DB(), 2)
+
+	acmeID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+	globexID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Globex", Title: "Engineer"})
+	require.NoError(t, err)
+
+	acmeOffer := jobapplication.NewOffer{
+		Currency:           "USD",
+		BaseSalary:         150000,
+		Bonus:              15000,
+		SignOnBonus:        10000,
+		EquityShares:       4000,
+		EquitySharePrice:   25,
+		VestingYears:       4,
+		VestingCliffMonths: 12,
+		Benefits:           "  Health insurance  ",
+		Deadline:           "2026-11-01",
+	}
+	require.ErrorIs(t, jobapplication.SaveOffer(ctx, database, 1, acmeID, acmeOffer), jobapplication.ErrNotOffered)
+
+	for _, id := range []int64{acmeID, globexID} {
+		_, err = jobapplication.UpdateStatus(ctx, database, 1, id, types.JobApplicationStatusOffered)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name     string
+		offer    jobapplication.NewOffer
+		expected error
+	}{
+		{name: "invalid currency", offer: jobapplication.NewOffer{Currency: "XYZ", VestingYears: 4}, expected: jobapplication.ErrInvalidCurrency},
+		{name: "negative amount", offer: jobapplication.NewOffer{Currency: "USD", BaseSalary: -1, VestingYears: 4}, expected: jobapplication.ErrInvalidOfferAmount},
+		{name: "no vesting", offer: jobapplication.NewOffer{Currency: "USD"}, expected: jobapplication.ErrInvalidVesting},
+		{name: "cliff after vesting", offer: jobapplication.NewOffer{Currency: "USD", VestingYears: 1, VestingCliffMonths: 13}, expected: jobapplication.ErrInvalidVesting},
+		{name: "invalid deadline", offer: jobapplication.NewOffer{Currency: "USD", VestingYears: 4, Deadline: "11/01/2026"}, expected: jobapplication.ErrInvalidDeadline},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, jobapplication.SaveOffer(ctx, database, 1, acmeID, tt.offer), tt.expected)
+		})
+	}
+	require.ErrorIs(t, jobapplication.SaveOffer(ctx, database, 2, acmeID, acmeOffer), jobapplication.ErrNotFound)
+
+	require.NoError(t, jobapplication.SaveOffer(ctx, database, 1, acmeID, acmeOffer))
+	offer, hasOffer, err := jobapplication.GetOffer(ctx, database.Queries(), acmeID)
+	require.NoError(t, err)
+	require.True(t, hasOffer)
+	assert.Equal(t, "Health insurance", offer.Benefits)
+	assert.Equal(t, "2026-11-01", offer.Deadline)
+	assert.Equal(t, int64(200000), offer.FirstYear)
+	assert.Equal(t, int64(770000), offer.FourYear)
+
+	// Saving again replaces the offer.
+	acmeOffer.BaseSalary = 160000
+	require.NoError(t, jobapplication.SaveOffer(ctx, database, 1, acmeID, acmeOffer))
+	offer, _, err = jobapplication.GetOffer(ctx, database.Queries(), acmeID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(160000), offer.BaseSalary)
+
+	require.NoError(t, jobapplication.SaveOffer(ctx, database, 1, globexID, jobapplication.NewOffer{Currency: "EUR", BaseSalary: 100000, VestingYears: 4}))
+
+	comparison, err := jobapplication.CompareOffers(ctx, database.Queries(), 1, "USD")
+	require.NoError(t, err)
+	require.Len(t, comparison.Offers, 2)
+	assert.Equal(t, "Acme", comparison.Offers[0].Company)
+	assert.True(t, comparison.Offers[0].Converted)
+	assert.False(t, comparison.Offers[1].Converted)
+
+	require.ErrorIs(t, jobapplication.SaveExchangeRates(ctx, database, 1, []types.ExchangeRate{{Currency: "USD", Rate: 1}}), jobapplication.ErrInvalidCurrency)
+	require.ErrorIs(t, jobapplication.SaveExchangeRates(ctx, database, 1, []types.ExchangeRate{{Currency: "EUR", Rate: -1}}), jobapplication.ErrInvalidExchangeRate)
+	require.NoError(t, jobapplication.SaveExchangeRates(ctx, database, 1, []types.ExchangeRate{{Currency: "EUR", Rate: 1.1}, {Currency: "GBP", Rate: 1.25}}))
+
+	comparison, err = jobapplication.CompareOffers(ctx, database.Queries(), 1, "EUR")
+	require.NoError(t, err)
+	assert.Len(t, comparison.Rates, 2)
+	globex := comparison.Offers[1]
+	require.True(t, globex.Converted)
+	assert.Equal(t, int64(100000), globex.ConvertedFirstYear)
+	assert.Equal(t, int64(400000), globex.ConvertedFourYear)
+	assert.Equal(t, int64(190909), comparison.Offers[0].ConvertedFirstYear)
+
+	// A rate of zero removes the rate.
+	require.NoError(t, jobapplication.SaveExchangeRates(ctx, database, 1, []types.ExchangeRate{{Currency: "GBP", Rate: 0}}))
+	rates, err := jobapplication.GetExchangeRates(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, []types.ExchangeRate{{Currency: "EUR", Rate: 1.1}}, rates)
+	rates, err = jobapplication.GetExchangeRates(ctx, database.Queries(), 2)
+	require.NoError(t, err)
+	assert.Empty(t, rates)
+
+	_, err = jobapplication.CompareOffers(ctx, database.Queries(), 1, "XYZ")
+	require.ErrorIs(t, err, jobapplication.ErrInvalidCurrency)
+
+	require.ErrorIs(t, jobapplication.DeleteOffer(ctx, database.Queries(), 2, globexID), jobapplication.ErrOfferNotFound)
+	require.NoError(t, jobapplication.DeleteOffer(ctx, database.Queries(), 1, globexID))
+	_, hasOffer, err = jobapplication.GetOffer(ctx, database.Queries(), globexID)
+	require.NoError(t, err)
+	assert.False(t, hasOffer)
+}
+
+func TestBulkDelete_RestoresOffer(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	createTestUser(t, database.DB(), 1)
+	acmeID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+	_, err = jobapplication.UpdateStatus(ctx, database, 1, acmeID, types.JobApplicationStatusOffered)
+	require.NoError(t, err)
+	require.NoError(t, jobapplication.SaveOffer(ctx, database, 1, acmeID, jobapplication.NewOffer{Currency: "GBP", BaseSalary: 90000, VestingYears: 4, Deadline: "2026-11-01"}))
+
+	result, err := jobapplication.BulkDelete(ctx, database, 1, []int64{acmeID}, now)
+	require.NoError(t, err)
+	var count int
+	require.NoError(t, database.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM job_application_offers").Scan(&count))
+	assert.Zero(t, count)
+
+	_, err = jobapplication.UndoBulkAction(ctx, database, 1, result.ActionID, now)
+	require.NoError(t, err)
+
+	comparison, err := jobapplication.CompareOffers(ctx, database.Queries(), 1, "GBP")
+	require.NoError(t, err)
+	require.Len(t, comparison.Offers, 1)
+	assert.Equal(t, int64(90000), comparison.Offers[0].BaseSalary)
+	assert.Equal(t, "2026-11-01", comparison.Offers[0].Deadline)
+}
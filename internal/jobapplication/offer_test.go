@@ -0,0 +1,90 @@
+package jobapplication_test
+
+import (
+	"testing"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOfferCompensation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name              string
+		offer             types.Offer
+		expectedFirstYear int64
+		expectedFourYear  int64
+	}{
+		{
+			name:              "salary only",
+			offer:             types.Offer{BaseSalary: 100000, VestingYears: 4, VestingCliffMonths: 12},
+			expectedFirstYear: 100000,
+			expectedFourYear:  400000,
+		},
+		{
+			name:              "bonus and sign-on",
+			offer:             types.Offer{BaseSalary: 100000, Bonus: 10000, SignOnBonus: 20000, VestingYears: 4, VestingCliffMonths: 12},
+			expectedFirstYear: 130000,
+			expectedFourYear:  460000,
+		},
+		{
+			name:              "equity vesting over four years",
+			offer:             types.Offer{BaseSalary: 100000, EquityShares: 4000, EquityStrikePrice: 5, EquitySharePrice: 30, VestingYears: 4, VestingCliffMonths: 12},
+			expectedFirstYear: 125000,
+			expectedFourYear:  500000,
+		},
+		{
+			name:              "cliff after the first year",
+			offer:             types.Offer{BaseSalary: 100000, EquityShares: 4000, EquitySharePrice: 10, VestingYears: 4, VestingCliffMonths: 18},
+			expectedFirstYear: 100000,
+			expectedFourYear:  440000,
+		},
+		{
+			name:              "vesting faster than four years",
+			offer:             types.Offer{EquityShares: 2000, EquitySharePrice: 10, VestingYears: 2, VestingCliffMonths: 0},
+			expectedFirstYear: 10000,
+			expectedFourYear:  20000,
+		},
+		{
+			name:              "underwater options",
+			offer:             types.Offer{BaseSalary: 100000, EquityShares: 4000, EquityStrikePrice: 20, EquitySharePrice: 10, VestingYears: 4, VestingCliffMonths: 12},
+			expectedFirstYear: 100000,
+			expectedFourYear:  400000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			firstYear, fourYear := jobapplication.OfferCompensation(tt.offer)
+			assert.Equal(t, tt.expectedFirstYear, firstYear)
+			assert.Equal(t, tt.expectedFourYear, fourYear)
+		})
+	}
+}
+
+func TestConvertAmount(t *testing.T) {
+	t.Parallel()
+	rates := []types.ExchangeRate{{Currency: "EUR", Rate: 1.1}, {Currency: "GBP", Rate: 1.25}}
+	tests := []struct {
+		name      string
+		from      string
+		to        string
+		expected  int64
+		converted bool
+	}{
+		{name: "same currency", from: "JPY", to: "JPY", expected: 1000, converted: true},
+		{name: "to base currency", from: "EUR", to: "USD", expected: 1100, converted: true},
+		{name: "from base currency", from: "USD", to: "GBP", expected: 800, converted: true},
+		{name: "between rates", from: "GBP", to: "EUR", expected: 1136, converted: true},
+		{name: "missing rate", from: "JPY", to: "USD", converted: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			amount, converted := jobapplication.ConvertAmount(1000, tt.from, tt.to, rates)
+			assert.Equal(t, tt.converted, converted)
+			assert.Equal(t, tt.expected, amount)
+		})
+	}
+}
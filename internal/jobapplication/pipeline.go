@@ -0,0 +1,203 @@
+package jobapplication
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+const maxStatusNameLength = 50
+
+var (
+	ErrInvalidStatusName = errors.New("status name must be between 1 and 50 characters")
+	ErrDuplicateStatus   = errors.New("status already exists")
+	ErrStatusNotFound    = errors.New("status not found")
+	ErrStatusInUse       = errors.New("status is used by job applications")
+	ErrStatusRequired    = errors.New("status is required by the application")
+)
+
+// NormalizeStatus trims, lowercases and collapses the whitespace of a status name so that
+// statuses compare equal regardless of how they were typed.
+func NormalizeStatus(name string) types.JobApplicationStatus {
+	return types.JobApplicationStatus(strings.Join(strings.Fields(strings.ToLower(name)), " "))
+}
+
+// SeedPipeline gives a new user the default pipeline.
+func SeedPipeline(ctx context.Context, q *queries.Queries, userID int64) error {
+	for _, s := range types.DefaultPipeline {
+		err := q.InsertDefaultJobApplicationStatus(ctx, queries.InsertDefaultJobApplicationStatusParams{
+			UserID:   userID,
+			Name:     s.Name.String(),
+			Position: s.Position,
+			Terminal: boolToInt64(s.Terminal),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert default status: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetPipeline returns the status pipeline of the user. It only reads, so it is safe on read-only
+// connections.
+func GetPipeline(ctx context.Context, q *queries.Queries, userID int64) (types.Pipeline, error) {
+	rows, err := q.GetJobApplicationStatusesByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statuses: %w", err)
+	}
+
+	pipeline := make(types.Pipeline, len(rows))
+	for i, row := range rows {
+		pipeline[i] = types.PipelineStatus{
+			Name:     types.JobApplicationStatus(row.Name),
+			ID:       row.ID,
+			Position: row.Position,
+			Terminal: row.Terminal == 1,
+		}
+	}
+	return pipeline, nil
+}
+
+// AddPipelineStatus appends a status to the end of the pipeline of the user.
+func AddPipelineStatus(ctx context.Context, q *queries.Queries, userID int64, name string, terminal bool) (types.PipelineStatus, error) {
+	status := NormalizeStatus(name)
+	if status == "" || utf8.RuneCountInString(status.String()) > maxStatusNameLength {
+		return types.PipelineStatus{}, ErrInvalidStatusName
+	}
+
+	pipeline, err := GetPipeline(ctx, q, userID)
+	if err != nil {
+		return types.PipelineStatus{}, err
+	}
+	if pipeline.Contains(status) {
+		return types.PipelineStatus{}, ErrDuplicateStatus
+	}
+
+	id, err := q.InsertJobApplicationStatus(ctx, queries.InsertJobApplicationStatusParams{
+		UserID:   userID,
+		Name:     status.String(),
+		Terminal: boolToInt64(terminal),
+	})
+	if err != nil {
+		return types.PipelineStatus{}, fmt.Errorf("failed to insert status: %w", err)
+	}
+	return types.PipelineStatus{Name: status, ID: id, Terminal: terminal}, nil
+}
+
+// MovePipelineStatus swaps a status with the status before it, or after it when up is false. Moving
+// the first status up or the last status down does nothing.
+func MovePipelineStatus(ctx context.Context, database db.Database, userID int64, statusID int64, up bool) (err error) {
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	qtx := queries.New(tx)
+	pipeline, err := GetPipeline(ctx, qtx, userID)
+	if err != nil {
+		return err
+	}
+
+	index := -1
+	for i, s := range pipeline {
+		if s.ID == statusID {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return ErrStatusNotFound
+	}
+
+	other := index + 1
+	if up {
+		other = index - 1
+	}
+	if other < 0 || other >= len(pipeline) {
+		return nil
+	}
+
+	// Every position is rewritten so that gaps and duplicate positions are fixed up as well.
+	pipeline[index], pipeline[other] = pipeline[other], pipeline[index]
+	for i, s := range pipeline {
+		err = qtx.UpdateJobApplicationStatusPosition(ctx, queries.UpdateJobApplicationStatusPositionParams{
+			Position: int64(i + 1),
+			ID:       s.ID,
+			UserID:   userID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update status position: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// SetPipelineStatusTerminal marks whether job applications end at the status.
+func SetPipelineStatusTerminal(ctx context.Context, q *queries.Queries, userID int64, statusID int64, terminal bool) error {
+	count, err := q.UpdateJobApplicationStatusTerminal(ctx, queries.UpdateJobApplicationStatusTerminalParams{
+		Terminal: boolToInt64(terminal),
+		ID:       statusID,
+		UserID:   userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+	if count == 0 {
+		return ErrStatusNotFound
+	}
+	return nil
+}
+
+// DeletePipelineStatus removes a status from the pipeline of the user. Statuses still used by job
+// applications, including archived ones, cannot be deleted, and neither can the applied status that
+// new job applications start at.
+func DeletePipelineStatus(ctx context.Context, q *queries.Queries, userID int64, statusID int64) error {
+	pipeline, err := GetPipeline(ctx, q, userID)
+	if err != nil {
+		return err
+	}
+
+	status, ok := pipeline.GetByID(statusID)
+	if !ok {
+		return ErrStatusNotFound
+	}
+	if status.Name == types.JobApplicationStatusApplied {
+		return ErrStatusRequired
+	}
+
+	count, err := q.CountJobApplicationsByStatus(ctx, queries.CountJobApplicationsByStatusParams{UserID: userID, Status: status.Name.String()})
+	if err != nil {
+		return fmt.Errorf("failed to count job applications: %w", err)
+	}
+	if count > 0 {
+		return ErrStatusInUse
+	}
+
+	if err = q.DeleteJobApplicationStatus(ctx, queries.DeleteJobApplicationStatusParams{ID: statusID, UserID: userID}); err != nil {
+		return fmt.Errorf("failed to delete status: %w", err)
+	}
+	return nil
+}
+
+func boolToInt64(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
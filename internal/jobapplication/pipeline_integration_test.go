@@ -0,0 +1,147 @@
+//go:build integration
+
+package jobapplication_test
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/testutil"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipeline(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+	createTestUser(t, database.DB(), 2)
+
+	pipeline, err := jobapplication.GetPipeline(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	require.Len(t, pipeline, len(types.DefaultPipeline))
+	for i, s := range types.DefaultPipeline {
+		assert.Equal(t, s.Name, pipeline[i].Name)
+		assert.Equal(t, s.Terminal, pipeline[i].Terminal)
+	}
+
+	_, err = jobapplication.AddPipelineStatus(ctx, database.Queries(), 1, "   ", false)
+	require.ErrorIs(t, err, jobapplication.ErrInvalidStatusName)
+	_, err = jobapplication.AddPipelineStatus(ctx, database.Queries(), 1, "Applied", false)
+	require.ErrorIs(t, err, jobapplication.ErrDuplicateStatus)
+
+	screen, err := jobapplication.AddPipelineStatus(ctx, database.Queries(), 1, "  Recruiter   Screen ", false)
+	require.NoError(t, err)
+	assert.Equal(t, types.JobApplicationStatus("recruiter screen"), screen.Name)
+
+	require.NoError(t, jobapplication.MovePipelineStatus(ctx, database, 1, screen.ID, true))
+	pipeline, err = jobapplication.GetPipeline(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, screen.Name, pipeline[len(pipeline)-2].Name)
+	assert.Equal(t, types.JobApplicationStatusClosed, pipeline[len(pipeline)-1].Name)
+	require.ErrorIs(t, jobapplication.MovePipelineStatus(ctx, database, 2, screen.ID, true), jobapplication.ErrStatusNotFound)
+
+	require.NoError(t, jobapplication.SetPipelineStatusTerminal(ctx, database.Queries(), 1, screen.ID, true))
+	require.ErrorIs(t, jobapplication.SetPipelineStatusTerminal(ctx, database.Queries(), 2, screen.ID, true), jobapplication.ErrStatusNotFound)
+	pipeline, err = jobapplication.GetPipeline(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	status, ok := pipeline.Get(screen.Name)
+	require.True(t, ok)
+	assert.True(t, status.Terminal)
+
+	jobID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+	_, err = jobapplication.Update(ctx, database, 1, jobapplication.UpdatedJobApplication{ID: jobID, Company: "Acme", Title: "Engineer", Status: "take-home"})
+	require.ErrorIs(t, err, jobapplication.ErrInvalidStatus)
+	result, err := jobapplication.Update(ctx, database, 1, jobapplication.UpdatedJobApplication{ID: jobID, Company: "Acme", Title: "Engineer", Status: screen.Name})
+	require.NoError(t, err)
+	assert.True(t, result.StatusChanged)
+	assert.True(t, result.StatsChanged)
+
+	// The other user has not added the status to their pipeline.
+	otherJobID, err := jobapplication.Create(ctx, database, 2, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+	_, err = jobapplication.Update(ctx, database, 2, jobapplication.UpdatedJobApplication{ID: otherJobID, Company: "Acme", Title: "Engineer", Status: screen.Name})
+	require.ErrorIs(t, err, jobapplication.ErrInvalidStatus)
+
+	require.ErrorIs(t, jobapplication.DeletePipelineStatus(ctx, database.Queries(), 1, screen.ID), jobapplication.ErrStatusInUse)
+	applied, ok := pipeline.Get(types.JobApplicationStatusApplied)
+	require.True(t, ok)
+	require.ErrorIs(t, jobapplication.DeletePipelineStatus(ctx, database.Queries(), 1, applied.ID), jobapplication.ErrStatusRequired)
+	require.ErrorIs(t, jobapplication.DeletePipelineStatus(ctx, database.Queries(), 2, screen.ID), jobapplication.ErrStatusNotFound)
+
+	_, err = jobapplication.Update(ctx, database, 1, jobapplication.UpdatedJobApplication{ID: jobID, Company: "Acme", Title: "Engineer", Status: types.JobApplicationStatusRejected})
+	require.NoError(t, err)
+	require.NoError(t, jobapplication.DeletePipelineStatus(ctx, database.Queries(), 1, screen.ID))
+	pipeline, err = jobapplication.GetPipeline(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	assert.False(t, pipeline.Contains(screen.Name))
+	assert.Len(t, pipeline, len(types.DefaultPipeline))
+}
+
+func TestPipeline_Archive(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+	createTestUser(t, database.DB(), 2)
+
+	screen, err := jobapplication.AddPipelineStatus(ctx, database.Queries(), 1, "Recruiter Screen", false)
+	require.NoError(t, err)
+	jobID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+	_, err = jobapplication.Update(ctx, database, 1, jobapplication.UpdatedJobApplication{ID: jobID, Company: "Acme", Title: "Engineer", Status: screen.Name})
+	require.NoError(t, err)
+
+	archive, err := jobapplication.Export(ctx, database.Queries(), setupTestStorage(t), 1)
+	require.NoError(t, err)
+	require.Len(t, archive.Pipeline, len(types.DefaultPipeline)+1)
+	assert.Equal(t, jobapplication.ArchivePipelineStatus{Name: "recruiter screen"}, archive.Pipeline[len(archive.Pipeline)-1])
+	assert.Empty(t, archive.Validate())
+
+	result, err := jobapplication.ImportArchive(ctx, database, setupTestStorage(t), 2, archive)
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, 1, result.Imported)
+
+	pipeline, err := jobapplication.GetPipeline(ctx, database.Queries(), 2)
+	require.NoError(t, err)
+	assert.True(t, pipeline.Contains(screen.Name))
+
+	archive.Pipeline = nil
+	assert.Len(t, archive.Validate(), 2)
+}
+
+func TestGetPipeline_ReadOnly(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "integration-test.sqlite3")
+	database, err := db.New(slog.New(slog.DiscardHandler), db.DatabaseOpts{URL: dbFile})
+	require.NoError(t, err)
+	require.NoError(t, testutil.RunMigrations(dbFile))
+	createTestUser(t, database.DB(), 1)
+	_, err = database.DB().ExecContext(context.Background(), "INSERT INTO users (id, email, password) VALUES (2, 'unseeded@example.com', 'password')")
+	require.NoError(t, err)
+	require.NoError(t, database.Close())
+
+	// The MCP server reads pipelines over a read-only connection.
+	readOnly, err := sql.Open("libsql", "file:"+dbFile+"?mode=ro")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = readOnly.Close() })
+	_, err = readOnly.ExecContext(context.Background(), "DELETE FROM job_application_statuses")
+	require.Error(t, err)
+
+	pipeline, err := jobapplication.GetPipeline(context.Background(), queries.New(readOnly), 1)
+	require.NoError(t, err)
+	assert.Len(t, pipeline, len(types.DefaultPipeline))
+	// Reading never seeds a pipeline, even for a user without one.
+	pipeline, err = jobapplication.GetPipeline(context.Background(), queries.New(readOnly), 2)
+	require.NoError(t, err)
+	assert.Empty(t, pipeline)
+}
@@ -0,0 +1,102 @@
+package jobapplication
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+var (
+	ErrInvalidReminderDate = errors.New("reminder date cannot be in the past")
+	ErrReminderNotFound    = errors.New("reminder not found")
+)
+
+// AddReminder schedules a follow-up reminder for a job application owned by the user. Reminders are
+// scheduled for the start of the day of remindAt.
+func AddReminder(ctx context.Context, database db.Database, userID int64, jobID int64, remindAt time.Time, note string, now time.Time) (queries.InsertJobApplicationReminderRow, error) {
+	remindAt = startOfDay(remindAt)
+	if remindAt.Before(startOfDay(now)) {
+		return queries.InsertJobApplicationReminderRow{}, ErrInvalidReminderDate
+	}
+
+	if _, err := database.Queries().GetJobApplicationByIDAndUserID(ctx, queries.GetJobApplicationByIDAndUserIDParams{ID: jobID, UserID: userID}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return queries.InsertJobApplicationReminderRow{}, ErrNotFound
+		}
+		return queries.InsertJobApplicationReminderRow{}, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	reminder, err := database.Queries().InsertJobApplicationReminder(ctx, queries.InsertJobApplicationReminderParams{
+		JobApplicationID: jobID,
+		RemindAt:         remindAt,
+		Note:             note,
+	})
+	if err != nil {
+		return queries.InsertJobApplicationReminderRow{}, fmt.Errorf("failed to insert reminder: %w", err)
+	}
+	return reminder, nil
+}
+
+// SnoozeReminder pushes an open reminder back to the given number of days from now.
+func SnoozeReminder(ctx context.Context, database db.Database, userID int64, reminderID int64, days int, now time.Time) error {
+	if days < 1 {
+		return ErrInvalidReminderDate
+	}
+	count, err := database.Queries().SnoozeJobApplicationReminder(ctx, queries.SnoozeJobApplicationReminderParams{
+		RemindAt: startOfDay(now).AddDate(0, 0, days),
+		ID:       reminderID,
+		UserID:   userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to snooze reminder: %w", err)
+	}
+	if count == 0 {
+		return ErrReminderNotFound
+	}
+	return nil
+}
+
+// DismissReminder marks an open reminder as done.
+func DismissReminder(ctx context.Context, database db.Database, userID int64, reminderID int64) error {
+	count, err := database.Queries().DismissJobApplicationReminder(ctx, queries.DismissJobApplicationReminderParams{ID: reminderID, UserID: userID})
+	if err != nil {
+		return fmt.Errorf("failed to dismiss reminder: %w", err)
+	}
+	if count == 0 {
+		return ErrReminderNotFound
+	}
+	return nil
+}
+
+// GetDueReminders returns the open reminders of the user that are due on the day of now or are
+// overdue.
+func GetDueReminders(ctx context.Context, q *queries.Queries, userID int64, now time.Time) ([]queries.GetDueJobApplicationRemindersByUserIDRow, error) {
+	reminders, err := q.GetDueJobApplicationRemindersByUserID(ctx, queries.GetDueJobApplicationRemindersByUserIDParams{
+		UserID:    userID,
+		DueBefore: startOfDay(now).AddDate(0, 0, 1),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due reminders: %w", err)
+	}
+	return reminders, nil
+}
+
+// clearsReminders reports whether moving to the status means the user has heard back, so
+// follow-up reminders are no longer needed. That is any stage after applied in the pipeline of the
+// user.
+func clearsReminders(pipeline types.Pipeline, status types.JobApplicationStatus) bool {
+	applied := slices.IndexFunc(pipeline, func(s types.PipelineStatus) bool { return s.Name == types.JobApplicationStatusApplied })
+	current := slices.IndexFunc(pipeline, func(s types.PipelineStatus) bool { return s.Name == status })
+	return applied >= 0 && current > applied
+}
+
+func startOfDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,152 @@
+//go:build integration
+
+package jobapplication_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReminders(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	createTestUser(t, database.DB(), 1)
+	createTestUser(t, database.DB(), 2)
+	jobID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+
+	_, err = jobapplication.AddReminder(ctx, database, 1, jobID, now.AddDate(0, 0, -1), "", now)
+	require.ErrorIs(t, err, jobapplication.ErrInvalidReminderDate)
+	_, err = jobapplication.AddReminder(ctx, database, 2, jobID, now, "", now)
+	require.ErrorIs(t, err, jobapplication.ErrNotFound)
+
+	today, err := jobapplication.AddReminder(ctx, database, 1, jobID, now, "email the recruiter", now)
+	require.NoError(t, err)
+	nextWeek, err := jobapplication.AddReminder(ctx, database, 1, jobID, now.AddDate(0, 0, 7), "", now)
+	require.NoError(t, err)
+
+	due, err := jobapplication.GetDueReminders(ctx, database.Queries(), 1, now)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, today.ID, due[0].ID)
+	assert.Equal(t, "email the recruiter", due[0].Note)
+
+	// Overdue reminders stay due until they are handled.
+	due, err = jobapplication.GetDueReminders(ctx, database.Queries(), 1, now.AddDate(0, 0, 3))
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+
+	require.ErrorIs(t, jobapplication.SnoozeReminder(ctx, database, 2, today.ID, 1, now), jobapplication.ErrReminderNotFound)
+	require.NoError(t, jobapplication.SnoozeReminder(ctx, database, 1, today.ID, 1, now))
+	due, err = jobapplication.GetDueReminders(ctx, database.Queries(), 1, now)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+	due, err = jobapplication.GetDueReminders(ctx, database.Queries(), 1, now.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Len(t, due, 1)
+
+	require.NoError(t, jobapplication.DismissReminder(ctx, database, 1, today.ID))
+	require.ErrorIs(t, jobapplication.DismissReminder(ctx, database, 1, today.ID), jobapplication.ErrReminderNotFound)
+
+	reminders, err := database.Queries().GetJobApplicationRemindersByJobApplicationID(ctx, jobID)
+	require.NoError(t, err)
+	require.Len(t, reminders, 2)
+	assert.True(t, reminders[0].DismissedAt.Valid)
+	assert.Equal(t, nextWeek.ID, reminders[1].ID)
+	assert.False(t, reminders[1].DismissedAt.Valid)
+}
+
+func TestReminders_ClearedWhenStatusMovesPastApplied(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          types.JobApplicationStatus
+		expectedCleared bool
+	}{
+		{
+			name:            "interviewing",
+			status:          types.JobApplicationStatusInterviewing,
+			expectedCleared: true,
+		},
+		{
+			name:            "rejected",
+			status:          types.JobApplicationStatusRejected,
+			expectedCleared: true,
+		},
+		{
+			name:            "watching",
+			status:          types.JobApplicationStatusWatching,
+			expectedCleared: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := setupTestDB(t)
+			ctx := context.Background()
+			now := time.Now()
+
+			createTestUser(t, database.DB(), 1)
+			jobID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+			require.NoError(t, err)
+			_, err = jobapplication.AddReminder(ctx, database, 1, jobID, now, "", now)
+			require.NoError(t, err)
+
+			result, err := jobapplication.Update(ctx, database, 1, jobapplication.UpdatedJobApplication{
+				ID:      jobID,
+				Company: "Acme",
+				Title:   "Engineer",
+				Status:  tt.status,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCleared, result.RemindersCleared)
+
+			due, err := jobapplication.GetDueReminders(ctx, database.Queries(), 1, now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCleared, len(due) == 0)
+		})
+	}
+}
+
+func TestReminders_KeptForStageBeforeApplied(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	createTestUser(t, database.DB(), 1)
+	jobID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+	_, err = jobapplication.AddReminder(ctx, database, 1, jobID, now, "", now)
+	require.NoError(t, err)
+
+	// Move a new stage up to just before applied.
+	screening, err := jobapplication.AddPipelineStatus(ctx, database.Queries(), 1, "Screening", false)
+	require.NoError(t, err)
+	for range len(types.DefaultPipeline) - 1 {
+		require.NoError(t, jobapplication.MovePipelineStatus(ctx, database, 1, screening.ID, true))
+	}
+	pipeline, err := jobapplication.GetPipeline(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	require.Equal(t, screening.Name, pipeline[1].Name)
+	require.Equal(t, types.JobApplicationStatusApplied, pipeline[2].Name)
+
+	result, err := jobapplication.Update(ctx, database, 1, jobapplication.UpdatedJobApplication{
+		ID:      jobID,
+		Company: "Acme",
+		Title:   "Engineer",
+		Status:  screening.Name,
+	})
+	require.NoError(t, err)
+	assert.False(t, result.RemindersCleared)
+
+	due, err := jobapplication.GetDueReminders(ctx, database.Queries(), 1, now)
+	require.NoError(t, err)
+	assert.Len(t, due, 1)
+}
@@ -0,0 +1,246 @@
+package jobapplication
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/storage"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/google/uuid"
+)
+
+const maxResumeLabelLength = 50
+
+var (
+	ErrInvalidResumeLabel = errors.New("resume label must be between 1 and 50 characters")
+	ErrDuplicateResume    = errors.New("resume version already exists")
+	ErrResumeNotFound     = errors.New("resume version not found")
+)
+
+// ResumeFile is the file uploaded for a resume version.
+type ResumeFile struct {
+	Name    string
+	Size    int64
+	Content io.Reader
+}
+
+// GetResumeVersions returns the resume versions of the user ordered by label.
+func GetResumeVersions(ctx context.Context, q *queries.Queries, userID int64) ([]types.ResumeVersion, error) {
+	rows, err := q.GetResumeVersionsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resume versions: %w", err)
+	}
+	versions := make([]types.ResumeVersion, len(rows))
+	for i, row := range rows {
+		versions[i] = toResumeVersion(row)
+	}
+	return versions, nil
+}
+
+// AddResumeVersion creates a resume version of the user, storing the file when there is one. The
+// file is checked like an attachment. Labels are unique per user regardless of case.
+func AddResumeVersion(ctx context.Context, q *queries.Queries, store storage.Storage, userID int64, label string, file *ResumeFile) (types.ResumeVersion, error) {
+	label = strings.Join(strings.Fields(label), " ")
+	if label == "" || utf8.RuneCountInString(label) > maxResumeLabelLength {
+		return types.ResumeVersion{}, ErrInvalidResumeLabel
+	}
+	if _, err := q.GetResumeVersionByLabelAndUserID(ctx, queries.GetResumeVersionByLabelAndUserIDParams{Label: label, UserID: userID}); err == nil {
+		return types.ResumeVersion{}, ErrDuplicateResume
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return types.ResumeVersion{}, fmt.Errorf("failed to check resume label: %w", err)
+	}
+
+	params := queries.InsertResumeVersionParams{UserID: userID, Label: label}
+	if file != nil {
+		name, contentType, err := validateAttachment(file.Name, types.AttachmentKindResume, file.Size)
+		if err != nil {
+			return types.ResumeVersion{}, err
+		}
+		content, err := checkAttachmentContent(name, file.Content)
+		if err != nil {
+			return types.ResumeVersion{}, err
+		}
+		params.FileName = sql.NullString{String: name, Valid: true}
+		params.ContentType = sql.NullString{String: contentType, Valid: true}
+		params.Size = sql.NullInt64{Int64: file.Size, Valid: true}
+		params.StorageKey = sql.NullString{String: "resumes/" + uuid.NewString(), Valid: true}
+		if err = store.Put(ctx, params.StorageKey.String, content, file.Size, contentType); err != nil {
+			return types.ResumeVersion{}, fmt.Errorf("failed to store resume: %w", err)
+		}
+	}
+
+	row, err := q.InsertResumeVersion(ctx, params)
+	if err != nil {
+		err = fmt.Errorf("failed to insert resume version: %w", err)
+		if params.StorageKey.Valid {
+			if deleteErr := store.Delete(ctx, params.StorageKey.String); deleteErr != nil {
+				err = errors.Join(err, fmt.Errorf("failed to delete stored resume: %w", deleteErr))
+			}
+		}
+		return types.ResumeVersion{}, err
+	}
+	return toResumeVersion(row), nil
+}
+
+// OpenResumeVersion returns a resume version of the user with the content of its file. The content
+// must be closed.
+func OpenResumeVersion(ctx context.Context, q *queries.Queries, store storage.Storage, userID int64, versionID int64) (queries.ResumeVersion, io.ReadCloser, error) {
+	v, err := getResumeVersion(ctx, q, userID, versionID)
+	if err != nil {
+		return queries.ResumeVersion{}, nil, err
+	}
+	if !v.StorageKey.Valid {
+		return queries.ResumeVersion{}, nil, ErrResumeNotFound
+	}
+	content, err := store.Get(ctx, v.StorageKey.String)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return queries.ResumeVersion{}, nil, ErrResumeNotFound
+		}
+		return queries.ResumeVersion{}, nil, fmt.Errorf("failed to get stored resume: %w", err)
+	}
+	return v, content, nil
+}
+
+// DeleteResumeVersion deletes a resume version of the user along with its file. Job applications
+// sent with the version no longer have a version.
+func DeleteResumeVersion(ctx context.Context, database db.Database, store storage.Storage, userID int64, versionID int64) (err error) {
+	v, err := getResumeVersion(ctx, database.Queries(), userID, versionID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	qtx := queries.New(tx)
+	deleted, err := qtx.DeleteResumeVersion(ctx, queries.DeleteResumeVersionParams{ID: v.ID, UserID: userID})
+	if err != nil {
+		return fmt.Errorf("failed to delete resume version: %w", err)
+	}
+	if deleted == 0 {
+		return ErrResumeNotFound
+	}
+	if err = qtx.ClearJobApplicationResumeVersions(ctx, sql.NullInt64{Int64: v.ID, Valid: true}); err != nil {
+		return fmt.Errorf("failed to remove resume version from job applications: %w", err)
+	}
+	// Like attachments, the file is deleted before the version is committed as deleted, so a version
+	// is never left without its file.
+	if v.StorageKey.Valid {
+		if err = store.Delete(ctx, v.StorageKey.String); err != nil {
+			return fmt.Errorf("failed to delete stored resume: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// DeleteAllResumeVersions deletes every resume version of the user along with their files.
+func DeleteAllResumeVersions(ctx context.Context, q *queries.Queries, store storage.Storage, userID int64) error {
+	keys, err := q.GetResumeVersionStorageKeysByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get resume versions: %w", err)
+	}
+	for _, key := range keys {
+		if err = store.Delete(ctx, key.String); err != nil {
+			return fmt.Errorf("failed to delete stored resume: %w", err)
+		}
+	}
+	if err = q.DeleteResumeVersionsByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete resume versions: %w", err)
+	}
+	return nil
+}
+
+// GetJobApplicationResumeVersion returns the ID of the resume version a job application of the user
+// was sent with, or 0 when it has none.
+func GetJobApplicationResumeVersion(ctx context.Context, q *queries.Queries, userID int64, jobID int64) (int64, error) {
+	id, err := q.GetJobApplicationResumeVersionID(ctx, queries.GetJobApplicationResumeVersionIDParams{ID: jobID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrNotFound
+		}
+		return 0, fmt.Errorf("failed to get resume version of job: %w", err)
+	}
+	return id.Int64, nil
+}
+
+// SetJobApplicationResumeVersion records the resume version a job application of the user was sent
+// with. A version ID of 0 removes the version.
+func SetJobApplicationResumeVersion(ctx context.Context, q *queries.Queries, userID int64, jobID int64, versionID int64) error {
+	var id sql.NullInt64
+	if versionID != 0 {
+		if _, err := getResumeVersion(ctx, q, userID, versionID); err != nil {
+			return err
+		}
+		id = sql.NullInt64{Int64: versionID, Valid: true}
+	}
+	updated, err := q.SetJobApplicationResumeVersion(ctx, queries.SetJobApplicationResumeVersionParams{ResumeVersionID: id, ID: jobID, UserID: userID})
+	if err != nil {
+		return fmt.Errorf("failed to set resume version of job: %w", err)
+	}
+	if updated == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// GetResumeVersionAnalytics returns how the active job applications sent with each resume version of
+// the user fared, ordered by label. Only job applications that reached applied are counted, and a
+// response is any status they moved on to afterwards, the same as the flow from applied in the
+// status graph.
+func GetResumeVersionAnalytics(ctx context.Context, q *queries.Queries, userID int64) ([]types.ResumeVersionStats, error) {
+	rows, err := q.GetResumeVersionResponseCounts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resume version responses: %w", err)
+	}
+	stats := make([]types.ResumeVersionStats, len(rows))
+	for i, row := range rows {
+		stats[i] = types.ResumeVersionStats{
+			Label:       row.Label,
+			ID:          row.ID,
+			Applied:     row.AppliedCount,
+			Responded:   row.RespondedCount,
+			Interviewed: row.InterviewedCount,
+		}
+	}
+	return stats, nil
+}
+
+func getResumeVersion(ctx context.Context, q *queries.Queries, userID int64, versionID int64) (queries.ResumeVersion, error) {
+	v, err := q.GetResumeVersionByIDAndUserID(ctx, queries.GetResumeVersionByIDAndUserIDParams{ID: versionID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return queries.ResumeVersion{}, ErrResumeNotFound
+		}
+		return queries.ResumeVersion{}, fmt.Errorf("failed to get resume version: %w", err)
+	}
+	return v, nil
+}
+
+func toResumeVersion(row queries.ResumeVersion) types.ResumeVersion {
+	return types.ResumeVersion{
+		CreatedAt: row.CreatedAt,
+		Label:     row.Label,
+		FileName:  row.FileName.String,
+		Size:      row.Size.Int64,
+		ID:        row.ID,
+	}
+}
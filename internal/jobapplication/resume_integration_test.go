@@ -0,0 +1,165 @@
+//go:build integration
+
+package jobapplication_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/storage"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func resumeFile(name string, content string) *jobapplication.ResumeFile {
+	return &jobapplication.ResumeFile{Name: name, Size: int64(len(content)), Content: strings.NewReader(content)}
+}
+
+func TestResumeVersions(t *testing.T) {
+	database := setupTestDB(t)
+	store := setupTestStorage(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+	createTestUser(t, database.DB(), 2)
+
+	backend, err := jobapplication.AddResumeVersion(ctx, database.Queries(), store, 1, "  Backend   focus ", resumeFile("backend.pdf", testPDF))
+	require.NoError(t, err)
+	assert.Equal(t, "Backend focus", backend.Label)
+	assert.Equal(t, "backend.pdf", backend.FileName)
+	assert.Equal(t, int64(len(testPDF)), backend.Size)
+	general, err := jobapplication.AddResumeVersion(ctx, database.Queries(), store, 1, "General", nil)
+	require.NoError(t, err)
+	assert.False(t, general.HasFile())
+
+	_, err = jobapplication.AddResumeVersion(ctx, database.Queries(), store, 1, "backend FOCUS", nil)
+	require.ErrorIs(t, err, jobapplication.ErrDuplicateResume)
+	_, err = jobapplication.AddResumeVersion(ctx, database.Queries(), store, 1, " ", nil)
+	require.ErrorIs(t, err, jobapplication.ErrInvalidResumeLabel)
+	_, err = jobapplication.AddResumeVersion(ctx, database.Queries(), store, 1, "Frontend", resumeFile("frontend.pdf", "<html></html>"))
+	require.ErrorIs(t, err, jobapplication.ErrAttachmentType)
+	_, err = jobapplication.AddResumeVersion(ctx, database.Queries(), store, 2, "General", nil)
+	require.NoError(t, err)
+
+	versions, err := jobapplication.GetResumeVersions(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, "Backend focus", versions[0].Label)
+	assert.Equal(t, "General", versions[1].Label)
+
+	v, content, err := jobapplication.OpenResumeVersion(ctx, database.Queries(), store, 1, backend.ID)
+	require.NoError(t, err)
+	b, err := io.ReadAll(content)
+	require.NoError(t, err)
+	require.NoError(t, content.Close())
+	assert.Equal(t, "application/pdf", v.ContentType.String)
+	assert.Equal(t, testPDF, string(b))
+	_, _, err = jobapplication.OpenResumeVersion(ctx, database.Queries(), store, 2, backend.ID)
+	require.ErrorIs(t, err, jobapplication.ErrResumeNotFound)
+	_, _, err = jobapplication.OpenResumeVersion(ctx, database.Queries(), store, 1, general.ID)
+	require.ErrorIs(t, err, jobapplication.ErrResumeNotFound)
+
+	jobID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+	require.NoError(t, jobapplication.SetJobApplicationResumeVersion(ctx, database.Queries(), 1, jobID, backend.ID))
+	selected, err := jobapplication.GetJobApplicationResumeVersion(ctx, database.Queries(), 1, jobID)
+	require.NoError(t, err)
+	assert.Equal(t, backend.ID, selected)
+	require.ErrorIs(t, jobapplication.SetJobApplicationResumeVersion(ctx, database.Queries(), 2, jobID, 0), jobapplication.ErrNotFound)
+	other, err := jobapplication.GetResumeVersions(ctx, database.Queries(), 2)
+	require.NoError(t, err)
+	require.ErrorIs(t, jobapplication.SetJobApplicationResumeVersion(ctx, database.Queries(), 1, jobID, other[0].ID), jobapplication.ErrResumeNotFound)
+
+	// Deleting a version removes it from the job applications sent with it.
+	require.ErrorIs(t, jobapplication.DeleteResumeVersion(ctx, database, store, 2, backend.ID), jobapplication.ErrResumeNotFound)
+	keys, err := database.Queries().GetResumeVersionStorageKeysByUserID(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.NoError(t, jobapplication.DeleteResumeVersion(ctx, database, store, 1, backend.ID))
+	_, err = store.Get(ctx, keys[0].String)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+	selected, err = jobapplication.GetJobApplicationResumeVersion(ctx, database.Queries(), 1, jobID)
+	require.NoError(t, err)
+	assert.Zero(t, selected)
+
+	require.NoError(t, jobapplication.DeleteAllResumeVersions(ctx, database.Queries(), store, 1))
+	versions, err = jobapplication.GetResumeVersions(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	assert.Empty(t, versions)
+}
+
+func TestGetResumeVersionAnalytics(t *testing.T) {
+	database := setupTestDB(t)
+	store := setupTestStorage(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+	backend, err := jobapplication.AddResumeVersion(ctx, database.Queries(), store, 1, "Backend", nil)
+	require.NoError(t, err)
+	general, err := jobapplication.AddResumeVersion(ctx, database.Queries(), store, 1, "General", nil)
+	require.NoError(t, err)
+	_, err = jobapplication.AddResumeVersion(ctx, database.Queries(), store, 1, "Unused", nil)
+	require.NoError(t, err)
+
+	apply := func(company string, versionID int64, statuses ...types.JobApplicationStatus) int64 {
+		id, createErr := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: company, Title: "Engineer"})
+		require.NoError(t, createErr)
+		if versionID != 0 {
+			require.NoError(t, jobapplication.SetJobApplicationResumeVersion(ctx, database.Queries(), 1, id, versionID))
+		}
+		for _, status := range statuses {
+			_, updateErr := jobapplication.UpdateStatus(ctx, database, 1, id, status)
+			require.NoError(t, updateErr)
+		}
+		return id
+	}
+	apply("Acme", backend.ID, types.JobApplicationStatusInterviewing, types.JobApplicationStatusRejected)
+	apply("Globex", backend.ID, types.JobApplicationStatusRejected)
+	apply("Initech", backend.ID)
+	apply("Hooli", backend.ID, types.JobApplicationStatusInterviewing)
+	apply("Umbrella", general.ID)
+	apply("Stark", general.ID, types.JobApplicationStatusRejected)
+	apply("Wayne", 0, types.JobApplicationStatusInterviewing)
+	archivedID := apply("Wonka", general.ID, types.JobApplicationStatusInterviewing)
+	_, err = database.DB().ExecContext(ctx, "UPDATE job_applications SET archived = 1 WHERE id = ?", archivedID)
+	require.NoError(t, err)
+
+	stats, err := jobapplication.GetResumeVersionAnalytics(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	require.Len(t, stats, 3)
+	assert.Equal(t, types.ResumeVersionStats{Label: "Backend", ID: backend.ID, Applied: 4, Responded: 3, Interviewed: 2}, stats[0])
+	assert.Equal(t, "75%", stats[0].ResponseRate())
+	assert.Equal(t, "50%", stats[0].InterviewRate())
+	assert.Equal(t, types.ResumeVersionStats{Label: "General", ID: general.ID, Applied: 2, Responded: 1}, stats[1])
+	assert.Equal(t, "Unused", stats[2].Label)
+	assert.Zero(t, stats[2].Applied)
+	assert.Equal(t, "—", stats[2].ResponseRate())
+}
+
+func TestResumeVersions_UndoBulkDelete(t *testing.T) {
+	database := setupTestDB(t)
+	store := setupTestStorage(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+	v, err := jobapplication.AddResumeVersion(ctx, database.Queries(), store, 1, "Backend", nil)
+	require.NoError(t, err)
+	jobID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+	require.NoError(t, jobapplication.SetJobApplicationResumeVersion(ctx, database.Queries(), 1, jobID, v.ID))
+
+	now := time.Now()
+	result, err := jobapplication.BulkDelete(ctx, database, 1, []int64{jobID}, now)
+	require.NoError(t, err)
+	_, err = jobapplication.UndoBulkAction(ctx, database, 1, result.ActionID, now)
+	require.NoError(t, err)
+
+	var restored int64
+	require.NoError(t, database.DB().QueryRowContext(ctx, "SELECT resume_version_id FROM job_applications WHERE company = 'Acme'").Scan(&restored))
+	assert.Equal(t, v.ID, restored)
+}
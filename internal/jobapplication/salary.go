@@ -0,0 +1,105 @@
+package jobapplication
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+// GetSalaryAnalytics returns the median salary range of the user's active job applications by
+// status, converted to the display currency. Salaries without a currency are assumed to be in the
+// display currency.
+func GetSalaryAnalytics(ctx context.Context, q *queries.Queries, userID int64) (types.SalaryAnalytics, error) {
+	currency, err := GetDisplayCurrency(ctx, q, userID)
+	if err != nil {
+		return types.SalaryAnalytics{}, err
+	}
+	rates, err := GetExchangeRates(ctx, q, userID)
+	if err != nil {
+		return types.SalaryAnalytics{}, err
+	}
+	pipeline, err := GetPipeline(ctx, q, userID)
+	if err != nil {
+		return types.SalaryAnalytics{}, err
+	}
+	rows, err := q.GetSalaryRangesForUser(ctx, userID)
+	if err != nil {
+		return types.SalaryAnalytics{}, fmt.Errorf("failed to get salaries: %w", err)
+	}
+
+	analytics := types.SalaryAnalytics{Currency: currency}
+	type salaries struct {
+		mins  []int64
+		maxes []int64
+		count int64
+	}
+	byStatus := make(map[types.JobApplicationStatus]*salaries)
+	for _, row := range rows {
+		from := currency
+		if row.SalaryCurrency.Valid && row.SalaryCurrency.String != "" {
+			from = row.SalaryCurrency.String
+		}
+		if _, ok := ConvertAmount(0, from, currency, rates); !ok {
+			analytics.Unconverted++
+			continue
+		}
+
+		status := types.JobApplicationStatus(row.Status)
+		s, ok := byStatus[status]
+		if !ok {
+			s = &salaries{}
+			byStatus[status] = s
+		}
+		s.count++
+		if row.SalaryMin.Valid {
+			converted, _ := ConvertAmount(row.SalaryMin.Int64, from, currency, rates)
+			s.mins = append(s.mins, converted)
+		}
+		if row.SalaryMax.Valid {
+			converted, _ := ConvertAmount(row.SalaryMax.Int64, from, currency, rates)
+			s.maxes = append(s.maxes, converted)
+		}
+	}
+
+	statuses := make([]types.JobApplicationStatus, 0, len(byStatus))
+	for _, p := range pipeline {
+		if _, ok := byStatus[p.Name]; ok {
+			statuses = append(statuses, p.Name)
+		}
+	}
+	var unknown []types.JobApplicationStatus
+	for status := range byStatus {
+		if !pipeline.Contains(status) {
+			unknown = append(unknown, status)
+		}
+	}
+	slices.Sort(unknown)
+	statuses = append(statuses, unknown...)
+
+	for _, status := range statuses {
+		s := byStatus[status]
+		salary := types.StatusSalary{Status: status, Count: s.count}
+		salary.MedianMin, salary.HasMin = median(s.mins)
+		salary.MedianMax, salary.HasMax = median(s.maxes)
+		analytics.Statuses = append(analytics.Statuses, salary)
+	}
+	return analytics, nil
+}
+
+// median returns the middle value, averaging the two middle values of an even number of values.
+// The returned bool is false when there are no values.
+func median(values []int64) (int64, bool) {
+	if len(values) == 0 {
+		return 0, false
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid], true
+	}
+	return (sorted[mid-1] + sorted[mid] + 1) / 2, true
+}
@@ -0,0 +1,93 @@
+//go:build integration
+
+package jobapplication_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisplayCurrency(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+
+	currency, err := jobapplication.GetDisplayCurrency(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, types.BaseCurrency, currency)
+
+	require.ErrorIs(t, jobapplication.SetDisplayCurrency(ctx, database.Queries(), 1, "XYZ"), jobapplication.ErrInvalidCurrency)
+	require.NoError(t, jobapplication.SetDisplayCurrency(ctx, database.Queries(), 1, "EUR"))
+	currency, err = jobapplication.GetDisplayCurrency(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", currency)
+}
+
+func TestGetSalaryAnalytics(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+
+	salary := func(company string, lower int64, upper int64, currency string) int64 {
+		job := jobapplication.NewJobApplication{
+			Company:   company,
+			Title:     "Engineer",
+			SalaryMin: sql.NullInt64{Int64: lower, Valid: lower > 0},
+			SalaryMax: sql.NullInt64{Int64: upper, Valid: upper > 0},
+		}
+		if currency != "" {
+			job.SalaryCurrency = sql.NullString{String: currency, Valid: true}
+		}
+		id, err := jobapplication.Create(ctx, database, 1, job)
+		require.NoError(t, err)
+		return id
+	}
+	salary("Acme", 100000, 120000, "USD")
+	salary("Globex", 80000, 0, "")
+	salary("Initech", 90000, 110000, "EUR")
+	interviewingID := salary("Umbrella", 150000, 170000, "GBP")
+	_, err := jobapplication.UpdateStatus(ctx, database, 1, interviewingID, types.JobApplicationStatusInterviewing)
+	require.NoError(t, err)
+	_, err = jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Hooli", Title: "Engineer"})
+	require.NoError(t, err)
+
+	analytics, err := jobapplication.GetSalaryAnalytics(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, types.BaseCurrency, analytics.Currency)
+	assert.Equal(t, int64(2), analytics.Unconverted)
+	require.Len(t, analytics.Statuses, 1)
+	assert.Equal(t, types.StatusSalary{
+		Status:    types.JobApplicationStatusApplied,
+		Count:     2,
+		MedianMin: 90000,
+		MedianMax: 120000,
+		HasMin:    true,
+		HasMax:    true,
+	}, analytics.Statuses[0])
+
+	require.NoError(t, jobapplication.SaveExchangeRates(ctx, database, 1, []types.ExchangeRate{{Currency: "EUR", Rate: 1.1}, {Currency: "GBP", Rate: 1.25}}))
+	require.NoError(t, jobapplication.SetDisplayCurrency(ctx, database.Queries(), 1, "EUR"))
+
+	analytics, err = jobapplication.GetSalaryAnalytics(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", analytics.Currency)
+	assert.Zero(t, analytics.Unconverted)
+	require.Len(t, analytics.Statuses, 2)
+	applied := analytics.Statuses[0]
+	assert.Equal(t, types.JobApplicationStatusApplied, applied.Status)
+	assert.Equal(t, int64(3), applied.Count)
+	assert.Equal(t, int64(90000), applied.MedianMin)
+	assert.Equal(t, int64(109546), applied.MedianMax)
+	interviewing := analytics.Statuses[1]
+	assert.Equal(t, types.JobApplicationStatusInterviewing, interviewing.Status)
+	assert.Equal(t, int64(170455), interviewing.MedianMin)
+	assert.Equal(t, int64(193182), interviewing.MedianMax)
+}
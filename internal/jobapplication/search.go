@@ -0,0 +1,160 @@
+package jobapplication
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+const (
+	highlightStart = "\x02"
+	highlightEnd   = "\x03"
+)
+
+// The search queries are not generated by sqlc since it cannot resolve the hidden column of an FTS5
+// table that is matched against.
+const (
+	searchJobApplicationsQuery = `SELECT
+  j.id,
+  j.updated_at,
+  j.applied_at,
+  j.company,
+  j.title,
+  j.url,
+  j.status,
+  j.archived,
+  CAST(highlight(job_applications_fts, 0, char(2), char(3)) AS TEXT),
+  CAST(highlight(job_applications_fts, 1, char(2), char(3)) AS TEXT),
+  CAST(snippet(job_applications_fts, 3, char(2), char(3), '…', 16) AS TEXT)
+FROM
+  job_applications_fts
+  JOIN job_applications j ON j.id = job_applications_fts.rowid
+WHERE
+  job_applications_fts MATCH ?
+  AND j.user_id = ?
+  AND j.archived = ?
+ORDER BY
+  bm25(job_applications_fts, 10.0, 5.0, 2.0, 1.0)
+LIMIT
+  ?
+OFFSET
+  ?`
+
+	countSearchJobApplicationsQuery = `SELECT
+  COUNT(*)
+FROM
+  job_applications_fts
+  JOIN job_applications j ON j.id = job_applications_fts.rowid
+WHERE
+  job_applications_fts MATCH ?
+  AND j.user_id = ?
+  AND j.archived = ?`
+)
+
+// Search finds the job applications of the user whose company, title, URL or notes match the query,
+// ranked by relevance. The total number of matches is returned with the page of results.
+func Search(ctx context.Context, database db.Database, userID int64, query string, archived bool, limit int64, offset int64) ([]types.SearchResult, int64, error) {
+	match := ftsQuery(query)
+	if match == "" {
+		return nil, 0, nil
+	}
+
+	var total int64
+	if err := database.DB().QueryRowContext(ctx, countSearchJobApplicationsQuery, match, userID, boolToInt64(archived)).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count search results: %w", err)
+	}
+
+	rows, err := database.DB().QueryContext(ctx, searchJobApplicationsQuery, match, userID, boolToInt64(archived), limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search job applications: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var results []types.SearchResult
+	for rows.Next() {
+		var (
+			job                   types.JobApplication
+			status                string
+			company, title, notes string
+			url                   *string
+		)
+		if err = rows.Scan(
+			&job.ID,
+			&job.UpdatedAt,
+			&job.AppliedAt,
+			&job.Company,
+			&job.Title,
+			&url,
+			&status,
+			&job.Archived,
+			&company,
+			&title,
+			&notes,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		if url != nil {
+			job.URL = *url
+		}
+		job.Status = types.JobApplicationStatus(status)
+		job.UserID = userID
+		results = append(results, types.SearchResult{
+			Job:          job,
+			Company:      parseHighlight(company),
+			Title:        parseHighlight(title),
+			NotesSnippet: parseHighlight(notes),
+		})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to read search results: %w", err)
+	}
+	return results, total, nil
+}
+
+// ftsQuery converts the search input of a user to an FTS5 query. Each word is quoted so the FTS5
+// query syntax cannot be used, and matched as a prefix so results show up while typing.
+func ftsQuery(input string) string {
+	words := strings.Fields(input)
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		// Words without letters or digits have no tokens and would leave an empty phrase.
+		if strings.IndexFunc(word, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) == -1 {
+			continue
+		}
+		word = strings.ReplaceAll(word, `"`, `""`)
+		terms = append(terms, `"`+word+`"*`)
+	}
+	return strings.Join(terms, " ")
+}
+
+// parseHighlight splits text highlighted by FTS5 on the markers around the matched terms.
+func parseHighlight(text string) types.HighlightedText {
+	var parts types.HighlightedText
+	for text != "" {
+		start := strings.Index(text, highlightStart)
+		if start == -1 {
+			parts = append(parts, types.HighlightedPart{Text: text})
+			break
+		}
+		if start > 0 {
+			parts = append(parts, types.HighlightedPart{Text: text[:start]})
+		}
+		text = text[start+len(highlightStart):]
+		end := strings.Index(text, highlightEnd)
+		if end == -1 {
+			parts = append(parts, types.HighlightedPart{Text: text, Match: true})
+			break
+		}
+		if end > 0 {
+			parts = append(parts, types.HighlightedPart{Text: text[:end], Match: true})
+		}
+		text = text[end+len(highlightEnd):]
+	}
+	return parts
+}
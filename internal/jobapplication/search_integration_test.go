@@ -0,0 +1,99 @@
+//go:build integration
+
+package jobapplication_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+	createTestUser(t, database.DB(), 2)
+
+	acmeID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Backend Engineer", URL: "https://acme.com/careers/42"})
+	require.NoError(t, err)
+	globexID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Globex", Title: "Platform Engineer"})
+	require.NoError(t, err)
+	_, err = jobapplication.AddNote(ctx, database, 1, globexID, "Referred by a friend who works at Acme on the payments team")
+	require.NoError(t, err)
+	_, err = jobapplication.Create(ctx, database, 2, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+
+	results, total, err := jobapplication.Search(ctx, database, 1, "acme", false, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, results, 2)
+	// A match on the company ranks above a match in the notes.
+	assert.Equal(t, acmeID, results[0].Job.ID)
+	assert.Equal(t, types.HighlightedText{{Text: "Acme", Match: true}}, results[0].Company)
+	assert.Equal(t, globexID, results[1].Job.ID)
+	assert.Equal(t, types.HighlightedText{{Text: "Globex"}}, results[1].Company)
+	assert.Contains(t, results[1].NotesSnippet, types.HighlightedPart{Text: "Acme", Match: true})
+
+	results, _, err = jobapplication.Search(ctx, database, 1, "engin", false, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Contains(t, results[0].Title, types.HighlightedPart{Text: "Engineer", Match: true})
+
+	results, _, err = jobapplication.Search(ctx, database, 1, "careers", false, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, acmeID, results[0].Job.ID)
+
+	results, _, err = jobapplication.Search(ctx, database, 1, "payments acme", false, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, globexID, results[0].Job.ID)
+
+	for _, query := range []string{`"acme`, "acme OR", "-", "NEAR(acme", "title:acme"} {
+		_, _, err = jobapplication.Search(ctx, database, 1, query, false, 10, 0)
+		require.NoError(t, err, query)
+	}
+	results, total, err = jobapplication.Search(ctx, database, 1, "  ", false, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	assert.Zero(t, total)
+
+	// The index follows changes to job applications.
+	_, err = jobapplication.Update(ctx, database, 1, jobapplication.UpdatedJobApplication{ID: acmeID, Company: "Initech", Title: "Backend Engineer", Status: types.JobApplicationStatusApplied})
+	require.NoError(t, err)
+	results, _, err = jobapplication.Search(ctx, database, 1, "initech", false, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, acmeID, results[0].Job.ID)
+	results, _, err = jobapplication.Search(ctx, database, 1, "acme", false, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, globexID, results[0].Job.ID)
+
+	_, err = database.DB().ExecContext(ctx, "DELETE FROM job_application_notes WHERE job_application_id = ?", globexID)
+	require.NoError(t, err)
+	results, _, err = jobapplication.Search(ctx, database, 1, "acme", false, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	_, err = database.DB().ExecContext(ctx, "UPDATE job_applications SET archived = 1 WHERE id = ?", globexID)
+	require.NoError(t, err)
+	results, _, err = jobapplication.Search(ctx, database, 1, "globex", false, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	results, _, err = jobapplication.Search(ctx, database, 1, "globex", true, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Job.Archived)
+
+	_, err = database.DB().ExecContext(ctx, "DELETE FROM job_applications WHERE id = ?", globexID)
+	require.NoError(t, err)
+	var count int
+	require.NoError(t, database.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM job_applications_fts WHERE rowid = ?", globexID).Scan(&count))
+	assert.Zero(t, count)
+}
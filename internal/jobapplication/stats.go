@@ -0,0 +1,104 @@
+package jobapplication
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+)
+
+var ErrInvalidHeardBackAtType = errors.New("invalid type for HeardBackAt field")
+
+// RecalculateStats rebuilds the job application stats of the user from the non-archived job applications.
+func RecalculateStats(ctx context.Context, qtx *queries.Queries, userID int64) error {
+	jobsCount, err := qtx.CountJobApplicationsForStats(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	var jobs []queries.GetJobApplicationsForStatsRow
+	if jobsCount > 0 {
+		jobs, err = qtx.GetJobApplicationsForStats(ctx, userID)
+		if err != nil {
+			return err
+		}
+	}
+
+	companyCount, err := qtx.CountJobApplicationCompanies(ctx, queries.CountJobApplicationCompaniesParams{UserID: userID, Archived: 0})
+	if err != nil {
+		return err
+	}
+
+	statArgs := queries.SetJobApplicationStatParams{TotalCompanies: companyCount, UserID: userID}
+	var allDays []int64
+
+	for _, j := range jobs {
+		statArgs.TotalApplications += 1
+		if j.HeardBackAt != nil {
+			var heardBackAt time.Time
+			var err error
+
+			switch v := j.HeardBackAt.(type) {
+			case time.Time:
+				heardBackAt = v
+			case string:
+				heardBackAt, err = time.Parse("2006-01-02 15:04:05", v)
+				if err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("unexpected type for HeardBackAt %T: %w", v, ErrInvalidHeardBackAtType)
+			}
+			diff := heardBackAt.Sub(j.AppliedAt)
+			daysSince := int64(diff.Hours() / 24)
+			allDays = append(allDays, daysSince)
+		}
+	}
+
+	if len(allDays) > 0 {
+		sum := int64(0)
+		for _, days := range allDays {
+			sum += days
+		}
+		statArgs.AverageTimeToHearBack = sum / int64(len(allDays))
+	}
+
+	return qtx.SetJobApplicationStat(ctx, statArgs)
+}
+
+// RecalculateAllStats rebuilds the job application stats of every user from scratch. It is a
+// maintenance routine for stats that drifted from the job applications. Each user is rebuilt in
+// its own transaction and the number of users rebuilt is returned.
+func RecalculateAllStats(ctx context.Context, database db.Database) (int, error) {
+	userIDs, err := database.Queries().GetUserIDs(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get users: %w", err)
+	}
+	for i, userID := range userIDs {
+		if err = recalculateUserStats(ctx, database, userID); err != nil {
+			return i, fmt.Errorf("failed to recalculate stats of user %d: %w", userID, err)
+		}
+	}
+	return len(userIDs), nil
+}
+
+func recalculateUserStats(ctx context.Context, database db.Database, userID int64) (err error) {
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	if err = RecalculateStats(ctx, queries.New(tx), userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
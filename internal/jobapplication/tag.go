@@ -0,0 +1,222 @@
+package jobapplication
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+const maxTagNameLength = 30
+
+var (
+	ErrInvalidTagName  = errors.New("tag name must be between 1 and 30 characters without commas")
+	ErrInvalidTagColor = errors.New("invalid tag color")
+	ErrDuplicateTag    = errors.New("tag already exists")
+	ErrTagNotFound     = errors.New("tag not found")
+)
+
+// NormalizeTagName trims and collapses the whitespace of a tag name.
+func NormalizeTagName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+func validateTag(name string, color types.TagColor) error {
+	// Tags are joined with commas in exports, so a comma would split the tag.
+	if name == "" || utf8.RuneCountInString(name) > maxTagNameLength || strings.Contains(name, ",") {
+		return ErrInvalidTagName
+	}
+	if !color.IsValid() {
+		return ErrInvalidTagColor
+	}
+	return nil
+}
+
+// GetTags returns the tags of the user ordered by name.
+func GetTags(ctx context.Context, q *queries.Queries, userID int64) ([]types.Tag, error) {
+	rows, err := q.GetTagsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+	tags := make([]types.Tag, len(rows))
+	for i, row := range rows {
+		tags[i] = types.Tag{Name: row.Name, Color: types.TagColor(row.Color), ID: row.ID}
+	}
+	return tags, nil
+}
+
+// AddTag creates a tag for the user. Tag names are unique per user regardless of case.
+func AddTag(ctx context.Context, q *queries.Queries, userID int64, name string, color types.TagColor) (types.Tag, error) {
+	name = NormalizeTagName(name)
+	if err := validateTag(name, color); err != nil {
+		return types.Tag{}, err
+	}
+
+	exists, err := tagExists(ctx, q, userID, name, 0)
+	if err != nil {
+		return types.Tag{}, err
+	}
+	if exists {
+		return types.Tag{}, ErrDuplicateTag
+	}
+
+	id, err := q.InsertTag(ctx, queries.InsertTagParams{UserID: userID, Name: name, Color: color.String()})
+	if err != nil {
+		return types.Tag{}, fmt.Errorf("failed to insert tag: %w", err)
+	}
+	return types.Tag{Name: name, Color: color, ID: id}, nil
+}
+
+// UpdateTag renames and recolors a tag of the user.
+func UpdateTag(ctx context.Context, q *queries.Queries, userID int64, tagID int64, name string, color types.TagColor) error {
+	name = NormalizeTagName(name)
+	if err := validateTag(name, color); err != nil {
+		return err
+	}
+
+	exists, err := tagExists(ctx, q, userID, name, tagID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrDuplicateTag
+	}
+
+	updated, err := q.UpdateTag(ctx, queries.UpdateTagParams{Name: name, Color: color.String(), ID: tagID, UserID: userID})
+	if err != nil {
+		return fmt.Errorf("failed to update tag: %w", err)
+	}
+	if updated == 0 {
+		return ErrTagNotFound
+	}
+	return nil
+}
+
+// DeleteTag deletes a tag of the user and removes it from the job applications it was on.
+func DeleteTag(ctx context.Context, database db.Database, userID int64, tagID int64) (err error) {
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	qtx := queries.New(tx)
+	deleted, err := qtx.DeleteTag(ctx, queries.DeleteTagParams{ID: tagID, UserID: userID})
+	if err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+	if deleted == 0 {
+		return ErrTagNotFound
+	}
+	if err = qtx.DeleteJobApplicationTagsByTagID(ctx, tagID); err != nil {
+		return fmt.Errorf("failed to remove tag from job applications: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetJobApplicationTags returns the tags of a job application ordered by name.
+func GetJobApplicationTags(ctx context.Context, q *queries.Queries, jobID int64) ([]types.Tag, error) {
+	rows, err := q.GetTagsByJobApplicationID(ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job application tags: %w", err)
+	}
+	tags := make([]types.Tag, len(rows))
+	for i, row := range rows {
+		tags[i] = types.Tag{Name: row.Name, Color: types.TagColor(row.Color), ID: row.ID}
+	}
+	return tags, nil
+}
+
+// WithTags fills in the tags of the job applications.
+func WithTags(ctx context.Context, q *queries.Queries, jobs []types.JobApplication) ([]types.JobApplication, error) {
+	if len(jobs) == 0 {
+		return jobs, nil
+	}
+	ids := make([]int64, len(jobs))
+	for i, job := range jobs {
+		ids[i] = job.ID
+	}
+	rows, err := q.GetTagsByJobApplicationIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job application tags: %w", err)
+	}
+	tags := make(map[int64][]types.Tag, len(jobs))
+	for _, row := range rows {
+		tags[row.JobApplicationID] = append(tags[row.JobApplicationID], types.Tag{Name: row.Name, Color: types.TagColor(row.Color), ID: row.ID})
+	}
+	for i := range jobs {
+		jobs[i].Tags = tags[jobs[i].ID]
+	}
+	return jobs, nil
+}
+
+// SetJobApplicationTags replaces the tags of a job application of the user.
+func SetJobApplicationTags(ctx context.Context, database db.Database, userID int64, jobID int64, tagIDs []int64) (err error) {
+	tx, err := database.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if txErr := tx.Rollback(); txErr != nil && !errors.Is(txErr, sql.ErrTxDone) {
+			err = errors.Join(err, txErr)
+		}
+	}()
+
+	qtx := queries.New(tx)
+	if _, err = qtx.GetJobApplicationByIDAndUserID(ctx, queries.GetJobApplicationByIDAndUserIDParams{ID: jobID, UserID: userID}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to get job: %w", err)
+	}
+
+	tags, err := GetTags(ctx, qtx, userID)
+	if err != nil {
+		return err
+	}
+	for _, tagID := range tagIDs {
+		if !slices.ContainsFunc(tags, func(t types.Tag) bool { return t.ID == tagID }) {
+			return ErrTagNotFound
+		}
+	}
+
+	if err = qtx.DeleteJobApplicationTagsByJobApplicationID(ctx, jobID); err != nil {
+		return fmt.Errorf("failed to remove tags: %w", err)
+	}
+	for _, tagID := range tagIDs {
+		if _, err = qtx.InsertJobApplicationTag(ctx, queries.InsertJobApplicationTagParams{JobApplicationID: jobID, TagID: tagID}); err != nil {
+			return fmt.Errorf("failed to add tag: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// tagExists reports whether the user has another tag with the name, ignoring the tag being updated.
+func tagExists(ctx context.Context, q *queries.Queries, userID int64, name string, ignoreID int64) (bool, error) {
+	tags, err := GetTags(ctx, q, userID)
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(tags, func(t types.Tag) bool {
+		return t.ID != ignoreID && strings.EqualFold(t.Name, name)
+	}), nil
+}
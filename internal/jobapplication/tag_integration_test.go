@@ -0,0 +1,211 @@
+//go:build integration
+
+package jobapplication_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTags(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+	createTestUser(t, database.DB(), 2)
+
+	remote, err := jobapplication.AddTag(ctx, database.Queries(), 1, "  Remote   first ", types.TagColorBlue)
+	require.NoError(t, err)
+	assert.Equal(t, "Remote first", remote.Name)
+	referral, err := jobapplication.AddTag(ctx, database.Queries(), 1, "Referral", types.TagColorGreen)
+	require.NoError(t, err)
+
+	_, err = jobapplication.AddTag(ctx, database.Queries(), 1, "", types.TagColorBlue)
+	require.ErrorIs(t, err, jobapplication.ErrInvalidTagName)
+	_, err = jobapplication.AddTag(ctx, database.Queries(), 1, "remote, hybrid", types.TagColorBlue)
+	require.ErrorIs(t, err, jobapplication.ErrInvalidTagName)
+	_, err = jobapplication.AddTag(ctx, database.Queries(), 1, "Contract", "orange")
+	require.ErrorIs(t, err, jobapplication.ErrInvalidTagColor)
+	_, err = jobapplication.AddTag(ctx, database.Queries(), 1, "referral", types.TagColorRed)
+	require.ErrorIs(t, err, jobapplication.ErrDuplicateTag)
+	// Tag names only need to be unique per user.
+	_, err = jobapplication.AddTag(ctx, database.Queries(), 2, "Referral", types.TagColorRed)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, jobapplication.UpdateTag(ctx, database.Queries(), 1, remote.ID, "REFERRAL", types.TagColorBlue), jobapplication.ErrDuplicateTag)
+	require.ErrorIs(t, jobapplication.UpdateTag(ctx, database.Queries(), 2, remote.ID, "Hybrid", types.TagColorBlue), jobapplication.ErrTagNotFound)
+	require.NoError(t, jobapplication.UpdateTag(ctx, database.Queries(), 1, remote.ID, "Remote", types.TagColorPurple))
+	// Changing only the case of its own name is not a duplicate.
+	require.NoError(t, jobapplication.UpdateTag(ctx, database.Queries(), 1, referral.ID, "referral", types.TagColorGreen))
+
+	tags, err := jobapplication.GetTags(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Tag{
+		{Name: "referral", Color: types.TagColorGreen, ID: referral.ID},
+		{Name: "Remote", Color: types.TagColorPurple, ID: remote.ID},
+	}, tags)
+
+	acmeID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+	globexID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Globex", Title: "Engineer"})
+	require.NoError(t, err)
+	otherTags, err := jobapplication.GetTags(ctx, database.Queries(), 2)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, jobapplication.SetJobApplicationTags(ctx, database, 2, acmeID, nil), jobapplication.ErrNotFound)
+	require.ErrorIs(t, jobapplication.SetJobApplicationTags(ctx, database, 1, acmeID, []int64{remote.ID, otherTags[0].ID}), jobapplication.ErrTagNotFound)
+	require.NoError(t, jobapplication.SetJobApplicationTags(ctx, database, 1, acmeID, []int64{remote.ID, referral.ID}))
+	require.NoError(t, jobapplication.SetJobApplicationTags(ctx, database, 1, globexID, []int64{remote.ID}))
+
+	jobs, err := jobapplication.WithTags(ctx, database.Queries(), []types.JobApplication{{ID: acmeID}, {ID: globexID}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"referral", "Remote"}, tagNames(jobs[0].Tags))
+	assert.Equal(t, []string{"Remote"}, tagNames(jobs[1].Tags))
+
+	require.NoError(t, jobapplication.SetJobApplicationTags(ctx, database, 1, acmeID, []int64{referral.ID}))
+	acmeTags, err := jobapplication.GetJobApplicationTags(ctx, database.Queries(), acmeID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"referral"}, tagNames(acmeTags))
+
+	all, err := database.Queries().GetAllJobApplicationsByUserID(ctx, 1)
+	require.NoError(t, err)
+	exported := make(map[string]string, len(all))
+	for _, app := range all {
+		exported[app.Company] = app.Tags
+	}
+	assert.Equal(t, map[string]string{"Acme": "referral", "Globex": "Remote"}, exported)
+
+	require.ErrorIs(t, jobapplication.DeleteTag(ctx, database, 2, remote.ID), jobapplication.ErrTagNotFound)
+	require.NoError(t, jobapplication.DeleteTag(ctx, database, 1, remote.ID))
+	globexTags, err := jobapplication.GetJobApplicationTags(ctx, database.Queries(), globexID)
+	require.NoError(t, err)
+	assert.Empty(t, globexTags)
+	var links int
+	require.NoError(t, database.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM job_application_tags WHERE tag_id = ?", remote.ID).Scan(&links))
+	assert.Zero(t, links)
+}
+
+func TestFilterJobApplications_Tag(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+	remote, err := jobapplication.AddTag(ctx, database.Queries(), 1, "Remote", types.TagColorBlue)
+	require.NoError(t, err)
+	for _, company := range []string{"Acme", "Acme Labs", "Globex", "Initech"} {
+		id, createErr := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: company, Title: "Engineer"})
+		require.NoError(t, createErr)
+		if company != "Initech" {
+			require.NoError(t, jobapplication.SetJobApplicationTags(ctx, database, 1, id, []int64{remote.ID}))
+		}
+		if company == "Acme Labs" {
+			_, err = jobapplication.UpdateStatus(ctx, database, 1, id, types.JobApplicationStatusInterviewing)
+			require.NoError(t, err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		company  string
+		status   string
+		expected []string
+	}{
+		{name: "tag only", expected: []string{"Acme", "Acme Labs", "Globex"}},
+		{name: "tag and company", company: "Acme", expected: []string{"Acme", "Acme Labs"}},
+		{name: "tag and status", status: "interviewing", expected: []string{"Acme Labs"}},
+		{name: "tag, company and status", company: "Globex", status: "interviewing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs, queryErr := jobapplication.FilterJobApplications(ctx, database.Queries(), 1, types.FilterOpts{
+				Company: tt.company,
+				Status:  types.JobApplicationStatus(tt.status),
+				Tag:     remote.ID,
+			}, 0, 10)
+			require.NoError(t, queryErr)
+			companies := make([]string, 0, len(jobs))
+			for _, job := range jobs {
+				companies = append(companies, job.Company)
+			}
+			assert.ElementsMatch(t, tt.expected, companies)
+		})
+	}
+}
+
+func TestBulkAddTag(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	createTestUser(t, database.DB(), 1)
+	createTestUser(t, database.DB(), 2)
+	remote, err := jobapplication.AddTag(ctx, database.Queries(), 1, "Remote", types.TagColorBlue)
+	require.NoError(t, err)
+	otherTag, err := jobapplication.AddTag(ctx, database.Queries(), 2, "Remote", types.TagColorBlue)
+	require.NoError(t, err)
+	acmeID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+	globexID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Globex", Title: "Engineer"})
+	require.NoError(t, err)
+	require.NoError(t, jobapplication.SetJobApplicationTags(ctx, database, 1, acmeID, []int64{remote.ID}))
+
+	_, err = jobapplication.BulkAddTag(ctx, database, 1, []int64{acmeID, globexID}, otherTag.ID, now)
+	require.ErrorIs(t, err, jobapplication.ErrTagNotFound)
+
+	result, err := jobapplication.BulkAddTag(ctx, database, 1, []int64{acmeID, globexID}, remote.ID, now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Count)
+	assert.Equal(t, jobapplication.BulkActionTag, result.Action)
+	jobs, err := jobapplication.WithTags(ctx, database.Queries(), []types.JobApplication{{ID: acmeID}, {ID: globexID}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Remote"}, tagNames(jobs[0].Tags))
+	assert.Equal(t, []string{"Remote"}, tagNames(jobs[1].Tags))
+
+	// Undo only removes the tag from the job applications the bulk action added it to.
+	undone, err := jobapplication.UndoBulkAction(ctx, database, 1, result.ActionID, now.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, jobapplication.BulkUndoResult{Action: jobapplication.BulkActionTag}, undone)
+	jobs, err = jobapplication.WithTags(ctx, database.Queries(), []types.JobApplication{{ID: acmeID}, {ID: globexID}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Remote"}, tagNames(jobs[0].Tags))
+	assert.Empty(t, jobs[1].Tags)
+}
+
+func TestBulkDelete_RestoresTags(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	createTestUser(t, database.DB(), 1)
+	remote, err := jobapplication.AddTag(ctx, database.Queries(), 1, "Remote", types.TagColorBlue)
+	require.NoError(t, err)
+	acmeID, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+	require.NoError(t, jobapplication.SetJobApplicationTags(ctx, database, 1, acmeID, []int64{remote.ID}))
+
+	result, err := jobapplication.BulkDelete(ctx, database, 1, []int64{acmeID}, now)
+	require.NoError(t, err)
+	var links int
+	require.NoError(t, database.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM job_application_tags WHERE job_application_id = ?", acmeID).Scan(&links))
+	assert.Zero(t, links)
+
+	_, err = jobapplication.UndoBulkAction(ctx, database, 1, result.ActionID, now.Add(time.Second))
+	require.NoError(t, err)
+	tags, err := jobapplication.GetJobApplicationTags(ctx, database.Queries(), acmeID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Remote"}, tagNames(tags))
+}
+
+func tagNames(tags []types.Tag) []string {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	return names
+}
@@ -0,0 +1,115 @@
+package jobapplication
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+const maxViewNameLength = 50
+
+var (
+	ErrInvalidViewName = errors.New("view name must be between 1 and 50 characters")
+	ErrDuplicateView   = errors.New("view already exists")
+	ErrViewNotFound    = errors.New("view not found")
+)
+
+// GetViews returns the saved views of the user ordered by name, with the number of job
+// applications currently matching each.
+func GetViews(ctx context.Context, q *queries.Queries, userID int64) ([]types.SavedView, error) {
+	rows, err := q.GetSavedViewsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get views: %w", err)
+	}
+	views := make([]types.SavedView, len(rows))
+	for i, row := range rows {
+		views[i], err = toSavedView(row.Name, row.Filters, row.ID)
+		if err != nil {
+			return nil, err
+		}
+		views[i].Count, err = q.CountFilterJobApplications(ctx, countFilterParams(userID, views[i].Filters))
+		if err != nil {
+			return nil, fmt.Errorf("failed to count job applications of view: %w", err)
+		}
+	}
+	return views, nil
+}
+
+// GetViewByName returns the saved view of the user with the name, regardless of case.
+func GetViewByName(ctx context.Context, q *queries.Queries, userID int64, name string) (types.SavedView, error) {
+	row, err := q.GetSavedViewByNameAndUserID(ctx, queries.GetSavedViewByNameAndUserIDParams{Name: normalizeViewName(name), UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return types.SavedView{}, ErrViewNotFound
+		}
+		return types.SavedView{}, fmt.Errorf("failed to get view: %w", err)
+	}
+	return toSavedView(row.Name, row.Filters, row.ID)
+}
+
+// GetViewJobApplications returns all the job applications in the saved view of the user with the
+// name, in the sort order of the view.
+func GetViewJobApplications(ctx context.Context, q *queries.Queries, userID int64, name string) ([]types.JobApplication, error) {
+	view, err := GetViewByName(ctx, q, userID, name)
+	if err != nil {
+		return nil, err
+	}
+	// A negative limit has no upper bound in SQLite.
+	return FilterJobApplications(ctx, q, userID, view.Filters, 0, -1)
+}
+
+// SaveView saves the filters as a named view of the user. Page numbers are not part of a view.
+// View names are unique per user regardless of case.
+func SaveView(ctx context.Context, q *queries.Queries, userID int64, name string, opts types.FilterOpts) (types.SavedView, error) {
+	name = normalizeViewName(name)
+	if name == "" || utf8.RuneCountInString(name) > maxViewNameLength {
+		return types.SavedView{}, ErrInvalidViewName
+	}
+
+	if _, err := q.GetSavedViewByNameAndUserID(ctx, queries.GetSavedViewByNameAndUserIDParams{Name: name, UserID: userID}); err == nil {
+		return types.SavedView{}, ErrDuplicateView
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return types.SavedView{}, fmt.Errorf("failed to check view name: %w", err)
+	}
+
+	id, err := q.InsertSavedView(ctx, queries.InsertSavedViewParams{UserID: userID, Name: name, Filters: opts.Query().Encode()})
+	if err != nil {
+		return types.SavedView{}, fmt.Errorf("failed to insert view: %w", err)
+	}
+	return types.SavedView{Name: name, Filters: opts, ID: id}, nil
+}
+
+// DeleteView deletes a saved view of the user.
+func DeleteView(ctx context.Context, q *queries.Queries, userID int64, viewID int64) error {
+	deleted, err := q.DeleteSavedView(ctx, queries.DeleteSavedViewParams{ID: viewID, UserID: userID})
+	if err != nil {
+		return fmt.Errorf("failed to delete view: %w", err)
+	}
+	if deleted == 0 {
+		return ErrViewNotFound
+	}
+	return nil
+}
+
+func normalizeViewName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+func toSavedView(name string, filters string, id int64) (types.SavedView, error) {
+	values, err := url.ParseQuery(filters)
+	if err != nil {
+		return types.SavedView{}, fmt.Errorf("failed to parse filters of view: %w", err)
+	}
+	opts, err := types.ParseFilterOpts(values)
+	if err != nil {
+		return types.SavedView{}, fmt.Errorf("failed to parse filters of view: %w", err)
+	}
+	return types.SavedView{Name: name, Filters: opts, ID: id}, nil
+}
@@ -0,0 +1,71 @@
+//go:build integration
+
+package jobapplication_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViews(t *testing.T) {
+	database := setupTestDB(t)
+	ctx := context.Background()
+
+	createTestUser(t, database.DB(), 1)
+	createTestUser(t, database.DB(), 2)
+
+	for _, company := range []string{"Acme", "Globex", "Hooli"} {
+		id, err := jobapplication.Create(ctx, database, 1, jobapplication.NewJobApplication{Company: company, Title: "Engineer"})
+		require.NoError(t, err)
+		if company != "Acme" {
+			_, err = jobapplication.UpdateStatus(ctx, database, 1, id, types.JobApplicationStatusInterviewing)
+			require.NoError(t, err)
+		}
+	}
+
+	interviewing := types.FilterOpts{Status: types.JobApplicationStatusInterviewing, Sort: types.JobSortCompany, Order: "desc"}
+	view, err := jobapplication.SaveView(ctx, database.Queries(), 1, "  Interviewing   now ", interviewing)
+	require.NoError(t, err)
+	assert.Equal(t, "Interviewing now", view.Name)
+	_, err = jobapplication.SaveView(ctx, database.Queries(), 1, "All", types.FilterOpts{})
+	require.NoError(t, err)
+
+	_, err = jobapplication.SaveView(ctx, database.Queries(), 1, "INTERVIEWING NOW", types.FilterOpts{})
+	require.ErrorIs(t, err, jobapplication.ErrDuplicateView)
+	_, err = jobapplication.SaveView(ctx, database.Queries(), 1, " ", types.FilterOpts{})
+	require.ErrorIs(t, err, jobapplication.ErrInvalidViewName)
+	_, err = jobapplication.SaveView(ctx, database.Queries(), 2, "Interviewing now", types.FilterOpts{})
+	require.NoError(t, err, "view names are only unique per user")
+
+	views, err := jobapplication.GetViews(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	require.Len(t, views, 2)
+	assert.Equal(t, "All", views[0].Name)
+	assert.Equal(t, int64(3), views[0].Count)
+	assert.Equal(t, "Interviewing now", views[1].Name)
+	assert.Equal(t, int64(2), views[1].Count)
+	assert.Equal(t, interviewing, views[1].Filters)
+
+	jobs, err := jobapplication.GetViewJobApplications(ctx, database.Queries(), 1, "interviewing now")
+	require.NoError(t, err)
+	companies := make([]string, len(jobs))
+	for i, job := range jobs {
+		companies[i] = job.Company
+	}
+	assert.Equal(t, []string{"Hooli", "Globex"}, companies)
+
+	_, err = jobapplication.GetViewJobApplications(ctx, database.Queries(), 1, "Remote")
+	require.ErrorIs(t, err, jobapplication.ErrViewNotFound)
+
+	require.ErrorIs(t, jobapplication.DeleteView(ctx, database.Queries(), 2, view.ID), jobapplication.ErrViewNotFound)
+	require.NoError(t, jobapplication.DeleteView(ctx, database.Queries(), 1, view.ID))
+	views, err = jobapplication.GetViews(ctx, database.Queries(), 1)
+	require.NoError(t, err)
+	require.Len(t, views, 1)
+	assert.Equal(t, "All", views[0].Name)
+}
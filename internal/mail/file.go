@@ -0,0 +1,54 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// File writes each email to its own file in a directory instead of sending it, so local development
+// and end-to-end tests can read them.
+type File struct {
+	dir  string
+	from string
+}
+
+var _ Mailer = (*File)(nil)
+
+// NewFile creates the directory when it does not exist and returns a mailer that writes its emails
+// to it.
+func NewFile(dir string, from string) (*File, error) {
+	if dir == "" {
+		return nil, errors.New("missing mail directory")
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create mail directory: %w", err)
+	}
+	return &File{dir: dir, from: from}, nil
+}
+
+func (f *File) Send(_ context.Context, msg Message) error {
+	var b strings.Builder
+	b.WriteString("From: " + f.from + "\r\n")
+	b.WriteString("To: " + msg.To + "\r\n")
+	b.WriteString("Subject: " + msg.Subject + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(msg.Body)
+
+	// The name starts with the time so the files sort in the order they were sent.
+	file, err := os.CreateTemp(f.dir, time.Now().UTC().Format("20060102T150405.000000000")+"-*.eml")
+	if err != nil {
+		return fmt.Errorf("failed to create mail file: %w", err)
+	}
+	if _, err = file.WriteString(b.String()); err != nil {
+		return errors.Join(fmt.Errorf("failed to write mail file: %w", err), file.Close())
+	}
+	if err = file.Close(); err != nil {
+		return fmt.Errorf("failed to close mail file: %w", err)
+	}
+	return nil
+}
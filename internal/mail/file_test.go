@@ -0,0 +1,50 @@
+package mail_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Piszmog/pathwise/internal/mail"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	m, err := mail.NewFile(dir, "noreply@example.com")
+	require.NoError(t, err)
+
+	require.NoError(t, m.Send(t.Context(), mail.Message{To: "user@example.com", Subject: "First", Body: "Hello"}))
+	require.NoError(t, m.Send(t.Context(), mail.Message{To: "user@example.com", Subject: "Second", Body: "Again"}))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	content, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "From: noreply@example.com\r\n")
+	assert.Contains(t, string(content), "To: user@example.com\r\n")
+	assert.Contains(t, string(content), "Subject: First\r\n")
+	assert.Contains(t, string(content), "\r\n\r\nHello")
+}
+
+func TestNew(t *testing.T) {
+	_, err := mail.New(nil, mail.Opts{Backend: "pigeon"})
+	require.ErrorIs(t, err, mail.ErrUnknownBackend)
+	_, err = mail.New(nil, mail.Opts{Backend: mail.BackendSMTP, From: "noreply@example.com"})
+	require.Error(t, err)
+	_, err = mail.New(nil, mail.Opts{Backend: mail.BackendFile})
+	require.Error(t, err)
+}
+
+func TestNew_LogBackendInProduction(t *testing.T) {
+	// Reset and verification links must never be written to the logs of a production deploy.
+	for _, backend := range []mail.Backend{"", mail.BackendLog} {
+		_, err := mail.New(nil, mail.Opts{Backend: backend, Production: true})
+		require.ErrorIs(t, err, mail.ErrBackendInProduction)
+	}
+	m, err := mail.New(nil, mail.Opts{Backend: mail.BackendFile, Dir: t.TempDir(), Production: true})
+	require.NoError(t, err)
+	assert.NotNil(t, m)
+}
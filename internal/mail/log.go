@@ -0,0 +1,23 @@
+package mail
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Log writes emails to the log instead of sending them. Emails contain links that sign users in,
+// so it is only meant for local development.
+type Log struct {
+	logger *slog.Logger
+}
+
+var _ Mailer = (*Log)(nil)
+
+func NewLog(logger *slog.Logger) *Log {
+	return &Log{logger: logger}
+}
+
+func (l *Log) Send(ctx context.Context, msg Message) error {
+	l.logger.InfoContext(ctx, "email", "to", msg.To, "subject", msg.Subject, "body", msg.Body)
+	return nil
+}
@@ -0,0 +1,65 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+var (
+	ErrUnknownBackend      = errors.New("unknown mail backend")
+	ErrBackendInProduction = errors.New("log mail backend is not allowed in production")
+)
+
+// Message is a plain text email to a single recipient.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends emails to users.
+type Mailer interface {
+	// Send delivers the message, or hands it to a server that delivers it.
+	Send(ctx context.Context, msg Message) error
+}
+
+// Backend is how emails are sent.
+type Backend string
+
+const (
+	BackendLog  Backend = "log"
+	BackendFile Backend = "file"
+	BackendSMTP Backend = "smtp"
+)
+
+// Opts configures the mailer returned by New.
+type Opts struct {
+	Backend Backend
+	// From is the address emails are sent from.
+	From string
+	// Dir is the directory of the file backend.
+	Dir  string
+	SMTP SMTPOpts
+	// Production refuses the log backend, since the links in emails would end up in the logs.
+	Production bool
+}
+
+// New creates the mailer of the backend. The log backend is used when no backend is set, so local
+// development works without a mail server.
+func New(logger *slog.Logger, opts Opts) (Mailer, error) {
+	switch opts.Backend {
+	case "", BackendLog:
+		if opts.Production {
+			return nil, ErrBackendInProduction
+		}
+		return NewLog(logger), nil
+	case BackendFile:
+		return NewFile(opts.Dir, opts.From)
+	case BackendSMTP:
+		return NewSMTP(opts.From, opts.SMTP)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownBackend, opts.Backend)
+	}
+}
@@ -0,0 +1,64 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	gomail "github.com/wneessen/go-mail"
+)
+
+// SMTPOpts configures the SMTP backend. Authentication is only used when a username is set.
+type SMTPOpts struct {
+	Host     string
+	Username string
+	Password string
+	Port     int
+}
+
+// SMTP sends emails through an SMTP server, upgrading the connection with STARTTLS when the server
+// supports it.
+type SMTP struct {
+	client *gomail.Client
+	from   string
+}
+
+var _ Mailer = (*SMTP)(nil)
+
+func NewSMTP(from string, opts SMTPOpts) (*SMTP, error) {
+	if opts.Host == "" || from == "" {
+		return nil, errors.New("missing SMTP host or from address")
+	}
+	clientOpts := []gomail.Option{gomail.WithTLSPolicy(gomail.TLSOpportunistic)}
+	if opts.Port != 0 {
+		clientOpts = append(clientOpts, gomail.WithPort(opts.Port))
+	}
+	if opts.Username != "" {
+		clientOpts = append(clientOpts,
+			gomail.WithSMTPAuth(gomail.SMTPAuthAutoDiscover),
+			gomail.WithUsername(opts.Username),
+			gomail.WithPassword(opts.Password),
+		)
+	}
+	client, err := gomail.NewClient(opts.Host, clientOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SMTP client: %w", err)
+	}
+	return &SMTP{client: client, from: from}, nil
+}
+
+func (s *SMTP) Send(ctx context.Context, msg Message) error {
+	m := gomail.NewMsg()
+	if err := m.From(s.from); err != nil {
+		return fmt.Errorf("invalid from address: %w", err)
+	}
+	if err := m.To(msg.To); err != nil {
+		return fmt.Errorf("invalid to address: %w", err)
+	}
+	m.Subject(msg.Subject)
+	m.SetBodyString(gomail.TypeTextPlain, msg.Body)
+	if err := s.client.DialAndSendWithContext(ctx, m); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
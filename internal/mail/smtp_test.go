@@ -0,0 +1,85 @@
+package mail_test
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/Piszmog/pathwise/internal/mail"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer accepts a single session without TLS or authentication and returns the
+// recipients and data of the email sent in it.
+func fakeSMTPServer(t *testing.T) (int, <-chan []string) {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+
+	received := make(chan []string, 1)
+	go func() {
+		conn, acceptErr := l.Accept()
+		if acceptErr != nil {
+			return
+		}
+		defer conn.Close()
+
+		var lines []string
+		r := bufio.NewReader(conn)
+		reply := func(s string) { _, _ = conn.Write([]byte(s + "\r\n")) }
+		reply("220 localhost ESMTP")
+		for {
+			line, readErr := r.ReadString('\n')
+			if readErr != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			switch {
+			case strings.HasPrefix(line, "EHLO"), strings.HasPrefix(line, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(line, "MAIL FROM"), strings.HasPrefix(line, "RCPT TO"), line == "RSET", line == "NOOP":
+				lines = append(lines, line)
+				reply("250 OK")
+			case line == "DATA":
+				reply("354 End data with <CR><LF>.<CR><LF>")
+				for {
+					data, dataErr := r.ReadString('\n')
+					if dataErr != nil {
+						return
+					}
+					data = strings.TrimRight(data, "\r\n")
+					if data == "." {
+						break
+					}
+					lines = append(lines, data)
+				}
+				reply("250 OK")
+			case line == "QUIT":
+				reply("221 Bye")
+				received <- lines
+				return
+			default:
+				reply("502 Not implemented")
+			}
+		}
+	}()
+	return l.Addr().(*net.TCPAddr).Port, received
+}
+
+func TestSMTP(t *testing.T) {
+	port, received := fakeSMTPServer(t)
+	m, err := mail.NewSMTP("noreply@example.com", mail.SMTPOpts{Host: "127.0.0.1", Port: port})
+	require.NoError(t, err)
+
+	require.NoError(t, m.Send(t.Context(), mail.Message{To: "user@example.com", Subject: "Reset your password", Body: "Hello"}))
+
+	lines := <-received
+	assert.Contains(t, lines, "MAIL FROM:<noreply@example.com>")
+	assert.Contains(t, lines, "RCPT TO:<user@example.com>")
+	assert.Contains(t, lines, "Subject: Reset your password")
+	assert.Contains(t, lines, "Hello")
+}
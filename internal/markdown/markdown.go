@@ -0,0 +1,49 @@
+// Package markdown renders the Markdown written by users as HTML that is safe to embed in a page.
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// md renders GitHub Flavored Markdown. The HTML renderer is left in its safe mode, so raw HTML is
+// dropped and links and images with dangerous URLs, such as javascript:, lose their URL.
+var md = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithParserOptions(
+		parser.WithASTTransformers(util.Prioritized(externalLinks{}, 100)),
+	),
+)
+
+// Render renders the Markdown as HTML. Raw HTML in the Markdown is not rendered.
+func Render(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(source), &buf); err != nil {
+		return "", fmt.Errorf("failed to render markdown: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// externalLinks opens links in a new tab without giving the linked page access to the app.
+type externalLinks struct{}
+
+func (externalLinks) Transform(node *ast.Document, _ text.Reader, _ parser.Context) {
+	_ = ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n.Kind() {
+		case ast.KindLink, ast.KindAutoLink:
+			n.SetAttributeString("target", []byte("_blank"))
+			n.SetAttributeString("rel", []byte("noopener noreferrer nofollow"))
+		}
+		return ast.WalkContinue, nil
+	})
+}
@@ -0,0 +1,61 @@
+package markdown_test
+
+import (
+	"testing"
+
+	"github.com/Piszmog/pathwise/internal/markdown"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{
+			name:     "plain text",
+			source:   "Called the recruiter",
+			expected: "<p>Called the recruiter</p>\n",
+		},
+		{
+			name:     "emphasis",
+			source:   "**Great** call",
+			expected: "<p><strong>Great</strong> call</p>\n",
+		},
+		{
+			name:     "link",
+			source:   "[posting](https://example.com/job)",
+			expected: "<p><a href=\"https://example.com/job\" target=\"_blank\" rel=\"noopener noreferrer nofollow\">posting</a></p>\n",
+		},
+		{
+			name:     "bare link",
+			source:   "See https://example.com",
+			expected: "<p>See <a href=\"https://example.com\" target=\"_blank\" rel=\"noopener noreferrer nofollow\">https://example.com</a></p>\n",
+		},
+		{
+			name:     "checklist",
+			source:   "- [x] Send resume\n- [ ] Follow up",
+			expected: "<ul>\n<li><input checked=\"\" disabled=\"\" type=\"checkbox\"> Send resume</li>\n<li><input disabled=\"\" type=\"checkbox\"> Follow up</li>\n</ul>\n",
+		},
+		{
+			name:     "raw html",
+			source:   "<script>alert(1)</script>\n\nHi <b onclick=\"alert(1)\">there</b>",
+			expected: "<!-- raw HTML omitted -->\n<p>Hi <!-- raw HTML omitted -->there<!-- raw HTML omitted --></p>\n",
+		},
+		{
+			name:     "javascript link",
+			source:   "[click](javascript:alert(1))",
+			expected: "<p><a href=\"\" target=\"_blank\" rel=\"noopener noreferrer nofollow\">click</a></p>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := markdown.Render(tt.source)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package tool
+
+import (
+	"context"
+	"errors"
+
+	contextkey "github.com/Piszmog/pathwise/internal/context_key"
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (h *Handler) NewAddJobApplicationNoteTool() Tool {
+	return Tool{
+		Tool: mcp.NewTool(
+			"add_job_application_note",
+			mcp.WithDescription("Add a note to a job application"),
+			mcp.WithNumber("job_application_id", mcp.Required(), mcp.Description("ID of the job application to add the note to")),
+			mcp.WithString("note", mcp.Required(), mcp.Description("Content of the note")),
+		),
+		HandlerFunc: h.AddJobApplicationNote,
+	}
+}
+
+func (h *Handler) AddJobApplicationNote(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	userID, ok := ctx.Value(contextkey.KeyUserID).(int64)
+	if !ok {
+		h.Logger.ErrorContext(ctx, "authentication failed - user ID not found in context", "tool", "add_job_application_note")
+		return mcp.NewToolResultError("failed to authenticate"), nil
+	}
+
+	args := req.GetArguments()
+	id, exists, err := getInt64Arg(args, "job_application_id")
+	if err != nil || !exists {
+		h.Logger.ErrorContext(ctx, "invalid job_application_id parameter", "tool", "add_job_application_note", "provided_value", args["job_application_id"], "expected_type", "float64", "user_id", userID)
+		return mcp.NewToolResultError("invalid job_application_id"), nil
+	}
+	note, _, err := getStringArg(args, "note")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	data, err := jobapplication.AddNote(ctx, h.Database, userID, id, note)
+	if err != nil {
+		if isValidationErr(err) {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		h.Logger.ErrorContext(ctx, "failed to add job application note", "error", err, "user_id", userID, "job_application_id", id)
+		return nil, errAddJobApplicationNote
+	}
+	return mcp.NewToolResultStructuredOnly(data), nil
+}
+
+var errAddJobApplicationNote = errors.New("failed to add job application note")
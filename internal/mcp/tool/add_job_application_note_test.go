@@ -0,0 +1,122 @@
+//go:build integration
+
+package tool_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	contextkey "github.com/Piszmog/pathwise/internal/context_key"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/mcp/tool"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "github.com/tursodatabase/go-libsql"
+)
+
+func TestAddJobApplicationNoteTool(t *testing.T) {
+	tests := []struct {
+		name          string
+		userID        int64
+		note          any
+		setupData     func(t *testing.T, db *sql.DB, userID int64) int64
+		expectedError bool
+	}{
+		{
+			name:   "unauthenticated user",
+			userID: 0,
+			note:   "A note",
+			setupData: func(t *testing.T, db *sql.DB, userID int64) int64 {
+				return 1
+			},
+			expectedError: true,
+		},
+		{
+			name:   "missing note",
+			userID: 1,
+			note:   "",
+			setupData: func(t *testing.T, db *sql.DB, userID int64) int64 {
+				return insertJobApplication(t, db, userID, "Company A", "Engineer", "applied")
+			},
+			expectedError: true,
+		},
+		{
+			name:   "invalid note type",
+			userID: 1,
+			note:   float64(1),
+			setupData: func(t *testing.T, db *sql.DB, userID int64) int64 {
+				return insertJobApplication(t, db, userID, "Company A", "Engineer", "applied")
+			},
+			expectedError: true,
+		},
+		{
+			name:   "job application belongs to other user",
+			userID: 1,
+			note:   "A note",
+			setupData: func(t *testing.T, db *sql.DB, userID int64) int64 {
+				createTestUser(t, db, 2)
+				return insertJobApplication(t, db, 2, "Other Company", "Engineer", "applied")
+			},
+			expectedError: true,
+		},
+		{
+			name:   "adds note",
+			userID: 1,
+			note:   "Recruiter reached out",
+			setupData: func(t *testing.T, db *sql.DB, userID int64) int64 {
+				return insertJobApplication(t, db, userID, "Company A", "Engineer", "applied")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			database := setupTestDB(t)
+			defer cleanupTestDB(t, database)
+
+			handler := &tool.Handler{
+				Logger:   setupTestLogger(),
+				Database: database,
+			}
+
+			ctx := context.Background()
+			var jobID int64 = 1
+			if tt.userID > 0 {
+				createTestUser(t, database.DB(), tt.userID)
+				jobID = tt.setupData(t, database.DB(), tt.userID)
+				ctx = context.WithValue(ctx, contextkey.KeyUserID, tt.userID)
+			}
+
+			req := mcp.CallToolRequest{}
+			req.Params.Arguments = map[string]any{
+				"job_application_id": float64(jobID),
+				"note":               tt.note,
+			}
+
+			result, err := handler.NewAddJobApplicationNoteTool().HandlerFunc(ctx, req)
+			require.NoError(t, err)
+			require.NotNil(t, result)
+
+			var count int
+			if tt.userID > 0 {
+				require.NoError(t, database.DB().QueryRowContext(context.Background(), "SELECT COUNT(*) FROM job_application_notes WHERE job_application_id = ?", jobID).Scan(&count))
+			}
+
+			if tt.expectedError {
+				assert.True(t, result.IsError)
+				assert.Zero(t, count)
+				return
+			}
+
+			assert.False(t, result.IsError)
+			note, ok := result.StructuredContent.(queries.InsertJobApplicationNoteRow)
+			require.True(t, ok, "expected structured content to be queries.InsertJobApplicationNoteRow, got %T", result.StructuredContent)
+			assert.Equal(t, tt.note, note.Note)
+			assert.Equal(t, jobID, note.JobApplicationID)
+			assert.Equal(t, 1, count)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package tool
+
+import (
+	"context"
+	"errors"
+
+	contextkey "github.com/Piszmog/pathwise/internal/context_key"
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (h *Handler) NewContactsTool() Tool {
+	return Tool{
+		Tool: mcp.NewTool(
+			"contacts",
+			mcp.WithDescription("Get your contacts, such as recruiters and hiring managers, along with the job applications they are linked to"),
+			mcp.WithNumber("job_application_id", mcp.Description("ID of the job application to get contacts for (optional - if not provided, returns all contacts)")),
+		),
+		HandlerFunc: h.GetContacts,
+	}
+}
+
+func (h *Handler) GetContacts(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	userID, ok := ctx.Value(contextkey.KeyUserID).(int64)
+	if !ok {
+		h.Logger.ErrorContext(ctx, "authentication failed - user ID not found in context", "tool", "contacts")
+		return mcp.NewToolResultError("failed to authenticate"), nil
+	}
+
+	args := req.GetArguments()
+	jobID, exists, err := getInt64Arg(args, "job_application_id")
+	if err != nil {
+		h.Logger.ErrorContext(ctx, "invalid job_application_id parameter", "tool", "contacts", "provided_value", args["job_application_id"], "expected_type", "float64", "user_id", userID)
+		return mcp.NewToolResultError("invalid job_application_id"), nil
+	}
+
+	if !exists {
+		data, contactsErr := jobapplication.GetContacts(ctx, h.Database.Queries(), userID)
+		if contactsErr != nil {
+			h.Logger.ErrorContext(ctx, "failed to retrieve contacts", "error", contactsErr, "user_id", userID)
+			return nil, errContacts
+		}
+		return mcp.NewToolResultStructuredOnly(data), nil
+	}
+
+	data, err := jobapplication.GetJobApplicationContacts(ctx, h.Database.Queries(), userID, jobID)
+	if err != nil {
+		if errors.Is(err, jobapplication.ErrNotFound) {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		h.Logger.ErrorContext(ctx, "failed to retrieve job application contacts", "error", err, "user_id", userID, "job_application_id", jobID)
+		return nil, errContacts
+	}
+	return mcp.NewToolResultStructuredOnly(data), nil
+}
+
+var errContacts = errors.New("failed to retrieve contacts")
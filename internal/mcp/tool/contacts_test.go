@@ -0,0 +1,123 @@
+//go:build integration
+
+package tool_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	contextkey "github.com/Piszmog/pathwise/internal/context_key"
+	"github.com/Piszmog/pathwise/internal/mcp/tool"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "github.com/tursodatabase/go-libsql"
+)
+
+func TestContactsTool(t *testing.T) {
+	tests := []struct {
+		name             string
+		userID           int64
+		jobApplicationID any
+		expectedNames    []string
+		expectedJobs     int
+		expectedError    bool
+	}{
+		{
+			name:          "unauthenticated user",
+			expectedError: true,
+		},
+		{
+			name:          "all contacts",
+			userID:        1,
+			expectedNames: []string{"Alice", "Bob"},
+			expectedJobs:  1,
+		},
+		{
+			name:             "contacts of a job application",
+			userID:           1,
+			jobApplicationID: "jobID",
+			expectedNames:    []string{"Alice"},
+		},
+		{
+			name:             "job application of another user",
+			userID:           1,
+			jobApplicationID: "otherJobID",
+			expectedError:    true,
+		},
+		{
+			name:             "invalid job_application_id type",
+			userID:           1,
+			jobApplicationID: "invalid_string",
+			expectedError:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			database := setupTestDB(t)
+			defer cleanupTestDB(t, database)
+
+			createTestUser(t, database.DB(), 1)
+			createTestUser(t, database.DB(), 2)
+			jobIDs := map[string]int64{
+				"jobID":      insertJobApplication(t, database.DB(), 1, "Company A", "Engineer", "applied"),
+				"otherJobID": insertJobApplication(t, database.DB(), 2, "Company B", "Developer", "applied"),
+			}
+			insertContact(t, database.DB(), 1, "Bob")
+			aliceID := insertContact(t, database.DB(), 1, "Alice")
+			insertContact(t, database.DB(), 2, "Carol")
+			_, err := database.DB().Exec("INSERT INTO job_application_contacts (job_application_id, contact_id) VALUES (?, ?)", jobIDs["jobID"], aliceID)
+			require.NoError(t, err)
+
+			handler := &tool.Handler{
+				Logger:   setupTestLogger(),
+				Database: database,
+			}
+
+			ctx := context.Background()
+			if tt.userID > 0 {
+				ctx = context.WithValue(ctx, contextkey.KeyUserID, tt.userID)
+			}
+			req := mcp.CallToolRequest{}
+			if tt.jobApplicationID != nil {
+				jobAppID := tt.jobApplicationID
+				if id, ok := jobIDs[tt.jobApplicationID.(string)]; ok {
+					jobAppID = id
+				}
+				req.Params.Arguments = map[string]any{"job_application_id": jobAppID}
+			}
+
+			result, err := handler.NewContactsTool().HandlerFunc(ctx, req)
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			if tt.expectedError {
+				assert.True(t, result.IsError)
+				return
+			}
+			assert.False(t, result.IsError)
+
+			contacts, ok := result.StructuredContent.([]types.Contact)
+			require.True(t, ok, "expected structured content to be []types.Contact, got %T", result.StructuredContent)
+			names := make([]string, len(contacts))
+			jobs := 0
+			for i, contact := range contacts {
+				names[i] = contact.Name
+				jobs += len(contact.JobApplications)
+			}
+			assert.Equal(t, tt.expectedNames, names)
+			assert.Equal(t, tt.expectedJobs, jobs)
+		})
+	}
+}
+
+func insertContact(t *testing.T, db *sql.DB, userID int64, name string) int64 {
+	t.Helper()
+	var id int64
+	err := db.QueryRow("INSERT INTO contacts (user_id, name) VALUES (?, ?) RETURNING id", userID, name).Scan(&id)
+	require.NoError(t, err)
+	return id
+}
@@ -0,0 +1,104 @@
+package tool
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	contextkey "github.com/Piszmog/pathwise/internal/context_key"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (h *Handler) NewCreateJobApplicationTool() Tool {
+	return Tool{
+		Tool: mcp.NewTool(
+			"create_job_application",
+			mcp.WithDescription("Create a new job application with the status 'applied'"),
+			mcp.WithString("company", mcp.Required(), mcp.Description("Name of the company")),
+			mcp.WithString("title", mcp.Required(), mcp.Description("Title of the job")),
+			mcp.WithString("url", mcp.Description("URL of the job posting (optional)")),
+			mcp.WithNumber("salary_min", mcp.Description("Minimum salary (optional)")),
+			mcp.WithNumber("salary_max", mcp.Description("Maximum salary (optional)")),
+			mcp.WithString("salary_currency", mcp.Description("Currency code of the salary, e.g. USD (optional)")),
+		),
+		HandlerFunc: h.CreateJobApplication,
+	}
+}
+
+func (h *Handler) CreateJobApplication(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	userID, ok := ctx.Value(contextkey.KeyUserID).(int64)
+	if !ok {
+		h.Logger.ErrorContext(ctx, "authentication failed - user ID not found in context", "tool", "create_job_application")
+		return mcp.NewToolResultError("failed to authenticate"), nil
+	}
+
+	app, err := getNewJobApplicationArgs(req.GetArguments())
+	if err != nil {
+		h.Logger.WarnContext(ctx, "invalid parameters", "tool", "create_job_application", "error", err, "user_id", userID)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	id, err := jobapplication.Create(ctx, h.Database, userID, app)
+	if err != nil {
+		if isValidationErr(err) {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		h.Logger.ErrorContext(ctx, "failed to create job application", "error", err, "user_id", userID)
+		return nil, errCreateJobApplication
+	}
+
+	data, err := h.Database.Queries().GetJobApplicationByIDAndUserID(ctx, queries.GetJobApplicationByIDAndUserIDParams{ID: id, UserID: userID})
+	if err != nil {
+		h.Logger.ErrorContext(ctx, "failed to retrieve created job application", "error", err, "user_id", userID, "job_application_id", id)
+		return nil, errCreateJobApplication
+	}
+	return mcp.NewToolResultStructuredOnly(data), nil
+}
+
+func getNewJobApplicationArgs(args map[string]any) (jobapplication.NewJobApplication, error) {
+	var app jobapplication.NewJobApplication
+	var err error
+	if app.Company, _, err = getStringArg(args, "company"); err != nil {
+		return app, err
+	}
+	if app.Title, _, err = getStringArg(args, "title"); err != nil {
+		return app, err
+	}
+	if app.URL, _, err = getStringArg(args, "url"); err != nil {
+		return app, err
+	}
+	if app.SalaryMin, err = getNullInt64Arg(args, "salary_min"); err != nil {
+		return app, err
+	}
+	if app.SalaryMax, err = getNullInt64Arg(args, "salary_max"); err != nil {
+		return app, err
+	}
+	currency, _, err := getStringArg(args, "salary_currency")
+	if err != nil {
+		return app, err
+	}
+	if currency != "" {
+		app.SalaryCurrency = sql.NullString{String: currency, Valid: true}
+	}
+	return app, nil
+}
+
+func getNullInt64Arg(args map[string]any, key string) (sql.NullInt64, error) {
+	val, exists, err := getInt64Arg(args, key)
+	if err != nil || !exists {
+		return sql.NullInt64{}, err
+	}
+	return sql.NullInt64{Int64: val, Valid: true}, nil
+}
+
+func isValidationErr(err error) bool {
+	return errors.Is(err, jobapplication.ErrMissingCompanyOrTitle) ||
+		errors.Is(err, jobapplication.ErrInvalidStatus) ||
+		errors.Is(err, jobapplication.ErrInvalidSalaryRange) ||
+		errors.Is(err, jobapplication.ErrMissingNote) ||
+		errors.Is(err, jobapplication.ErrNotFound)
+}
+
+var errCreateJobApplication = errors.New("failed to create job application")
@@ -0,0 +1,116 @@
+//go:build integration
+
+package tool_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	contextkey "github.com/Piszmog/pathwise/internal/context_key"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/mcp/tool"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "github.com/tursodatabase/go-libsql"
+)
+
+func TestCreateJobApplicationTool(t *testing.T) {
+	tests := []struct {
+		name          string
+		userID        int64
+		args          map[string]any
+		setupData     func(t *testing.T, db *sql.DB, userID int64)
+		expectedError bool
+		expectedStats testStats
+	}{
+		{
+			name:          "unauthenticated user",
+			userID:        0,
+			args:          map[string]any{"company": "Company A", "title": "Engineer"},
+			setupData:     func(t *testing.T, db *sql.DB, userID int64) {},
+			expectedError: true,
+		},
+		{
+			name:          "missing company",
+			userID:        1,
+			args:          map[string]any{"title": "Engineer"},
+			setupData:     func(t *testing.T, db *sql.DB, userID int64) {},
+			expectedError: true,
+			expectedStats: testStats{},
+		},
+		{
+			name:          "invalid salary range",
+			userID:        1,
+			args:          map[string]any{"company": "Company A", "title": "Engineer", "salary_min": float64(200000), "salary_max": float64(100000)},
+			setupData:     func(t *testing.T, db *sql.DB, userID int64) {},
+			expectedError: true,
+			expectedStats: testStats{},
+		},
+		{
+			name:          "creates job application",
+			userID:        1,
+			args:          map[string]any{"company": "Company A", "title": "Engineer", "url": "https://a.com/jobs", "salary_min": float64(100000), "salary_max": float64(150000), "salary_currency": "USD"},
+			setupData:     func(t *testing.T, db *sql.DB, userID int64) {},
+			expectedStats: testStats{TotalApplications: 1, TotalCompanies: 1, TotalApplied: 1},
+		},
+		{
+			name:   "existing company is not counted twice",
+			userID: 1,
+			args:   map[string]any{"company": "Company A", "title": "Manager"},
+			setupData: func(t *testing.T, db *sql.DB, userID int64) {
+				insertJobApplication(t, db, userID, "Company A", "Engineer", "applied")
+				_, err := db.ExecContext(context.Background(), "UPDATE job_application_stats SET total_applications = 1, total_companies = 1 WHERE user_id = ?", userID)
+				require.NoError(t, err)
+			},
+			expectedStats: testStats{TotalApplications: 2, TotalCompanies: 1, TotalApplied: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			database := setupTestDB(t)
+			defer cleanupTestDB(t, database)
+
+			handler := &tool.Handler{
+				Logger:   setupTestLogger(),
+				Database: database,
+			}
+
+			ctx := context.Background()
+			if tt.userID > 0 {
+				createTestUser(t, database.DB(), tt.userID)
+				tt.setupData(t, database.DB(), tt.userID)
+				ctx = context.WithValue(ctx, contextkey.KeyUserID, tt.userID)
+			}
+
+			req := mcp.CallToolRequest{}
+			req.Params.Arguments = tt.args
+
+			result, err := handler.NewCreateJobApplicationTool().HandlerFunc(ctx, req)
+			require.NoError(t, err)
+			require.NotNil(t, result)
+
+			if tt.expectedError {
+				assert.True(t, result.IsError)
+				if tt.userID > 0 {
+					assert.Equal(t, tt.expectedStats, getTestStats(t, database.DB(), tt.userID))
+				}
+				return
+			}
+
+			assert.False(t, result.IsError)
+			job, ok := result.StructuredContent.(queries.GetJobApplicationByIDAndUserIDRow)
+			require.True(t, ok, "expected structured content to be queries.GetJobApplicationByIDAndUserIDRow, got %T", result.StructuredContent)
+			assert.Equal(t, tt.args["company"], job.Company)
+			assert.Equal(t, tt.args["title"], job.Title)
+			assert.Equal(t, "applied", job.Status)
+			assert.Equal(t, tt.userID, job.UserID)
+
+			assert.Equal(t, []string{"applied"}, getTestStatusHistory(t, database.DB(), job.ID))
+			assert.Equal(t, tt.expectedStats, getTestStats(t, database.DB(), tt.userID))
+		})
+	}
+}
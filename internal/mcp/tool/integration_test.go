@@ -15,6 +15,8 @@ import (
 	"time"
 
 	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/jobapplication"
 	"github.com/Piszmog/pathwise/internal/testutil"
 	"github.com/stretchr/testify/require"
 )
@@ -119,10 +121,11 @@ func createTestUser(t *testing.T, db *sql.DB, userID int64) {
 	require.NoError(t, err)
 
 	_, err = tx.ExecContext(ctx,
-		"INSERT INTO job_application_stats (user_id, total_applications, total_companies, total_applied) VALUES (?, 0, 0, 0)",
+		"INSERT INTO job_application_stats (user_id, total_applications, total_companies) VALUES (?, 0, 0)",
 		userID,
 	)
 	require.NoError(t, err)
+	require.NoError(t, jobapplication.SeedPipeline(ctx, queries.New(tx), userID))
 
 	require.NoError(t, tx.Commit())
 }
@@ -137,14 +140,22 @@ func insertJobApplication(t *testing.T, db *sql.DB, userID int64, company, title
 		_ = tx.Rollback()
 	}()
 
+	var companyID int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO companies (user_id, name, normalized_name) VALUES (?, ?, ?)
+		ON CONFLICT (user_id, normalized_name) DO UPDATE SET normalized_name = excluded.normalized_name
+		RETURNING id
+	`, userID, company, strings.ToLower(strings.ReplaceAll(company, " ", ""))).Scan(&companyID)
+	require.NoError(t, err)
+
 	var jobID int64
 	err = tx.QueryRowContext(ctx, `
 		INSERT INTO job_applications (
-			user_id, company, title, url, status, applied_at, archived,
+			user_id, company, company_id, title, url, status, applied_at, archived,
 			salary_min, salary_max, salary_currency, created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP, 0, NULL, NULL, NULL, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		) VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, 0, NULL, NULL, NULL, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
 		RETURNING id
-	`, userID, company, title, fmt.Sprintf("https://%s.com/jobs", strings.ToLower(strings.ReplaceAll(company, " ", ""))), status).Scan(&jobID)
+	`, userID, company, companyID, title, fmt.Sprintf("https://%s.com/jobs", strings.ToLower(strings.ReplaceAll(company, " ", ""))), status).Scan(&jobID)
 	require.NoError(t, err)
 
 	_, err = tx.ExecContext(ctx, `
@@ -205,3 +216,53 @@ func insertJobApplicationStatusHistory(t *testing.T, db *sql.DB, jobApplicationI
 func setupTestLogger() *slog.Logger {
 	return slog.New(slog.DiscardHandler)
 }
+
+type testStats struct {
+	TotalApplications int64
+	TotalCompanies    int64
+	TotalApplied      int64
+	TotalInterviewing int64
+	TotalRejected     int64
+}
+
+func getTestStats(t *testing.T, db *sql.DB, userID int64) testStats {
+	t.Helper()
+
+	var stats testStats
+	err := db.QueryRowContext(context.Background(), `
+		SELECT
+			s.total_applications,
+			s.total_companies,
+			(SELECT COUNT(*) FROM job_applications j WHERE j.user_id = s.user_id AND j.archived = 0 AND j.status = 'applied'),
+			(SELECT COUNT(*) FROM job_applications j WHERE j.user_id = s.user_id AND j.archived = 0 AND j.status = 'interviewing'),
+			(SELECT COUNT(*) FROM job_applications j WHERE j.user_id = s.user_id AND j.archived = 0 AND j.status = 'rejected')
+		FROM job_application_stats s
+		WHERE s.user_id = ?
+	`, userID).Scan(&stats.TotalApplications, &stats.TotalCompanies, &stats.TotalApplied, &stats.TotalInterviewing, &stats.TotalRejected)
+	require.NoError(t, err)
+	return stats
+}
+
+func getTestStatusHistory(t *testing.T, db *sql.DB, jobApplicationID int64) []string {
+	t.Helper()
+
+	rows, err := db.QueryContext(context.Background(), `
+		SELECT status
+		FROM job_application_status_histories
+		WHERE job_application_id = ?
+		ORDER BY id
+	`, jobApplicationID)
+	require.NoError(t, err)
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var statuses []string
+	for rows.Next() {
+		var status string
+		require.NoError(t, rows.Scan(&status))
+		statuses = append(statuses, status)
+	}
+	require.NoError(t, rows.Err())
+	return statuses
+}
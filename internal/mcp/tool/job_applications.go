@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	contextkey "github.com/Piszmog/pathwise/internal/context_key"
+	"github.com/Piszmog/pathwise/internal/jobapplication"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
@@ -12,7 +13,8 @@ func (h *Handler) NewJobApplicationsTool() Tool {
 	return Tool{
 		Tool: mcp.NewTool(
 			"job_applications",
-			mcp.WithDescription("Information about your job applications"),
+			mcp.WithDescription("Information about your job applications, including the tags on them"),
+			mcp.WithString("view", mcp.Description("Name of a saved view to only return the job applications matching its filters, in its sort order")),
 		),
 		HandlerFunc: h.GetJobApplications,
 	}
@@ -25,6 +27,23 @@ func (h *Handler) GetJobApplications(ctx context.Context, req mcp.CallToolReques
 		return mcp.NewToolResultError("failed to authenticate"), nil
 	}
 
+	view, _, err := getStringArg(req.GetArguments(), "view")
+	if err != nil {
+		h.Logger.ErrorContext(ctx, "invalid view parameter", "tool", "job_applications", "error", err, "user_id", userID)
+		return mcp.NewToolResultError("invalid view"), nil
+	}
+	if view != "" {
+		jobs, err := jobapplication.GetViewJobApplications(ctx, h.Database.Queries(), userID, view)
+		if err != nil {
+			if errors.Is(err, jobapplication.ErrViewNotFound) {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			h.Logger.ErrorContext(ctx, "failed to retrieve job applications of view", "error", err, "user_id", userID, "view", view)
+			return nil, errJobApplications
+		}
+		return mcp.NewToolResultStructuredOnly(jobs), nil
+	}
+
 	data, err := h.Database.Queries().GetAllJobApplicationsByUserID(ctx, userID)
 	if err != nil {
 		h.Logger.ErrorContext(ctx, "failed to retrieve job applications", "error", err, "user_id", userID)
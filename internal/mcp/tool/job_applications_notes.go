@@ -2,10 +2,13 @@ package tool
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"time"
 
 	contextkey "github.com/Piszmog/pathwise/internal/context_key"
 	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/markdown"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
@@ -13,13 +16,24 @@ func (h *Handler) NewJobApplicationsNotesTool() Tool {
 	return Tool{
 		Tool: mcp.NewTool(
 			"job_applications_notes",
-			mcp.WithDescription("Get notes for job applications"),
+			mcp.WithDescription("Get notes for job applications. Notes are written in Markdown and returned both as written and rendered as HTML"),
 			mcp.WithNumber("job_application_id", mcp.Description("ID of the job application to get notes for (optional - if not provided, returns notes for all applications)")),
 		),
 		HandlerFunc: h.GetJobApplicationsNotes,
 	}
 }
 
+// JobApplicationNote is a note of a job application in both its raw Markdown and rendered HTML forms.
+type JobApplicationNote struct {
+	CreatedAt time.Time
+	// UpdatedAt is when the note was last edited. It is nil when the note was never edited.
+	UpdatedAt        *time.Time
+	Note             string
+	NoteHTML         string
+	JobApplicationID int64
+	ID               int64
+}
+
 func (h *Handler) GetJobApplicationsNotes(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	userID, ok := ctx.Value(contextkey.KeyUserID).(int64)
 	if !ok {
@@ -27,30 +41,54 @@ func (h *Handler) GetJobApplicationsNotes(ctx context.Context, req mcp.CallToolR
 		return mcp.NewToolResultError("failed to authenticate"), nil
 	}
 
-	if jobAppID, exists := req.GetArguments()["job_application_id"]; exists {
-		jobAppIDInt, ok := jobAppID.(int64)
-		if !ok {
-			h.Logger.ErrorContext(ctx, "invalid job_application_id parameter", "tool", "job_applications_notes", "provided_value", jobAppID, "expected_type", "int64", "user_id", userID)
-			return mcp.NewToolResultError("invalid job_application_id"), nil
-		}
+	args := req.GetArguments()
+	jobAppID, exists, err := getInt64Arg(args, "job_application_id")
+	if err != nil {
+		h.Logger.ErrorContext(ctx, "invalid job_application_id parameter", "tool", "job_applications_notes", "provided_value", args["job_application_id"], "expected_type", "float64", "user_id", userID)
+		return mcp.NewToolResultError("invalid job_application_id"), nil
+	}
 
-		data, err := h.Database.Queries().GetJobApplicationNotesByJobApplicationIDAndUserID(ctx, queries.GetJobApplicationNotesByJobApplicationIDAndUserIDParams{
-			JobApplicationID: jobAppIDInt,
+	var notes []JobApplicationNote
+	if exists {
+		rows, err := h.Database.Queries().GetJobApplicationNotesByJobApplicationIDAndUserID(ctx, queries.GetJobApplicationNotesByJobApplicationIDAndUserIDParams{
+			JobApplicationID: jobAppID,
 			UserID:           userID,
 		})
 		if err != nil {
-			h.Logger.ErrorContext(ctx, "failed to retrieve job application notes", "error", err, "user_id", userID, "job_application_id", jobAppIDInt)
+			h.Logger.ErrorContext(ctx, "failed to retrieve job application notes", "error", err, "user_id", userID, "job_application_id", jobAppID)
 			return nil, errJobApplicationsNotes
 		}
-		return mcp.NewToolResultStructuredOnly(data), nil
+		notes = make([]JobApplicationNote, len(rows))
+		for i, row := range rows {
+			notes[i] = JobApplicationNote{CreatedAt: row.CreatedAt, UpdatedAt: nullTime(row.UpdatedAt), Note: row.Note, JobApplicationID: row.JobApplicationID, ID: row.ID}
+		}
 	} else {
-		data, err := h.Database.Queries().GetAllJobApplicationNotesByUserID(ctx, userID)
+		rows, err := h.Database.Queries().GetAllJobApplicationNotesByUserID(ctx, userID)
 		if err != nil {
 			h.Logger.ErrorContext(ctx, "failed to retrieve job applications notes", "error", err, "user_id", userID)
 			return nil, errJobApplicationsNotes
 		}
-		return mcp.NewToolResultStructuredOnly(data), nil
+		notes = make([]JobApplicationNote, len(rows))
+		for i, row := range rows {
+			notes[i] = JobApplicationNote{CreatedAt: row.CreatedAt, UpdatedAt: nullTime(row.UpdatedAt), Note: row.Note, JobApplicationID: row.JobApplicationID, ID: row.ID}
+		}
+	}
+
+	for i := range notes {
+		notes[i].NoteHTML, err = markdown.Render(notes[i].Note)
+		if err != nil {
+			h.Logger.ErrorContext(ctx, "failed to render job application note", "error", err, "user_id", userID, "note_id", notes[i].ID)
+			return nil, errJobApplicationsNotes
+		}
+	}
+	return mcp.NewToolResultStructuredOnly(notes), nil
+}
+
+func nullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
 	}
+	return &t.Time
 }
 
 var errJobApplicationsNotes = errors.New("failed to retrieve job applications notes")
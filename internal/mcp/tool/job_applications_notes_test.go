@@ -9,7 +9,6 @@ import (
 	"testing"
 
 	contextkey "github.com/Piszmog/pathwise/internal/context_key"
-	"github.com/Piszmog/pathwise/internal/db/queries"
 	"github.com/Piszmog/pathwise/internal/mcp/tool"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
@@ -234,27 +233,44 @@ func TestJobApplicationsNotesTool(t *testing.T) {
 				return
 			}
 
-			if tt.jobApplicationID != nil {
-				notes, ok := result.StructuredContent.([]queries.GetJobApplicationNotesByJobApplicationIDAndUserIDRow)
-				require.True(t, ok, "expected structured content to be []queries.GetJobApplicationNotesByJobApplicationIDAndUserIDRow, got %T", result.StructuredContent)
-				assert.Len(t, notes, tt.expectedCount)
-				if tt.expectedCount > 0 {
-					for _, note := range notes {
-						assert.NotEmpty(t, note.Note)
-						assert.NotZero(t, note.JobApplicationID)
-					}
-				}
-			} else {
-				notes, ok := result.StructuredContent.([]queries.GetAllJobApplicationNotesByUserIDRow)
-				require.True(t, ok, "expected structured content to be []queries.GetAllJobApplicationNotesByUserIDRow, got %T", result.StructuredContent)
-				assert.Len(t, notes, tt.expectedCount)
-				if tt.expectedCount > 0 {
-					for _, note := range notes {
-						assert.NotEmpty(t, note.Note)
-						assert.NotZero(t, note.JobApplicationID)
-					}
-				}
+			notes, ok := result.StructuredContent.([]tool.JobApplicationNote)
+			require.True(t, ok, "expected structured content to be []tool.JobApplicationNote, got %T", result.StructuredContent)
+			assert.Len(t, notes, tt.expectedCount)
+			for _, note := range notes {
+				assert.NotEmpty(t, note.Note)
+				assert.NotEmpty(t, note.NoteHTML)
+				assert.NotZero(t, note.JobApplicationID)
 			}
 		})
 	}
 }
+
+func TestJobApplicationsNotesTool_Markdown(t *testing.T) {
+	database := setupTestDB(t)
+	defer cleanupTestDB(t, database)
+
+	createTestUser(t, database.DB(), 1)
+	jobID := insertJobApplication(t, database.DB(), 1, "Company A", "Engineer", "applied")
+	insertJobApplicationNote(t, database.DB(), jobID, "- [x] Send **resume**")
+	_, err := database.DB().Exec("UPDATE job_application_notes SET updated_at = CURRENT_TIMESTAMP WHERE job_application_id = ?", jobID)
+	require.NoError(t, err)
+
+	handler := &tool.Handler{
+		Logger:   setupTestLogger(),
+		Database: database,
+	}
+	ctx := context.WithValue(context.Background(), contextkey.KeyUserID, int64(1))
+	req := mcp.CallToolRequest{}
+	// Numbers are decoded from JSON as float64.
+	req.Params.Arguments = map[string]any{"job_application_id": float64(jobID)}
+	result, err := handler.NewJobApplicationsNotesTool().HandlerFunc(ctx, req)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	notes, ok := result.StructuredContent.([]tool.JobApplicationNote)
+	require.True(t, ok)
+	require.Len(t, notes, 1)
+	assert.Equal(t, "- [x] Send **resume**", notes[0].Note)
+	assert.Equal(t, "<ul>\n<li><input checked=\"\" disabled=\"\" type=\"checkbox\"> Send <strong>resume</strong></li>\n</ul>\n", notes[0].NoteHTML)
+	assert.NotNil(t, notes[0].UpdatedAt)
+}
@@ -11,6 +11,7 @@ import (
 	contextkey "github.com/Piszmog/pathwise/internal/context_key"
 	"github.com/Piszmog/pathwise/internal/db/queries"
 	"github.com/Piszmog/pathwise/internal/mcp/tool"
+	"github.com/Piszmog/pathwise/internal/ui/types"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -135,3 +136,86 @@ func TestJobApplicationsTool(t *testing.T) {
 		})
 	}
 }
+
+func TestJobApplicationsTool_Tags(t *testing.T) {
+	database := setupTestDB(t)
+	defer cleanupTestDB(t, database)
+
+	createTestUser(t, database.DB(), 1)
+	jobID := insertJobApplication(t, database.DB(), 1, "Company A", "Engineer", "applied")
+	insertJobApplication(t, database.DB(), 1, "Company B", "Developer", "applied")
+	for _, name := range []string{"remote", "referral"} {
+		var tagID int64
+		err := database.DB().QueryRow("INSERT INTO tags (user_id, name, color) VALUES (1, ?, 'blue') RETURNING id", name).Scan(&tagID)
+		require.NoError(t, err)
+		_, err = database.DB().Exec("INSERT INTO job_application_tags (job_application_id, tag_id) VALUES (?, ?)", jobID, tagID)
+		require.NoError(t, err)
+	}
+
+	handler := &tool.Handler{
+		Logger:   setupTestLogger(),
+		Database: database,
+	}
+	ctx := context.WithValue(context.Background(), contextkey.KeyUserID, int64(1))
+	result, err := handler.NewJobApplicationsTool().HandlerFunc(ctx, mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	applications, ok := result.StructuredContent.([]queries.GetAllJobApplicationsByUserIDRow)
+	require.True(t, ok)
+	tags := make(map[string]string, len(applications))
+	for _, app := range applications {
+		tags[app.Company] = app.Tags
+	}
+	assert.Equal(t, map[string]string{"Company A": "referral, remote", "Company B": ""}, tags)
+}
+
+func TestJobApplicationsTool_View(t *testing.T) {
+	database := setupTestDB(t)
+	defer cleanupTestDB(t, database)
+
+	createTestUser(t, database.DB(), 1)
+	insertJobApplication(t, database.DB(), 1, "Acme", "Engineer", "interviewing")
+	insertJobApplication(t, database.DB(), 1, "Hooli", "Developer", "interviewing")
+	insertJobApplication(t, database.DB(), 1, "Initech", "Manager", "applied")
+	_, err := database.DB().Exec("INSERT INTO saved_views (user_id, name, filters) VALUES (1, 'Interviewing', 'sort=company&order=desc&status=interviewing')")
+	require.NoError(t, err)
+
+	handler := &tool.Handler{
+		Logger:   setupTestLogger(),
+		Database: database,
+	}
+	ctx := context.WithValue(context.Background(), contextkey.KeyUserID, int64(1))
+
+	t.Run("saved view", func(t *testing.T) {
+		req := mcp.CallToolRequest{}
+		req.Params.Arguments = map[string]any{"view": "interviewing"}
+		result, err := handler.NewJobApplicationsTool().HandlerFunc(ctx, req)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		applications, ok := result.StructuredContent.([]types.JobApplication)
+		require.True(t, ok, "expected structured content to be []types.JobApplication, got %T", result.StructuredContent)
+		companies := make([]string, len(applications))
+		for i, app := range applications {
+			companies[i] = app.Company
+		}
+		assert.Equal(t, []string{"Hooli", "Acme"}, companies)
+	})
+
+	t.Run("unknown view", func(t *testing.T) {
+		req := mcp.CallToolRequest{}
+		req.Params.Arguments = map[string]any{"view": "Remote"}
+		result, err := handler.NewJobApplicationsTool().HandlerFunc(ctx, req)
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+	})
+
+	t.Run("invalid view", func(t *testing.T) {
+		req := mcp.CallToolRequest{}
+		req.Params.Arguments = map[string]any{"view": 1}
+		result, err := handler.NewJobApplicationsTool().HandlerFunc(ctx, req)
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+	})
+}
@@ -1,6 +1,9 @@
 package tool
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -10,3 +13,34 @@ type Tool struct {
 
 	HandlerFunc server.ToolHandlerFunc
 }
+
+// getInt64Arg returns the numeric argument as an int64. Numbers are decoded from JSON as float64.
+func getInt64Arg(args map[string]any, key string) (int64, bool, error) {
+	val, exists := args[key]
+	if !exists || val == nil {
+		return 0, false, nil
+	}
+	switch v := val.(type) {
+	case float64:
+		return int64(v), true, nil
+	case int64:
+		return v, true, nil
+	case int:
+		return int64(v), true, nil
+	default:
+		return 0, true, fmt.Errorf("invalid %s: expected a number, got %T", key, val)
+	}
+}
+
+// getStringArg returns the string argument.
+func getStringArg(args map[string]any, key string) (string, bool, error) {
+	val, exists := args[key]
+	if !exists || val == nil {
+		return "", false, nil
+	}
+	s, ok := val.(string)
+	if !ok {
+		return "", true, fmt.Errorf("invalid %s: expected a string, got %T", key, val)
+	}
+	return strings.TrimSpace(s), true, nil
+}
@@ -0,0 +1,133 @@
+package tool
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	contextkey "github.com/Piszmog/pathwise/internal/context_key"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (h *Handler) NewUpdateJobApplicationTool() Tool {
+	return Tool{
+		Tool: mcp.NewTool(
+			"update_job_application",
+			mcp.WithDescription("Update a job application. Only the provided fields are changed. Changing the status records it in the status history."),
+			mcp.WithNumber("job_application_id", mcp.Required(), mcp.Description("ID of the job application to update")),
+			mcp.WithString("company", mcp.Description("Name of the company")),
+			mcp.WithString("title", mcp.Description("Title of the job")),
+			mcp.WithString("url", mcp.Description("URL of the job posting")),
+			mcp.WithString("status", mcp.Description("Status of the job application. Must be one of the statuses of the user's pipeline, e.g. applied, interviewing or rejected")),
+			mcp.WithNumber("salary_min", mcp.Description("Minimum salary, or null to clear it")),
+			mcp.WithNumber("salary_max", mcp.Description("Maximum salary, or null to clear it")),
+			mcp.WithString("salary_currency", mcp.Description("Currency code of the salary, e.g. USD, or an empty string to clear it")),
+		),
+		HandlerFunc: h.UpdateJobApplication,
+	}
+}
+
+func (h *Handler) UpdateJobApplication(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	userID, ok := ctx.Value(contextkey.KeyUserID).(int64)
+	if !ok {
+		h.Logger.ErrorContext(ctx, "authentication failed - user ID not found in context", "tool", "update_job_application")
+		return mcp.NewToolResultError("failed to authenticate"), nil
+	}
+
+	args := req.GetArguments()
+	id, exists, err := getInt64Arg(args, "job_application_id")
+	if err != nil || !exists {
+		h.Logger.ErrorContext(ctx, "invalid job_application_id parameter", "tool", "update_job_application", "provided_value", args["job_application_id"], "expected_type", "float64", "user_id", userID)
+		return mcp.NewToolResultError("invalid job_application_id"), nil
+	}
+
+	current, err := h.Database.Queries().GetJobApplicationByIDAndUserID(ctx, queries.GetJobApplicationByIDAndUserIDParams{ID: id, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return mcp.NewToolResultError(jobapplication.ErrNotFound.Error()), nil
+		}
+		h.Logger.ErrorContext(ctx, "failed to retrieve job application", "error", err, "user_id", userID, "job_application_id", id)
+		return nil, errUpdateJobApplication
+	}
+
+	app, err := getUpdatedJobApplicationArgs(args, current)
+	if err != nil {
+		h.Logger.WarnContext(ctx, "invalid parameters", "tool", "update_job_application", "error", err, "user_id", userID)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if _, err = jobapplication.Update(ctx, h.Database, userID, app); err != nil {
+		if isValidationErr(err) {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		h.Logger.ErrorContext(ctx, "failed to update job application", "error", err, "user_id", userID, "job_application_id", id)
+		return nil, errUpdateJobApplication
+	}
+
+	data, err := h.Database.Queries().GetJobApplicationByIDAndUserID(ctx, queries.GetJobApplicationByIDAndUserIDParams{ID: id, UserID: userID})
+	if err != nil {
+		h.Logger.ErrorContext(ctx, "failed to retrieve updated job application", "error", err, "user_id", userID, "job_application_id", id)
+		return nil, errUpdateJobApplication
+	}
+	return mcp.NewToolResultStructuredOnly(data), nil
+}
+
+func getUpdatedJobApplicationArgs(args map[string]any, current queries.GetJobApplicationByIDAndUserIDRow) (jobapplication.UpdatedJobApplication, error) {
+	app := jobapplication.UpdatedJobApplication{
+		ID:             current.ID,
+		Company:        current.Company,
+		Title:          current.Title,
+		URL:            current.Url.String,
+		Status:         types.JobApplicationStatus(current.Status),
+		SalaryMin:      current.SalaryMin,
+		SalaryMax:      current.SalaryMax,
+		SalaryCurrency: current.SalaryCurrency,
+	}
+
+	if val, exists, err := getStringArg(args, "company"); err != nil {
+		return app, err
+	} else if exists {
+		app.Company = val
+	}
+	if val, exists, err := getStringArg(args, "title"); err != nil {
+		return app, err
+	} else if exists {
+		app.Title = val
+	}
+	if val, exists, err := getStringArg(args, "url"); err != nil {
+		return app, err
+	} else if exists {
+		app.URL = val
+	}
+	if val, exists, err := getStringArg(args, "status"); err != nil {
+		return app, err
+	} else if exists {
+		app.Status = jobapplication.NormalizeStatus(val)
+	}
+	// An explicit null clears a salary, while a missing argument keeps it.
+	if _, exists := args["salary_min"]; exists {
+		val, err := getNullInt64Arg(args, "salary_min")
+		if err != nil {
+			return app, err
+		}
+		app.SalaryMin = val
+	}
+	if _, exists := args["salary_max"]; exists {
+		val, err := getNullInt64Arg(args, "salary_max")
+		if err != nil {
+			return app, err
+		}
+		app.SalaryMax = val
+	}
+	if val, exists, err := getStringArg(args, "salary_currency"); err != nil {
+		return app, err
+	} else if exists {
+		app.SalaryCurrency = sql.NullString{String: val, Valid: val != ""}
+	}
+	return app, nil
+}
+
+var errUpdateJobApplication = errors.New("failed to update job application")
@@ -0,0 +1,187 @@
+//go:build integration
+
+package tool_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	contextkey "github.com/Piszmog/pathwise/internal/context_key"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/mcp/tool"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "github.com/tursodatabase/go-libsql"
+)
+
+func TestUpdateJobApplicationTool(t *testing.T) {
+	tests := []struct {
+		name            string
+		userID          int64
+		args            map[string]any
+		setupData       func(t *testing.T, db *sql.DB, userID int64) int64
+		expectedError   bool
+		expectedCompany string
+		expectedStatus  string
+		expectedHistory []string
+		expectedStats   testStats
+	}{
+		{
+			name:   "unauthenticated user",
+			userID: 0,
+			args:   map[string]any{"status": "interviewing"},
+			setupData: func(t *testing.T, db *sql.DB, userID int64) int64 {
+				return 1
+			},
+			expectedError: true,
+		},
+		{
+			name:   "missing job_application_id",
+			userID: 1,
+			args:   map[string]any{"status": "interviewing"},
+			setupData: func(t *testing.T, db *sql.DB, userID int64) int64 {
+				return 0
+			},
+			expectedError: true,
+		},
+		{
+			name:   "invalid status",
+			userID: 1,
+			args:   map[string]any{"status": "hired"},
+			setupData: func(t *testing.T, db *sql.DB, userID int64) int64 {
+				return insertJobApplication(t, db, userID, "Company A", "Engineer", "applied")
+			},
+			expectedError:   true,
+			expectedHistory: []string{"applied"},
+		},
+		{
+			name:   "job application belongs to other user",
+			userID: 1,
+			args:   map[string]any{"status": "interviewing"},
+			setupData: func(t *testing.T, db *sql.DB, userID int64) int64 {
+				createTestUser(t, db, 2)
+				return insertJobApplication(t, db, 2, "Other Company", "Engineer", "applied")
+			},
+			expectedError:   true,
+			expectedHistory: []string{"applied"},
+		},
+		{
+			name:   "status change records history and stats",
+			userID: 1,
+			args:   map[string]any{"status": "interviewing"},
+			setupData: func(t *testing.T, db *sql.DB, userID int64) int64 {
+				insertJobApplication(t, db, userID, "Company B", "Developer", "rejected")
+				return insertJobApplication(t, db, userID, "Company A", "Engineer", "applied")
+			},
+			expectedCompany: "Company A",
+			expectedStatus:  "interviewing",
+			expectedHistory: []string{"applied", "interviewing"},
+			expectedStats:   testStats{TotalApplications: 2, TotalCompanies: 2, TotalInterviewing: 1, TotalRejected: 1},
+		},
+		{
+			name:   "partial update keeps status",
+			userID: 1,
+			args:   map[string]any{"company": "Company C"},
+			setupData: func(t *testing.T, db *sql.DB, userID int64) int64 {
+				return insertJobApplication(t, db, userID, "Company A", "Engineer", "applied")
+			},
+			expectedCompany: "Company C",
+			expectedStatus:  "applied",
+			expectedHistory: []string{"applied"},
+			expectedStats:   testStats{TotalApplications: 1, TotalCompanies: 1, TotalApplied: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			database := setupTestDB(t)
+			defer cleanupTestDB(t, database)
+
+			handler := &tool.Handler{
+				Logger:   setupTestLogger(),
+				Database: database,
+			}
+
+			ctx := context.Background()
+			var jobID int64
+			if tt.userID > 0 {
+				createTestUser(t, database.DB(), tt.userID)
+				jobID = tt.setupData(t, database.DB(), tt.userID)
+				ctx = context.WithValue(ctx, contextkey.KeyUserID, tt.userID)
+			}
+
+			args := map[string]any{}
+			for k, v := range tt.args {
+				args[k] = v
+			}
+			if jobID > 0 {
+				args["job_application_id"] = float64(jobID)
+			}
+			req := mcp.CallToolRequest{}
+			req.Params.Arguments = args
+
+			result, err := handler.NewUpdateJobApplicationTool().HandlerFunc(ctx, req)
+			require.NoError(t, err)
+			require.NotNil(t, result)
+
+			if tt.expectedError {
+				assert.True(t, result.IsError)
+				if tt.expectedHistory != nil {
+					assert.Equal(t, tt.expectedHistory, getTestStatusHistory(t, database.DB(), jobID))
+				}
+				return
+			}
+
+			assert.False(t, result.IsError)
+			job, ok := result.StructuredContent.(queries.GetJobApplicationByIDAndUserIDRow)
+			require.True(t, ok, "expected structured content to be queries.GetJobApplicationByIDAndUserIDRow, got %T", result.StructuredContent)
+			assert.Equal(t, tt.expectedCompany, job.Company)
+			assert.Equal(t, tt.expectedStatus, job.Status)
+
+			assert.Equal(t, tt.expectedHistory, getTestStatusHistory(t, database.DB(), jobID))
+			assert.Equal(t, tt.expectedStats, getTestStats(t, database.DB(), tt.userID))
+		})
+	}
+}
+
+func TestUpdateJobApplicationTool_ClearSalary(t *testing.T) {
+	database := setupTestDB(t)
+	defer cleanupTestDB(t, database)
+	handler := &tool.Handler{
+		Logger:   setupTestLogger(),
+		Database: database,
+	}
+
+	createTestUser(t, database.DB(), 1)
+	jobID := insertJobApplication(t, database.DB(), 1, "Company A", "Engineer", "applied")
+	ctx := context.WithValue(context.Background(), contextkey.KeyUserID, int64(1))
+
+	update := func(args map[string]any) queries.GetJobApplicationByIDAndUserIDRow {
+		t.Helper()
+		args["job_application_id"] = float64(jobID)
+		req := mcp.CallToolRequest{}
+		req.Params.Arguments = args
+		result, err := handler.NewUpdateJobApplicationTool().HandlerFunc(ctx, req)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		job, ok := result.StructuredContent.(queries.GetJobApplicationByIDAndUserIDRow)
+		require.True(t, ok)
+		return job
+	}
+
+	job := update(map[string]any{"salary_min": float64(100000), "salary_max": float64(150000), "salary_currency": "USD"})
+	assert.Equal(t, sql.NullInt64{Int64: 100000, Valid: true}, job.SalaryMin)
+	assert.Equal(t, sql.NullInt64{Int64: 150000, Valid: true}, job.SalaryMax)
+
+	// Other updates keep the salary.
+	job = update(map[string]any{"title": "Senior Engineer"})
+	assert.Equal(t, sql.NullInt64{Int64: 100000, Valid: true}, job.SalaryMin)
+
+	job = update(map[string]any{"salary_min": nil, "salary_max": nil, "salary_currency": ""})
+	assert.False(t, job.SalaryMin.Valid)
+	assert.False(t, job.SalaryMax.Valid)
+	assert.False(t, job.SalaryCurrency.Valid)
+}
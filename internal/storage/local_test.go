@@ -0,0 +1,69 @@
+package storage_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Piszmog/pathwise/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocal(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "files")
+	s, err := storage.NewLocal(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Put(t.Context(), "attachments/resume", strings.NewReader("my resume"), 9, "application/pdf"))
+
+	r, err := s.Get(t.Context(), "attachments/resume")
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, "my resume", string(content))
+
+	require.NoError(t, s.Put(t.Context(), "attachments/resume", strings.NewReader("new"), 3, "application/pdf"))
+	r, err = s.Get(t.Context(), "attachments/resume")
+	require.NoError(t, err)
+	content, err = io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, "new", string(content))
+
+	require.NoError(t, s.Delete(t.Context(), "attachments/resume"))
+	_, err = s.Get(t.Context(), "attachments/resume")
+	require.ErrorIs(t, err, storage.ErrNotFound)
+	require.NoError(t, s.Delete(t.Context(), "attachments/resume"))
+}
+
+func TestLocal_ShortContent(t *testing.T) {
+	dir := t.TempDir()
+	s, err := storage.NewLocal(dir)
+	require.NoError(t, err)
+
+	require.Error(t, s.Put(t.Context(), "resume", strings.NewReader("short"), 10, "text/plain"))
+	_, err = s.Get(t.Context(), "resume")
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestLocal_InvalidKey(t *testing.T) {
+	s, err := storage.NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "../secret", "/etc/passwd", "attachments/../../secret"} {
+		t.Run(key, func(t *testing.T) {
+			require.ErrorIs(t, s.Put(t.Context(), key, strings.NewReader("x"), 1, "text/plain"), storage.ErrInvalidKey)
+			_, err = s.Get(t.Context(), key)
+			require.ErrorIs(t, err, storage.ErrInvalidKey)
+			require.ErrorIs(t, s.Delete(t.Context(), key), storage.ErrInvalidKey)
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	amzDateFormat      = "20060102T150405Z"
+	unsignedPayload    = "UNSIGNED-PAYLOAD"
+	maxS3ErrorBodySize = 1 << 10
+)
+
+var ErrS3RequestFailed = errors.New("s3 request failed")
+
+// S3Opts configures the S3 backend.
+type S3Opts struct {
+	// Endpoint is the URL of the S3 compatible service, such as https://s3.us-east-1.amazonaws.com
+	// or http://localhost:9000 for MinIO.
+	Endpoint        string
+	Bucket          string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// S3 stores files in a bucket of an S3 compatible service. Objects are addressed with path style
+// URLs, which every S3 compatible service supports, and requests are signed with AWS Signature
+// Version 4.
+type S3 struct {
+	client   *http.Client
+	endpoint *url.URL
+	opts     S3Opts
+	now      func() time.Time
+}
+
+var _ Storage = (*S3)(nil)
+
+// NewS3 returns a storage that keeps its files in the bucket. The region defaults to us-east-1.
+func NewS3(client *http.Client, opts S3Opts) (*S3, error) {
+	if opts.Endpoint == "" || opts.Bucket == "" {
+		return nil, errors.New("missing s3 endpoint or bucket")
+	}
+	if opts.AccessKeyID == "" || opts.SecretAccessKey == "" {
+		return nil, errors.New("missing s3 credentials")
+	}
+	endpoint, err := url.Parse(opts.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse s3 endpoint: %w", err)
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return nil, fmt.Errorf("invalid s3 endpoint scheme %q", endpoint.Scheme)
+	}
+	if opts.Region == "" {
+		opts.Region = "us-east-1"
+	}
+	return &S3{client: client, endpoint: endpoint, opts: opts, now: time.Now}, nil
+}
+
+func (s *S3) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	req, err := s.newRequest(ctx, http.MethodPut, key, content)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return s3Error(resp)
+	}
+	return nil
+}
+
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		_ = resp.Body.Close()
+		return nil, ErrNotFound
+	default:
+		defer func() { _ = resp.Body.Close() }()
+		return nil, s3Error(resp)
+	}
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// S3 answers 204 whether or not the object existed, but some compatible services answer 404.
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return s3Error(resp)
+	}
+}
+
+func (s *S3) newRequest(ctx context.Context, method string, key string, body io.Reader) (*http.Request, error) {
+	if key == "" || strings.HasPrefix(key, "/") || slices.Contains(strings.Split(key, "/"), "..") {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+
+	u := *s.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.opts.Bucket + "/" + key
+	u.RawPath = uriEncode(u.Path, false)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build new request: %w", err)
+	}
+	return req, nil
+}
+
+func (s *S3) do(req *http.Request) (*http.Response, error) {
+	// The payload is not hashed so uploads can be streamed, which S3 allows for every request.
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	signV4(req, s.opts.Region, s.opts.AccessKeyID, s.opts.SecretAccessKey, s.now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call s3 %s %s: %w", req.Method, req.URL.Path, err)
+	}
+	return resp, nil
+}
+
+func s3Error(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxS3ErrorBodySize))
+	return fmt.Errorf("%w: %s %s: %d %s", ErrS3RequestFailed, resp.Request.Method, resp.Request.URL.Path, resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// signV4 adds the X-Amz-Date and Authorization headers of AWS Signature Version 4 to the request.
+// Every header already set on the request is signed, along with the host. The request must have
+// the X-Amz-Content-Sha256 header.
+func signV4(req *http.Request, region string, accessKeyID string, secretAccessKey string, now time.Time) {
+	amzDate := now.Format(amzDateFormat)
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	signedHeaders := make([]string, 0, len(req.Header)+1)
+	signedHeaders = append(signedHeaders, "host")
+	for name := range req.Header {
+		signedHeaders = append(signedHeaders, strings.ToLower(name))
+	}
+	slices.Sort(signedHeaders)
+
+	scope := now.Format("20060102") + "/" + region + "/s3/aws4_request"
+	signature := signatureV4(req, signedHeaders, scope, secretAccessKey, amzDate)
+	req.Header.Set(
+		"Authorization",
+		"AWS4-HMAC-SHA256 Credential="+accessKeyID+"/"+scope+",SignedHeaders="+strings.Join(signedHeaders, ";")+",Signature="+signature,
+	)
+}
+
+// signatureV4 computes the signature of the request over the headers for the credential scope.
+func signatureV4(req *http.Request, signedHeaders []string, scope string, secretAccessKey string, amzDate string) string {
+	var canonicalHeaders strings.Builder
+	for _, name := range signedHeaders {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = req.Host
+			if value == "" {
+				value = req.URL.Host
+			}
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uriEncode(req.URL.Path, false),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		strings.Join(signedHeaders, ";"),
+		req.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalRequestHash[:])
+
+	parts := strings.Split(scope, "/")
+	key := []byte("AWS4" + secretAccessKey)
+	for _, part := range parts {
+		key = hmacSHA256(key, part)
+	}
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func canonicalQuery(query url.Values) string {
+	pairs := make([]string, 0, len(query))
+	for name, values := range query {
+		for _, value := range values {
+			pairs = append(pairs, uriEncode(name, true)+"="+uriEncode(value, true))
+		}
+	}
+	slices.Sort(pairs)
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode percent-encodes every byte except the unreserved characters, as Signature Version 4
+// requires. Slashes are kept unless encodeSlash is set.
+func uriEncode(s string, encodeSlash bool) string {
+	const hexDigits = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := range len(s) {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hexDigits[c>>4])
+			b.WriteByte(hexDigits[c&0x0f])
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+var (
+	ErrNotFound       = errors.New("file not found")
+	ErrInvalidKey     = errors.New("invalid key")
+	ErrUnknownBackend = errors.New("unknown storage backend")
+)
+
+// Storage stores the content of files under keys. Keys are slash separated relative paths, such as
+// "attachments/9b2c…".
+type Storage interface {
+	// Put stores the content under the key, replacing what is stored under it. The size is the
+	// number of bytes of the content.
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+	// Get returns the content stored under the key. ErrNotFound is returned when nothing is stored
+	// under the key.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the content stored under the key. Deleting a key without content is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// Backend is where the files are stored.
+type Backend string
+
+const (
+	BackendLocal Backend = "local"
+	BackendS3    Backend = "s3"
+)
+
+// Opts configures the storage returned by New.
+type Opts struct {
+	Backend Backend
+	// Dir is the directory of the local backend.
+	Dir string
+	S3  S3Opts
+}
+
+// New creates the storage of the backend. The local backend is used when no backend is set.
+func New(client *http.Client, opts Opts) (Storage, error) {
+	switch opts.Backend {
+	case "", BackendLocal:
+		return NewLocal(opts.Dir)
+	case BackendS3:
+		return NewS3(client, opts.S3)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownBackend, opts.Backend)
+	}
+}
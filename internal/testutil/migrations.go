@@ -79,12 +79,7 @@ func executeMigrationFile(db *sql.DB, path string) error {
 	}
 
 	// Split SQL statements and execute them individually for go-libsql compatibility
-	statements := strings.SplitSeq(string(content), ";")
-	for stmt := range statements {
-		stmt = strings.TrimSpace(stmt)
-		if stmt == "" || strings.HasPrefix(stmt, "--") {
-			continue
-		}
+	for _, stmt := range splitStatements(string(content)) {
 		if _, err := db.ExecContext(context.Background(), stmt); err != nil {
 			return fmt.Errorf("failed to execute migration SQL: %w", err)
 		}
@@ -93,6 +88,32 @@ func executeMigrationFile(db *sql.DB, path string) error {
 	return nil
 }
 
+// splitStatements splits SQL on semicolons, keeping the statements in the body of a trigger
+// together with the trigger that holds them.
+func splitStatements(content string) []string {
+	var statements []string
+	var current strings.Builder
+	for part := range strings.SplitSeq(content, ";") {
+		current.WriteString(part)
+		stmt := strings.TrimSpace(current.String())
+		if isInTriggerBody(stmt) {
+			current.WriteString(";")
+			continue
+		}
+		current.Reset()
+		if stmt == "" || strings.HasPrefix(stmt, "--") {
+			continue
+		}
+		statements = append(statements, stmt)
+	}
+	return statements
+}
+
+func isInTriggerBody(stmt string) bool {
+	upper := strings.ToUpper(stmt)
+	return strings.HasPrefix(upper, "CREATE TRIGGER") && !strings.HasSuffix(upper, "END")
+}
+
 // getRepoRoot finds the project root directory by looking for go.mod
 func getRepoRoot() (string, error) {
 	wd, err := os.Getwd()
@@ -0,0 +1,71 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const attachmentPDF = "%PDF-1.7\n1 0 obj << /Type /Catalog >> endobj\n%%EOF\n"
+
+func TestAttachment_UploadDownloadAndDelete(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addJobApplication(t, "Attachment Company", "Engineer", "https://attachment.com")
+	require.NoError(t, page.GetByRole("button", playwright.PageGetByRoleOptions{Name: "View job"}).First().Click())
+	require.NoError(t, expect.Locator(page.Locator("#attachment-form")).ToBeVisible())
+
+	require.NoError(t, page.Locator("#attachment-file").SetInputFiles([]playwright.InputFile{
+		{Name: "resume.pdf", MimeType: "application/pdf", Buffer: []byte(attachmentPDF)},
+	}))
+	_, err := page.Locator("#attachment-kind").SelectOption(playwright.SelectOptionValues{Values: &[]string{"resume"}})
+	require.NoError(t, err)
+	require.NoError(t, page.Locator("#attachment-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Upload"}).Click())
+	waitForHTMXRequest(t)
+
+	link := page.Locator("#timeline-list").GetByRole("link", playwright.LocatorGetByRoleOptions{Name: "resume.pdf"})
+	require.NoError(t, expect.Locator(link).ToBeVisible())
+	require.NoError(t, expect.Locator(page.Locator("#timeline-list")).ToContainText("Resume attached"))
+
+	download, err := page.ExpectDownload(func() error {
+		return link.Click()
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "resume.pdf", download.SuggestedFilename())
+	path, err := download.Path()
+	require.NoError(t, err)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, attachmentPDF, string(content))
+
+	page.OnDialog(func(dialog playwright.Dialog) {
+		_ = dialog.Accept()
+	})
+	require.NoError(t, page.GetByRole("button", playwright.PageGetByRoleOptions{Name: "Delete resume.pdf"}).Click())
+	waitForHTMXRequest(t)
+	require.NoError(t, expect.Locator(link).ToHaveCount(0))
+}
+
+func TestAttachment_RejectsFileType(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addJobApplication(t, "Rejected Attachment Company", "Engineer", "https://rejected.com")
+	require.NoError(t, page.GetByRole("button", playwright.PageGetByRoleOptions{Name: "View job"}).First().Click())
+	require.NoError(t, expect.Locator(page.Locator("#attachment-form")).ToBeVisible())
+
+	require.NoError(t, page.Locator("#attachment-file").SetInputFiles([]playwright.InputFile{
+		{Name: "resume.pdf", MimeType: "application/pdf", Buffer: []byte("<html><body>not a pdf</body></html>")},
+	}))
+	require.NoError(t, page.Locator("#attachment-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Upload"}).Click())
+	waitForHTMXRequest(t)
+
+	require.NoError(t, expect.Locator(page.Locator("#attachment-form-error")).ToContainText("File type not allowed"))
+	require.NoError(t, expect.Locator(page.Locator("#timeline-list").GetByRole("link", playwright.LocatorGetByRoleOptions{Name: "resume.pdf"})).ToHaveCount(0))
+}
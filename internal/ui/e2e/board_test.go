@@ -0,0 +1,43 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoard_DragCardToColumn(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addJobApplication(t, "Board Company", "Software Engineer", "https://board.com")
+
+	_, err := page.Goto(getFullPath("board"))
+	require.NoError(t, err)
+
+	applied := page.Locator("#board section[data-status='applied']")
+	interviewing := page.Locator("#board section[data-status='interviewing']")
+	require.NoError(t, expect.Locator(page.Locator("#board section")).ToHaveCount(10))
+	require.NoError(t, expect.Locator(applied.Locator("li").GetByText("Board Company")).ToHaveCount(1))
+	require.NoError(t, expect.Locator(applied.Locator("header span")).ToHaveText("1"))
+	require.NoError(t, expect.Locator(interviewing.Locator("header span")).ToHaveText("0"))
+
+	require.NoError(t, applied.Locator("li").Filter(playwright.LocatorFilterOptions{HasText: "Board Company"}).DragTo(interviewing))
+	waitForHTMXRequest(t)
+
+	require.NoError(t, expect.Locator(applied.Locator("li")).ToHaveCount(0))
+	require.NoError(t, expect.Locator(interviewing.Locator("li").GetByText("Board Company")).ToHaveCount(1))
+	require.NoError(t, expect.Locator(applied.Locator("header span")).ToHaveText("0"))
+	require.NoError(t, expect.Locator(interviewing.Locator("header span")).ToHaveText("1"))
+
+	require.NoError(t, interviewing.GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "View"}).Click())
+	require.NoError(t, expect.Locator(page.Locator("#timeline").GetByText("interviewing")).ToHaveCount(1))
+	require.NoError(t, expect.Locator(page.Locator("#timeline").GetByText("applied")).ToHaveCount(1))
+
+	_, err = page.Goto(getFullPath("board"))
+	require.NoError(t, err)
+	require.NoError(t, expect.Locator(interviewing.Locator("li").GetByText("Board Company")).ToHaveCount(1))
+}
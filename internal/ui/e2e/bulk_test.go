@@ -0,0 +1,82 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulk_ChangeStatus(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addJobApplication(t, "Bulk One", "Software Engineer", "https://one.com")
+	addJobApplication(t, "Bulk Two", "Software Engineer", "https://two.com")
+	addJobApplication(t, "Bulk Three", "Software Engineer", "https://three.com")
+
+	selectJobApplication(t, "Bulk One")
+	selectJobApplication(t, "Bulk Two")
+	_, err := page.Locator("#bulk-status").SelectOption(playwright.SelectOptionValues{Values: &[]string{"rejected"}})
+	require.NoError(t, err)
+	require.NoError(t, page.Locator("#bulk-status-button").Click())
+	waitForHTMXRequest(t)
+
+	require.NoError(t, expect.Locator(page.Locator("#bulk-undo-message")).ToHaveText("Changed the status of 2 job applications."))
+	require.NoError(t, expect.Locator(jobRow("Bulk One").GetByText("Rejected")).ToBeVisible())
+	require.NoError(t, expect.Locator(jobRow("Bulk Two").GetByText("Rejected")).ToBeVisible())
+	require.NoError(t, expect.Locator(jobRow("Bulk Three").GetByText("Applied")).ToBeVisible())
+
+	require.NoError(t, page.Locator("#bulk-undo").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Undo"}).Click())
+	waitForHTMXRequest(t)
+
+	require.NoError(t, expect.Locator(page.Locator("#bulk-undo-message")).ToHaveCount(0))
+	require.NoError(t, expect.Locator(jobRow("Bulk One").GetByText("Applied")).ToBeVisible())
+	require.NoError(t, expect.Locator(jobRow("Bulk Two").GetByText("Applied")).ToBeVisible())
+}
+
+func TestBulk_DeleteAndUndo(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addJobApplication(t, "Bulk One", "Software Engineer", "https://one.com")
+	addJobApplication(t, "Bulk Two", "Software Engineer", "https://two.com")
+
+	selectJobApplication(t, "Bulk One")
+	selectJobApplication(t, "Bulk Two")
+	require.NoError(t, page.Locator("#bulk-delete-button").Click())
+	waitForHTMXRequest(t)
+
+	require.NoError(t, expect.Locator(page.Locator("#bulk-undo-message")).ToHaveText("Deleted 2 job applications."))
+	require.NoError(t, expect.Locator(page.Locator("#job-list li")).ToHaveCount(0))
+
+	require.NoError(t, page.Locator("#bulk-undo").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Undo"}).Click())
+	waitForHTMXRequest(t)
+
+	require.NoError(t, expect.Locator(page.Locator("#job-list li")).ToHaveCount(2))
+	require.NoError(t, expect.Locator(jobRow("Bulk One")).ToBeVisible())
+	require.NoError(t, expect.Locator(jobRow("Bulk Two")).ToBeVisible())
+}
+
+func TestBulk_NoSelection(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addJobApplication(t, "Bulk One", "Software Engineer", "https://one.com")
+
+	require.NoError(t, page.Locator("#bulk-delete-button").Click())
+	waitForHTMXRequest(t)
+
+	require.NoError(t, expect.Locator(page.Locator("#bulk-error").GetByText("No job applications selected")).ToBeVisible())
+	require.NoError(t, expect.Locator(page.Locator("#job-list li")).ToHaveCount(1))
+}
+
+func jobRow(company string) playwright.Locator {
+	return page.Locator("#job-list li").Filter(playwright.LocatorFilterOptions{HasText: company})
+}
+
+func selectJobApplication(t *testing.T, company string) {
+	require.NoError(t, page.GetByRole("checkbox", playwright.PageGetByRoleOptions{Name: "Select " + company}).Check())
+}
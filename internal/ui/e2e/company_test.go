@@ -0,0 +1,41 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompanies_GroupAndMerge(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	// The names overlap, so the rows are counted rather than looked up by company.
+	addCompanyJobApplication(t, "Merge Company", "Software Engineer", 1)
+	addCompanyJobApplication(t, " merge  company", "Engineering Manager", 2)
+	addCompanyJobApplication(t, "Merge Company Inc", "Staff Engineer", 3)
+
+	_, err := page.Goto(getFullPath("companies"))
+	require.NoError(t, err)
+	require.NoError(t, expect.Locator(page.Locator("#company-list > li")).ToHaveCount(2))
+
+	require.NoError(t, page.Locator("#company-list").GetByRole("link", playwright.LocatorGetByRoleOptions{Name: "Merge Company", Exact: playwright.Bool(true)}).Click())
+	require.NoError(t, expect.Locator(page.Locator("#company-name")).ToHaveText("Merge Company"))
+	require.NoError(t, expect.Locator(page.Locator("#company-jobs > li")).ToHaveCount(2))
+
+	require.NoError(t, page.Locator("#company-merge-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Merge"}).Click())
+	require.NoError(t, expect.Locator(page.Locator("#company-name")).ToHaveText("Merge Company Inc"))
+	require.NoError(t, expect.Locator(page.Locator("#company-jobs > li")).ToHaveCount(3))
+	require.NoError(t, expect.Locator(page.Locator("#company-merge-form")).ToHaveCount(0))
+}
+
+func addCompanyJobApplication(t *testing.T, company, title string, count int) {
+	require.NoError(t, page.GetByRole("button", playwright.PageGetByRoleOptions{Name: "Add"}).First().Click())
+	require.NoError(t, page.Locator("#new-job-form #company").Fill(company))
+	require.NoError(t, page.Locator("#new-job-form #title").Fill(title))
+	require.NoError(t, page.Locator("#new-job-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Add"}).Click())
+	require.NoError(t, expect.Locator(page.Locator("#job-list > li")).ToHaveCount(count))
+}
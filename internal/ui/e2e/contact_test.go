@@ -0,0 +1,50 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContacts_AddFromJobDetails(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addJobApplication(t, "Contact Company", "Software Engineer", "https://contact.com")
+	require.NoError(t, jobRow("Contact Company").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "View job"}).Click())
+	require.NoError(t, expect.Locator(page.Locator("#job-form #company")).ToHaveValue("Contact Company"))
+
+	require.NoError(t, page.Locator("#job-contacts").GetByText("New contact").Click())
+	require.NoError(t, expect.Locator(page.Locator("#job-contact-company")).ToHaveValue("Contact Company"))
+	require.NoError(t, page.Locator("#job-contact-name").Fill("Jane Recruiter"))
+	require.NoError(t, page.Locator("#job-contact-role").Fill("Recruiter"))
+	require.NoError(t, page.Locator("#job-contact-email").Fill("jane@contact.com"))
+	require.NoError(t, page.Locator("#job-contact-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Add contact"}).Click())
+	waitForHTMXRequest(t)
+
+	require.NoError(t, expect.Locator(page.Locator("#job-contacts li")).ToHaveCount(1))
+	require.NoError(t, expect.Locator(page.Locator("#job-contacts").GetByText("jane@contact.com")).ToBeVisible())
+
+	_, err := page.Goto(getFullPath("contacts"))
+	require.NoError(t, err)
+	require.NoError(t, expect.Locator(page.Locator("#contact-list > li")).ToHaveCount(1))
+	require.NoError(t, expect.Locator(page.Locator("#contact-list").GetByText("Contact Company · Software Engineer")).ToBeVisible())
+}
+
+func TestContacts_InvalidLinkedInURL(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	_, err := page.Goto(getFullPath("contacts"))
+	require.NoError(t, err)
+	require.NoError(t, page.Locator("#contact-name").Fill("Jane"))
+	require.NoError(t, page.Locator("#contact-linkedin").Fill("https://example.com/in/jane"))
+	require.NoError(t, page.Locator("#contact-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Add Contact"}).Click())
+	waitForHTMXRequest(t)
+
+	require.NoError(t, expect.Locator(page.Locator("#contacts-error")).ToContainText("Invalid LinkedIn URL"))
+	require.NoError(t, expect.Locator(page.Locator("#contact-list > li")).ToHaveCount(0))
+}
@@ -0,0 +1,55 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrency_SalaryAnalyticsInDisplayCurrency(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addJobApplicationWithSalary(t, "Dollar Corp", "Software Engineer", "https://dollar.com", "USD", "100000", "120000")
+	addJobApplicationWithSalary(t, "Euro Corp", "Software Engineer", "https://euro.com", "EUR", "90000", "110000")
+
+	_, err := page.Goto(getFullPath("analytics"))
+	require.NoError(t, err)
+	require.NoError(t, expect.Locator(page.Locator("#salary-table")).ToContainText("$100,000"))
+	require.NoError(t, expect.Locator(page.Locator("#salary-unconverted")).ToContainText("1"))
+
+	_, err = page.Goto(getFullPath("settings"))
+	require.NoError(t, err)
+	require.NoError(t, page.Locator("#exchange_rates_file").SetInputFiles([]playwright.InputFile{
+		{Name: "rates.csv", MimeType: "text/csv", Buffer: []byte("currency,rate\nEUR,1.25\n")},
+	}))
+	require.NoError(t, page.Locator("#exchange-rates-import-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Import"}).Click())
+	require.NoError(t, expect.Locator(page.Locator("#exchange-rates-list")).ToContainText("1.25"))
+
+	_, err = page.Locator("#display_currency").SelectOption(playwright.SelectOptionValues{Values: &[]string{"EUR"}})
+	require.NoError(t, err)
+	require.NoError(t, page.Locator("#display-currency-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Save"}).Click())
+	waitForHTMXRequest(t)
+
+	_, err = page.Goto(getFullPath("analytics"))
+	require.NoError(t, err)
+	require.NoError(t, expect.Locator(page.Locator("#salary-table")).ToContainText("€85,000"))
+	require.NoError(t, expect.Locator(page.Locator("#salary-table")).ToContainText("€103,000"))
+	require.NoError(t, expect.Locator(page.Locator("#salary-unconverted")).ToHaveCount(0))
+}
+
+func TestCurrency_InvalidExchangeRatesFile(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	_, err := page.Goto(getFullPath("settings"))
+	require.NoError(t, err)
+	require.NoError(t, page.Locator("#exchange_rates_file").SetInputFiles([]playwright.InputFile{
+		{Name: "rates.csv", MimeType: "text/csv", Buffer: []byte("EUR,abc\n")},
+	}))
+	require.NoError(t, page.Locator("#exchange-rates-import-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Import"}).Click())
+	require.NoError(t, expect.Locator(page.GetByText("Invalid exchange rates file")).ToBeVisible())
+}
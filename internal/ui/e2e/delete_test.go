@@ -0,0 +1,38 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteJobApplication(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addJobApplication(t, "Delete Company", "Software Engineer", "https://delete.com")
+	addJobApplication(t, "Keep Company", "Software Engineer", "https://keep.com")
+	require.NoError(t, jobRow("Delete Company").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "View job"}).Click())
+	require.NoError(t, expect.Locator(page.Locator("#job-form #company")).ToHaveValue("Delete Company"))
+	addNote(t, "This note goes with the job")
+
+	require.NoError(t, page.Locator("#job-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Delete", Exact: playwright.Bool(true)}).Click())
+	confirm := page.Locator("#delete-job-confirm")
+	require.NoError(t, expect.Locator(confirm).ToBeVisible())
+
+	// Keeping the job application leaves it in the list.
+	require.NoError(t, confirm.GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Keep"}).Click())
+	require.NoError(t, expect.Locator(confirm).ToBeHidden())
+	require.NoError(t, expect.Locator(page.Locator("#job-list li")).ToHaveCount(2))
+
+	require.NoError(t, page.Locator("#job-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Delete", Exact: playwright.Bool(true)}).Click())
+	require.NoError(t, confirm.GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Delete permanently"}).Click())
+	waitForHTMXRequest(t)
+
+	require.NoError(t, expect.Locator(page.Locator("#job-list li")).ToHaveCount(1))
+	require.NoError(t, expect.Locator(page.Locator("#job-list").GetByText("Keep Company")).ToBeVisible())
+	require.NoError(t, expect.Locator(page.Locator("#job-list").GetByText("Delete Company")).ToHaveCount(0))
+}
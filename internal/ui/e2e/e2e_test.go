@@ -20,6 +20,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/jobapplication"
 	"github.com/Piszmog/pathwise/internal/testutil"
 	"github.com/playwright-community/playwright-go"
 	_ "github.com/tursodatabase/go-libsql"
@@ -29,6 +31,12 @@ const (
 	dbFile = "test-db.sqlite3"
 )
 
+// mailDir is where the app writes the emails it sends, so the tests can follow the links in them.
+var mailDir = filepath.Join(os.TempDir(), "pathwise-e2e-mail")
+
+// fixedTime is the time the app is started with, so two-factor codes can be generated by the tests.
+var fixedTime = time.Date(2026, time.January, 15, 10, 0, 0, 0, time.UTC)
+
 // global variables, can be used in any tests
 var (
 	pw          *playwright.Playwright
@@ -135,8 +143,12 @@ func startApp() error {
 	app.Env = append(
 		os.Environ(),
 		"DB_URL=./"+dbFile,
+		"STORAGE_DIR="+filepath.Join(os.TempDir(), "pathwise-e2e-attachments"),
+		"MAIL_BACKEND=file",
+		"MAIL_DIR="+mailDir,
 		fmt.Sprintf("PORT=%d", port),
 		"LOG_LEVEL=DEBUG",
+		"FIXED_TIME="+fixedTime.Format(time.RFC3339),
 	)
 
 	baseUrL, err = url.Parse(fmt.Sprintf("http://localhost:%d", port))
@@ -222,8 +234,29 @@ func cleanDB(t *testing.T) error {
 	clearQueries := []string{
 		"DELETE FROM job_application_notes;",
 		"DELETE FROM job_application_status_histories;",
+		"DELETE FROM job_application_tags;",
+		"DELETE FROM tags;",
+		"DELETE FROM job_application_contacts;",
+		"DELETE FROM contacts;",
+		"DELETE FROM job_application_offers;",
+		"DELETE FROM exchange_rates;",
+		"DELETE FROM saved_views;",
+		"DELETE FROM job_application_attachments;",
 		"DELETE FROM job_applications;",
+		"DELETE FROM resume_versions;",
+		"DELETE FROM companies;",
 		"DELETE FROM job_application_stats;",
+		"DELETE FROM job_application_statuses;",
+		"DELETE FROM signin_audits;",
+		"DELETE FROM signin_throttles;",
+		"DELETE FROM email_verification_tokens;",
+		"DELETE FROM password_reset_tokens;",
+		"DELETE FROM passkey_sessions;",
+		"DELETE FROM passkeys;",
+		"DELETE FROM two_factor_failures;",
+		"DELETE FROM two_factor_challenges;",
+		"DELETE FROM user_recovery_codes;",
+		"DELETE FROM user_totp;",
 		"DELETE FROM sessions;",
 		"DELETE FROM user_ips;",
 		"DELETE FROM users;",
@@ -260,9 +293,24 @@ func seedDB(t *testing.T) error {
 		return err
 	}
 
+	var userID int64
+	if err = tx.QueryRow(`SELECT id FROM users WHERE email = 'existing-user@test.com'`).Scan(&userID); err != nil {
+		return err
+	}
+	if err = jobapplication.SeedPipeline(context.Background(), queries.New(tx), userID); err != nil {
+		return err
+	}
+
+	// Create the company of the job application
+	_, err = tx.Exec(`INSERT INTO companies (user_id, name, normalized_name)
+		SELECT id, 'Company A', 'companya' FROM users WHERE email = 'existing-user@test.com'`)
+	if err != nil {
+		return err
+	}
+
 	// Create job application using the user ID from the subquery
-	_, err = tx.Exec(`INSERT INTO job_applications (company, title, url, applied_at, user_id, archived) 
-		SELECT 'Company A', 'Title A', 'http://companyA/titleA', datetime('now', '-2 days'), id, 0
+	_, err = tx.Exec(`INSERT INTO job_applications (company, company_id, title, url, applied_at, user_id, archived) 
+		SELECT 'Company A', last_insert_rowid(), 'Title A', 'http://companyA/titleA', datetime('now', '-2 days'), id, 0
 		FROM users WHERE email = 'existing-user@test.com'`)
 	if err != nil {
 		return err
@@ -276,8 +324,8 @@ func seedDB(t *testing.T) error {
 	}
 
 	// Create stats (use INSERT OR REPLACE to handle existing stats from migration)
-	_, err = tx.Exec(`INSERT OR REPLACE INTO job_application_stats (total_applications, total_companies, total_applied, user_id)
-		SELECT 1, 1, 1, id FROM users WHERE email = 'existing-user@test.com'`)
+	_, err = tx.Exec(`INSERT OR REPLACE INTO job_application_stats (total_applications, total_companies, user_id)
+		SELECT 1, 1, id FROM users WHERE email = 'existing-user@test.com'`)
 	if err != nil {
 		return err
 	}
@@ -389,7 +437,7 @@ func createTestUser(t *testing.T, email string) {
 	hashedPassword := "$2a$14$YRpu0/fntbFMA8Zne3hyLufuYhNkeoM/.68SvNXduN0/eE/s0A3hm"
 
 	var userID int64
-	err = tx.QueryRow("INSERT INTO users (email, password) VALUES (?, ?) RETURNING id", email, hashedPassword).Scan(&userID)
+	err = tx.QueryRow("INSERT INTO users (email, password, email_verified_at) VALUES (?, ?, CURRENT_TIMESTAMP) RETURNING id", email, hashedPassword).Scan(&userID)
 	if err != nil {
 		t.Fatalf("could not create test user: %v", err)
 	}
@@ -399,6 +447,10 @@ func createTestUser(t *testing.T, email string) {
 		t.Fatalf("could not create job application stats: %v", err)
 	}
 
+	if err = jobapplication.SeedPipeline(context.Background(), queries.New(tx), userID); err != nil {
+		t.Fatalf("could not seed pipeline: %v", err)
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		t.Fatalf("failed to commit transaction: %v", err)
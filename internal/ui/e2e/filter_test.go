@@ -0,0 +1,64 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter_SortAndKeepInURL(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addJobApplicationWithSalary(t, "Beta Corp", "Engineer", "https://beta.com", "USD", "90000", "100000")
+	addJobApplicationWithSalary(t, "Alpha Corp", "Engineer", "", "USD", "140000", "150000")
+	addJobApplication(t, "Gamma Corp", "Engineer", "https://gamma.com")
+
+	_, err := page.Locator("#filter-form #sort-select").SelectOption(playwright.SelectOptionValues{Values: &[]string{"company"}})
+	require.NoError(t, err)
+	require.NoError(t, page.Locator("#filter-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Filter"}).Click())
+	waitForHTMXRequest(t)
+	require.NoError(t, expect.Locator(page.Locator("#job-list > li").First()).ToContainText("Alpha Corp"))
+	require.NoError(t, expect.Page(page).ToHaveURL(regexp.MustCompile(`sort=company`)))
+
+	_, err = page.Locator("#filter-form #sort-select").SelectOption(playwright.SelectOptionValues{Values: &[]string{"salary"}})
+	require.NoError(t, err)
+	_, err = page.Locator("#filter-form #order-select").SelectOption(playwright.SelectOptionValues{Values: &[]string{"asc"}})
+	require.NoError(t, err)
+	require.NoError(t, page.Locator("#more-filters summary").Click())
+	require.NoError(t, page.Locator("#filter-has-url").Check())
+	require.NoError(t, page.Locator("#filter-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Filter"}).Click())
+	waitForHTMXRequest(t)
+	require.NoError(t, expect.Locator(page.Locator("#job-list > li")).ToHaveCount(2))
+	require.NoError(t, expect.Locator(page.Locator("#job-list > li").First()).ToContainText("Beta Corp"))
+
+	// The filters survive a reload.
+	_, err = page.Reload()
+	require.NoError(t, err)
+	require.NoError(t, expect.Locator(page.Locator("#filter-form #sort-select")).ToHaveValue("salary"))
+	require.NoError(t, expect.Locator(page.Locator("#filter-has-url")).ToBeChecked())
+	require.NoError(t, expect.Locator(page.Locator("#job-list > li")).ToHaveCount(2))
+	require.NoError(t, expect.Locator(page.Locator("#job-list > li").First()).ToContainText("Beta Corp"))
+
+	clearFilter(t)
+	require.NoError(t, expect.Locator(page.Locator("#job-list > li")).ToHaveCount(3))
+}
+
+func TestFilter_SharedURL(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addJobApplicationWithSalary(t, "Low Pay", "Engineer", "https://low.com", "USD", "50000", "60000")
+	addJobApplicationWithSalary(t, "High Pay", "Engineer", "https://high.com", "USD", "150000", "160000")
+
+	_, err := page.Goto(getFullPath("") + "?salary_min=100000")
+	require.NoError(t, err)
+	require.NoError(t, expect.Locator(page.Locator("#more-filters")).ToHaveAttribute("open", ""))
+	require.NoError(t, expect.Locator(page.Locator("#filter-salary-min")).ToHaveValue("100000"))
+	require.NoError(t, expect.Locator(page.Locator("#job-list > li")).ToHaveCount(1))
+	require.NoError(t, expect.Locator(page.Locator("#job-list")).ToContainText("High Pay"))
+}
@@ -0,0 +1,68 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+const importCSV = `Company,Job Title,Status,Min Salary,Max Salary,Currency,URL,Applied Date,Last Updated
+Import Corp,Backend Engineer,interviewing,100000,150000,USD,https://importcorp.com,2025-01-02,2025-01-10 12:00:00
+Duplicate Corp,Frontend Engineer,applied,,,,https://duplicatecorp.com,2025-01-03,2025-01-03 09:00:00
+Invalid Corp,Designer,hired,,,,,2025-01-04,
+`
+
+func TestImport_CSV(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addJobApplication(t, "Duplicate Corp", "Frontend Engineer", "https://duplicatecorp.com")
+	require.NoError(t, expect.Locator(page.Locator("#job-list > li")).ToHaveCount(1))
+
+	_, err := page.Goto(getFullPath("import/csv"))
+	require.NoError(t, err)
+
+	require.NoError(t, page.Locator("#file").SetInputFiles([]playwright.InputFile{
+		{Name: "job-applications.csv", MimeType: "text/csv", Buffer: []byte(importCSV)},
+	}))
+	require.NoError(t, page.GetByRole("button", playwright.PageGetByRoleOptions{Name: "Preview"}).Click())
+
+	require.NoError(t, expect.Locator(page.Locator("#import-csv-row-2")).ToContainText("Ready"))
+	require.NoError(t, expect.Locator(page.Locator("#import-csv-row-3")).ToContainText("Duplicate"))
+	require.NoError(t, expect.Locator(page.Locator("#import-csv-row-4")).ToContainText(`invalid status "hired"`))
+
+	require.NoError(t, page.GetByRole("button", playwright.PageGetByRoleOptions{Name: "Import"}).Click())
+	require.NoError(t, expect.Locator(page.GetByText("Imported 1 job applications.")).ToBeVisible())
+	require.NoError(t, expect.Locator(page.GetByText("Line 3: duplicate")).ToBeVisible())
+
+	_, err = page.Goto(getFullPath(""))
+	require.NoError(t, err)
+
+	require.NoError(t, expect.Locator(page.Locator("#job-list > li")).ToHaveCount(2))
+	require.NoError(t, expect.Locator(page.GetByText("Import Corp")).ToHaveCount(1))
+}
+
+func TestImport_CSVMissingMapping(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	_, err := page.Goto(getFullPath("import/csv"))
+	require.NoError(t, err)
+
+	require.NoError(t, page.Locator("#file").SetInputFiles([]playwright.InputFile{
+		{Name: "job-applications.csv", MimeType: "text/csv", Buffer: []byte("Employer,Role\nImport Corp,Engineer\n")},
+	}))
+	require.NoError(t, page.GetByRole("button", playwright.PageGetByRoleOptions{Name: "Preview"}).Click())
+	require.NoError(t, expect.Locator(page.GetByText("Invalid column mapping")).ToBeVisible())
+
+	_, err = page.Locator("#mapping-0").SelectOption(playwright.SelectOptionValues{Values: playwright.StringSlice("company")})
+	require.NoError(t, err)
+	require.NoError(t, expect.Locator(page.Locator("#mapping-0")).ToHaveValue("company"))
+	_, err = page.Locator("#mapping-1").SelectOption(playwright.SelectOptionValues{Values: playwright.StringSlice("title")})
+	require.NoError(t, err)
+
+	require.NoError(t, expect.Locator(page.Locator("#import-csv-row-2")).ToContainText("Ready"))
+}
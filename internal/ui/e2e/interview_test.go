@@ -0,0 +1,65 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterview_ScheduleWhileInterviewing(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addJobApplication(t, "Interview Company", "Software Engineer", "https://interview.com")
+	updateJobApplication(t, "", "", "", "applied")
+	require.NoError(t, expect.Locator(page.Locator("#interview-form")).ToHaveCount(0))
+
+	updateJobApplication(t, "", "", "", "interviewing")
+	require.NoError(t, expect.Locator(page.Locator("#interview-form")).ToBeVisible())
+
+	require.NoError(t, page.Locator("#interview-form #interview_scheduled_at").Fill("2030-01-15T10:30"))
+	_, err := page.Locator("#interview-form #timezone-select").SelectOption(playwright.SelectOptionValues{Values: &[]string{"America/New_York"}})
+	require.NoError(t, err)
+	_, err = page.Locator("#interview-form #type-select").SelectOption(playwright.SelectOptionValues{Values: &[]string{"technical"}})
+	require.NoError(t, err)
+	require.NoError(t, page.Locator("#interview-form #interview_interviewers").Fill("Jane Doe"))
+	require.NoError(t, page.Locator("#interview-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Add interview"}).Click())
+	waitForHTMXRequest(t)
+
+	require.NoError(t, expect.Locator(page.Locator("#job-interviews > ul > li")).ToHaveCount(1))
+	require.NoError(t, expect.Locator(page.Locator("#timeline").GetByText("Technical interview scheduled for")).ToHaveCount(1))
+	require.NoError(t, expect.Locator(page.Locator("#timeline").GetByText("Tue Jan 15 2030 10:30 AM (America/New_York)")).ToHaveCount(1))
+
+	_, err = page.Locator("#job-interviews select[name=outcome]").SelectOption(playwright.SelectOptionValues{Values: &[]string{"passed"}})
+	require.NoError(t, err)
+	waitForHTMXRequest(t)
+	require.NoError(t, expect.Locator(page.Locator("#job-interviews select[name=outcome]")).ToHaveValue("passed"))
+}
+
+func TestInterview_CalendarFeed(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	_, err := page.Goto(getFullPath("settings"))
+	require.NoError(t, err)
+	require.NoError(t, page.GetByRole("button", playwright.PageGetByRoleOptions{Name: "Create Calendar Link"}).Click())
+	require.NoError(t, expect.Locator(page.Locator("#calendar-feed-input")).ToBeVisible())
+
+	feedURL, err := page.Locator("#calendar-feed-input").InputValue()
+	require.NoError(t, err)
+	require.Contains(t, feedURL, "/calendar/")
+
+	resp, err := page.Request().Get(feedURL)
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.Status())
+	body, err := resp.Text()
+	require.NoError(t, err)
+	require.Contains(t, body, "BEGIN:VCALENDAR")
+
+	resp, err = page.Request().Get(getFullPath("calendar/unknown.ics"))
+	require.NoError(t, err)
+	require.Equal(t, 404, resp.Status())
+}
@@ -0,0 +1,51 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNote_Markdown(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addJobApplication(t, "Markdown Company", "Engineer", "https://markdown.com")
+	addNote(t, "**Prep** for [the call](https://example.com/call)\n\n- [x] Send resume\n- [ ] Follow up\n\n<script>document.title = 'hacked'</script>")
+
+	note := page.Locator("#timeline-list .markdown").First()
+	require.NoError(t, expect.Locator(note.Locator("strong")).ToHaveText("Prep"))
+	require.NoError(t, expect.Locator(note.GetByRole("link", playwright.LocatorGetByRoleOptions{Name: "the call"})).ToHaveAttribute("href", "https://example.com/call"))
+	require.NoError(t, expect.Locator(note.Locator("input[type=checkbox]")).ToHaveCount(2))
+	require.NoError(t, expect.Locator(note.Locator("input[type=checkbox]").First()).ToBeChecked())
+	require.NoError(t, expect.Locator(note.Locator("script")).ToHaveCount(0))
+}
+
+func TestNote_EditAndDelete(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addJobApplication(t, "Edit Note Company", "Engineer", "https://editnote.com")
+	addNote(t, "Phone screen on Monday")
+	note := page.Locator("#timeline-list .note-content").First()
+	require.NoError(t, expect.Locator(note).ToContainText("Phone screen on Monday"))
+	require.NoError(t, expect.Locator(note.Locator(".note-edited")).ToHaveCount(0))
+
+	require.NoError(t, note.GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Edit"}).Click())
+	require.NoError(t, expect.Locator(note.GetByLabel("Edit note")).ToHaveValue("Phone screen on Monday"))
+	require.NoError(t, note.GetByLabel("Edit note").Fill("Phone screen moved to Tuesday"))
+	require.NoError(t, note.GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Save"}).Click())
+	waitForHTMXRequest(t)
+	require.NoError(t, expect.Locator(note).ToContainText("Phone screen moved to Tuesday"))
+	require.NoError(t, expect.Locator(note.Locator(".note-edited")).ToHaveText("(edited)"))
+
+	page.OnDialog(func(dialog playwright.Dialog) {
+		_ = dialog.Accept()
+	})
+	require.NoError(t, note.GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Delete"}).Click())
+	waitForHTMXRequest(t)
+	require.NoError(t, expect.Locator(page.Locator("#timeline-list .note-content")).ToHaveCount(0))
+}
@@ -0,0 +1,44 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOffers_RecordAndCompare(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addJobApplication(t, "Offer Company", "Software Engineer", "https://offer.com")
+	require.NoError(t, expect.Locator(page.Locator("#offer-form")).ToHaveCount(0))
+	updateJobApplication(t, "", "", "", "offered")
+	waitForHTMXRequest(t)
+
+	require.NoError(t, expect.Locator(page.Locator("#offer-form")).ToBeVisible())
+	require.NoError(t, page.Locator("#offer_base_salary").Fill("150000"))
+	require.NoError(t, page.Locator("#offer_bonus").Fill("15000"))
+	require.NoError(t, page.Locator("#offer_sign_on_bonus").Fill("10000"))
+	require.NoError(t, page.Locator("#offer-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Save offer"}).Click())
+	waitForHTMXRequest(t)
+
+	require.NoError(t, expect.Locator(page.Locator("#job-offer-summary")).ToContainText("$175,000"))
+	require.NoError(t, expect.Locator(page.Locator("#job-offer-summary")).ToContainText("$670,000"))
+
+	_, err := page.Goto(getFullPath("offers"))
+	require.NoError(t, err)
+	require.NoError(t, expect.Locator(page.Locator("#offers-table")).ToContainText("Offer Company"))
+	require.NoError(t, expect.Locator(page.Locator("#offers-table")).ToContainText("$175,000"))
+
+	_, err = page.Locator("#offers_currency").SelectOption(playwright.SelectOptionValues{Values: &[]string{"EUR"}})
+	require.NoError(t, err)
+	require.NoError(t, expect.Locator(page.Locator("#offers-table")).ToContainText("no exchange rate"))
+
+	require.NoError(t, page.Locator("#rate_EUR").Fill("1.25"))
+	require.NoError(t, page.Locator("#exchange-rates-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Save rates"}).Click())
+	waitForHTMXRequest(t)
+	require.NoError(t, expect.Locator(page.Locator("#offers-table")).ToContainText("€140,000"))
+}
@@ -0,0 +1,95 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasskey_Signin(t *testing.T) {
+	beforeEach(t)
+	addVirtualAuthenticator(t)
+	createUserAndSignIn(t)
+	enableTwoFactor(t)
+
+	addPasskey(t, "Laptop")
+	require.NoError(t, expect.Locator(page.Locator("#passkeys li")).ToHaveCount(1))
+	require.NoError(t, expect.Locator(page.Locator("#passkeys")).ToContainText("Never used."))
+
+	// The passkey stands in for both the password and the two-factor code.
+	signout(t)
+	require.NoError(t, page.GetByRole("button", playwright.PageGetByRoleOptions{Name: "Sign in with a passkey"}).Click())
+	require.NoError(t, expect.Page(page).ToHaveURL(getFullPath("")+"/", playwright.PageAssertionsToHaveURLOptions{
+		Timeout: playwright.Float(10000),
+	}))
+
+	_, err := page.Goto(getFullPath("settings"))
+	require.NoError(t, err)
+	require.NoError(t, expect.Locator(page.Locator("#passkeys")).ToContainText("Last used on"))
+	require.NoError(t, expect.Locator(page.Locator("#two-factor-section")).ToContainText("Enabled"))
+}
+
+func TestPasskey_Delete(t *testing.T) {
+	beforeEach(t)
+	addVirtualAuthenticator(t)
+	createUserAndSignIn(t)
+
+	addPasskey(t, " ")
+	require.NoError(t, expect.Locator(page.Locator("#passkeys-error")).ToContainText("Invalid name"))
+	require.NoError(t, expect.Locator(page.Locator("#passkeys li")).ToHaveCount(0))
+
+	addPasskey(t, "Laptop")
+	require.NoError(t, expect.Locator(page.Locator("#passkeys li")).ToHaveCount(1))
+
+	page.OnDialog(func(dialog playwright.Dialog) {
+		_ = dialog.Accept()
+	})
+	require.NoError(t, page.Locator("#passkeys-section").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Delete Laptop"}).Click())
+	waitForHTMXRequest(t)
+	require.NoError(t, expect.Locator(page.Locator("#passkeys li")).ToHaveCount(0))
+
+	// The authenticator still holds the passkey, but the app no longer accepts it.
+	signout(t)
+	require.NoError(t, page.GetByRole("button", playwright.PageGetByRoleOptions{Name: "Sign in with a passkey"}).Click())
+	require.NoError(t, expect.Locator(page.Locator("#login-error")).ToContainText("Passkey not accepted"))
+}
+
+// addVirtualAuthenticator attaches a platform authenticator to the page that verifies the user
+// without a prompt. It relies on the DevTools protocol, so only Chromium supports it.
+func addVirtualAuthenticator(t *testing.T) {
+	t.Helper()
+	if !isChromium {
+		t.Skip("virtual authenticators are only supported by Chromium")
+	}
+	session, err := ctx.NewCDPSession(page)
+	require.NoError(t, err)
+	_, err = session.Send("WebAuthn.enable", map[string]interface{}{})
+	require.NoError(t, err)
+	_, err = session.Send("WebAuthn.addVirtualAuthenticator", map[string]interface{}{
+		"options": map[string]interface{}{
+			"protocol":                    "ctap2",
+			"transport":                   "internal",
+			"hasResidentKey":              true,
+			"hasUserVerification":         true,
+			"isUserVerified":              true,
+			"automaticPresenceSimulation": true,
+		},
+	})
+	require.NoError(t, err)
+}
+
+func addPasskey(t *testing.T, name string) {
+	t.Helper()
+	if page.URL() != getFullPath("settings") {
+		_, err := page.Goto(getFullPath("settings"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, page.Locator("#passkey-name").Fill(name))
+	_, err := page.ExpectResponse("**/settings/passkeys/finish*", func() error {
+		return page.GetByRole("button", playwright.PageGetByRoleOptions{Name: "Add Passkey"}).Click()
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,125 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordReset(t *testing.T) {
+	beforeEach(t)
+	email := createUserAndSignIn(t)
+	signedIn, err := ctx.Cookies()
+	require.NoError(t, err)
+
+	_, err = page.Goto(getFullPath("signin"))
+	require.NoError(t, err)
+	require.NoError(t, page.GetByRole("link", playwright.PageGetByRoleOptions{Name: "Forgot password?"}).Click())
+	require.NoError(t, expect.Page(page).ToHaveURL(getFullPath("forgot-password")))
+	requestPasswordReset(t, email)
+	link := emailLink(t, email, "reset-password")
+
+	_, err = page.Goto(link)
+	require.NoError(t, err)
+	require.NoError(t, page.Locator("#password").Fill("NewPassword1!"))
+	require.NoError(t, page.Locator("#confirmPassword").Fill("NewPassword1!"))
+	require.NoError(t, page.GetByRole("button", playwright.PageGetByRoleOptions{Name: "Reset Password"}).Click())
+	require.NoError(t, expect.Page(page).ToHaveURL(getFullPath("signin")))
+
+	// The session from before the reset is signed out.
+	require.NoError(t, ctx.AddCookies(toOptionalCookies(signedIn)))
+	_, err = page.Goto(getFullPath(""))
+	require.NoError(t, err)
+	require.NoError(t, expect.Page(page).ToHaveURL(getFullPath("signin")))
+
+	signin(t, email, "NewPassword1!")
+
+	// The link only works once.
+	_, err = page.Goto(link)
+	require.NoError(t, err)
+	require.NoError(t, expect.Locator(page.Locator("#reset-password-error")).ToContainText("Link expired"))
+	require.NoError(t, expect.Locator(page.Locator("#reset-password-form")).ToHaveCount(0))
+}
+
+func TestPasswordReset_UnknownEmail(t *testing.T) {
+	beforeEach(t)
+	email := generateUniqueEmail(t)
+
+	_, err := page.Goto(getFullPath("forgot-password"))
+	require.NoError(t, err)
+	requestPasswordReset(t, email)
+	require.Empty(t, emailsTo(t, email))
+}
+
+func TestPasswordReset_PasswordsDoNotMatch(t *testing.T) {
+	beforeEach(t)
+	email := generateUniqueEmail(t)
+	createTestUser(t, email)
+
+	_, err := page.Goto(getFullPath("forgot-password"))
+	require.NoError(t, err)
+	requestPasswordReset(t, email)
+
+	_, err = page.Goto(emailLink(t, email, "reset-password"))
+	require.NoError(t, err)
+	require.NoError(t, page.Locator("#password").Fill("NewPassword1!"))
+	require.NoError(t, page.Locator("#confirmPassword").Fill("OtherPassword1!"))
+	require.NoError(t, page.GetByRole("button", playwright.PageGetByRoleOptions{Name: "Reset Password"}).Click())
+	require.NoError(t, expect.Locator(page.Locator("#reset-password-error")).ToContainText("Passwords do not match"))
+
+	// The old password still works.
+	signin(t, email, "password")
+}
+
+// requestPasswordReset submits the forgot password form, which answers the same whether or not the
+// email has an account.
+func requestPasswordReset(t *testing.T, email string) {
+	t.Helper()
+	require.NoError(t, page.Locator("#email").Fill(email))
+	require.NoError(t, page.GetByRole("button", playwright.PageGetByRoleOptions{Name: "Send Reset Link"}).Click())
+	require.NoError(t, expect.Locator(page.Locator("#forgot-password-message")).ToContainText("Check your email"))
+}
+
+// emailsTo returns the emails the app sent to the address, oldest first.
+func emailsTo(t *testing.T, email string) []string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(mailDir, "*.eml"))
+	require.NoError(t, err)
+	slices.Sort(files)
+
+	var emails []string
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		require.NoError(t, err)
+		if strings.Contains(string(b), "\r\nTo: "+email+"\r\n") {
+			emails = append(emails, string(b))
+		}
+	}
+	return emails
+}
+
+// emailLink returns the link to the path in the latest email sent to the address.
+func emailLink(t *testing.T, email string, path string) string {
+	t.Helper()
+	emails := emailsTo(t, email)
+	require.NotEmpty(t, emails, "no email sent to %s", email)
+	link := regexp.MustCompile(regexp.QuoteMeta(getFullPath(path)) + `\?\S+`).FindString(emails[len(emails)-1])
+	require.NotEmpty(t, link, "no link to %s in the email", path)
+	return link
+}
+
+func toOptionalCookies(cookies []playwright.Cookie) []playwright.OptionalCookie {
+	optional := make([]playwright.OptionalCookie, len(cookies))
+	for i, cookie := range cookies {
+		optional[i] = cookie.ToOptionalCookie()
+	}
+	return optional
+}
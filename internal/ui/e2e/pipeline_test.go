@@ -0,0 +1,33 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipeline_AddStage(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	_, err := page.Goto(getFullPath("settings"))
+	require.NoError(t, err)
+	require.NoError(t, expect.Locator(page.Locator("#pipeline-section li")).ToHaveCount(10))
+
+	require.NoError(t, page.Locator("#pipeline-status-name").Fill("Recruiter Screen"))
+	require.NoError(t, page.Locator("#pipeline-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Add Stage"}).Click())
+	waitForHTMXRequest(t)
+	require.NoError(t, expect.Locator(page.Locator("#pipeline-section li")).ToHaveCount(11))
+	require.NoError(t, expect.Locator(page.Locator("#pipeline-section li").Last()).ToContainText("Recruiter screen"))
+
+	require.NoError(t, page.Locator("#pipeline-section li").Filter(playwright.LocatorFilterOptions{HasText: "Applied"}).GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Delete"}).Click())
+	waitForHTMXRequest(t)
+	require.NoError(t, expect.Locator(page.Locator("#pipeline-error")).ToContainText("Stage required"))
+
+	_, err = page.Goto(getFullPath(""))
+	require.NoError(t, err)
+	require.NoError(t, expect.Locator(page.Locator("#filter-form #status-select option[value='recruiter screen']")).ToHaveCount(1))
+}
@@ -0,0 +1,71 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReminder_DueTodayAndDismiss(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addJobApplication(t, "Reminder Company", "Software Engineer", "https://reminder.com")
+	addReminder(t, time.Now().UTC().Format("2006-01-02"), "Email the recruiter")
+
+	require.NoError(t, expect.Locator(page.Locator("#job-reminders").GetByText("Email the recruiter")).ToHaveCount(1))
+	require.NoError(t, expect.Locator(page.Locator("#timeline").GetByText("Follow-up reminder for")).ToHaveCount(1))
+	require.NoError(t, expect.Locator(page.Locator("#due-reminders").GetByText("Reminder Company")).ToHaveCount(1))
+
+	require.NoError(t, page.Locator("#job-reminders").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Done"}).Click())
+	require.NoError(t, expect.Locator(page.Locator("#due-reminders > div")).ToHaveCount(0))
+	require.NoError(t, expect.Locator(page.Locator("#job-reminders > ul > li")).ToHaveCount(0))
+}
+
+func TestReminder_Snooze(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addJobApplication(t, "Snooze Company", "Software Engineer", "https://snooze.com")
+	addReminder(t, time.Now().UTC().Format("2006-01-02"), "")
+	require.NoError(t, expect.Locator(page.Locator("#due-reminders").GetByText("Snooze Company")).ToHaveCount(1))
+
+	require.NoError(t, page.Locator("#due-reminders").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Snooze 1 week"}).Click())
+	require.NoError(t, expect.Locator(page.Locator("#due-reminders > div")).ToHaveCount(0))
+	require.NoError(t, expect.Locator(page.Locator("#job-reminders > ul > li")).ToHaveCount(1))
+}
+
+func TestReminder_ClearedWhenStatusChanges(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addJobApplication(t, "Interview Company", "Software Engineer", "https://interview.com")
+	addReminder(t, time.Now().UTC().Format("2006-01-02"), "")
+	require.NoError(t, expect.Locator(page.Locator("#due-reminders").GetByText("Interview Company")).ToHaveCount(1))
+
+	updateJobApplication(t, "", "", "", "interviewing")
+	require.NoError(t, expect.Locator(page.Locator("#due-reminders > div")).ToHaveCount(0))
+	require.NoError(t, expect.Locator(page.Locator("#job-reminders > ul > li")).ToHaveCount(0))
+}
+
+func addReminder(t *testing.T, date, note string) {
+	reminderForm := page.Locator("#reminder-form")
+	if count, _ := reminderForm.Count(); count == 0 {
+		require.NoError(t, page.GetByRole("button", playwright.PageGetByRoleOptions{Name: "View job"}).First().Click())
+		// Wait for reminder form to load
+		require.NoError(t, expect.Locator(page.Locator("#reminder-form")).ToBeVisible(playwright.LocatorAssertionsToBeVisibleOptions{
+			Timeout: playwright.Float(5000),
+		}))
+	}
+	require.NoError(t, page.Locator("#reminder-form #remind_at").Fill(date))
+	if note != "" {
+		require.NoError(t, page.Locator("#reminder-form #reminder_note").Fill(note))
+	}
+	require.NoError(t, page.Locator("#reminder-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Remind me"}).Click())
+
+	waitForHTMXRequest(t)
+}
@@ -0,0 +1,67 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResumeVersions_RecordAndAnalyze(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addResumeVersion(t, "Backend focus", &playwright.InputFile{Name: "backend.pdf", MimeType: "application/pdf", Buffer: []byte(attachmentPDF)})
+	addResumeVersion(t, "General", nil)
+	require.NoError(t, expect.Locator(page.Locator("#resumes-section li")).ToHaveCount(2))
+	require.NoError(t, expect.Locator(page.Locator("#resumes-section").GetByRole("link", playwright.LocatorGetByRoleOptions{Name: "backend.pdf"})).ToBeVisible())
+
+	_, err := page.Goto(getFullPath(""))
+	require.NoError(t, err)
+	addJobApplication(t, "Resume Company", "Software Engineer", "https://resume.com")
+	require.NoError(t, jobRow("Resume Company").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "View job"}).Click())
+	require.NoError(t, expect.Locator(page.Locator("#job-resume-form")).ToBeVisible())
+	_, err = page.Locator("#job-resume-version").SelectOption(playwright.SelectOptionValues{Labels: &[]string{"Backend focus"}})
+	require.NoError(t, err)
+	require.NoError(t, page.Locator("#job-resume-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Save"}).Click())
+	waitForHTMXRequest(t)
+
+	_, err = page.Goto(getFullPath("analytics"))
+	require.NoError(t, err)
+	row := page.Locator("#resume-table tbody tr").Filter(playwright.LocatorFilterOptions{HasText: "Backend focus"})
+	require.NoError(t, expect.Locator(row).ToContainText("0%"))
+	require.NoError(t, expect.Locator(row.Locator("td").Nth(1)).ToHaveText("1"))
+	require.NoError(t, expect.Locator(page.Locator("#resume-table tbody tr").Filter(playwright.LocatorFilterOptions{HasText: "General"}).Locator("td").Nth(1)).ToHaveText("0"))
+}
+
+func TestResumeVersions_DuplicateAndDelete(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addResumeVersion(t, "General", nil)
+	addResumeVersion(t, "general", nil)
+	require.NoError(t, expect.Locator(page.Locator("#resumes-error")).ToContainText("Resume version already exists"))
+	require.NoError(t, expect.Locator(page.Locator("#resumes-section li")).ToHaveCount(1))
+
+	page.OnDialog(func(dialog playwright.Dialog) {
+		_ = dialog.Accept()
+	})
+	require.NoError(t, page.Locator("#resumes-section").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Delete General"}).Click())
+	waitForHTMXRequest(t)
+	require.NoError(t, expect.Locator(page.Locator("#resumes-section li")).ToHaveCount(0))
+}
+
+func addResumeVersion(t *testing.T, label string, file *playwright.InputFile) {
+	if page.URL() != getFullPath("settings") {
+		_, err := page.Goto(getFullPath("settings"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, page.Locator("#resume-label").Fill(label))
+	if file != nil {
+		require.NoError(t, page.Locator("#resume-file").SetInputFiles([]playwright.InputFile{*file}))
+	}
+	require.NoError(t, page.Locator("#resume-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Add Version"}).Click())
+	waitForHTMXRequest(t)
+}
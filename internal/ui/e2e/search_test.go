@@ -0,0 +1,41 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch_CompanyAndNotes(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addJobApplication(t, "Search Company", "Software Engineer", "https://search.com")
+	addNote(t, "Recruiter mentioned the Kubernetes migration")
+
+	_, err := page.Goto(getFullPath("search"))
+	require.NoError(t, err)
+
+	require.NoError(t, page.Locator("#search-query").Fill("kubernetes"))
+	waitForHTMXRequest(t)
+	require.NoError(t, expect.Locator(page.Locator("#search-result-list > li")).ToHaveCount(1))
+	require.NoError(t, expect.Locator(page.Locator("#search-result-list mark")).ToHaveText("Kubernetes"))
+	require.NoError(t, expect.Locator(page.Locator("#search-total")).ToContainText("1 result"))
+
+	require.NoError(t, page.Locator("#search-query").Fill("search comp"))
+	waitForHTMXRequest(t)
+	require.NoError(t, expect.Locator(page.Locator("#search-result-list > li")).ToHaveCount(1))
+	require.NoError(t, expect.Locator(page.Locator("#search-result-list mark")).ToHaveText([]string{"Search", "Company"}))
+
+	require.NoError(t, page.Locator("#search-query").Fill("nothing matches"))
+	waitForHTMXRequest(t)
+	require.NoError(t, expect.Locator(page.Locator("#search-result-list > li")).ToHaveCount(0))
+
+	require.NoError(t, page.Locator("#search-query").Fill("software"))
+	waitForHTMXRequest(t)
+	require.NoError(t, page.Locator("#search-result-list").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "View job"}).Click())
+	require.NoError(t, expect.Locator(page.Locator("#job-form #company")).ToHaveValue("Search Company"))
+}
@@ -39,6 +39,26 @@ func TestSignin_InvalidCredentials(t *testing.T) {
 	require.NoError(t, expect.Locator(page.GetByText("Incorrect email or password")).ToBeVisible())
 }
 
+func TestSignin_TooManyAttempts(t *testing.T) {
+	beforeEach(t)
+	email := generateUniqueEmail(t)
+	createTestUser(t, email)
+	_, err := page.Goto(getFullPath("signin"))
+	require.NoError(t, err)
+
+	for range 4 {
+		submitSignin(t, email, "wrong-password")
+		require.NoError(t, expect.Locator(page.Locator("#login-error")).ToContainText("Incorrect email or password"))
+	}
+	submitSignin(t, email, "wrong-password")
+	require.NoError(t, expect.Locator(page.Locator("#login-error")).ToContainText("Too many attempts"))
+
+	// Once locked, even the right password is turned away.
+	submitSignin(t, email, "password")
+	require.NoError(t, expect.Locator(page.Locator("#login-error")).ToContainText("Too many attempts"))
+	require.NoError(t, expect.Page(page).ToHaveURL(getFullPath("signin")))
+}
+
 func TestSignin_AuthMiddleware(t *testing.T) {
 	beforeEach(t)
 	_, err := page.Goto(getFullPath("") + "/")
@@ -76,6 +96,18 @@ func TestSignin_ClearsCookiesOnPageLoad(t *testing.T) {
 	}
 }
 
+// submitSignin fills in the signin form and waits for its answer, so the next attempt is not typed
+// while the form is still being sent.
+func submitSignin(t *testing.T, email, password string) {
+	t.Helper()
+	require.NoError(t, page.Locator("#email").Fill(email))
+	require.NoError(t, page.Locator("#password").Fill(password))
+	_, err := page.ExpectResponse("**/signin", func() error {
+		return page.Locator("button[type=submit]").Click()
+	})
+	require.NoError(t, err)
+}
+
 func findCookie(cookies []playwright.Cookie, name string) *playwright.Cookie {
 	for i, cookie := range cookies {
 		if cookie.Name == name {
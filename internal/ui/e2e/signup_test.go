@@ -3,6 +3,7 @@
 package e2e_test
 
 import (
+	"net/http"
 	"testing"
 	"time"
 
@@ -25,6 +26,54 @@ func TestSignup(t *testing.T) {
 	}))
 }
 
+func TestSignup_Unverified(t *testing.T) {
+	beforeEach(t)
+	email := generateUniqueEmail(t)
+	signupUser(t, email)
+	require.Len(t, emailsTo(t, email), 1)
+	signin(t, email, "MySuperPassword1234!")
+
+	_, err := page.Goto(getFullPath("settings"))
+	require.NoError(t, err)
+	require.NoError(t, expect.Locator(page.Locator("#email-verification")).ToContainText("Verify your email"))
+	require.NoError(t, expect.Locator(page.Locator("#mcp-api-key-section")).ToContainText("Verify your email"))
+	require.NoError(t, expect.Locator(page.GetByRole("button", playwright.PageGetByRoleOptions{Name: "Generate API Key"})).ToHaveCount(0))
+
+	// The feature is held back by the server too, not only hidden.
+	res, err := page.Request().Post(getFullPath("settings/mcp/auth"))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusForbidden, res.Status())
+
+	// The clock of the app is fixed, so the email from the signup is always too recent to resend.
+	require.NoError(t, page.GetByRole("button", playwright.PageGetByRoleOptions{Name: "Resend Verification Email"}).Click())
+	require.NoError(t, expect.Locator(page.Locator("#email-verification-message")).ToContainText("Email already sent"))
+	require.Len(t, emailsTo(t, email), 1)
+}
+
+func TestSignup_Verified(t *testing.T) {
+	beforeEach(t)
+	email := generateUniqueEmail(t)
+	signupUser(t, email)
+	link := emailLink(t, email, "verify-email")
+
+	_, err := page.Goto(link)
+	require.NoError(t, err)
+	require.NoError(t, expect.Locator(page.Locator("#verify-email-message")).ToContainText("Email verified"))
+
+	signin(t, email, "MySuperPassword1234!")
+	_, err = page.Goto(getFullPath("settings"))
+	require.NoError(t, err)
+	require.NoError(t, expect.Locator(page.Locator("#email-verification")).ToContainText("Your email is verified."))
+	require.NoError(t, expect.Locator(page.GetByRole("button", playwright.PageGetByRoleOptions{Name: "Resend Verification Email"})).ToHaveCount(0))
+	require.NoError(t, page.GetByRole("button", playwright.PageGetByRoleOptions{Name: "Generate API Key"}).Click())
+	require.NoError(t, expect.Locator(page.Locator("#mcp-api-key-section")).ToContainText("Regenerate Key"))
+
+	// The link only works once.
+	_, err = page.Goto(link)
+	require.NoError(t, err)
+	require.NoError(t, expect.Locator(page.Locator("#verify-email-message")).ToContainText("Link expired"))
+}
+
 func TestSignUp_NavigationFromSignIn(t *testing.T) {
 	beforeEach(t)
 	_, err := page.Goto(getFullPath("signin"))
@@ -133,3 +182,19 @@ func TestSignup_ClearsCookiesOnPageLoad(t *testing.T) {
 			"session cookie should be expired after visiting signup page")
 	}
 }
+
+// signupUser creates an account through the signup form, which leaves its email unverified.
+func signupUser(t *testing.T, email string) {
+	t.Helper()
+	_, err := page.Goto(getFullPath("signup"))
+	require.NoError(t, err)
+
+	require.NoError(t, page.Locator("#email").Fill(email))
+	require.NoError(t, page.Locator("#password").Fill("MySuperPassword1234!"))
+	require.NoError(t, page.Locator("#confirmPassword").Fill("MySuperPassword1234!"))
+	require.NoError(t, page.Locator("button[type=submit]").Click())
+
+	require.NoError(t, expect.Page(page).ToHaveURL(getFullPath("signin"), playwright.PageAssertionsToHaveURLOptions{
+		Timeout: playwright.Float(10000),
+	}))
+}
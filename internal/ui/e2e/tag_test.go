@@ -0,0 +1,85 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTags_TagAndFilter(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addTag(t, "Remote", "blue")
+	addTag(t, "Referral", "green")
+	require.NoError(t, expect.Locator(page.Locator("#tags-section li")).ToHaveCount(2))
+
+	_, err := page.Goto(getFullPath(""))
+	require.NoError(t, err)
+	addJobApplication(t, "Tagged Company", "Software Engineer", "https://tagged.com")
+	addJobApplication(t, "Untagged Company", "Software Engineer", "https://untagged.com")
+
+	require.NoError(t, jobRow("Tagged Company").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "View job"}).Click())
+	require.NoError(t, expect.Locator(page.Locator("#job-form #company")).ToHaveValue("Tagged Company"))
+	require.NoError(t, page.Locator("#job-tags").GetByRole("checkbox", playwright.LocatorGetByRoleOptions{Name: "Remote"}).Check())
+	require.NoError(t, page.Locator("#job-tags").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Save tags"}).Click())
+	waitForHTMXRequest(t)
+
+	require.NoError(t, expect.Locator(jobRow("Tagged Company").GetByText("Remote")).ToBeVisible())
+	require.NoError(t, expect.Locator(jobRow("Untagged Company").GetByText("Remote")).ToHaveCount(0))
+	require.NoError(t, page.Locator("#job-details").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Cancel"}).Click())
+
+	_, err = page.Locator("#filter-form #tag-select").SelectOption(playwright.SelectOptionValues{Labels: &[]string{"Remote"}})
+	require.NoError(t, err)
+	require.NoError(t, page.Locator("#filter-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Filter"}).Click())
+	waitForHTMXRequest(t)
+
+	require.NoError(t, expect.Locator(page.Locator("#job-list li")).ToHaveCount(1))
+	require.NoError(t, expect.Locator(jobRow("Tagged Company")).ToBeVisible())
+}
+
+func TestTags_BulkAddTag(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addTag(t, "Remote", "blue")
+	_, err := page.Goto(getFullPath(""))
+	require.NoError(t, err)
+	addJobApplication(t, "Bulk One", "Software Engineer", "https://one.com")
+	addJobApplication(t, "Bulk Two", "Software Engineer", "https://two.com")
+
+	selectJobApplication(t, "Bulk One")
+	selectJobApplication(t, "Bulk Two")
+	require.NoError(t, page.Locator("#bulk-tag-button").Click())
+	waitForHTMXRequest(t)
+
+	require.NoError(t, expect.Locator(page.Locator("#bulk-undo-message")).ToHaveText("Tagged 2 job applications."))
+	require.NoError(t, expect.Locator(jobRow("Bulk One").GetByText("Remote")).ToBeVisible())
+	require.NoError(t, expect.Locator(jobRow("Bulk Two").GetByText("Remote")).ToBeVisible())
+}
+
+func TestTags_DuplicateName(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addTag(t, "Remote", "blue")
+	addTag(t, "remote", "red")
+
+	require.NoError(t, expect.Locator(page.Locator("#tags-error")).ToContainText("Tag already exists"))
+	require.NoError(t, expect.Locator(page.Locator("#tags-section li")).ToHaveCount(1))
+}
+
+func addTag(t *testing.T, name string, color string) {
+	if page.URL() != getFullPath("settings") {
+		_, err := page.Goto(getFullPath("settings"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, page.Locator("#tag-name").Fill(name))
+	_, err := page.Locator("#tag-color").SelectOption(playwright.SelectOptionValues{Values: &[]string{color}})
+	require.NoError(t, err)
+	require.NoError(t, page.Locator("#tag-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Add Tag"}).Click())
+	waitForHTMXRequest(t)
+}
@@ -28,5 +28,5 @@ INSERT INTO job_application_status_histories (
 	datetime('now')
 );
 
-INSERT INTO job_application_stats (total_applications, total_companies, total_applied, user_id)
-SELECT 1, 1, 1, id FROM users where email = 'existing-user@test.com';
+INSERT INTO job_application_stats (total_applications, total_companies, user_id)
+SELECT 1, 1, id FROM users where email = 'existing-user@test.com';
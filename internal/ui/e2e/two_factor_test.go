@@ -0,0 +1,153 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTwoFactor_Signin(t *testing.T) {
+	beforeEach(t)
+	email := createUserAndSignIn(t)
+	secret, recoveryCodes := enableTwoFactor(t)
+
+	// The code used to enable cannot be used again, so signing in needs the next one.
+	signout(t)
+	signinWithPassword(t, email)
+	submitTwoFactorCode(t, twoFactorCode(t, secret, fixedTime))
+	require.NoError(t, expect.Locator(page.Locator("#two-factor-error")).ToContainText("Incorrect code"))
+	cookies, err := ctx.Cookies()
+	require.NoError(t, err)
+	require.Nil(t, findCookie(cookies, "session"), "session cookie should not be issued before the code is accepted")
+
+	submitTwoFactorCode(t, twoFactorCode(t, secret, fixedTime.Add(30*time.Second)))
+	require.NoError(t, expect.Page(page).ToHaveURL(getFullPath("")+"/", playwright.PageAssertionsToHaveURLOptions{
+		Timeout: playwright.Float(10000),
+	}))
+
+	// Recovery codes sign in once.
+	signout(t)
+	signinWithPassword(t, email)
+	submitTwoFactorCode(t, recoveryCodes[0])
+	require.NoError(t, expect.Page(page).ToHaveURL(getFullPath("")+"/", playwright.PageAssertionsToHaveURLOptions{
+		Timeout: playwright.Float(10000),
+	}))
+
+	signout(t)
+	signinWithPassword(t, email)
+	submitTwoFactorCode(t, recoveryCodes[0])
+	require.NoError(t, expect.Locator(page.Locator("#two-factor-error")).ToContainText("Incorrect code"))
+}
+
+func TestTwoFactor_TooManyAttempts(t *testing.T) {
+	beforeEach(t)
+	email := createUserAndSignIn(t)
+	secret, _ := enableTwoFactor(t)
+
+	signout(t)
+	signinWithPassword(t, email)
+	for range 4 {
+		submitTwoFactorCode(t, "000000")
+		require.NoError(t, expect.Locator(page.Locator("#two-factor-error")).ToContainText("Incorrect code"))
+	}
+	submitTwoFactorCode(t, "000000")
+	require.NoError(t, expect.Locator(page.Locator("#two-factor-error")).ToContainText("Too many attempts"))
+
+	// The clock does not move, so the account stays locked even with the right code.
+	signinWithPassword(t, email)
+	submitTwoFactorCode(t, twoFactorCode(t, secret, fixedTime.Add(30*time.Second)))
+	require.NoError(t, expect.Locator(page.Locator("#two-factor-error")).ToContainText("Too many attempts"))
+}
+
+func TestTwoFactor_RegenerateAndDisable(t *testing.T) {
+	beforeEach(t)
+	email := createUserAndSignIn(t)
+	secret, recoveryCodes := enableTwoFactor(t)
+
+	_, err := page.Goto(getFullPath("settings"))
+	require.NoError(t, err)
+	require.NoError(t, expect.Locator(page.Locator("#two-factor-section")).ToContainText("10 unused recovery codes"))
+	require.NoError(t, page.Locator("#two-factor-recovery-code").Fill(twoFactorCode(t, secret, fixedTime.Add(30*time.Second))))
+	require.NoError(t, page.GetByRole("button", playwright.PageGetByRoleOptions{Name: "Regenerate Recovery Codes"}).Click())
+	require.NoError(t, expect.Locator(page.Locator("#two-factor-recovery-codes li")).ToHaveCount(10))
+	require.NoError(t, expect.Locator(page.Locator("#two-factor-recovery-codes")).Not().ToContainText(recoveryCodes[0]))
+	regenerated, err := page.Locator("#two-factor-recovery-codes li").AllTextContents()
+	require.NoError(t, err)
+
+	require.NoError(t, page.Locator("#two-factor-password").Fill("password"))
+	require.NoError(t, page.Locator("#two-factor-disable-code").Fill(recoveryCodes[1]))
+	require.NoError(t, page.GetByRole("button", playwright.PageGetByRoleOptions{Name: "Disable Two-Factor Authentication"}).Click())
+	require.NoError(t, expect.Locator(page.Locator("#two-factor-error")).ToContainText("Incorrect code"))
+
+	require.NoError(t, page.Locator("#two-factor-password").Fill("password"))
+	require.NoError(t, page.Locator("#two-factor-disable-code").Fill(regenerated[0]))
+	require.NoError(t, page.GetByRole("button", playwright.PageGetByRoleOptions{Name: "Disable Two-Factor Authentication"}).Click())
+	require.NoError(t, expect.Locator(page.GetByRole("button", playwright.PageGetByRoleOptions{Name: "Set Up Two-Factor Authentication"})).ToBeVisible())
+
+	signout(t)
+	signin(t, email, "password")
+}
+
+// enableTwoFactor sets up two-factor authentication from settings with the code of the fixed time
+// and returns the secret and the recovery codes.
+func enableTwoFactor(t *testing.T) (string, []string) {
+	t.Helper()
+	_, err := page.Goto(getFullPath("settings"))
+	require.NoError(t, err)
+	require.NoError(t, page.GetByRole("button", playwright.PageGetByRoleOptions{Name: "Set Up Two-Factor Authentication"}).Click())
+	require.NoError(t, expect.Locator(page.Locator("#two-factor-qr")).ToBeVisible())
+	secret, err := page.Locator("#two-factor-secret").TextContent()
+	require.NoError(t, err)
+
+	require.NoError(t, page.Locator("#two-factor-code").Fill("000000"))
+	require.NoError(t, page.Locator("#two-factor-enable-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Enable"}).Click())
+	require.NoError(t, expect.Locator(page.Locator("#two-factor-error")).ToContainText("Incorrect code"))
+
+	require.NoError(t, page.Locator("#two-factor-code").Fill(twoFactorCode(t, secret, fixedTime)))
+	require.NoError(t, page.Locator("#two-factor-enable-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Enable"}).Click())
+	require.NoError(t, expect.Locator(page.Locator("#two-factor-recovery-codes li")).ToHaveCount(10))
+	codes, err := page.Locator("#two-factor-recovery-codes li").AllTextContents()
+	require.NoError(t, err)
+	return secret, codes
+}
+
+func twoFactorCode(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+	code, err := totp.GenerateCode(secret, at)
+	require.NoError(t, err)
+	return code
+}
+
+func signout(t *testing.T) {
+	t.Helper()
+	_, err := page.Goto(getFullPath("signout"))
+	require.NoError(t, err)
+	require.NoError(t, expect.Page(page).ToHaveURL(getFullPath("signin")))
+}
+
+func signinWithPassword(t *testing.T, email string) {
+	t.Helper()
+	_, err := page.Goto(getFullPath("signin"))
+	require.NoError(t, err)
+	require.NoError(t, page.Locator("#email").Fill(email))
+	require.NoError(t, page.Locator("#password").Fill("password"))
+	require.NoError(t, page.Locator("button[type=submit]").Click())
+	require.NoError(t, expect.Page(page).ToHaveURL(getFullPath("signin/two-factor"), playwright.PageAssertionsToHaveURLOptions{
+		Timeout: playwright.Float(10000),
+	}))
+}
+
+func submitTwoFactorCode(t *testing.T, code string) {
+	t.Helper()
+	require.NoError(t, page.Locator("#code").Fill(code))
+	// Waiting for the response keeps the form from being reset while the next code is typed.
+	_, err := page.ExpectResponse("**/signin/two-factor", func() error {
+		return page.Locator("#two-factor-form button[type=submit]").Click()
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,60 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/playwright-community/playwright-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedViews_SaveAndCount(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	addJobApplication(t, "Alpha Corp", "Engineer", "https://alpha.com")
+	addJobApplication(t, "Beta Corp", "Engineer", "https://beta.com")
+	require.NoError(t, expect.Locator(page.Locator("#saved-views")).ToContainText("No saved views yet"))
+
+	require.NoError(t, page.Locator("#filter-form #company").Fill("Alpha"))
+	require.NoError(t, page.Locator("#view-name").Fill("Alpha jobs"))
+	require.NoError(t, page.Locator("#save-view-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Save"}).Click())
+	waitForHTMXRequest(t)
+	view := page.Locator("#saved-views li")
+	require.NoError(t, expect.Locator(view).ToHaveCount(1))
+	require.NoError(t, expect.Locator(view).ToContainText("Alpha jobs"))
+	require.NoError(t, expect.Locator(view.Locator(".saved-view-count")).ToHaveText("1"))
+	require.NoError(t, expect.Locator(page.Locator("#view-name")).ToHaveValue(""))
+
+	// The count follows the job applications.
+	addJobApplication(t, "Alpha Labs", "Engineer", "https://alphalabs.com")
+	require.NoError(t, expect.Locator(view.Locator(".saved-view-count")).ToHaveText("2"))
+
+	require.NoError(t, view.GetByRole("link", playwright.LocatorGetByRoleOptions{Name: "Alpha jobs"}).Click())
+	require.NoError(t, expect.Page(page).ToHaveURL(regexp.MustCompile(`company=Alpha`)))
+	require.NoError(t, expect.Locator(page.Locator("#filter-form #company")).ToHaveValue("Alpha"))
+	require.NoError(t, expect.Locator(page.Locator("#job-list > li")).ToHaveCount(2))
+}
+
+func TestSavedViews_DuplicateAndDelete(t *testing.T) {
+	beforeEach(t)
+	createUserAndSignIn(t)
+
+	saveView := func(name string) {
+		require.NoError(t, page.Locator("#view-name").Fill(name))
+		require.NoError(t, page.Locator("#save-view-form").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Save"}).Click())
+		waitForHTMXRequest(t)
+	}
+	saveView("Everything")
+	require.NoError(t, expect.Locator(page.Locator("#saved-views li")).ToHaveCount(1))
+
+	saveView("everything")
+	require.NoError(t, expect.Locator(page.Locator("#saved-views-error")).ToContainText("View already exists"))
+	require.NoError(t, expect.Locator(page.Locator("#saved-views li")).ToHaveCount(1))
+
+	require.NoError(t, page.Locator("#saved-views").GetByRole("button", playwright.LocatorGetByRoleOptions{Name: "Delete Everything"}).Click())
+	waitForHTMXRequest(t)
+	require.NoError(t, expect.Locator(page.Locator("#saved-views")).ToContainText("No saved views yet"))
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"math"
 	"net/http"
 	"sort"
 
+	"github.com/Piszmog/pathwise/internal/jobapplication"
 	"github.com/Piszmog/pathwise/internal/ui/components"
 	"github.com/Piszmog/pathwise/internal/ui/types"
 )
@@ -59,6 +61,11 @@ func (h *Handler) getSankeyData(ctx context.Context, userID int64) (types.Sankey
 		return types.SankeyData{}, err
 	}
 
+	pipeline, err := jobapplication.GetPipeline(ctx, h.Database.Queries(), userID)
+	if err != nil {
+		return types.SankeyData{}, err
+	}
+
 	transitions := make([]types.StatusTransition, 0, len(dbTransitions))
 	for _, t := range dbTransitions {
 		transitions = append(transitions, types.StatusTransition{
@@ -76,10 +83,12 @@ func (h *Handler) getSankeyData(ctx context.Context, userID int64) (types.Sankey
 		})
 	}
 
-	return buildSankeyFromTransitions(transitions, statusCounts, appliedOnlyCount), nil
+	return buildSankeyFromTransitions(transitions, statusCounts, appliedOnlyCount, pipeline), nil
 }
 
-func buildSankeyFromTransitions(transitions []types.StatusTransition, statusCounts []types.StatusCount, appliedOnlyCount int64) types.SankeyData {
+// buildSankeyFromTransitions orders the nodes by the pipeline of the user. Statuses that are no longer
+// part of the pipeline follow alphabetically, with "no response" last.
+func buildSankeyFromTransitions(transitions []types.StatusTransition, statusCounts []types.StatusCount, appliedOnlyCount int64, pipeline types.Pipeline) types.SankeyData {
 	statusSet := make(map[string]bool)
 	for _, t := range transitions {
 		statusSet[t.FromStatus] = true
@@ -99,6 +108,9 @@ func buildSankeyFromTransitions(transitions []types.StatusTransition, statusCoun
 		statuses = append(statuses, status)
 	}
 	sort.Strings(statuses)
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return sankeyNodeOrder(statuses[i], pipeline, noResponseStatus) < sankeyNodeOrder(statuses[j], pipeline, noResponseStatus)
+	})
 
 	nodes := make([]types.SankeyNode, 0, len(statuses))
 	statusToIndex := make(map[string]int)
@@ -147,3 +159,49 @@ func buildSankeyFromTransitions(transitions []types.StatusTransition, statusCoun
 		Links: links,
 	}
 }
+
+func sankeyNodeOrder(status string, pipeline types.Pipeline, noResponseStatus string) int64 {
+	if status == noResponseStatus {
+		return math.MaxInt64
+	}
+	if s, ok := pipeline.Get(types.JobApplicationStatus(status)); ok {
+		return s.Position
+	}
+	return math.MaxInt64 - 1
+}
+
+func (h *Handler) AnalyticsSalary(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Bad request."))
+		return
+	}
+
+	analytics, err := jobapplication.GetSalaryAnalytics(r.Context(), h.Database.Queries(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get salary analytics", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Unable to load analytics", "There was a problem retrieving your data. Please try again later."))
+		return
+	}
+
+	h.html(r.Context(), w, http.StatusOK, components.SalaryAnalytics(analytics))
+}
+
+func (h *Handler) AnalyticsResumes(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Bad request."))
+		return
+	}
+
+	stats, err := jobapplication.GetResumeVersionAnalytics(r.Context(), h.Database.Queries(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get resume analytics", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Unable to load analytics", "There was a problem retrieving your data. Please try again later."))
+		return
+	}
+
+	h.html(r.Context(), w, http.StatusOK, components.ResumeAnalytics(stats))
+}
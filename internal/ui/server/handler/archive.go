@@ -3,9 +3,42 @@ package handler
 import (
 	"net/http"
 
+	"github.com/Piszmog/pathwise/internal/jobapplication"
 	"github.com/Piszmog/pathwise/internal/ui/components"
+	"github.com/Piszmog/pathwise/internal/ui/types"
 )
 
 func (h *Handler) Archives(w http.ResponseWriter, r *http.Request) {
-	h.html(r.Context(), w, http.StatusOK, components.Archives())
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	pipeline, err := jobapplication.GetPipeline(r.Context(), h.Database.Queries(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get pipeline", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	tags, err := jobapplication.GetTags(r.Context(), h.Database.Queries(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get tags", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	filterOpts, err := getFilterOpts(r)
+	if err != nil {
+		h.Logger.WarnContext(r.Context(), "failed to get filter options", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Bad request."))
+		return
+	}
+	filterOpts.IsArchived = true
+	page, _, err := getPageOpts(r)
+	if err != nil {
+		h.Logger.WarnContext(r.Context(), "failed to get page opts", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Bad request."))
+		return
+	}
+	h.html(r.Context(), w, http.StatusOK, components.Archives(pipeline, tags, filterOpts, page))
 }
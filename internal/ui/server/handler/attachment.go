@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/components"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+func (h *Handler) AddAttachment(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, jobapplication.MaxAttachmentSize+(1<<20))
+	f, header, err := r.FormFile("file")
+	if err != nil {
+		h.Logger.WarnContext(r.Context(), "failed to get uploaded file", "error", err)
+		if maxBytesErr := (*http.MaxBytesError)(nil); errors.As(err, &maxBytesErr) {
+			h.attachmentError(w, r, userID, id, jobapplication.ErrAttachmentTooLarge)
+			return
+		}
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Missing file", "Please choose a file to attach."))
+		return
+	}
+	defer func() { _ = f.Close() }()
+
+	a, err := jobapplication.AddAttachment(r.Context(), h.Database, h.Storage, userID, id, jobapplication.AttachmentUpload{
+		Name:    header.Filename,
+		Kind:    types.AttachmentKind(r.FormValue("kind")),
+		Size:    header.Size,
+		Content: f,
+	})
+	if err != nil {
+		h.attachmentError(w, r, userID, id, err)
+		return
+	}
+
+	h.html(r.Context(), w, http.StatusOK, components.TimelineEntry(newAttachmentEntry(a, false), false))
+}
+
+func (h *Handler) Attachment(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	id, attachmentID, ok := h.attachmentIDs(w, r)
+	if !ok {
+		return
+	}
+
+	a, content, err := jobapplication.OpenAttachment(r.Context(), h.Database.Queries(), h.Storage, userID, id, attachmentID)
+	if err != nil {
+		h.attachmentError(w, r, userID, id, err)
+		return
+	}
+	defer func() { _ = content.Close() }()
+
+	// Attachments are always downloaded rather than shown, so an uploaded file is never rendered as
+	// part of the site.
+	w.Header().Set("Content-Type", a.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(a.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": a.Name}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	if _, err = io.Copy(w, content); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to write attachment", "error", err)
+	}
+}
+
+func (h *Handler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	id, attachmentID, ok := h.attachmentIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err = jobapplication.DeleteAttachment(r.Context(), h.Database, h.Storage, userID, id, attachmentID); err != nil {
+		h.attachmentError(w, r, userID, id, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) attachmentIDs(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return 0, 0, false
+	}
+	attachmentID, err := strconv.ParseInt(r.PathValue("attachmentID"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse attachment id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return 0, 0, false
+	}
+	return id, attachmentID, true
+}
+
+func (h *Handler) attachmentError(w http.ResponseWriter, r *http.Request, userID int64, jobID int64, err error) {
+	switch {
+	case errors.Is(err, jobapplication.ErrInvalidAttachmentName):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid file name", "Please choose a file with a name of up to 255 characters."))
+	case errors.Is(err, jobapplication.ErrInvalidAttachmentKind):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid attachment kind", "Please select resume, cover letter or other."))
+	case errors.Is(err, jobapplication.ErrAttachmentType):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "File type not allowed", "Please attach a PDF, Word, OpenDocument, RTF, text, Markdown, PNG or JPEG file."))
+	case errors.Is(err, jobapplication.ErrAttachmentEmpty):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Empty file", "Please choose a file that is not empty."))
+	case errors.Is(err, jobapplication.ErrAttachmentTooLarge):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "File too large", "Please attach a file smaller than 10 MB."))
+	case errors.Is(err, jobapplication.ErrNotFound):
+		h.Logger.WarnContext(r.Context(), "job application not found", "userID", userID, "jobID", jobID)
+		h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Job application not found", "Try again later."))
+	case errors.Is(err, jobapplication.ErrAttachmentNotFound):
+		h.Logger.WarnContext(r.Context(), "attachment not found", "userID", userID, "jobID", jobID)
+		h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Attachment not found", "Try again later."))
+	default:
+		h.Logger.ErrorContext(r.Context(), "failed to change attachment", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+	}
+}
+
+func newAttachmentEntry(a queries.JobApplicationAttachment, readOnly bool) types.JobApplicationAttachment {
+	return types.JobApplicationAttachment{
+		CreatedAt:        a.CreatedAt,
+		Name:             a.Name,
+		Kind:             types.AttachmentKind(a.Kind),
+		ContentType:      a.ContentType,
+		Size:             a.Size,
+		ID:               a.ID,
+		JobApplicationID: a.JobApplicationID.Int64,
+		ReadOnly:         readOnly,
+	}
+}
@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/components"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+const boardPerPage = int64(20)
+
+func (h *Handler) Board(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	pipeline, err := jobapplication.GetPipeline(r.Context(), h.Database.Queries(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get pipeline", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	statusCounts, err := h.Database.Queries().GetCurrentStatusCounts(r.Context(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get status counts", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	counts := make(map[string]int64, len(statusCounts))
+	for _, c := range statusCounts {
+		counts[c.Status] = c.Count
+	}
+	columns := make([]types.BoardColumn, len(pipeline))
+	for i, s := range pipeline {
+		columns[i] = types.BoardColumn{Status: s, Count: counts[s.Name.String()]}
+	}
+
+	h.html(r.Context(), w, http.StatusOK, components.Board(columns))
+}
+
+func (h *Handler) BoardColumn(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	statusID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Bad request."))
+		return
+	}
+	page, _, err := getPageOpts(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get page opts", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Bad request."))
+		return
+	}
+
+	pipeline, err := jobapplication.GetPipeline(r.Context(), h.Database.Queries(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get pipeline", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	status, ok := pipeline.GetByID(statusID)
+	if !ok {
+		h.Logger.WarnContext(r.Context(), "pipeline status not found", "userID", userID, "statusID", statusID)
+		h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Stage not found", "Try again later."))
+		return
+	}
+
+	jobs, err := h.filterJobs(r.Context(), userID, types.FilterOpts{Status: status.Name}, page, boardPerPage)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get jobs", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	h.html(r.Context(), w, http.StatusOK, components.BoardCards(types.BoardCardsOpts{
+		Jobs:     jobs,
+		StatusID: statusID,
+		NextPage: page + 1,
+		HasMore:  int64(len(jobs)) == boardPerPage,
+	}))
+}
+
+// MoveBoardJob moves a job application dropped on another column to the status of the column.
+func (h *Handler) MoveBoardJob(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	jobID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse form", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	status := types.JobApplicationStatus(r.FormValue("status"))
+
+	result, err := jobapplication.UpdateStatus(r.Context(), h.Database, userID, jobID, status)
+	if err != nil {
+		switch {
+		case errors.Is(err, jobapplication.ErrInvalidStatus):
+			h.Logger.WarnContext(r.Context(), "invalid status", "status", status)
+			h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid status", "Please select a valid status."))
+		case errors.Is(err, jobapplication.ErrNotFound):
+			h.Logger.WarnContext(r.Context(), "user does not own job", "userID", userID, "jobID", jobID)
+			h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Job application not found", "Try again later."))
+		default:
+			h.Logger.ErrorContext(r.Context(), "failed to update job status", "error", err)
+			h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		}
+		return
+	}
+
+	job, err := h.Database.Queries().GetJobApplicationByIDAndUserID(r.Context(), queries.GetJobApplicationByIDAndUserIDParams{ID: jobID, UserID: userID})
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get job", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	from, err := h.getBoardColumn(r.Context(), userID, types.JobApplicationStatus(result.Previous.Status))
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get board column", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	to, err := h.getBoardColumn(r.Context(), userID, status)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get board column", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	j := types.JobApplication{
+		ID:        job.ID,
+		Company:   job.Company,
+		Title:     job.Title,
+		URL:       job.Url.String,
+		Status:    types.JobApplicationStatus(job.Status),
+		AppliedAt: job.AppliedAt,
+		UpdatedAt: job.UpdatedAt,
+		UserID:    job.UserID,
+	}
+	h.html(r.Context(), w, http.StatusOK, components.BoardMoveCard(j, from, to))
+}
+
+func (h *Handler) getBoardColumn(ctx context.Context, userID int64, status types.JobApplicationStatus) (types.BoardColumn, error) {
+	pipeline, err := jobapplication.GetPipeline(ctx, h.Database.Queries(), userID)
+	if err != nil {
+		return types.BoardColumn{}, err
+	}
+	s, _ := pipeline.Get(status)
+	count, err := h.Database.Queries().CountJobApplicationsByUserIDAndStatus(ctx, queries.CountJobApplicationsByUserIDAndStatusParams{
+		Status:   status.String(),
+		UserID:   userID,
+		Archived: 0,
+	})
+	if err != nil {
+		return types.BoardColumn{}, err
+	}
+	return types.BoardColumn{Status: s, Count: count}, nil
+}
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/components"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+func (h *Handler) BulkUpdateStatus(w http.ResponseWriter, r *http.Request) {
+	userID, jobIDs, ok := h.bulkRequest(w, r)
+	if !ok {
+		return
+	}
+	status := types.JobApplicationStatus(r.FormValue("status"))
+
+	result, err := jobapplication.BulkUpdateStatus(r.Context(), h.Database, userID, jobIDs, status, time.Now())
+	if err != nil {
+		h.bulkError(w, r, err)
+		return
+	}
+	h.bulkResult(w, r, userID, result)
+}
+
+func (h *Handler) BulkAddNote(w http.ResponseWriter, r *http.Request) {
+	userID, jobIDs, ok := h.bulkRequest(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := jobapplication.BulkAddNote(r.Context(), h.Database, userID, jobIDs, r.FormValue("note"), time.Now())
+	if err != nil {
+		h.bulkError(w, r, err)
+		return
+	}
+	h.bulkResult(w, r, userID, result)
+}
+
+func (h *Handler) BulkAddTag(w http.ResponseWriter, r *http.Request) {
+	userID, jobIDs, ok := h.bulkRequest(w, r)
+	if !ok {
+		return
+	}
+
+	tagID, err := strconv.ParseInt(r.FormValue("tag"), 10, 64)
+	if err != nil {
+		h.Logger.WarnContext(r.Context(), "invalid tag id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid tag", "Please select a tag."))
+		return
+	}
+
+	result, err := jobapplication.BulkAddTag(r.Context(), h.Database, userID, jobIDs, tagID, time.Now())
+	if err != nil {
+		h.bulkError(w, r, err)
+		return
+	}
+	h.bulkResult(w, r, userID, result)
+}
+
+func (h *Handler) BulkDelete(w http.ResponseWriter, r *http.Request) {
+	userID, jobIDs, ok := h.bulkRequest(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := jobapplication.BulkDelete(r.Context(), h.Database, userID, jobIDs, time.Now())
+	if err != nil {
+		h.bulkError(w, r, err)
+		return
+	}
+	h.bulkResult(w, r, userID, result)
+}
+
+func (h *Handler) UndoBulkAction(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	actionID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	undone, err := jobapplication.UndoBulkAction(r.Context(), h.Database, userID, actionID, time.Now())
+	if err != nil {
+		h.bulkError(w, r, err)
+		return
+	}
+	h.bulkJobs(w, r, userID, types.BulkUndoOpts{Message: bulkUndoMessage(undone)})
+}
+
+// bulkRequest reads the user and the selected job applications of a bulk action request.
+// If the request is invalid, the error is written to the response.
+func (h *Handler) bulkRequest(w http.ResponseWriter, r *http.Request) (int64, []int64, bool) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return 0, nil, false
+	}
+
+	if err = r.ParseForm(); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse form", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return 0, nil, false
+	}
+
+	jobIDs, err := getBulkJobIDs(r)
+	if err != nil {
+		h.Logger.WarnContext(r.Context(), "invalid job ids", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Bad request."))
+		return 0, nil, false
+	}
+	return userID, jobIDs, true
+}
+
+func getBulkJobIDs(r *http.Request) ([]int64, error) {
+	jobIDs := make([]int64, len(r.Form["ids"]))
+	for i, idStr := range r.Form["ids"] {
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse job id %q: %w", idStr, err)
+		}
+		jobIDs[i] = id
+	}
+	return jobIDs, nil
+}
+
+func (h *Handler) bulkError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, jobapplication.ErrNoJobsSelected):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "No job applications selected", "Select at least one job application."))
+	case errors.Is(err, jobapplication.ErrTooManyJobs):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Too many job applications selected", fmt.Sprintf("Select at most %d job applications.", jobapplication.MaxBulkJobs)))
+	case errors.Is(err, jobapplication.ErrInvalidStatus):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid status", "Please select a valid status."))
+	case errors.Is(err, jobapplication.ErrMissingNote):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Missing note", "Please enter a note."))
+	case errors.Is(err, jobapplication.ErrTagNotFound):
+		h.Logger.WarnContext(r.Context(), "bulk action with missing tag", "error", err)
+		h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Tag not found", "Try again later."))
+	case errors.Is(err, jobapplication.ErrUndoExpired):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Undo expired", "The changes can no longer be undone."))
+	case errors.Is(err, jobapplication.ErrNotFound):
+		h.Logger.WarnContext(r.Context(), "bulk action on missing job application", "error", err)
+		h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Job application not found", "Try again later."))
+	default:
+		h.Logger.ErrorContext(r.Context(), "failed to run bulk action", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+	}
+}
+
+// bulkResult reloads the job applications and offers to undo the bulk action. An empty result clears the undo.
+func (h *Handler) bulkResult(w http.ResponseWriter, r *http.Request, userID int64, result jobapplication.BulkResult) {
+	h.bulkJobs(w, r, userID, types.BulkUndoOpts{ActionID: result.ActionID, Message: bulkResultMessage(result), Window: jobapplication.BulkUndoWindow})
+}
+
+// bulkJobs refreshes the job applications after a bulk action or its undo.
+func (h *Handler) bulkJobs(w http.ResponseWriter, r *http.Request, userID int64, undo types.BulkUndoOpts) {
+	jobs, err := h.filterJobs(r.Context(), userID, types.FilterOpts{}, defaultPage, defaultPerPage)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get jobs", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	triggerJobsChanged(w)
+	h.html(r.Context(), w, http.StatusOK, components.BulkActionResult(jobs, types.PaginationOpts{Page: defaultPage, PerPage: defaultPerPage, Showing: len(jobs)}, undo))
+}
+
+// bulkUndoMessage tells the user about the job applications an undo left as they are.
+func bulkUndoMessage(result jobapplication.BulkUndoResult) string {
+	switch result.Skipped {
+	case 0:
+		return ""
+	case 1:
+		return "Skipped 1 job application that changed after the bulk action."
+	default:
+		return fmt.Sprintf("Skipped %d job applications that changed after the bulk action.", result.Skipped)
+	}
+}
+
+func bulkResultMessage(result jobapplication.BulkResult) string {
+	noun := "job applications"
+	if result.Count == 1 {
+		noun = "job application"
+	}
+	switch result.Action {
+	case jobapplication.BulkActionStatus:
+		return fmt.Sprintf("Changed the status of %d %s.", result.Count, noun)
+	case jobapplication.BulkActionNote:
+		return fmt.Sprintf("Added a note to %d %s.", result.Count, noun)
+	case jobapplication.BulkActionTag:
+		return fmt.Sprintf("Tagged %d %s.", result.Count, noun)
+	case jobapplication.BulkActionDelete:
+		return fmt.Sprintf("Deleted %d %s.", result.Count, noun)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Piszmog/pathwise/internal/calendar"
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/components"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/google/uuid"
+)
+
+// CalendarFeed serves the interviews of the user owning the token in the path as an iCalendar
+// feed. Calendar clients cannot sign in, so the secret token is the only authentication.
+func (h *Handler) CalendarFeed(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutSuffix(r.PathValue("file"), ".ics")
+	if !ok || token == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	userID, err := h.Database.Queries().GetCalendarFeedTokenByHash(r.Context(), fmt.Sprintf("%x", sha256.Sum256([]byte(token))))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.NotFound(w, r)
+			return
+		}
+		h.Logger.ErrorContext(r.Context(), "failed to get calendar feed token", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	cal, err := jobapplication.InterviewCalendar(r.Context(), h.Database.Queries(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get interview calendar", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="pathwise-interviews.ics"`)
+	w.WriteHeader(http.StatusOK)
+	if err = calendar.Write(w, cal); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to write calendar", "error", err)
+	}
+}
+
+func (h *Handler) CreateCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	_, err = h.Database.Queries().GetCalendarFeedTokenByUserID(r.Context(), userID)
+	if err == nil {
+		h.Logger.DebugContext(r.Context(), "user already has calendar feed")
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Calendar feed already exists", "You already have a calendar feed. Delete it first to create a new one."))
+		return
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		h.Logger.ErrorContext(r.Context(), "failed to check existing calendar feed", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	h.newCalendarFeed(w, r, userID)
+}
+
+func (h *Handler) RegenerateCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = h.Database.Queries().DeleteCalendarFeedTokenByUserID(r.Context(), userID); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to delete existing calendar feed", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	h.newCalendarFeed(w, r, userID)
+}
+
+func (h *Handler) DeleteCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = h.Database.Queries().DeleteCalendarFeedTokenByUserID(r.Context(), userID); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to delete calendar feed", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	h.html(r.Context(), w, http.StatusOK, components.CalendarFeedSection(false, ""))
+}
+
+func (h *Handler) newCalendarFeed(w http.ResponseWriter, r *http.Request, userID int64) {
+	token := uuid.New().String()
+	result, err := h.Database.Queries().InsertCalendarFeedToken(r.Context(), queries.InsertCalendarFeedTokenParams{
+		UserID:    userID,
+		TokenHash: fmt.Sprintf("%x", sha256.Sum256([]byte(token))),
+	})
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to create calendar feed", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	feedURL, err := url.JoinPath(h.AppURL, "calendar", token+".ics")
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to build calendar feed url", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	createdAt := result.CreatedAt.Format("January 2, 2006")
+
+	h.html(r.Context(), w, http.StatusOK, components.CalendarFeedModalWithSectionUpdate(feedURL, createdAt))
+}
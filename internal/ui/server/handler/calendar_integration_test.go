@@ -0,0 +1,32 @@
+//go:build integration
+
+package handler_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCalendarFeed_UsesAppURL(t *testing.T) {
+	h, database := setupHandler(t)
+	h.AppURL = "https://pathwise.example.com/app"
+	createTestUser(t, database, 1)
+
+	// The feed link must not follow a host or scheme the request claims.
+	r := request(http.MethodPost, "/settings/calendar", &bytes.Buffer{}, "", 1, 0)
+	r.Host = "attacker.example.com"
+	r.Header.Set("X-Forwarded-Proto", "http")
+	w := httptest.NewRecorder()
+	h.CreateCalendarFeed(w, r)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	body := w.Body.String()
+	assert.Regexp(t, regexp.MustCompile(`value="https://pathwise.example.com/app/calendar/[0-9a-f-]{36}\.ics"`), body)
+	assert.NotContains(t, body, "attacker.example.com")
+}
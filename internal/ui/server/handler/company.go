@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/components"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+func (h *Handler) Companies(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	companies, err := jobapplication.GetCompanies(r.Context(), h.Database.Queries(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get companies", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	h.html(r.Context(), w, http.StatusOK, components.Companies(companies))
+}
+
+func (h *Handler) Company(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	companyID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	details, err := jobapplication.GetCompany(r.Context(), h.Database.Queries(), userID, companyID)
+	if err != nil {
+		h.companyError(w, r, userID, companyID, err)
+		return
+	}
+	h.html(r.Context(), w, http.StatusOK, components.Company(details))
+}
+
+func (h *Handler) MergeCompany(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	companyID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse form", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	targetID, err := strconv.ParseInt(r.FormValue("target_id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse target id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = jobapplication.MergeCompanies(r.Context(), h.Database, userID, companyID, targetID); err != nil {
+		h.companyError(w, r, userID, companyID, err)
+		return
+	}
+
+	w.Header().Set("HX-Redirect", "/companies/"+strconv.FormatInt(targetID, 10))
+}
+
+func (h *Handler) companyError(w http.ResponseWriter, r *http.Request, userID int64, companyID int64, err error) {
+	switch {
+	case errors.Is(err, jobapplication.ErrSameCompany):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid company", "A company cannot be merged into itself."))
+	case errors.Is(err, jobapplication.ErrCompanyNotFound):
+		h.Logger.WarnContext(r.Context(), "company not found", "userID", userID, "companyID", companyID)
+		h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Company not found", "Try again later."))
+	default:
+		h.Logger.ErrorContext(r.Context(), "failed to get company", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+	}
+}
@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/components"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+func (h *Handler) Contacts(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	contacts, err := jobapplication.GetContacts(r.Context(), h.Database.Queries(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get contacts", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	h.html(r.Context(), w, http.StatusOK, components.Contacts(contacts))
+}
+
+func (h *Handler) AddContact(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse form", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if _, err = jobapplication.AddContact(r.Context(), h.Database.Queries(), userID, getNewContact(r)); err != nil {
+		h.contactError(w, r, userID, 0, err)
+		return
+	}
+
+	h.contactsSection(w, r, userID)
+}
+
+func (h *Handler) UpdateContact(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	contactID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse form", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = jobapplication.UpdateContact(r.Context(), h.Database.Queries(), userID, contactID, getNewContact(r)); err != nil {
+		h.contactError(w, r, userID, contactID, err)
+		return
+	}
+
+	h.contactsSection(w, r, userID)
+}
+
+func (h *Handler) DeleteContact(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	contactID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = jobapplication.DeleteContact(r.Context(), h.Database, userID, contactID); err != nil {
+		h.contactError(w, r, userID, contactID, err)
+		return
+	}
+
+	h.contactsSection(w, r, userID)
+}
+
+// AddJobContact links an existing contact to a job application when a contact ID is given,
+// otherwise it creates a new contact from the form and links it.
+func (h *Handler) AddJobContact(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	jobID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse form", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	var contactID int64
+	if idStr := r.FormValue("contact_id"); idStr != "" {
+		contactID, err = strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			h.Logger.WarnContext(r.Context(), "invalid contact id", "error", err)
+			h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Bad request."))
+			return
+		}
+		err = jobapplication.LinkContact(r.Context(), h.Database, userID, jobID, contactID)
+	} else {
+		_, err = jobapplication.AddJobApplicationContact(r.Context(), h.Database, userID, jobID, getNewContact(r))
+	}
+	if err != nil {
+		h.contactError(w, r, userID, contactID, err)
+		return
+	}
+
+	h.jobContacts(w, r, userID, jobID)
+}
+
+func (h *Handler) RemoveJobContact(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	jobID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	contactID, err := strconv.ParseInt(r.PathValue("contactID"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse contact id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = jobapplication.UnlinkContact(r.Context(), h.Database.Queries(), userID, jobID, contactID); err != nil {
+		h.contactError(w, r, userID, contactID, err)
+		return
+	}
+
+	h.jobContacts(w, r, userID, jobID)
+}
+
+func getNewContact(r *http.Request) jobapplication.NewContact {
+	return jobapplication.NewContact{
+		Name:        r.FormValue("name"),
+		Role:        r.FormValue("role"),
+		Email:       r.FormValue("email"),
+		LinkedInURL: r.FormValue("linkedin_url"),
+		Company:     r.FormValue("company"),
+	}
+}
+
+func (h *Handler) contactError(w http.ResponseWriter, r *http.Request, userID int64, contactID int64, err error) {
+	switch {
+	case errors.Is(err, jobapplication.ErrInvalidContactName):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid name", "Names must be between 1 and 100 characters."))
+	case errors.Is(err, jobapplication.ErrInvalidContactEmail):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid email", "Please enter a valid email address."))
+	case errors.Is(err, jobapplication.ErrInvalidLinkedInURL):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid LinkedIn URL", "Please enter a linkedin.com URL."))
+	case errors.Is(err, jobapplication.ErrContactNotFound):
+		h.Logger.WarnContext(r.Context(), "contact not found", "userID", userID, "contactID", contactID)
+		h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Contact not found", "Try again later."))
+	case errors.Is(err, jobapplication.ErrNotFound):
+		h.Logger.WarnContext(r.Context(), "job application not found", "userID", userID)
+		h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Job application not found", "Try again later."))
+	default:
+		h.Logger.ErrorContext(r.Context(), "failed to change contacts", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+	}
+}
+
+func (h *Handler) contactsSection(w http.ResponseWriter, r *http.Request, userID int64) {
+	contacts, err := jobapplication.GetContacts(r.Context(), h.Database.Queries(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get contacts", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	h.html(r.Context(), w, http.StatusOK, components.ContactsSection(contacts))
+}
+
+func (h *Handler) jobContacts(w http.ResponseWriter, r *http.Request, userID int64, jobID int64) {
+	job, err := h.Database.Queries().GetJobApplicationByID(r.Context(), jobID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get job", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	opts, err := h.getContactsOpts(r.Context(), userID, jobID, job.Company)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get contacts", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	h.html(r.Context(), w, http.StatusOK, components.JobContacts(opts, job.Archived == 1, ""))
+}
+
+func (h *Handler) getContactsOpts(ctx context.Context, userID int64, jobID int64, company string) (types.ContactsOpts, error) {
+	contacts, err := jobapplication.GetContacts(ctx, h.Database.Queries(), userID)
+	if err != nil {
+		return types.ContactsOpts{}, err
+	}
+	linked, err := jobapplication.GetJobApplicationContacts(ctx, h.Database.Queries(), userID, jobID)
+	if err != nil {
+		return types.ContactsOpts{}, err
+	}
+	return types.ContactsOpts{JobApplicationID: jobID, Company: company, Contacts: contacts, Linked: linked}, nil
+}
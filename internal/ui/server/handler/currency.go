@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/components"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+const maxExchangeRatesFileSize = 1 << 16
+
+func (h *Handler) UpdateDisplayCurrency(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse form", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = jobapplication.SetDisplayCurrency(r.Context(), h.Database.Queries(), userID, r.FormValue("currency")); err != nil {
+		h.currencyError(w, r, err)
+		return
+	}
+
+	h.currencySection(w, r, userID)
+}
+
+func (h *Handler) ImportExchangeRates(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxExchangeRatesFileSize+(1<<20))
+	f, _, err := r.FormFile("file")
+	if err != nil {
+		h.Logger.WarnContext(r.Context(), "failed to get uploaded file", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Missing exchange rates file", "Please select a CSV file smaller than 64 KB."))
+		return
+	}
+	defer func() { _ = f.Close() }()
+
+	rates, err := jobapplication.ReadExchangeRates(f)
+	if err != nil {
+		h.currencyError(w, r, err)
+		return
+	}
+	if err = jobapplication.SaveExchangeRates(r.Context(), h.Database, userID, rates); err != nil {
+		h.currencyError(w, r, err)
+		return
+	}
+
+	h.currencySection(w, r, userID)
+}
+
+func (h *Handler) currencyError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, jobapplication.ErrInvalidCurrency):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid currency", "Please use currencies from the list."))
+	case errors.Is(err, jobapplication.ErrInvalidExchangeRate):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid exchange rate", "Exchange rates must be greater than zero."))
+	case errors.Is(err, jobapplication.ErrInvalidExchangeRatesFile):
+		h.Logger.WarnContext(r.Context(), "invalid exchange rates file", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid exchange rates file", "Each row must have a currency code and a rate, such as EUR,1.08."))
+	default:
+		h.Logger.ErrorContext(r.Context(), "failed to change currency settings", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+	}
+}
+
+func (h *Handler) currencySection(w http.ResponseWriter, r *http.Request, userID int64) {
+	settings, err := h.getCurrencySettings(r.Context(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get currency settings", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	h.html(r.Context(), w, http.StatusOK, components.CurrencySection(settings))
+}
+
+func (h *Handler) getCurrencySettings(ctx context.Context, userID int64) (types.CurrencySettings, error) {
+	currency, err := jobapplication.GetDisplayCurrency(ctx, h.Database.Queries(), userID)
+	if err != nil {
+		return types.CurrencySettings{}, err
+	}
+	rates, err := jobapplication.GetExchangeRates(ctx, h.Database.Queries(), userID)
+	if err != nil {
+		return types.CurrencySettings{}, err
+	}
+	return types.CurrencySettings{DisplayCurrency: currency, Rates: rates}, nil
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Piszmog/pathwise/internal/auth"
+	"github.com/Piszmog/pathwise/internal/ui/components"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+func (h *Handler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	_, err := auth.VerifyEmail(r.Context(), h.Database, r.URL.Query().Get("token"), h.now())
+	if err != nil && !errors.Is(err, auth.ErrEmailVerificationNotFound) {
+		h.Logger.ErrorContext(r.Context(), "failed to verify email", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	h.html(r.Context(), w, http.StatusOK, components.VerifyEmail(err == nil))
+}
+
+func (h *Handler) ResendEmailVerification(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	err = auth.SendEmailVerification(r.Context(), h.Database.Queries(), h.Mailer, h.AppURL, userID, h.now())
+	switch {
+	case err == nil:
+		h.html(r.Context(), w, http.StatusOK, components.Alert(types.AlertTypeSuccess, "Check your email", "We sent you a new link. It expires in 24 hours."))
+	case errors.Is(err, auth.ErrEmailVerificationTooSoon):
+		h.html(r.Context(), w, http.StatusTooManyRequests, components.Alert(types.AlertTypeError, "Email already sent", "Wait a minute before asking for another email."))
+	case errors.Is(err, auth.ErrEmailAlreadyVerified):
+		h.html(r.Context(), w, http.StatusOK, components.Alert(types.AlertTypeSuccess, "Email verified", "Your email is already verified."))
+	default:
+		h.Logger.ErrorContext(r.Context(), "failed to send email verification", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+	}
+}
+
+// requireVerifiedEmail writes an error and returns false when the user has not verified their email,
+// for features that are held back until they do.
+func (h *Handler) requireVerifiedEmail(w http.ResponseWriter, r *http.Request, userID int64) bool {
+	verified, err := auth.IsEmailVerified(r.Context(), h.Database.Queries(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to check email verification", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return false
+	}
+	if !verified {
+		h.Logger.DebugContext(r.Context(), "email not verified", "userID", userID)
+		h.html(r.Context(), w, http.StatusForbidden, components.Alert(types.AlertTypeError, "Verify your email", "Follow the link we emailed you to use this feature."))
+		return false
+	}
+	return true
+}
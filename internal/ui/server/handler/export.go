@@ -2,11 +2,13 @@ package handler
 
 import (
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
 	"time"
 
+	"github.com/Piszmog/pathwise/internal/jobapplication"
 	"github.com/Piszmog/pathwise/internal/ui/utils"
 )
 
@@ -44,6 +46,7 @@ func (h *Handler) ExportCSV(w http.ResponseWriter, r *http.Request) {
 		"URL",
 		"Applied Date",
 		"Last Updated",
+		"Tags",
 	}
 	if err := writer.Write(header); err != nil {
 		h.Logger.ErrorContext(r.Context(), "failed to write CSV header", "error", err)
@@ -76,6 +79,7 @@ func (h *Handler) ExportCSV(w http.ResponseWriter, r *http.Request) {
 			job.Url.String,
 			job.AppliedAt.Format("2006-01-02"),
 			job.UpdatedAt.Format("2006-01-02 15:04:05"),
+			job.Tags,
 		}
 
 		if err := writer.Write(record); err != nil {
@@ -86,3 +90,32 @@ func (h *Handler) ExportCSV(w http.ResponseWriter, r *http.Request) {
 
 	h.Logger.InfoContext(r.Context(), "CSV export completed", "userID", userID, "recordCount", len(jobApplications))
 }
+
+func (h *Handler) ExportJSON(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get user ID", "error", err)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	archive, err := jobapplication.Export(r.Context(), h.Database.Queries(), h.Storage, userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get job applications for export", "error", err, "userID", userID)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	filename := fmt.Sprintf("pathwise-backup-%s.json", time.Now().Format("2006-01-02"))
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(archive); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to write JSON archive", "error", err)
+		return
+	}
+
+	h.Logger.InfoContext(r.Context(), "JSON export completed", "userID", userID, "recordCount", len(archive.JobApplications))
+}
@@ -0,0 +1,3 @@
+package handler
+
+var GetClientIP = getClientIP
@@ -5,12 +5,18 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+	"net/netip"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/Piszmog/pathwise/internal/auth"
 	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/mail"
 	"github.com/Piszmog/pathwise/internal/search"
+	"github.com/Piszmog/pathwise/internal/storage"
 	"github.com/a-h/templ"
+	"github.com/go-webauthn/webauthn/webauthn"
 )
 
 const (
@@ -22,6 +28,28 @@ type Handler struct {
 	Logger       *slog.Logger
 	Database     db.Database
 	SearchClient *search.Client
+	Storage      storage.Storage
+	WebAuthn     *webauthn.WebAuthn
+	Mailer       mail.Mailer
+	// AppURL is where the app is served from, used for links in emails and the calendar feed so they
+	// never depend on the host of the request.
+	AppURL string
+	// TrustedProxies are the reverse proxies in front of the app. X-Forwarded-For is only read from
+	// requests they send.
+	TrustedProxies []netip.Prefix
+	// Lockout decides when too many wrong passwords stop an account or address from signing in.
+	Lockout auth.LockoutPolicy
+	// Clock returns the current time for two-factor codes, sign in lockouts, and the expiry of
+	// password reset and email verification links. It defaults to time.Now and is only replaced to
+	// make them predictable in tests.
+	Clock func() time.Time
+}
+
+func (h *Handler) now() time.Time {
+	if h.Clock == nil {
+		return time.Now()
+	}
+	return h.Clock()
 }
 
 func (h *Handler) html(ctx context.Context, w http.ResponseWriter, status int, t templ.Component) {
@@ -50,20 +78,39 @@ func getUserID(r *http.Request) (int64, error) {
 	return userID, nil
 }
 
-func getClientIP(r *http.Request) string {
-	xff := r.Header.Get("X-Forwarded-For")
-	if xff != "" {
-		ip := strings.Split(xff, ",")[0]
-		ip = strings.TrimSpace(ip)
-		if net.ParseIP(ip) != nil {
-			return ip
-		}
+// getClientIP returns the address of the client. X-Forwarded-For is only read when the request comes
+// from a trusted proxy, and then the right-most hop that is not a trusted proxy is the client, since
+// everything to the left of it can be set by the client.
+func getClientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	remote, err := netip.ParseAddr(host)
+	if err != nil || !isTrustedProxy(remote, trustedProxies) {
+		return host
 	}
 
-	ip, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		return r.RemoteAddr
+	ip := remote
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, parseErr := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if parseErr != nil {
+			break
+		}
+		ip = hop
+		if !isTrustedProxy(hop, trustedProxies) {
+			break
+		}
 	}
+	return ip.Unmap().String()
+}
 
-	return ip
+func isTrustedProxy(ip netip.Addr, trustedProxies []netip.Prefix) bool {
+	for _, p := range trustedProxies {
+		if p.Contains(ip.Unmap()) {
+			return true
+		}
+	}
+	return false
 }
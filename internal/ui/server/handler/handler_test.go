@@ -0,0 +1,102 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/Piszmog/pathwise/internal/ui/server/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetClientIP(t *testing.T) {
+	proxies := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("2001:db8::1/128")}
+
+	tests := []struct {
+		name           string
+		remoteAddr     string
+		forwardedFor   []string
+		trustedProxies []netip.Prefix
+		expected       string
+	}{
+		{
+			name:       "no proxy",
+			remoteAddr: "192.0.2.1:1234",
+			expected:   "192.0.2.1",
+		},
+		{
+			name:         "header ignored without trusted proxies",
+			remoteAddr:   "192.0.2.1:1234",
+			forwardedFor: []string{"198.51.100.1"},
+			expected:     "192.0.2.1",
+		},
+		{
+			name:           "header ignored from untrusted address",
+			remoteAddr:     "192.0.2.1:1234",
+			forwardedFor:   []string{"198.51.100.1"},
+			trustedProxies: proxies,
+			expected:       "192.0.2.1",
+		},
+		{
+			name:           "client from trusted proxy",
+			remoteAddr:     "10.0.0.1:1234",
+			forwardedFor:   []string{"198.51.100.1"},
+			trustedProxies: proxies,
+			expected:       "198.51.100.1",
+		},
+		{
+			name:           "spoofed entries left of the client",
+			remoteAddr:     "10.0.0.1:1234",
+			forwardedFor:   []string{"203.0.113.7, 198.51.100.1"},
+			trustedProxies: proxies,
+			expected:       "198.51.100.1",
+		},
+		{
+			name:           "chain of trusted proxies",
+			remoteAddr:     "10.0.0.1:1234",
+			forwardedFor:   []string{"203.0.113.7, 198.51.100.1", "10.0.0.2"},
+			trustedProxies: proxies,
+			expected:       "198.51.100.1",
+		},
+		{
+			name:           "invalid hop",
+			remoteAddr:     "10.0.0.1:1234",
+			forwardedFor:   []string{"198.51.100.1, unknown, 10.0.0.2"},
+			trustedProxies: proxies,
+			expected:       "10.0.0.2",
+		},
+		{
+			name:           "only trusted proxies",
+			remoteAddr:     "10.0.0.1:1234",
+			forwardedFor:   []string{"10.0.0.3, 10.0.0.2"},
+			trustedProxies: proxies,
+			expected:       "10.0.0.3",
+		},
+		{
+			name:           "ipv6 proxy",
+			remoteAddr:     "[2001:db8::1]:1234",
+			forwardedFor:   []string{"2001:db8::2"},
+			trustedProxies: proxies,
+			expected:       "2001:db8::2",
+		},
+		{
+			name:           "ipv4 mapped client",
+			remoteAddr:     "10.0.0.1:1234",
+			forwardedFor:   []string{"::ffff:198.51.100.1"},
+			trustedProxies: proxies,
+			expected:       "198.51.100.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for _, v := range tt.forwardedFor {
+				r.Header.Add("X-Forwarded-For", v)
+			}
+			assert.Equal(t, tt.expected, handler.GetClientIP(r, tt.trustedProxies))
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/components"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+const (
+	maxImportCSVSize = 2 << 20
+	// maxImportJSONSize leaves room for the attachments, which are part of the backup.
+	maxImportJSONSize = 100 << 20
+)
+
+func (h *Handler) ImportCSVPage(w http.ResponseWriter, r *http.Request) {
+	h.html(r.Context(), w, http.StatusOK, components.ImportCSV())
+}
+
+func (h *Handler) PreviewImportCSV(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	data, ok := h.readImportCSV(w, r)
+	if !ok {
+		return
+	}
+
+	file, err := jobapplication.ReadCSV(strings.NewReader(data))
+	if err != nil {
+		h.Logger.WarnContext(r.Context(), "failed to read csv", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid CSV file", "Please upload a valid CSV file."))
+		return
+	}
+	mapping := getImportCSVMapping(r, file.Header)
+
+	result, err := jobapplication.PreviewCSV(r.Context(), h.Database, userID, file.Records, mapping)
+	if err != nil && !isImportCSVMappingErr(err) {
+		h.Logger.ErrorContext(r.Context(), "failed to preview csv import", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	h.html(r.Context(), w, http.StatusOK, components.ImportCSVPreview(file, data, mapping, result, err))
+}
+
+func (h *Handler) ImportCSV(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	data, ok := h.readImportCSV(w, r)
+	if !ok {
+		return
+	}
+
+	file, err := jobapplication.ReadCSV(strings.NewReader(data))
+	if err != nil {
+		h.Logger.WarnContext(r.Context(), "failed to read csv", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid CSV file", "Please upload a valid CSV file."))
+		return
+	}
+
+	result, err := jobapplication.ImportCSV(r.Context(), h.Database, userID, file.Records, getImportCSVMapping(r, file.Header))
+	if err != nil {
+		if isImportCSVMappingErr(err) {
+			h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid column mapping", err.Error()))
+			return
+		}
+		h.Logger.ErrorContext(r.Context(), "failed to import csv", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	h.Logger.InfoContext(r.Context(), "CSV import completed", "userID", userID, "recordCount", len(result.Rows), "importedCount", result.Imported)
+	h.html(r.Context(), w, http.StatusOK, components.ImportCSVResult(result))
+}
+
+func (h *Handler) ImportJSON(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportJSONSize+(1<<20))
+	f, _, err := r.FormFile("file")
+	if err != nil {
+		h.Logger.WarnContext(r.Context(), "failed to get uploaded file", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Missing backup file", "Please select a backup file smaller than 100 MB."))
+		return
+	}
+	defer func() { _ = f.Close() }()
+
+	archive, err := jobapplication.ReadArchive(f)
+	if err != nil {
+		h.Logger.WarnContext(r.Context(), "failed to read archive", "error", err)
+		if errors.Is(err, jobapplication.ErrUnsupportedArchiveVersion) {
+			h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Unsupported backup file", "The backup was created by a newer version of Pathwise."))
+			return
+		}
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid backup file", "Please upload a backup created by Export Backup."))
+		return
+	}
+
+	result, err := jobapplication.ImportArchive(r.Context(), h.Database, h.Storage, userID, archive)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to import archive", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	if len(result.Errors) > 0 {
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid backup file", result.Errors...))
+		return
+	}
+
+	h.Logger.InfoContext(r.Context(), "JSON import completed", "userID", userID, "recordCount", len(archive.JobApplications), "importedCount", result.Imported)
+	h.html(r.Context(), w, http.StatusOK, components.ImportJSONResult(result))
+}
+
+// readImportCSV reads the CSV from the uploaded file, or from the form when the file has already
+// been previewed.
+func (h *Handler) readImportCSV(w http.ResponseWriter, r *http.Request) (string, bool) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportCSVSize+(1<<20))
+	if err := r.ParseMultipartForm(maxImportCSVSize); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		h.Logger.WarnContext(r.Context(), "failed to parse form", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid CSV file", "The file must be smaller than 2 MB."))
+		return "", false
+	}
+	if err := r.ParseForm(); err != nil {
+		h.Logger.WarnContext(r.Context(), "failed to parse form", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return "", false
+	}
+
+	f, _, err := r.FormFile("file")
+	if errors.Is(err, http.ErrMissingFile) || errors.Is(err, http.ErrNotMultipart) {
+		data := r.FormValue("csv")
+		if data == "" {
+			h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Missing CSV file", "Please select a CSV file."))
+			return "", false
+		}
+		return data, true
+	} else if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get uploaded file", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid CSV file", "Please upload a valid CSV file."))
+		return "", false
+	}
+	defer func() { _ = f.Close() }()
+
+	var buf bytes.Buffer
+	if _, err = io.Copy(&buf, io.LimitReader(f, maxImportCSVSize+1)); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to read uploaded file", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid CSV file", "Please upload a valid CSV file."))
+		return "", false
+	}
+	if buf.Len() > maxImportCSVSize {
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid CSV file", "The file must be smaller than 2 MB."))
+		return "", false
+	}
+	return buf.String(), true
+}
+
+// getImportCSVMapping returns the column mapping from the form, falling back to detecting it from
+// the header.
+func getImportCSVMapping(r *http.Request, header []string) []jobapplication.CSVField {
+	values := r.Form["mapping"]
+	if len(values) != len(header) {
+		return jobapplication.DetectCSVMapping(header)
+	}
+	mapping := make([]jobapplication.CSVField, len(values))
+	for i, v := range values {
+		mapping[i] = jobapplication.ToCSVField(v)
+	}
+	return mapping
+}
+
+func isImportCSVMappingErr(err error) bool {
+	return errors.Is(err, jobapplication.ErrMissingCSVMapping) || errors.Is(err, jobapplication.ErrDuplicateCSVColumn)
+}
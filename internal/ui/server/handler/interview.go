@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/components"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+func (h *Handler) AddInterview(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	jobID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse form", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	duration, err := strconv.ParseInt(r.FormValue("duration_minutes"), 10, 64)
+	if err != nil {
+		h.Logger.WarnContext(r.Context(), "invalid duration", "duration", r.FormValue("duration_minutes"))
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid duration", "Please enter the duration in minutes."))
+		return
+	}
+
+	interview, err := jobapplication.AddInterview(r.Context(), h.Database, userID, jobID, jobapplication.NewInterview{
+		ScheduledAt:     r.FormValue("scheduled_at"),
+		Timezone:        r.FormValue("timezone"),
+		Type:            types.ToInterviewType(r.FormValue("type")),
+		Interviewers:    r.FormValue("interviewers"),
+		DurationMinutes: duration,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, jobapplication.ErrInvalidInterviewTime):
+			h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid date and time", "Please enter when the interview is."))
+		case errors.Is(err, jobapplication.ErrInvalidTimezone):
+			h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid timezone", "Please select a valid timezone."))
+		case errors.Is(err, jobapplication.ErrInvalidInterviewType):
+			h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid interview type", "Please select a valid interview type."))
+		case errors.Is(err, jobapplication.ErrInvalidInterviewDuration):
+			h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid duration", "The duration must be between 1 and 1440 minutes."))
+		case errors.Is(err, jobapplication.ErrNotInterviewing):
+			h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Not interviewing", "Change the status to interviewing to schedule interviews."))
+		case errors.Is(err, jobapplication.ErrNotFound):
+			h.Logger.WarnContext(r.Context(), "user does not own job", "userID", userID, "jobID", jobID)
+			h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Job application not found", "Try again later."))
+		default:
+			h.Logger.ErrorContext(r.Context(), "failed to add interview", "error", err)
+			h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		}
+		return
+	}
+
+	opts, err := h.getInterviewsOpts(r.Context(), userID, jobID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get interviews", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	entry := types.JobApplicationInterview{
+		ID:               interview.ID,
+		JobApplicationID: interview.JobApplicationID,
+		ScheduledAt:      interview.ScheduledAt,
+		Timezone:         interview.Timezone,
+		InterviewType:    types.ToInterviewType(interview.Type),
+		Outcome:          types.ToInterviewOutcome(interview.Outcome),
+		Interviewers:     interview.Interviewers,
+		DurationMinutes:  interview.DurationMinutes,
+		CreatedAt:        interview.CreatedAt,
+	}
+	h.html(r.Context(), w, http.StatusOK, components.AddInterview(opts, entry))
+}
+
+func (h *Handler) UpdateInterview(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	jobID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	interviewID, err := strconv.ParseInt(r.PathValue("interviewID"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse interview id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse form", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if _, err = jobapplication.UpdateInterviewOutcome(r.Context(), h.Database, userID, interviewID, types.ToInterviewOutcome(r.FormValue("outcome"))); err != nil {
+		switch {
+		case errors.Is(err, jobapplication.ErrInvalidInterviewOutcome):
+			h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid outcome", "Please select a valid outcome."))
+		case errors.Is(err, jobapplication.ErrInterviewNotFound):
+			h.Logger.WarnContext(r.Context(), "interview not found", "userID", userID, "interviewID", interviewID)
+			h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Interview not found", "Try again later."))
+		default:
+			h.Logger.ErrorContext(r.Context(), "failed to update interview", "error", err)
+			h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		}
+		return
+	}
+
+	opts, err := h.getInterviewsOpts(r.Context(), userID, jobID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get interviews", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	h.html(r.Context(), w, http.StatusOK, components.JobInterviews(opts, false, "true"))
+}
+
+func (h *Handler) getInterviewsOpts(ctx context.Context, userID int64, jobID int64) (types.InterviewsOpts, error) {
+	job, err := h.Database.Queries().GetJobApplicationByIDAndUserID(ctx, queries.GetJobApplicationByIDAndUserIDParams{ID: jobID, UserID: userID})
+	if err != nil {
+		return types.InterviewsOpts{}, err
+	}
+
+	interviews, err := h.getInterviews(ctx, jobID)
+	if err != nil {
+		return types.InterviewsOpts{}, err
+	}
+	return types.InterviewsOpts{JobApplicationID: jobID, Interviews: interviews, Status: types.JobApplicationStatus(job.Status)}, nil
+}
+
+func (h *Handler) getInterviews(ctx context.Context, jobID int64) ([]types.JobApplicationInterview, error) {
+	rows, err := h.Database.Queries().GetJobApplicationInterviewsByJobApplicationID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	interviews := make([]types.JobApplicationInterview, len(rows))
+	for i, row := range rows {
+		interviews[i] = types.JobApplicationInterview{
+			ID:               row.ID,
+			JobApplicationID: row.JobApplicationID,
+			ScheduledAt:      row.ScheduledAt,
+			Timezone:         row.Timezone,
+			InterviewType:    types.ToInterviewType(row.Type),
+			Outcome:          types.ToInterviewOutcome(row.Outcome),
+			Interviewers:     row.Interviewers,
+			DurationMinutes:  row.DurationMinutes,
+			CreatedAt:        row.CreatedAt,
+		}
+	}
+	return interviews, nil
+}
@@ -4,21 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-	"fmt"
 	"net/http"
 	"sort"
 	"strconv"
 	"time"
 
-	"github.com/Piszmog/pathwise/internal/db"
 	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/jobapplication"
 	"github.com/Piszmog/pathwise/internal/ui/components"
 	"github.com/Piszmog/pathwise/internal/ui/types"
-	"github.com/Piszmog/pathwise/internal/ui/utils"
-)
-
-var (
-	ErrInvalidHeardBackAtType = errors.New("invalid type for HeardBackAt field")
 )
 
 func (h *Handler) JobDetails(w http.ResponseWriter, r *http.Request) {
@@ -34,7 +28,49 @@ func (h *Handler) JobDetails(w http.ResponseWriter, r *http.Request) {
 		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
 		return
 	}
-	timelineEntries, err := h.getTimelineEntries(r.Context(), id)
+	pipeline, err := jobapplication.GetPipeline(r.Context(), h.Database.Queries(), job.UserID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get pipeline", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	reminders, err := h.getReminders(r.Context(), id)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get reminders", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	interviews, err := h.getInterviews(r.Context(), id)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get interviews", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	tags, err := h.getTagsOpts(r.Context(), job.UserID, id)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get tags", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resume, err := h.getResumeVersionOpts(r.Context(), job.UserID, id)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get resume versions", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	contacts, err := h.getContactsOpts(r.Context(), job.UserID, id, job.Company)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get contacts", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	offer, err := h.getOfferOpts(r.Context(), job.UserID, id)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get offer", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	timelineEntries, err := h.getTimelineEntries(r.Context(), id, job.Archived == 1, reminders, interviews)
 	if err != nil {
 		h.Logger.ErrorContext(r.Context(), "failed to get timeline entries", "error", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -54,12 +90,15 @@ func (h *Handler) JobDetails(w http.ResponseWriter, r *http.Request) {
 		SalaryMin:      job.SalaryMin,
 		SalaryMax:      job.SalaryMax,
 		SalaryCurrency: job.SalaryCurrency,
+		Tags:           tags.Selected,
 	}
 
-	h.html(r.Context(), w, http.StatusOK, components.JobDetails(j, timelineEntries))
+	remindersOpts := types.RemindersOpts{JobApplicationID: job.ID, Reminders: reminders, Now: time.Now()}
+	interviewsOpts := types.InterviewsOpts{JobApplicationID: job.ID, Interviews: interviews, Status: j.Status}
+	h.html(r.Context(), w, http.StatusOK, components.JobDetails(j, timelineEntries, remindersOpts, interviewsOpts, offer, tags, resume, contacts, pipeline))
 }
 
-func (h *Handler) getTimelineEntries(ctx context.Context, id int64) ([]types.JobApplicationTimelineEntry, error) {
+func (h *Handler) getTimelineEntries(ctx context.Context, id int64, archived bool, reminders []types.JobApplicationReminder, interviews []types.JobApplicationInterview) ([]types.JobApplicationTimelineEntry, error) {
 	notes, err := h.Database.Queries().GetJobApplicationNotesByJobApplicationID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -68,14 +107,13 @@ func (h *Handler) getTimelineEntries(ctx context.Context, id int64) ([]types.Job
 	if err != nil {
 		return nil, err
 	}
-	timelineEntries := make([]types.JobApplicationTimelineEntry, len(notes)+len(histories))
+	attachments, err := h.Database.Queries().GetJobApplicationAttachmentsByJobApplicationID(ctx, sql.NullInt64{Int64: id, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	timelineEntries := make([]types.JobApplicationTimelineEntry, len(notes)+len(histories), len(notes)+len(histories)+len(reminders)+len(interviews)+len(attachments))
 	for i, note := range notes {
-		timelineEntries[i] = types.JobApplicationNote{
-			ID:               note.ID,
-			JobApplicationID: note.JobApplicationID,
-			Note:             note.Note,
-			CreatedAt:        note.CreatedAt,
-		}
+		timelineEntries[i] = newNoteEntry(note.ID, note.JobApplicationID, note.Note, note.CreatedAt, note.UpdatedAt, archived)
 	}
 	for i, history := range histories {
 		timelineEntries[i+len(notes)] = types.JobApplicationStatusHistory{
@@ -85,6 +123,15 @@ func (h *Handler) getTimelineEntries(ctx context.Context, id int64) ([]types.Job
 			CreatedAt:        history.CreatedAt,
 		}
 	}
+	for _, reminder := range reminders {
+		timelineEntries = append(timelineEntries, reminder)
+	}
+	for _, interview := range interviews {
+		timelineEntries = append(timelineEntries, interview)
+	}
+	for _, attachment := range attachments {
+		timelineEntries = append(timelineEntries, newAttachmentEntry(attachment, archived))
+	}
 	sort.Slice(timelineEntries, func(i, j int) bool {
 		return timelineEntries[i].Created().After(timelineEntries[j].Created())
 	})
@@ -150,69 +197,28 @@ func (h *Handler) AddJob(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tx, err := h.Database.DB().BeginTx(r.Context(), nil)
-	if err != nil {
-		h.Logger.ErrorContext(r.Context(), "failed to begin transaction", "error", err)
-		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
-		return
-	}
-	defer func() {
-		if txErr := tx.Rollback(); txErr != nil {
-			err = errors.Join(err, txErr)
-		}
-	}()
-
-	qtx := queries.New(tx)
-
-	job := queries.InsertJobApplicationParams{
+	_, err = jobapplication.Create(r.Context(), h.Database, userID, jobapplication.NewJobApplication{
 		Company:        company,
 		Title:          title,
-		Url:            db.NewNullString(url),
-		UserID:         userID,
+		URL:            url,
 		SalaryMin:      salaryMin,
 		SalaryMax:      salaryMax,
 		SalaryCurrency: salaryCurrency,
-	}
-	var jobID int64
-	if jobID, err = qtx.InsertJobApplication(r.Context(), job); err != nil {
-		h.Logger.ErrorContext(r.Context(), "failed to insert job", "error", err)
-		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
-		return
-	}
-	if err = qtx.InsertJobApplicationStatusHistory(r.Context(), jobID); err != nil {
-		h.Logger.ErrorContext(r.Context(), "failed to insert job status history", "error", err)
-		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
-		return
-	}
-	companyCount, err := h.Database.Queries().CountJobApplicationCompany(r.Context(), queries.CountJobApplicationCompanyParams{UserID: userID, Company: company})
+	})
 	if err != nil {
-		h.Logger.ErrorContext(r.Context(), "failed to count company", "error", err)
-		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
-		return
-	}
-	companyIncrement := int64(0)
-	if companyCount == 0 {
-		companyIncrement = 1
-	}
-	if err = qtx.IncrementNewJobApplicationStat(r.Context(), queries.IncrementNewJobApplicationStatParams{UserID: userID, TotalCompanies: companyIncrement}); err != nil {
-		h.Logger.ErrorContext(r.Context(), "failed to increment new job application", "error", err)
+		h.Logger.ErrorContext(r.Context(), "failed to create job", "error", err)
 		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
 		return
 	}
 
-	if err = tx.Commit(); err != nil {
-		h.Logger.ErrorContext(r.Context(), "failed to commit transaction", "error", err)
-		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
-		return
-	}
-
-	jobs, err := h.getJobApplicationsByUserID(r.Context(), userID, int64(0), defaultPerPage, defaultPage*defaultPerPage)
+	jobs, err := h.filterJobs(r.Context(), userID, types.FilterOpts{}, defaultPage, defaultPerPage)
 	if err != nil {
 		h.Logger.ErrorContext(r.Context(), "failed to get jobs", "error", err)
 		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
 		return
 	}
 
+	triggerJobsChanged(w)
 	h.html(r.Context(), w, http.StatusOK, components.JobsReload(jobs, types.PaginationOpts{Page: defaultPage, PerPage: defaultPerPage, Showing: len(jobs)}, types.FilterOpts{}))
 }
 
@@ -297,109 +303,31 @@ func (h *Handler) UpdateJob(w http.ResponseWriter, r *http.Request) {
 		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Missing company, title, url, or status", "Please enter a company, title, url, and status."))
 		return
 	}
-	previousStatus := r.FormValue("previousStatus")
-	firstTimelineEntryID := r.FormValue("firstTimelineEntryID")
-	firstTimelineEntryType := r.FormValue("firstTimelineEntryType")
 
-	tx, err := h.Database.DB().BeginTx(r.Context(), nil)
-	if err != nil {
-		h.Logger.ErrorContext(r.Context(), "failed to begin transaction", "error", err)
-		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
-		return
-	}
-	defer func() {
-		if txErr := tx.Rollback(); txErr != nil {
-			err = errors.Join(err, txErr)
-		}
-	}()
-
-	qtx := queries.New(tx)
-
-	err = qtx.UpdateJobApplication(r.Context(), queries.UpdateJobApplicationParams{
+	result, err := jobapplication.Update(r.Context(), h.Database, userID, jobapplication.UpdatedJobApplication{
 		ID:             job.ID,
 		Company:        company,
 		Title:          title,
-		Url:            db.NewNullString(url),
-		Status:         status,
+		URL:            url,
+		Status:         types.JobApplicationStatus(status),
 		SalaryMin:      salaryMin,
 		SalaryMax:      salaryMax,
 		SalaryCurrency: salaryCurrency,
-		UserID:         userID,
 	})
 	if err != nil {
-		h.Logger.ErrorContext(r.Context(), "failed to update job", "error", err)
-		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
-		return
-	}
-
-	var daysSince int
-	if types.ToJobApplicationStatus(job.Status) != types.ToJobApplicationStatus(status) {
-		histories, countErr := h.Database.Queries().CountJobApplicationStatusHistoriesByJobApplicationID(r.Context(), job.ID)
-		if countErr != nil {
-			h.Logger.ErrorContext(r.Context(), "failed to count job application status histories", "error", err)
-			h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
-			return
-		}
-		if histories == 1 {
-			daysSince = int(time.Since(job.AppliedAt).Hours() / 24)
-		}
-
-		err = qtx.InsertJobApplicationStatusHistoryWithStatus(r.Context(), queries.InsertJobApplicationStatusHistoryWithStatusParams{JobApplicationID: job.ID, Status: status})
-		if err != nil {
-			h.Logger.ErrorContext(r.Context(), "failed to insert job status history", "error", err)
-			h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
-			return
-		}
-	}
-
-	statParams := getStatsDiff(types.JobApplicationStatus(previousStatus), types.JobApplicationStatus(status))
-	statParams.UserID = userID
-
-	if job.Company != company {
-		currentCompanyCount, currentCountErr := h.Database.Queries().CountJobApplicationCompany(r.Context(), queries.CountJobApplicationCompanyParams{UserID: userID, Company: job.Company})
-		if currentCountErr != nil {
-			h.Logger.ErrorContext(r.Context(), "failed to count current company", "error", err)
-			h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		if errors.Is(err, jobapplication.ErrInvalidStatus) {
+			h.Logger.WarnContext(r.Context(), "invalid status", "status", status)
+			h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid status", "Please select a valid status."))
 			return
 		}
-		companyCount, countErr := h.Database.Queries().CountJobApplicationCompany(r.Context(), queries.CountJobApplicationCompanyParams{UserID: userID, Company: company})
-		if countErr != nil {
-			h.Logger.ErrorContext(r.Context(), "failed to count company", "error", err)
-			h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
-			return
-		}
-
-		if currentCompanyCount > 1 && companyCount == 0 {
-			statParams.TotalCompanies = 1
-		} else if currentCompanyCount == 1 && companyCount > 0 {
-			statParams.TotalCompanies = -1
-		}
-	}
-
-	if daysSince > 0 {
-		statParams.AverageTimeToHearBack = int64(daysSince)
-		statParams.AverageTimeToHearBack_2 = int64(daysSince)
-	}
-
-	statChanged := hasChanged(statParams)
-	if statChanged {
-		err = h.recalculateStats(r.Context(), qtx, userID)
-		if err != nil {
-			h.Logger.ErrorContext(r.Context(), "failed to recalculate stats", "error", err)
-			h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
-			return
-		}
-	}
-
-	if err = tx.Commit(); err != nil {
-		h.Logger.ErrorContext(r.Context(), "failed to commit transaction", "error", err)
+		h.Logger.ErrorContext(r.Context(), "failed to update job", "error", err)
 		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
 		return
 	}
 
 	var stats types.StatsOpts
 	var newTimelineEntry types.NewTimelineEntry
-	if previousStatus != status {
+	if result.StatusChanged {
 		latestStatus, latestErr := h.Database.Queries().GetLatestJobApplicationStatusHistoryByID(r.Context(), id)
 		if latestErr != nil {
 			h.Logger.ErrorContext(r.Context(), "failed to get latest status", "error", err)
@@ -408,7 +336,7 @@ func (h *Handler) UpdateJob(w http.ResponseWriter, r *http.Request) {
 		}
 		if latestStatus.ID > 0 {
 			newTimelineEntry = types.NewTimelineEntry{
-				SwapOOB: "beforebegin:#" + newTimelineID(types.ToJobApplicationTimelineType(firstTimelineEntryType), firstTimelineEntryID),
+				SwapOOB: "afterbegin:#timeline-list",
 				Entry: types.JobApplicationStatusHistory{
 					ID:               latestStatus.ID,
 					JobApplicationID: latestStatus.JobApplicationID,
@@ -418,7 +346,7 @@ func (h *Handler) UpdateJob(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	if statChanged {
+	if result.StatsChanged {
 		stats, err = h.getStats(r.Context(), userID)
 		if err != nil {
 			h.Logger.ErrorContext(r.Context(), "failed to get stats", "error", err)
@@ -427,6 +355,42 @@ func (h *Handler) UpdateJob(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	var reminders types.RemindersOpts
+	if result.RemindersCleared {
+		reminders, err = h.getRemindersOpts(r.Context(), userID, job.ID)
+		if err != nil {
+			h.Logger.ErrorContext(r.Context(), "failed to get reminders", "error", err)
+			h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+			return
+		}
+	}
+
+	// Interviews can only be scheduled while interviewing and offers only recorded while offered,
+	// so the forms need to follow the status.
+	var interviews types.InterviewsOpts
+	var offer types.OfferOpts
+	if result.StatusChanged {
+		interviews, err = h.getInterviewsOpts(r.Context(), userID, job.ID)
+		if err != nil {
+			h.Logger.ErrorContext(r.Context(), "failed to get interviews", "error", err)
+			h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+			return
+		}
+		offer, err = h.getOfferOpts(r.Context(), userID, job.ID)
+		if err != nil {
+			h.Logger.ErrorContext(r.Context(), "failed to get offer", "error", err)
+			h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+			return
+		}
+	}
+
+	tags, err := jobapplication.GetJobApplicationTags(r.Context(), h.Database.Queries(), job.ID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get tags", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
 	actualJob := types.JobApplication{
 		ID:        job.ID,
 		Company:   company,
@@ -436,9 +400,11 @@ func (h *Handler) UpdateJob(w http.ResponseWriter, r *http.Request) {
 		AppliedAt: job.AppliedAt,
 		UpdatedAt: job.UpdatedAt,
 		UserID:    job.UserID,
+		Tags:      tags,
 	}
 
-	h.html(r.Context(), w, http.StatusOK, components.UpdateJob(actualJob, stats, newTimelineEntry))
+	triggerJobsChanged(w)
+	h.html(r.Context(), w, http.StatusOK, components.UpdateJob(actualJob, stats, newTimelineEntry, reminders, interviews, offer))
 }
 
 func (h *Handler) ArchiveJobs(w http.ResponseWriter, r *http.Request) {
@@ -491,75 +457,9 @@ func (h *Handler) ArchiveJobs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	jobsCount, err := qtx.CountJobApplicationsForStats(r.Context(), userID)
+	err = jobapplication.RecalculateStats(r.Context(), qtx, userID)
 	if err != nil {
-		h.Logger.ErrorContext(r.Context(), "failed to count job applications for stats", "userID", userID, "error", err)
-		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
-		return
-	}
-
-	var jobs []queries.GetJobApplicationsForStatsRow
-	if jobsCount > 0 {
-		jobs, err = qtx.GetJobApplicationsForStats(r.Context(), userID)
-		if err != nil {
-			h.Logger.ErrorContext(r.Context(), "failed to get job applications for stats", "userID", userID, "error", err)
-			h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
-			return
-		}
-	}
-
-	companyCount, err := qtx.CountJobApplicationCompanies(r.Context(), queries.CountJobApplicationCompaniesParams{UserID: userID, Archived: 0})
-	if err != nil {
-		h.Logger.ErrorContext(r.Context(), "failed to get count application companies", "userID", userID, "error", err)
-		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
-		return
-	}
-
-	statArgs := queries.SetJobApplicationStatParams{TotalCompanies: companyCount, UserID: userID}
-	for _, j := range jobs {
-		statArgs.TotalApplications += 1
-		if j.HeardBackAt != nil {
-			heardBackAt, err := time.Parse("2006-01-02 15:04:05", j.HeardBackAt.(string))
-			if err != nil {
-				h.Logger.ErrorContext(r.Context(), "failed to convert string to date", "heardBackAt", j.HeardBackAt, "error", err)
-				h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
-				return
-			}
-			diff := heardBackAt.Sub(j.AppliedAt)
-			daysSince := int64(diff.Hours() / 24)
-
-			if statArgs.AverageTimeToHearBack == 0 {
-				statArgs.AverageTimeToHearBack = daysSince
-			} else {
-				statArgs.AverageTimeToHearBack = (daysSince + statArgs.AverageTimeToHearBack) / 2
-			}
-		}
-		switch types.JobApplicationStatus(j.Status) {
-		case types.JobApplicationStatusAccepted:
-			statArgs.TotalAccepted += 1
-		case types.JobApplicationStatusApplied:
-			statArgs.TotalApplied += 1
-		case types.JobApplicationStatusCanceled:
-			statArgs.TotalCanceled += 1
-		case types.JobApplicationStatusDeclined:
-			statArgs.TotalDeclined += 1
-		case types.JobApplicationStatusInterviewing:
-			statArgs.TotalInterviewing += 1
-		case types.JobApplicationStatusOffered:
-			statArgs.TotalOffers += 1
-		case types.JobApplicationStatusRejected:
-			statArgs.TotalRejected += 1
-		case types.JobApplicationStatusWatching:
-			statArgs.TotalWatching += 1
-		case types.JobApplicationStatusWithdrawn:
-			statArgs.TotalWatching += 1
-		case types.JobApplicationStatusClosed:
-		}
-	}
-
-	err = qtx.SetJobApplicationStat(r.Context(), statArgs)
-	if err != nil {
-		h.Logger.ErrorContext(r.Context(), "failed to set stats", "error", err)
+		h.Logger.ErrorContext(r.Context(), "failed to recalculate stats", "error", err)
 		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
 		return
 	}
@@ -570,14 +470,15 @@ func (h *Handler) ArchiveJobs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	jobsPage, err := h.getJobApplicationsByUserID(r.Context(), userID, int64(0), defaultPerPage, defaultPage*defaultPerPage)
+	jobs, err := h.filterJobs(r.Context(), userID, types.FilterOpts{}, defaultPage, defaultPerPage)
 	if err != nil {
 		h.Logger.ErrorContext(r.Context(), "failed to get jobs", "error", err)
 		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
 		return
 	}
 
-	h.html(r.Context(), w, http.StatusOK, components.JobsReload(jobsPage, types.PaginationOpts{Page: defaultPage, PerPage: defaultPerPage, Showing: len(jobsPage)}, types.FilterOpts{}))
+	triggerJobsChanged(w)
+	h.html(r.Context(), w, http.StatusOK, components.JobsReload(jobs, types.PaginationOpts{Page: defaultPage, PerPage: defaultPerPage, Showing: len(jobs)}, types.FilterOpts{}))
 }
 
 func (h *Handler) UnarchiveJob(w http.ResponseWriter, r *http.Request) {
@@ -621,7 +522,7 @@ func (h *Handler) UnarchiveJob(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Recalculate stats after unarchiving
-	err = h.recalculateStats(r.Context(), qtx, userID)
+	err = jobapplication.RecalculateStats(r.Context(), qtx, userID)
 	if err != nil {
 		h.Logger.ErrorContext(r.Context(), "failed to recalculate stats", "error", err)
 		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
@@ -635,13 +536,14 @@ func (h *Handler) UnarchiveJob(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get the updated archived jobs list (archived = 1)
-	jobs, err := h.getJobApplicationsByUserID(r.Context(), userID, int64(1), defaultPerPage, defaultPage*defaultPerPage)
+	jobs, err := h.filterJobs(r.Context(), userID, types.FilterOpts{IsArchived: true}, defaultPage, defaultPerPage)
 	if err != nil {
 		h.Logger.ErrorContext(r.Context(), "failed to get archived jobs", "error", err)
 		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
 		return
 	}
 
+	triggerJobsChanged(w)
 	h.html(r.Context(), w, http.StatusOK, components.JobsReload(jobs, types.PaginationOpts{Page: defaultPage, PerPage: defaultPerPage, Showing: len(jobs)}, types.FilterOpts{}))
 }
 
@@ -686,7 +588,7 @@ func (h *Handler) ArchiveJob(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Recalculate stats after archiving
-	err = h.recalculateStats(r.Context(), qtx, userID)
+	err = jobapplication.RecalculateStats(r.Context(), qtx, userID)
 	if err != nil {
 		h.Logger.ErrorContext(r.Context(), "failed to recalculate stats", "error", err)
 		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
@@ -700,152 +602,53 @@ func (h *Handler) ArchiveJob(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get the updated active jobs list (archived = 0)
-	jobs, err := h.getJobApplicationsByUserID(r.Context(), userID, int64(0), defaultPerPage, defaultPage*defaultPerPage)
+	jobs, err := h.filterJobs(r.Context(), userID, types.FilterOpts{}, defaultPage, defaultPerPage)
 	if err != nil {
 		h.Logger.ErrorContext(r.Context(), "failed to get active jobs", "error", err)
 		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
 		return
 	}
 
+	triggerJobsChanged(w)
 	h.html(r.Context(), w, http.StatusOK, components.JobsReload(jobs, types.PaginationOpts{Page: defaultPage, PerPage: defaultPerPage, Showing: len(jobs)}, types.FilterOpts{}))
 }
 
-func newTimelineID(entryType types.JobApplicationTimelineType, entryID string) string {
-	switch entryType {
-	case types.JobApplicationTimelineTypeStatus:
-		return utils.TimelineStatusRowStringID(entryID)
-	case types.JobApplicationTimelineTypeNote:
-		return utils.TimelineNoteRowStringID(entryID)
-	default:
-		return "unknown"
-	}
-}
-
-func getStatsDiff(currentStatus types.JobApplicationStatus, newStatus types.JobApplicationStatus) queries.UpdateJobApplicationStatParams {
-	params := queries.UpdateJobApplicationStatParams{}
-	switch currentStatus {
-	case types.JobApplicationStatusAccepted:
-		params.TotalAccepted = -1
-	case types.JobApplicationStatusApplied:
-		params.TotalApplied = -1
-	case types.JobApplicationStatusCanceled:
-		params.TotalCanceled = -1
-	case types.JobApplicationStatusDeclined:
-		params.TotalDeclined = -1
-	case types.JobApplicationStatusInterviewing:
-		params.TotalInterviewing = -1
-	case types.JobApplicationStatusOffered:
-		params.TotalOffers = -1
-	case types.JobApplicationStatusRejected:
-		params.TotalRejected = -1
-	case types.JobApplicationStatusWatching:
-		params.TotalWatching = -1
-	case types.JobApplicationStatusWithdrawn:
-		params.TotalWidthdrawn = -1
-	case types.JobApplicationStatusClosed:
-	}
-
-	switch newStatus {
-	case types.JobApplicationStatusAccepted:
-		params.TotalAccepted = 1
-	case types.JobApplicationStatusApplied:
-		params.TotalApplied = 1
-	case types.JobApplicationStatusCanceled:
-		params.TotalCanceled = 1
-	case types.JobApplicationStatusDeclined:
-		params.TotalDeclined = 1
-	case types.JobApplicationStatusInterviewing:
-		params.TotalInterviewing = 1
-	case types.JobApplicationStatusOffered:
-		params.TotalOffers = 1
-	case types.JobApplicationStatusRejected:
-		params.TotalRejected = 1
-	case types.JobApplicationStatusWatching:
-		params.TotalWatching = 1
-	case types.JobApplicationStatusWithdrawn:
-		params.TotalWidthdrawn = 1
-	case types.JobApplicationStatusClosed:
-	}
-	return params
-}
-
-func hasChanged(diff queries.UpdateJobApplicationStatParams) bool {
-	return diff.TotalAccepted != 0 || diff.TotalApplied != 0 || diff.TotalCanceled != 0 || diff.TotalDeclined != 0 || diff.TotalInterviewing != 0 || diff.TotalOffers != 0 || diff.TotalRejected != 0 || diff.TotalWatching != 0 || diff.TotalWidthdrawn != 0 || diff.TotalCompanies != 0
-}
-
-func (h *Handler) recalculateStats(ctx context.Context, qtx *queries.Queries, userID int64) error {
-	jobsCount, err := qtx.CountJobApplicationsForStats(ctx, userID)
+func (h *Handler) DeleteJob(w http.ResponseWriter, r *http.Request) {
+	jobIDStr := r.PathValue("id")
+	jobID, err := strconv.ParseInt(jobIDStr, 10, 64)
 	if err != nil {
-		return err
-	}
-
-	var jobs []queries.GetJobApplicationsForStatsRow
-	if jobsCount > 0 {
-		jobs, err = qtx.GetJobApplicationsForStats(ctx, userID)
-		if err != nil {
-			return err
-		}
+		h.Logger.ErrorContext(r.Context(), "failed to parse job id", "jobID", jobIDStr, "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid job ID", "Please try again."))
+		return
 	}
 
-	companyCount, err := qtx.CountJobApplicationCompanies(ctx, queries.CountJobApplicationCompaniesParams{UserID: userID, Archived: 0})
+	userID, err := getUserID(r)
 	if err != nil {
-		return err
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
 	}
 
-	statArgs := queries.SetJobApplicationStatParams{TotalCompanies: companyCount, UserID: userID}
-	var allDays []int64
-
-	for _, j := range jobs {
-		statArgs.TotalApplications += 1
-		if j.HeardBackAt != nil {
-			var heardBackAt time.Time
-			var err error
-
-			switch v := j.HeardBackAt.(type) {
-			case time.Time:
-				heardBackAt = v
-			case string:
-				heardBackAt, err = time.Parse("2006-01-02 15:04:05", v)
-				if err != nil {
-					return err
-				}
-			default:
-				return fmt.Errorf("unexpected type for HeardBackAt %T: %w", v, ErrInvalidHeardBackAtType)
-			}
-			diff := heardBackAt.Sub(j.AppliedAt)
-			daysSince := int64(diff.Hours() / 24)
-			allDays = append(allDays, daysSince)
-		}
-		switch types.JobApplicationStatus(j.Status) {
-		case types.JobApplicationStatusAccepted:
-			statArgs.TotalAccepted += 1
-		case types.JobApplicationStatusApplied:
-			statArgs.TotalApplied += 1
-		case types.JobApplicationStatusCanceled:
-			statArgs.TotalCanceled += 1
-		case types.JobApplicationStatusDeclined:
-			statArgs.TotalDeclined += 1
-		case types.JobApplicationStatusInterviewing:
-			statArgs.TotalInterviewing += 1
-		case types.JobApplicationStatusOffered:
-			statArgs.TotalOffers += 1
-		case types.JobApplicationStatusRejected:
-			statArgs.TotalRejected += 1
-		case types.JobApplicationStatusWatching:
-			statArgs.TotalWatching += 1
-		case types.JobApplicationStatusWithdrawn:
-			statArgs.TotalWatching += 1
-		case types.JobApplicationStatusClosed:
+	job, err := jobapplication.Delete(r.Context(), h.Database, userID, jobID)
+	if err != nil {
+		if errors.Is(err, jobapplication.ErrNotFound) {
+			h.Logger.WarnContext(r.Context(), "user does not own job", "userID", userID, "jobID", jobID)
+			h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Job application not found", "Try again later."))
+			return
 		}
+		h.Logger.ErrorContext(r.Context(), "failed to delete job application", "jobID", jobID, "userID", userID, "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
 	}
 
-	if len(allDays) > 0 {
-		sum := int64(0)
-		for _, days := range allDays {
-			sum += days
-		}
-		statArgs.AverageTimeToHearBack = sum / int64(len(allDays))
+	// Reload the list the job application was deleted from
+	jobs, err := h.filterJobs(r.Context(), userID, types.FilterOpts{IsArchived: job.Archived == 1}, defaultPage, defaultPerPage)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get jobs", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
 	}
 
-	return qtx.SetJobApplicationStat(ctx, statArgs)
+	triggerJobsChanged(w)
+	h.html(r.Context(), w, http.StatusOK, components.JobsReload(jobs, types.PaginationOpts{Page: defaultPage, PerPage: defaultPerPage, Showing: len(jobs)}, types.FilterOpts{IsArchived: job.Archived == 1}))
 }
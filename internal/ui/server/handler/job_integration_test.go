@@ -0,0 +1,93 @@
+//go:build integration
+
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/storage"
+	"github.com/Piszmog/pathwise/internal/testutil"
+	"github.com/Piszmog/pathwise/internal/ui/server/handler"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/Piszmog/pathwise/internal/ui/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupHandler(t *testing.T) (*handler.Handler, db.Database) {
+	t.Helper()
+
+	dbFile := filepath.Join(t.TempDir(), "integration-test.sqlite3")
+	database, err := db.New(slog.New(slog.DiscardHandler), db.DatabaseOpts{URL: dbFile})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+	_, err = database.DB().ExecContext(context.Background(), "PRAGMA foreign_keys = ON")
+	require.NoError(t, err)
+	require.NoError(t, testutil.RunMigrations(dbFile))
+
+	store, err := storage.NewLocal(t.TempDir())
+	require.NoError(t, err)
+	return &handler.Handler{Logger: slog.New(slog.DiscardHandler), Database: database, Storage: store}, database
+}
+
+func createTestUser(t *testing.T, database db.Database, userID int64) {
+	t.Helper()
+
+	_, err := database.DB().ExecContext(context.Background(), "INSERT INTO users (id, email, password) VALUES (?, ?, ?)", userID, "user@example.com", "password")
+	require.NoError(t, err)
+	_, err = database.DB().ExecContext(context.Background(), "INSERT INTO job_application_stats (user_id) VALUES (?)", userID)
+	require.NoError(t, err)
+	require.NoError(t, jobapplication.SeedPipeline(context.Background(), database.Queries(), userID))
+}
+
+// request builds a request as the auth middleware passes it on for the user.
+func request(method string, target string, body *bytes.Buffer, contentType string, userID int64, jobID int64) *http.Request {
+	r := httptest.NewRequest(method, target, body)
+	r.Header.Set("Content-Type", contentType)
+	r.Header.Set("USER-ID", strconv.FormatInt(userID, 10))
+	r.SetPathValue("id", strconv.FormatInt(jobID, 10))
+	return r
+}
+
+func TestUpdateJob_StatusAfterAttachment(t *testing.T) {
+	h, database := setupHandler(t)
+	createTestUser(t, database, 1)
+	jobID, err := jobapplication.Create(context.Background(), database, 1, jobapplication.NewJobApplication{Company: "Acme", Title: "Engineer"})
+	require.NoError(t, err)
+
+	var upload bytes.Buffer
+	form := multipart.NewWriter(&upload)
+	require.NoError(t, form.WriteField("kind", string(types.AttachmentKindResume)))
+	file, err := form.CreateFormFile("file", "resume.pdf")
+	require.NoError(t, err)
+	_, err = file.Write([]byte("%PDF-1.7\n1 0 obj << /Type /Catalog >> endobj\n%%EOF\n"))
+	require.NoError(t, err)
+	require.NoError(t, form.Close())
+	w := httptest.NewRecorder()
+	h.AddAttachment(w, request(http.MethodPost, "/jobs/1/attachments", &upload, form.FormDataContentType(), 1, jobID))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// The attachment is now the newest entry of the timeline, and the new status goes above it.
+	update := url.Values{"company": {"Acme"}, "title": {"Engineer"}, "status": {string(types.JobApplicationStatusInterviewing)}}
+	w = httptest.NewRecorder()
+	h.UpdateJob(w, request(http.MethodPatch, "/jobs/1", bytes.NewBufferString(update.Encode()), "application/x-www-form-urlencoded", 1, jobID))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	latest, err := database.Queries().GetLatestJobApplicationStatusHistoryByID(context.Background(), jobID)
+	require.NoError(t, err)
+	body := w.Body.String()
+	assert.Contains(t, body, `hx-swap-oob="afterbegin:#timeline-list"`)
+	assert.Contains(t, body, `id="`+utils.TimelineStatusRowID(latest.ID)+`"`)
+	assert.NotContains(t, body, "#unknown")
+}
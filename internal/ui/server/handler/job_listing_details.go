@@ -6,8 +6,8 @@ import (
 	"net/http"
 	"strconv"
 
-	"github.com/Piszmog/pathwise/internal/db"
 	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/jobapplication"
 	"github.com/Piszmog/pathwise/internal/ui/components"
 	"github.com/Piszmog/pathwise/internal/ui/types"
 )
@@ -35,11 +35,17 @@ func (h *Handler) GetJobListingDetails(w http.ResponseWriter, r *http.Request) {
 	}
 
 	hasAdded := false
+	var companyID int64
 	userID, err := getUserID(r)
 	if err == nil {
 		_, err = h.Database.Queries().CheckUserHasAddedHNJob(r.Context(),
 			queries.CheckUserHasAddedHNJobParams{UserID: userID, HnJobID: id})
 		hasAdded = (err == nil)
+
+		companyID, err = jobapplication.GetCompanyIDByName(r.Context(), h.Database.Queries(), userID, hnJob.Company)
+		if err != nil {
+			h.Logger.ErrorContext(r.Context(), "failed to get company", "error", err)
+		}
 	}
 
 	appURL := hnJob.ApplicationUrl.String
@@ -71,6 +77,7 @@ func (h *Handler) GetJobListingDetails(w http.ResponseWriter, r *http.Request) {
 		},
 		TechStacks: techStacks,
 		HasAdded:   hasAdded,
+		CompanyID:  companyID,
 	}
 
 	h.html(r.Context(), w, http.StatusOK, components.JobListingDetails(jobDetails))
@@ -122,27 +129,13 @@ func (h *Handler) AddJobApplicationFromListing(w http.ResponseWriter, r *http.Re
 
 	qtx := queries.New(tx)
 
-	jobApp := queries.InsertJobApplicationParams{
-		Company:        hnJob.Company,
-		Title:          hnJob.Title,
-		Url:            db.NewNullString(appURL),
-		UserID:         userID,
-		SalaryMin:      sql.NullInt64{},
-		SalaryMax:      sql.NullInt64{},
-		SalaryCurrency: sql.NullString{},
-	}
-
-	jobID, err := qtx.InsertJobApplication(r.Context(), jobApp)
-	if err != nil {
-		h.Logger.ErrorContext(r.Context(), "failed to insert job application", "error", err)
-		h.html(r.Context(), w, http.StatusInternalServerError,
-			components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
-		return
-	}
-
-	err = qtx.InsertJobApplicationStatusHistory(r.Context(), jobID)
+	jobID, err := jobapplication.CreateTx(r.Context(), qtx, userID, jobapplication.NewJobApplication{
+		Company: hnJob.Company,
+		Title:   hnJob.Title,
+		URL:     appURL,
+	})
 	if err != nil {
-		h.Logger.ErrorContext(r.Context(), "failed to insert status history", "error", err)
+		h.Logger.ErrorContext(r.Context(), "failed to create job application", "error", err)
 		h.html(r.Context(), w, http.StatusInternalServerError,
 			components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
 		return
@@ -160,26 +153,8 @@ func (h *Handler) AddJobApplicationFromListing(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	companyCount, err := h.Database.Queries().CountJobApplicationCompany(r.Context(),
-		queries.CountJobApplicationCompanyParams{UserID: userID, Company: hnJob.Company})
-	if err != nil {
-		h.Logger.ErrorContext(r.Context(), "failed to count company", "error", err)
-		h.html(r.Context(), w, http.StatusInternalServerError,
-			components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
-		return
-	}
-
-	companyIncrement := int64(0)
-	if companyCount == 0 {
-		companyIncrement = 1
-	}
-
-	err = qtx.IncrementNewJobApplicationStat(r.Context(), queries.IncrementNewJobApplicationStatParams{
-		UserID:         userID,
-		TotalCompanies: companyIncrement,
-	})
-	if err != nil {
-		h.Logger.ErrorContext(r.Context(), "failed to increment stats", "error", err)
+	if err = jobapplication.AddListingContactTx(r.Context(), qtx, userID, jobID, hnJob.Company, hnJob.ContactEmail.String); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to add listing contact", "error", err)
 		h.html(r.Context(), w, http.StatusInternalServerError,
 			components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
 		return
@@ -1,11 +1,14 @@
 package handler
 
 import (
+	"database/sql"
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
+	"github.com/Piszmog/pathwise/internal/jobapplication"
 	"github.com/Piszmog/pathwise/internal/ui/components"
-	"github.com/Piszmog/pathwise/internal/db/queries"
 	"github.com/Piszmog/pathwise/internal/ui/types"
 )
 
@@ -17,23 +20,38 @@ func (h *Handler) AddNote(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	id, err := strconv.Atoi(r.PathValue("id"))
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
 	if err != nil {
 		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
 		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
 		return
 	}
 
-	job, err := h.Database.Queries().GetJobApplicationByIDAndUserID(r.Context(), queries.GetJobApplicationByIDAndUserIDParams{ID: int64(id), UserID: userID})
+	if err = r.ParseForm(); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse form", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	n, err := jobapplication.AddNote(r.Context(), h.Database, userID, id, r.FormValue("note"))
 	if err != nil {
-		h.Logger.ErrorContext(r.Context(), "failed to get job", "error", err)
+		h.noteError(w, r, userID, id, err)
+		return
+	}
+
+	h.html(r.Context(), w, http.StatusOK, components.TimelineEntry(newNoteEntry(n.ID, n.JobApplicationID, n.Note, n.CreatedAt, n.UpdatedAt, false), false))
+}
+
+func (h *Handler) UpdateNote(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
 		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
 		return
 	}
 
-	if job.UserID != userID {
-		h.Logger.WarnContext(r.Context(), "user does not own job", "userID", userID, "jobUserID", job.UserID)
-		h.html(r.Context(), w, http.StatusForbidden, components.Alert(types.AlertTypeError, "You do not have permission to add a note", "You can only add notes to your own job applications."))
+	id, noteID, ok := h.noteIDs(w, r)
+	if !ok {
 		return
 	}
 
@@ -42,30 +60,76 @@ func (h *Handler) AddNote(w http.ResponseWriter, r *http.Request) {
 		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
 		return
 	}
-	note := r.FormValue("note")
-	if note == "" {
-		h.Logger.ErrorContext(r.Context(), "missing required form values", "note", note)
-		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Missing note", "Please enter a note."))
+
+	n, err := jobapplication.UpdateNote(r.Context(), h.Database, userID, id, noteID, r.FormValue("note"))
+	if err != nil {
+		h.noteError(w, r, userID, id, err)
 		return
 	}
 
-	jobNote := queries.InsertJobApplicationNoteParams{
-		JobApplicationID: int64(id),
-		Note:             note,
-	}
-	n, err := h.Database.Queries().InsertJobApplicationNote(r.Context(), jobNote)
+	h.html(r.Context(), w, http.StatusOK, components.NoteContent(newNoteEntry(n.ID, n.JobApplicationID, n.Note, n.CreatedAt, n.UpdatedAt, false)))
+}
+
+func (h *Handler) DeleteNote(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
 	if err != nil {
-		h.Logger.ErrorContext(r.Context(), "failed to insert note", "error", err)
-		w.WriteHeader(http.StatusInternalServerError)
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	id, noteID, ok := h.noteIDs(w, r)
+	if !ok {
 		return
 	}
 
-	noteEntry := types.JobApplicationNote{
-		ID:               n.ID,
-		JobApplicationID: n.JobApplicationID,
-		Note:             n.Note,
-		CreatedAt:        n.CreatedAt,
+	if err = jobapplication.DeleteNote(r.Context(), h.Database, userID, id, noteID); err != nil {
+		h.noteError(w, r, userID, id, err)
+		return
 	}
 
-	h.html(r.Context(), w, http.StatusOK, components.TimelineEntry(noteEntry, false))
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) noteIDs(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return 0, 0, false
+	}
+	noteID, err := strconv.ParseInt(r.PathValue("noteID"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse note id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return 0, 0, false
+	}
+	return id, noteID, true
+}
+
+func (h *Handler) noteError(w http.ResponseWriter, r *http.Request, userID int64, jobID int64, err error) {
+	switch {
+	case errors.Is(err, jobapplication.ErrMissingNote):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Missing note", "Please enter a note."))
+	case errors.Is(err, jobapplication.ErrNotFound):
+		h.Logger.WarnContext(r.Context(), "job application not found", "userID", userID, "jobID", jobID)
+		h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Job application not found", "Try again later."))
+	case errors.Is(err, jobapplication.ErrNoteNotFound):
+		h.Logger.WarnContext(r.Context(), "note not found", "userID", userID, "jobID", jobID)
+		h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Note not found", "Try again later."))
+	default:
+		h.Logger.ErrorContext(r.Context(), "failed to change note", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+	}
+}
+
+func newNoteEntry(id int64, jobID int64, note string, createdAt time.Time, updatedAt sql.NullTime, readOnly bool) types.JobApplicationNote {
+	return types.JobApplicationNote{
+		CreatedAt:        createdAt,
+		UpdatedAt:        updatedAt.Time,
+		Note:             note,
+		ID:               id,
+		JobApplicationID: jobID,
+		ReadOnly:         readOnly,
+	}
 }
@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/components"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+func (h *Handler) Offers(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	currency, err := h.getOffersCurrency(r, userID)
+	if err != nil {
+		h.offerError(w, r, userID, 0, err)
+		return
+	}
+	comparison, err := jobapplication.CompareOffers(r.Context(), h.Database.Queries(), userID, currency)
+	if err != nil {
+		h.offerError(w, r, userID, 0, err)
+		return
+	}
+	h.html(r.Context(), w, http.StatusOK, components.Offers(comparison))
+}
+
+func (h *Handler) SaveOffer(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	jobID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse form", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	offer, err := getNewOffer(r)
+	if err != nil {
+		h.Logger.WarnContext(r.Context(), "invalid offer", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid offer", "Please enter valid numbers for the offer."))
+		return
+	}
+
+	if err = jobapplication.SaveOffer(r.Context(), h.Database, userID, jobID, offer); err != nil {
+		h.offerError(w, r, userID, jobID, err)
+		return
+	}
+
+	h.jobOffer(w, r, userID, jobID)
+}
+
+func (h *Handler) DeleteOffer(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	jobID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = jobapplication.DeleteOffer(r.Context(), h.Database.Queries(), userID, jobID); err != nil {
+		h.offerError(w, r, userID, jobID, err)
+		return
+	}
+
+	h.jobOffer(w, r, userID, jobID)
+}
+
+func (h *Handler) SaveExchangeRates(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse form", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	var rates []types.ExchangeRate
+	for _, c := range types.AvailableCurrencies {
+		if c.Code == types.BaseCurrency {
+			continue
+		}
+		rate, parseErr := parseOfferFloat(r.PostFormValue("rate_" + c.Code))
+		if parseErr != nil {
+			h.Logger.WarnContext(r.Context(), "invalid exchange rate", "currency", c.Code, "error", parseErr)
+			h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid exchange rate", "Please enter valid numbers for the exchange rates."))
+			return
+		}
+		rates = append(rates, types.ExchangeRate{Currency: c.Code, Rate: rate})
+	}
+
+	if err = jobapplication.SaveExchangeRates(r.Context(), h.Database, userID, rates); err != nil {
+		h.offerError(w, r, userID, 0, err)
+		return
+	}
+
+	currency, err := h.getOffersCurrency(r, userID)
+	if err != nil {
+		h.offerError(w, r, userID, 0, err)
+		return
+	}
+	comparison, err := jobapplication.CompareOffers(r.Context(), h.Database.Queries(), userID, currency)
+	if err != nil {
+		h.offerError(w, r, userID, 0, err)
+		return
+	}
+	h.html(r.Context(), w, http.StatusOK, components.OffersSection(comparison))
+}
+
+func (h *Handler) offerError(w http.ResponseWriter, r *http.Request, userID int64, jobID int64, err error) {
+	switch {
+	case errors.Is(err, jobapplication.ErrInvalidCurrency):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid currency", "Please select a valid currency."))
+	case errors.Is(err, jobapplication.ErrInvalidOfferAmount):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid amount", "Offer amounts cannot be negative."))
+	case errors.Is(err, jobapplication.ErrInvalidVesting):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid vesting", "Vesting must be between 1 and 10 years with the cliff within it."))
+	case errors.Is(err, jobapplication.ErrInvalidDeadline):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid deadline", "Please enter a valid date."))
+	case errors.Is(err, jobapplication.ErrInvalidExchangeRate):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid exchange rate", "Exchange rates must be greater than zero."))
+	case errors.Is(err, jobapplication.ErrNotOffered):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Not offered", "Change the status to offered to record the offer."))
+	case errors.Is(err, jobapplication.ErrOfferNotFound):
+		h.Logger.WarnContext(r.Context(), "offer not found", "userID", userID, "jobID", jobID)
+		h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Offer not found", "Try again later."))
+	case errors.Is(err, jobapplication.ErrNotFound):
+		h.Logger.WarnContext(r.Context(), "user does not own job", "userID", userID, "jobID", jobID)
+		h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Job application not found", "Try again later."))
+	default:
+		h.Logger.ErrorContext(r.Context(), "failed to change offers", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+	}
+}
+
+func (h *Handler) jobOffer(w http.ResponseWriter, r *http.Request, userID int64, jobID int64) {
+	opts, err := h.getOfferOpts(r.Context(), userID, jobID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get offer", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	h.html(r.Context(), w, http.StatusOK, components.JobOffer(opts, false, ""))
+}
+
+// getOfferOpts returns the offer section of a job application. Without an offer, the form starts
+// from the salary currency of the job application and a four year vesting with a one year cliff.
+func (h *Handler) getOfferOpts(ctx context.Context, userID int64, jobID int64) (types.OfferOpts, error) {
+	job, err := h.Database.Queries().GetJobApplicationByIDAndUserID(ctx, queries.GetJobApplicationByIDAndUserIDParams{ID: jobID, UserID: userID})
+	if err != nil {
+		return types.OfferOpts{}, err
+	}
+
+	offer, hasOffer, err := jobapplication.GetOffer(ctx, h.Database.Queries(), jobID)
+	if err != nil {
+		return types.OfferOpts{}, err
+	}
+	if !hasOffer {
+		offer = types.Offer{Currency: types.BaseCurrency, VestingYears: 4, VestingCliffMonths: 12}
+		if job.SalaryCurrency.Valid {
+			offer.Currency = job.SalaryCurrency.String
+		}
+	}
+	return types.OfferOpts{
+		Offer:            offer,
+		Status:           types.JobApplicationStatus(job.Status),
+		JobApplicationID: jobID,
+		HasOffer:         hasOffer,
+	}, nil
+}
+
+// getOffersCurrency returns the currency offers are compared in, defaulting to the display currency
+// of the user.
+func (h *Handler) getOffersCurrency(r *http.Request, userID int64) (string, error) {
+	if currency := r.URL.Query().Get("currency"); currency != "" {
+		return currency, nil
+	}
+	return jobapplication.GetDisplayCurrency(r.Context(), h.Database.Queries(), userID)
+}
+
+func getNewOffer(r *http.Request) (jobapplication.NewOffer, error) {
+	var offer jobapplication.NewOffer
+	ints := []struct {
+		name  string
+		value *int64
+	}{
+		{name: "base_salary", value: &offer.BaseSalary},
+		{name: "bonus", value: &offer.Bonus},
+		{name: "sign_on_bonus", value: &offer.SignOnBonus},
+		{name: "equity_shares", value: &offer.EquityShares},
+		{name: "vesting_years", value: &offer.VestingYears},
+		{name: "vesting_cliff_months", value: &offer.VestingCliffMonths},
+	}
+	for _, i := range ints {
+		val, err := parseOfferInt(r.FormValue(i.name))
+		if err != nil {
+			return jobapplication.NewOffer{}, err
+		}
+		*i.value = val
+	}
+
+	var err error
+	if offer.EquityStrikePrice, err = parseOfferFloat(r.FormValue("equity_strike_price")); err != nil {
+		return jobapplication.NewOffer{}, err
+	}
+	if offer.EquitySharePrice, err = parseOfferFloat(r.FormValue("equity_share_price")); err != nil {
+		return jobapplication.NewOffer{}, err
+	}
+	offer.Currency = r.FormValue("currency")
+	offer.Benefits = r.FormValue("benefits")
+	offer.Deadline = r.FormValue("deadline")
+	return offer, nil
+}
+
+func parseOfferInt(val string) (int64, error) {
+	if val == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(val, 10, 64)
+}
+
+func parseOfferFloat(val string) (float64, error) {
+	if val == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(val, 64)
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Piszmog/pathwise/internal/auth"
+	"github.com/Piszmog/pathwise/internal/ui/components"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/Piszmog/pathwise/internal/ui/utils"
+)
+
+func (h *Handler) BeginPasskeyLogin(w http.ResponseWriter, r *http.Request) {
+	assertion, token, err := auth.BeginPasskeyLogin(r.Context(), h.Database.Queries(), h.WebAuthn, h.now())
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to begin passkey login", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	utils.SetPasskeyCookie(w, token, auth.PasskeyCeremonyDuration)
+	h.passkeyOptions(w, r, assertion)
+}
+
+func (h *Handler) FinishPasskeyLogin(w http.ResponseWriter, r *http.Request) {
+	cookie, err := r.Cookie("passkey")
+	if err != nil {
+		h.Logger.DebugContext(r.Context(), "missing passkey cookie")
+		h.passkeyError(w, r, auth.ErrPasskeyCeremonyNotFound)
+		return
+	}
+	utils.ClearPasskeyCookie(w)
+
+	userID, err := auth.FinishPasskeyLogin(r.Context(), h.Database.Queries(), h.WebAuthn, cookie.Value, r.Body, h.now())
+	if err != nil {
+		h.passkeyError(w, r, err)
+		return
+	}
+
+	if err = h.startSession(w, r, userID); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to create session", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeWarning, "Something went wrong", "Try again later."))
+		return
+	}
+
+	w.Header().Set("HX-Redirect", "/")
+}
+
+func (h *Handler) BeginPasskeyRegistration(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	user, err := h.Database.Queries().GetUserByID(r.Context(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get user", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	creation, token, err := auth.BeginPasskeyRegistration(r.Context(), h.Database.Queries(), h.WebAuthn, userID, user.Email, h.now())
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to begin passkey registration", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	utils.SetPasskeyCookie(w, token, auth.PasskeyCeremonyDuration)
+	h.passkeyOptions(w, r, creation)
+}
+
+func (h *Handler) FinishPasskeyRegistration(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	cookie, err := r.Cookie("passkey")
+	if err != nil {
+		h.Logger.DebugContext(r.Context(), "missing passkey cookie")
+		h.passkeyError(w, r, auth.ErrPasskeyCeremonyNotFound)
+		return
+	}
+	utils.ClearPasskeyCookie(w)
+
+	user, err := h.Database.Queries().GetUserByID(r.Context(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get user", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	// The body is the answer of the authenticator, so the name comes with the URL.
+	_, err = auth.FinishPasskeyRegistration(r.Context(), h.Database.Queries(), h.WebAuthn, userID, user.Email, cookie.Value, r.URL.Query().Get("name"), r.Body, h.now())
+	if err != nil {
+		h.passkeyError(w, r, err)
+		return
+	}
+
+	h.passkeysSection(w, r, userID)
+}
+
+func (h *Handler) DeletePasskey(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.DebugContext(r.Context(), "failed to parse passkey id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid passkey", "The passkey could not be found."))
+		return
+	}
+
+	if err = auth.DeletePasskey(r.Context(), h.Database.Queries(), userID, id); err != nil {
+		h.passkeyError(w, r, err)
+		return
+	}
+
+	h.passkeysSection(w, r, userID)
+}
+
+// passkeyOptions writes the options the browser passes to the authenticator.
+func (h *Handler) passkeyOptions(w http.ResponseWriter, r *http.Request, options any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(options); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to encode passkey options", "error", err)
+	}
+}
+
+func (h *Handler) passkeyError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, auth.ErrInvalidPasskeyName):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid name", "Name your passkey with 1 to 50 characters."))
+	case errors.Is(err, auth.ErrPasskeyInvalid):
+		h.Logger.DebugContext(r.Context(), "failed to verify passkey", "error", err)
+		h.html(r.Context(), w, http.StatusUnauthorized, components.Alert(types.AlertTypeError, "Passkey not accepted", "Try again, or use a different passkey."))
+	case errors.Is(err, auth.ErrPasskeyCeremonyNotFound):
+		h.html(r.Context(), w, http.StatusUnauthorized, components.Alert(types.AlertTypeError, "Passkey request expired", "Please try again."))
+	case errors.Is(err, auth.ErrPasskeyNotFound):
+		h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Passkey not found", "The passkey may have already been removed."))
+	default:
+		h.Logger.ErrorContext(r.Context(), "failed to check passkey", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+	}
+}
+
+func (h *Handler) passkeysSection(w http.ResponseWriter, r *http.Request, userID int64) {
+	passkeys, err := auth.GetPasskeys(r.Context(), h.Database.Queries(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get passkeys", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	h.html(r.Context(), w, http.StatusOK, components.PasskeysSection(passkeys))
+}
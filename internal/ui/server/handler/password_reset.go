@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Piszmog/pathwise/internal/auth"
+	"github.com/Piszmog/pathwise/internal/ui/components"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/Piszmog/pathwise/internal/ui/utils"
+)
+
+func (h *Handler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	h.html(r.Context(), w, http.StatusOK, components.ForgotPassword())
+}
+
+func (h *Handler) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	email := r.FormValue("email")
+	if email == "" {
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Missing email", "Please enter your email."))
+		return
+	}
+
+	if err := auth.RequestPasswordReset(r.Context(), h.Database.Queries(), h.Mailer, h.AppURL, email, h.now()); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to request password reset", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	h.html(r.Context(), w, http.StatusOK, components.Alert(types.AlertTypeSuccess, "Check your email", "If an account uses that address, we sent it a link to reset the password. The link expires in 1 hour."))
+}
+
+func (h *Handler) ResetPasswordPage(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	valid, err := auth.HasPasswordReset(r.Context(), h.Database.Queries(), token, h.now())
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get password reset", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	h.html(r.Context(), w, http.StatusOK, components.ResetPassword(token, valid))
+}
+
+func (h *Handler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	token := r.FormValue("token")
+	password := r.FormValue("password")
+	confirmPassword := r.FormValue("confirmPassword")
+	if password == "" || confirmPassword == "" {
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Missing password", "Please enter and confirm your new password."))
+		return
+	}
+	if password != confirmPassword {
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Passwords do not match", "Please enter matching passwords."))
+		return
+	}
+	if !isValidPassword(password) {
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Password does not meet requirements", "Password must be at least 12 characters long.", "Password must contain at least one uppercase letter.", "Password must contain at least one lowercase letter.", "Password must contain at least one number.", "Password must contain at least one special character (!@#$%^&*)."))
+		return
+	}
+
+	hashedPassword, err := utils.HashPassword([]byte(password), 14)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to hash password", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if _, err = auth.ResetPassword(r.Context(), h.Database, token, string(hashedPassword), h.now()); err != nil {
+		if errors.Is(err, auth.ErrResetTokenNotFound) {
+			h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Link expired", "This link has expired or was already used. Ask for a new one to reset your password."))
+			return
+		}
+		h.Logger.ErrorContext(r.Context(), "failed to reset password", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	utils.ClearSessionCookie(w)
+	w.Header().Set("HX-Redirect", "/signin")
+}
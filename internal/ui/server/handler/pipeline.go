@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/components"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+func (h *Handler) AddPipelineStatus(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse form", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if _, err = jobapplication.AddPipelineStatus(r.Context(), h.Database.Queries(), userID, r.FormValue("name"), r.FormValue("terminal") == "on"); err != nil {
+		switch {
+		case errors.Is(err, jobapplication.ErrInvalidStatusName):
+			h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid stage name", "Stage names must be between 1 and 50 characters."))
+		case errors.Is(err, jobapplication.ErrDuplicateStatus):
+			h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Stage already exists", "Please enter a different name."))
+		default:
+			h.Logger.ErrorContext(r.Context(), "failed to add pipeline status", "error", err)
+			h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		}
+		return
+	}
+
+	h.pipelineSection(w, r, userID)
+}
+
+func (h *Handler) MovePipelineStatus(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	statusID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse form", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = jobapplication.MovePipelineStatus(r.Context(), h.Database, userID, statusID, r.FormValue("direction") == "up"); err != nil {
+		if errors.Is(err, jobapplication.ErrStatusNotFound) {
+			h.Logger.WarnContext(r.Context(), "pipeline status not found", "userID", userID, "statusID", statusID)
+			h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Stage not found", "Try again later."))
+			return
+		}
+		h.Logger.ErrorContext(r.Context(), "failed to move pipeline status", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	h.pipelineSection(w, r, userID)
+}
+
+func (h *Handler) UpdatePipelineStatus(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	statusID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse form", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = jobapplication.SetPipelineStatusTerminal(r.Context(), h.Database.Queries(), userID, statusID, r.FormValue("terminal") == "on"); err != nil {
+		if errors.Is(err, jobapplication.ErrStatusNotFound) {
+			h.Logger.WarnContext(r.Context(), "pipeline status not found", "userID", userID, "statusID", statusID)
+			h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Stage not found", "Try again later."))
+			return
+		}
+		h.Logger.ErrorContext(r.Context(), "failed to update pipeline status", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	h.pipelineSection(w, r, userID)
+}
+
+func (h *Handler) DeletePipelineStatus(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	statusID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = jobapplication.DeletePipelineStatus(r.Context(), h.Database.Queries(), userID, statusID); err != nil {
+		switch {
+		case errors.Is(err, jobapplication.ErrStatusInUse):
+			h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Stage in use", "Move the job applications at this stage to another stage first."))
+		case errors.Is(err, jobapplication.ErrStatusRequired):
+			h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Stage required", "New job applications start at the applied stage."))
+		case errors.Is(err, jobapplication.ErrStatusNotFound):
+			h.Logger.WarnContext(r.Context(), "pipeline status not found", "userID", userID, "statusID", statusID)
+			h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Stage not found", "Try again later."))
+		default:
+			h.Logger.ErrorContext(r.Context(), "failed to delete pipeline status", "error", err)
+			h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		}
+		return
+	}
+
+	h.pipelineSection(w, r, userID)
+}
+
+func (h *Handler) pipelineSection(w http.ResponseWriter, r *http.Request, userID int64) {
+	pipeline, err := jobapplication.GetPipeline(r.Context(), h.Database.Queries(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get pipeline", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	h.html(r.Context(), w, http.StatusOK, components.PipelineSection(pipeline))
+}
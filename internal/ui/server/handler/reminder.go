@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/components"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+func (h *Handler) DueReminders(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	now := time.Now()
+	due, err := h.getDueReminders(r.Context(), userID, now)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get due reminders", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	h.html(r.Context(), w, http.StatusOK, components.DueReminders(types.RemindersOpts{Due: due, Now: now}, ""))
+}
+
+func (h *Handler) AddReminder(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	jobID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse form", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	remindAt, err := time.Parse("2006-01-02", r.FormValue("remind_at"))
+	if err != nil {
+		h.Logger.WarnContext(r.Context(), "invalid date format", "date", r.FormValue("remind_at"))
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid date", "Please enter a date."))
+		return
+	}
+
+	now := time.Now()
+	reminder, err := jobapplication.AddReminder(r.Context(), h.Database, userID, jobID, remindAt, r.FormValue("note"), now)
+	if err != nil {
+		switch {
+		case errors.Is(err, jobapplication.ErrInvalidReminderDate):
+			h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid date", "Please pick today or a day in the future."))
+		case errors.Is(err, jobapplication.ErrNotFound):
+			h.Logger.WarnContext(r.Context(), "user does not own job", "userID", userID, "jobID", jobID)
+			h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Job application not found", "Try again later."))
+		default:
+			h.Logger.ErrorContext(r.Context(), "failed to add reminder", "error", err)
+			h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		}
+		return
+	}
+
+	opts, err := h.getRemindersOpts(r.Context(), userID, jobID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get reminders", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	entry := types.JobApplicationReminder{
+		ID:               reminder.ID,
+		JobApplicationID: reminder.JobApplicationID,
+		RemindAt:         reminder.RemindAt,
+		Note:             reminder.Note,
+		CreatedAt:        reminder.CreatedAt,
+	}
+	h.html(r.Context(), w, http.StatusOK, components.AddReminder(opts, entry))
+}
+
+func (h *Handler) SnoozeReminder(w http.ResponseWriter, r *http.Request) {
+	h.updateReminder(w, r, func(ctx context.Context, userID int64, reminderID int64) error {
+		days, err := strconv.Atoi(r.FormValue("days"))
+		if err != nil {
+			return jobapplication.ErrInvalidReminderDate
+		}
+		return jobapplication.SnoozeReminder(ctx, h.Database, userID, reminderID, days, time.Now())
+	})
+}
+
+func (h *Handler) DismissReminder(w http.ResponseWriter, r *http.Request) {
+	h.updateReminder(w, r, func(ctx context.Context, userID int64, reminderID int64) error {
+		return jobapplication.DismissReminder(ctx, h.Database, userID, reminderID)
+	})
+}
+
+// updateReminder applies the update to the reminder and refreshes both the reminders of the job
+// application and the due reminders, since the request can come from either.
+func (h *Handler) updateReminder(w http.ResponseWriter, r *http.Request, update func(ctx context.Context, userID int64, reminderID int64) error) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	jobID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	reminderID, err := strconv.ParseInt(r.PathValue("reminderID"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse reminder id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse form", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = update(r.Context(), userID, reminderID); err != nil {
+		switch {
+		case errors.Is(err, jobapplication.ErrInvalidReminderDate):
+			h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid snooze", "Please pick how many days to snooze for."))
+		case errors.Is(err, jobapplication.ErrReminderNotFound):
+			h.Logger.WarnContext(r.Context(), "reminder not found", "userID", userID, "reminderID", reminderID)
+			h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Reminder not found", "It may have already been completed."))
+		default:
+			h.Logger.ErrorContext(r.Context(), "failed to update reminder", "error", err)
+			h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		}
+		return
+	}
+
+	opts, err := h.getRemindersOpts(r.Context(), userID, jobID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get reminders", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	h.html(r.Context(), w, http.StatusOK, components.UpdateReminders(opts))
+}
+
+func (h *Handler) getRemindersOpts(ctx context.Context, userID int64, jobID int64) (types.RemindersOpts, error) {
+	if _, err := h.Database.Queries().GetJobApplicationByIDAndUserID(ctx, queries.GetJobApplicationByIDAndUserIDParams{ID: jobID, UserID: userID}); err != nil {
+		return types.RemindersOpts{}, err
+	}
+
+	now := time.Now()
+	reminders, err := h.getReminders(ctx, jobID)
+	if err != nil {
+		return types.RemindersOpts{}, err
+	}
+	due, err := h.getDueReminders(ctx, userID, now)
+	if err != nil {
+		return types.RemindersOpts{}, err
+	}
+	return types.RemindersOpts{JobApplicationID: jobID, Reminders: reminders, Due: due, Now: now}, nil
+}
+
+func (h *Handler) getReminders(ctx context.Context, jobID int64) ([]types.JobApplicationReminder, error) {
+	rows, err := h.Database.Queries().GetJobApplicationRemindersByJobApplicationID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	reminders := make([]types.JobApplicationReminder, len(rows))
+	for i, row := range rows {
+		reminders[i] = types.JobApplicationReminder{
+			ID:               row.ID,
+			JobApplicationID: row.JobApplicationID,
+			RemindAt:         row.RemindAt,
+			DismissedAt:      row.DismissedAt,
+			Note:             row.Note,
+			CreatedAt:        row.CreatedAt,
+		}
+	}
+	return reminders, nil
+}
+
+func (h *Handler) getDueReminders(ctx context.Context, userID int64, now time.Time) ([]types.DueReminder, error) {
+	rows, err := jobapplication.GetDueReminders(ctx, h.Database.Queries(), userID, now)
+	if err != nil {
+		return nil, err
+	}
+	due := make([]types.DueReminder, len(rows))
+	for i, row := range rows {
+		due[i] = types.DueReminder{
+			Company: row.Company,
+			Title:   row.Title,
+			Status:  types.JobApplicationStatus(row.Status),
+			Reminder: types.JobApplicationReminder{
+				ID:               row.ID,
+				JobApplicationID: row.JobApplicationID,
+				RemindAt:         row.RemindAt,
+				Note:             row.Note,
+				CreatedAt:        row.CreatedAt,
+			},
+		}
+	}
+	return due, nil
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/components"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+func (h *Handler) AddResumeVersion(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, jobapplication.MaxAttachmentSize+(1<<20))
+	if err = r.ParseMultipartForm(1 << 20); err != nil {
+		h.Logger.WarnContext(r.Context(), "failed to parse form", "error", err)
+		if maxBytesErr := (*http.MaxBytesError)(nil); errors.As(err, &maxBytesErr) {
+			h.resumeError(w, r, userID, 0, jobapplication.ErrAttachmentTooLarge)
+			return
+		}
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	// The file is optional, so a version can be tracked by its label alone.
+	var file *jobapplication.ResumeFile
+	f, header, err := r.FormFile("file")
+	if err == nil {
+		defer func() { _ = f.Close() }()
+		file = &jobapplication.ResumeFile{Name: header.Filename, Size: header.Size, Content: f}
+	} else if !errors.Is(err, http.ErrMissingFile) {
+		h.Logger.ErrorContext(r.Context(), "failed to get uploaded file", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if _, err = jobapplication.AddResumeVersion(r.Context(), h.Database.Queries(), h.Storage, userID, r.FormValue("label"), file); err != nil {
+		h.resumeError(w, r, userID, 0, err)
+		return
+	}
+
+	h.resumesSection(w, r, userID)
+}
+
+func (h *Handler) ResumeVersionFile(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	versionID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	v, content, err := jobapplication.OpenResumeVersion(r.Context(), h.Database.Queries(), h.Storage, userID, versionID)
+	if err != nil {
+		h.resumeError(w, r, userID, versionID, err)
+		return
+	}
+	defer func() { _ = content.Close() }()
+
+	// Like attachments, resumes are always downloaded rather than shown.
+	w.Header().Set("Content-Type", v.ContentType.String)
+	w.Header().Set("Content-Length", strconv.FormatInt(v.Size.Int64, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": v.FileName.String}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	if _, err = io.Copy(w, content); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to write resume", "error", err)
+	}
+}
+
+func (h *Handler) DeleteResumeVersion(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	versionID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = jobapplication.DeleteResumeVersion(r.Context(), h.Database, h.Storage, userID, versionID); err != nil {
+		h.resumeError(w, r, userID, versionID, err)
+		return
+	}
+
+	h.resumesSection(w, r, userID)
+}
+
+func (h *Handler) SetJobResumeVersion(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	jobID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse form", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	var versionID int64
+	if val := r.FormValue("resume_version_id"); val != "" {
+		versionID, err = strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			h.Logger.WarnContext(r.Context(), "invalid resume version id", "error", err)
+			h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Bad request."))
+			return
+		}
+	}
+
+	if err = jobapplication.SetJobApplicationResumeVersion(r.Context(), h.Database.Queries(), userID, jobID, versionID); err != nil {
+		h.resumeError(w, r, userID, versionID, err)
+		return
+	}
+
+	opts, err := h.getResumeVersionOpts(r.Context(), userID, jobID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get resume versions", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	h.html(r.Context(), w, http.StatusOK, components.JobResumeVersion(opts, false, ""))
+}
+
+func (h *Handler) resumeError(w http.ResponseWriter, r *http.Request, userID int64, versionID int64, err error) {
+	switch {
+	case errors.Is(err, jobapplication.ErrInvalidResumeLabel):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid label", "Labels must be between 1 and 50 characters."))
+	case errors.Is(err, jobapplication.ErrDuplicateResume):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Resume version already exists", "Please enter a different label."))
+	case errors.Is(err, jobapplication.ErrInvalidAttachmentName):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid file name", "Please choose a file with a name of up to 255 characters."))
+	case errors.Is(err, jobapplication.ErrAttachmentType):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "File type not allowed", "Please choose a PDF, Word, OpenDocument, RTF, text or Markdown file."))
+	case errors.Is(err, jobapplication.ErrAttachmentEmpty):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Empty file", "Please choose a file that is not empty."))
+	case errors.Is(err, jobapplication.ErrAttachmentTooLarge):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "File too large", "Please choose a file smaller than 10 MB."))
+	case errors.Is(err, jobapplication.ErrResumeNotFound):
+		h.Logger.WarnContext(r.Context(), "resume version not found", "userID", userID, "versionID", versionID)
+		h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Resume version not found", "Try again later."))
+	case errors.Is(err, jobapplication.ErrNotFound):
+		h.Logger.WarnContext(r.Context(), "job application not found", "userID", userID)
+		h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Job application not found", "Try again later."))
+	default:
+		h.Logger.ErrorContext(r.Context(), "failed to change resume versions", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+	}
+}
+
+func (h *Handler) resumesSection(w http.ResponseWriter, r *http.Request, userID int64) {
+	versions, err := jobapplication.GetResumeVersions(r.Context(), h.Database.Queries(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get resume versions", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	h.html(r.Context(), w, http.StatusOK, components.ResumesSection(versions))
+}
+
+func (h *Handler) getResumeVersionOpts(ctx context.Context, userID int64, jobID int64) (types.ResumeVersionOpts, error) {
+	versions, err := jobapplication.GetResumeVersions(ctx, h.Database.Queries(), userID)
+	if err != nil {
+		return types.ResumeVersionOpts{}, err
+	}
+	selected, err := jobapplication.GetJobApplicationResumeVersion(ctx, h.Database.Queries(), userID, jobID)
+	if err != nil {
+		return types.ResumeVersionOpts{}, err
+	}
+	return types.ResumeVersionOpts{JobApplicationID: jobID, Versions: versions, Selected: selected}, nil
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/components"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
+	opts, ok := h.search(w, r)
+	if !ok {
+		return
+	}
+	h.html(r.Context(), w, http.StatusOK, components.Search(opts))
+}
+
+func (h *Handler) SearchResults(w http.ResponseWriter, r *http.Request) {
+	opts, ok := h.search(w, r)
+	if !ok {
+		return
+	}
+	h.html(r.Context(), w, http.StatusOK, components.SearchResults(opts))
+}
+
+// search runs the search of the request. If the search fails, the error is written to the response.
+func (h *Handler) search(w http.ResponseWriter, r *http.Request) (types.SearchOpts, bool) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Bad request."))
+		return types.SearchOpts{}, false
+	}
+
+	opts, err := getSearchOpts(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get search options", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Bad request."))
+		return types.SearchOpts{}, false
+	}
+
+	opts.Results, opts.Total, err = jobapplication.Search(r.Context(), h.Database, userID, opts.Query, opts.Archived, opts.PerPage, opts.Page*opts.PerPage)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to search jobs", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return types.SearchOpts{}, false
+	}
+	return opts, true
+}
+
+func getSearchOpts(r *http.Request) (types.SearchOpts, error) {
+	page, perPage, err := getPageOpts(r)
+	if err != nil {
+		return types.SearchOpts{}, fmt.Errorf("failed to get page opts: %w", err)
+	}
+
+	archived := false
+	if archivedStr := r.URL.Query().Get("archived"); archivedStr != "" {
+		archived, err = strconv.ParseBool(archivedStr)
+		if err != nil {
+			return types.SearchOpts{}, fmt.Errorf("failed to parse archive query: %w", err)
+		}
+	}
+
+	return types.SearchOpts{
+		Query:    r.URL.Query().Get("q"),
+		Page:     page,
+		PerPage:  perPage,
+		Archived: archived,
+	}, nil
+}
@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/Piszmog/pathwise/internal/auth"
 	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/jobapplication"
 	"github.com/Piszmog/pathwise/internal/ui/components"
 	"github.com/Piszmog/pathwise/internal/ui/types"
 	"github.com/Piszmog/pathwise/internal/ui/utils"
@@ -29,6 +31,13 @@ func (h *Handler) Settings(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	emailVerified, err := auth.IsEmailVerified(r.Context(), h.Database.Queries(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to check email verification", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
 	mcpAPIKeyCreatedAt, err := h.Database.Queries().GetMcpAPIKeyByUserID(r.Context(), userID)
 	hasMcpAPIKey := false
 	mcpKeyCreatedAt := ""
@@ -41,7 +50,61 @@ func (h *Handler) Settings(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.html(r.Context(), w, http.StatusOK, components.Settings(user.Email, hasMcpAPIKey, mcpKeyCreatedAt))
+	twoFactor, err := auth.GetTwoFactor(r.Context(), h.Database.Queries(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get two-factor authentication", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	passkeys, err := auth.GetPasskeys(r.Context(), h.Database.Queries(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get passkeys", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	calendarFeedCreatedAt, err := h.Database.Queries().GetCalendarFeedTokenByUserID(r.Context(), userID)
+	hasCalendarFeed := false
+	calendarCreatedAt := ""
+	if err == nil {
+		hasCalendarFeed = true
+		calendarCreatedAt = calendarFeedCreatedAt.Format("January 2, 2006")
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		h.Logger.ErrorContext(r.Context(), "failed to check calendar feed", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	pipeline, err := jobapplication.GetPipeline(r.Context(), h.Database.Queries(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get pipeline", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	tags, err := jobapplication.GetTags(r.Context(), h.Database.Queries(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get tags", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	resumes, err := jobapplication.GetResumeVersions(r.Context(), h.Database.Queries(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get resume versions", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	currency, err := h.getCurrencySettings(r.Context(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get currency settings", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	h.html(r.Context(), w, http.StatusOK, components.Settings(user.Email, emailVerified, hasMcpAPIKey, mcpKeyCreatedAt, twoFactor, passkeys, hasCalendarFeed, calendarCreatedAt, pipeline, tags, resumes, currency))
 }
 
 func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
@@ -150,6 +213,18 @@ func (h *Handler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err = jobapplication.DeleteAllAttachments(r.Context(), h.Database.Queries(), h.Storage, userID); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to delete attachments", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = jobapplication.DeleteAllResumeVersions(r.Context(), h.Database.Queries(), h.Storage, userID); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to delete resume versions", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
 	if err = h.Database.Queries().DeleteUserByID(r.Context(), userID); err != nil {
 		h.Logger.ErrorContext(r.Context(), "failed to delete user", "error", err)
 		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
@@ -168,6 +243,9 @@ func (h *Handler) CreateMcpAuth(w http.ResponseWriter, r *http.Request) {
 		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
 		return
 	}
+	if !h.requireVerifiedEmail(w, r, userID) {
+		return
+	}
 
 	_, err = h.Database.Queries().GetMcpAPIKeyByUserID(r.Context(), userID)
 	if err == nil {
@@ -205,6 +283,9 @@ func (h *Handler) RegenerateMcpAuth(w http.ResponseWriter, r *http.Request) {
 		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
 		return
 	}
+	if !h.requireVerifiedEmail(w, r, userID) {
+		return
+	}
 
 	if err = h.Database.Queries().DeleteMcpAPIKeyByUserID(r.Context(), userID); err != nil {
 		h.Logger.ErrorContext(r.Context(), "failed to delete existing MCP API key", "error", err)
@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Piszmog/pathwise/internal/auth"
 	"github.com/Piszmog/pathwise/internal/db/queries"
 	"github.com/Piszmog/pathwise/internal/ui/components"
 	"github.com/Piszmog/pathwise/internal/ui/server/middleware"
@@ -44,23 +45,89 @@ func (h *Handler) Authenticate(w http.ResponseWriter, r *http.Request) {
 		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Missing email or password", "Please enter your email and password."))
 		return
 	}
+
+	attempt := auth.SigninAttempt{Email: email, IPAddress: getClientIP(r, h.TrustedProxies), UserAgent: r.UserAgent()}
+	if err := auth.CheckSigninLockout(r.Context(), h.Database.Queries(), attempt, h.now()); err != nil {
+		h.signinError(w, r, err)
+		return
+	}
+
 	user, err := h.Database.Queries().GetUserByEmail(r.Context(), email)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			h.Logger.DebugContext(r.Context(), "user not found", "email", email)
-			h.html(r.Context(), w, http.StatusUnauthorized, components.Alert(types.AlertTypeError, "Incorrect email or password", "Double check your email and password and try again."))
+			h.failSignin(w, r, attempt, http.StatusUnauthorized)
 		} else {
 			h.Logger.ErrorContext(r.Context(), "failed to get user", "error", err)
 			h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeWarning, "Something went wrong", "Try again later."))
 		}
 		return
 	}
+	attempt.UserID = user.ID
 	if err = utils.CheckPasswordHash([]byte(user.Password), []byte(password)); err != nil {
 		h.Logger.DebugContext(r.Context(), "failed to compare password and hash", "error", err)
-		h.html(r.Context(), w, http.StatusForbidden, components.Alert(types.AlertTypeError, "Incorrect email or password", "Double check your email and password and try again."))
+		h.failSignin(w, r, attempt, http.StatusForbidden)
+		return
+	}
+	if err = auth.RecordSigninSuccess(r.Context(), h.Database.Queries(), attempt, h.now()); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to record sign in", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeWarning, "Something went wrong", "Try again later."))
+		return
+	}
+
+	enabled, err := auth.IsTwoFactorEnabled(r.Context(), h.Database.Queries(), user.ID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to check two-factor authentication", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeWarning, "Something went wrong", "Try again later."))
+		return
+	}
+	if enabled {
+		// The session is only issued once the second step is passed, the challenge cookie ties that
+		// step to the password that was just checked.
+		token, _, challengeErr := auth.NewChallenge(r.Context(), h.Database.Queries(), user.ID, h.now())
+		if challengeErr != nil {
+			h.Logger.ErrorContext(r.Context(), "failed to create two-factor challenge", "error", challengeErr)
+			h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeWarning, "Something went wrong", "Try again later."))
+			return
+		}
+		utils.SetTwoFactorCookie(w, token, auth.ChallengeDuration)
+		w.Header().Set("HX-Redirect", "/signin/two-factor")
+		return
+	}
+
+	if err = h.startSession(w, r, user.ID); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to create session", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeWarning, "Something went wrong", "Try again later."))
+		return
+	}
+
+	w.Header().Set("HX-Redirect", "/")
+}
+
+// failSignin counts the wrong email or password against the attempt and tells the user, without
+// saying which of the two was wrong.
+func (h *Handler) failSignin(w http.ResponseWriter, r *http.Request, attempt auth.SigninAttempt, status int) {
+	if err := auth.RecordSigninFailure(r.Context(), h.Database, h.Lockout, attempt, h.now()); err != nil {
+		h.signinError(w, r, err)
 		return
 	}
+	h.html(r.Context(), w, status, components.Alert(types.AlertTypeError, "Incorrect email or password", "Double check your email and password and try again."))
+}
 
+// signinError writes the alert of a lockout without saying whether the account or the address is
+// locked, or whether the account exists.
+func (h *Handler) signinError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, auth.ErrSigninLocked) {
+		h.Logger.WarnContext(r.Context(), "too many sign in attempts", "ip", getClientIP(r, h.TrustedProxies))
+		h.html(r.Context(), w, http.StatusTooManyRequests, components.Alert(types.AlertTypeError, "Too many attempts", "Wait a while before trying to sign in again."))
+		return
+	}
+	h.Logger.ErrorContext(r.Context(), "failed to check sign in attempts", "error", err)
+	h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeWarning, "Something went wrong", "Try again later."))
+}
+
+// startSession signs the user in by replacing the session of the request with a new one.
+func (h *Handler) startSession(w http.ResponseWriter, r *http.Request, userID int64) error {
 	var cookieValue string
 	cookie, err := r.Cookie("session")
 	if err != nil {
@@ -71,20 +138,17 @@ func (h *Handler) Authenticate(w http.ResponseWriter, r *http.Request) {
 		cookieValue = cookie.Value
 	}
 
-	token, expiresAt, err := h.newSession(r.Context(), user.ID, r.UserAgent(), cookieValue, getClientIP(r))
+	token, expiresAt, err := h.newSession(r.Context(), userID, r.UserAgent(), cookieValue, getClientIP(r, h.TrustedProxies))
 	if err != nil {
-		h.Logger.ErrorContext(r.Context(), "failed to create session", "error", err)
-		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeWarning, "Something went wrong", "Try again later."))
-		return
+		return err
 	}
 	utils.SetSessionCookie(w, token, expiresAt)
 
-	err = h.Database.Queries().DeleteOldUserSessions(r.Context(), user.ID)
+	err = h.Database.Queries().DeleteOldUserSessions(r.Context(), userID)
 	if err != nil {
-		h.Logger.WarnContext(r.Context(), "failed to delete old user sessions", "userID", user.ID, "error", err)
+		h.Logger.WarnContext(r.Context(), "failed to delete old user sessions", "userID", userID, "error", err)
 	}
-
-	w.Header().Set("HX-Redirect", "/")
+	return nil
 }
 
 func (h *Handler) newSession(ctx context.Context, userID int64, userAgent string, currentToken string, ipAddress string) (string, time.Time, error) {
@@ -6,7 +6,9 @@ import (
 	"regexp"
 	"sync"
 
+	"github.com/Piszmog/pathwise/internal/auth"
 	"github.com/Piszmog/pathwise/internal/db/queries"
+	"github.com/Piszmog/pathwise/internal/jobapplication"
 	"github.com/Piszmog/pathwise/internal/ui/components"
 	"github.com/Piszmog/pathwise/internal/ui/types"
 	"github.com/Piszmog/pathwise/internal/ui/utils"
@@ -66,7 +68,7 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 	user := queries.InsertUserParams{
 		Email:            email,
 		Password:         string(hashedPassword),
-		InitialIpAddress: getClientIP(r),
+		InitialIpAddress: getClientIP(r, h.TrustedProxies),
 	}
 	userID, err := h.Database.Queries().InsertUser(r.Context(), user)
 	if err != nil {
@@ -79,6 +81,15 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
 		return
 	}
+	if err = jobapplication.SeedPipeline(r.Context(), h.Database.Queries(), userID); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to seed pipeline", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	// The account works without the email, and the user can ask for another one from the settings.
+	if err = auth.SendEmailVerification(r.Context(), h.Database.Queries(), h.Mailer, h.AppURL, userID, h.now()); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to send email verification", "error", err)
+	}
 	w.Header().Set("HX-Redirect", "/signin")
 }
 
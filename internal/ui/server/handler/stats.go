@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/Piszmog/pathwise/internal/jobapplication"
 	"github.com/Piszmog/pathwise/internal/ui/components"
 	"github.com/Piszmog/pathwise/internal/ui/types"
 )
@@ -33,17 +34,51 @@ func (h *Handler) getStats(ctx context.Context, userID int64) (types.StatsOpts,
 	if err != nil {
 		return types.StatsOpts{}, err
 	}
+	statusCounts, err := h.Database.Queries().GetCurrentStatusCounts(ctx, userID)
+	if err != nil {
+		return types.StatsOpts{}, err
+	}
+	pipeline, err := jobapplication.GetPipeline(ctx, h.Database.Queries(), userID)
+	if err != nil {
+		return types.StatsOpts{}, err
+	}
+
+	counts := make(map[types.JobApplicationStatus]int64, len(statusCounts))
+	for _, c := range statusCounts {
+		counts[types.JobApplicationStatus(c.Status)] = c.Count
+	}
 
 	statsOpts := types.StatsOpts{
 		TotalApplications:           stats.TotalApplications,
 		TotalCompanies:              stats.TotalCompanies,
 		AverageTimeToHearBackInDays: stats.AverageTimeToHearBack,
-		TotalInterviewingPercentage: toPercentage(stats.TotalInterviewing, stats.TotalApplications),
-		TotalRejectionsPercentage:   toPercentage(stats.TotalRejected, stats.TotalApplications),
+	}
+	addStage := func(status types.PipelineStatus, count int64) {
+		if !status.Terminal {
+			statsOpts.TotalActive += count
+		}
+		statsOpts.Stages = append(statsOpts.Stages, types.PipelineStageStat{
+			Status:     status,
+			Count:      count,
+			Percentage: toPercentage(count, stats.TotalApplications),
+		})
+	}
+	for _, s := range pipeline {
+		addStage(s, counts[s.Name])
+		delete(counts, s.Name)
+	}
+	// Statuses that are not part of the pipeline are shown last rather than dropped.
+	for _, c := range statusCounts {
+		if count, ok := counts[types.JobApplicationStatus(c.Status)]; ok {
+			addStage(types.PipelineStatus{Name: types.JobApplicationStatus(c.Status)}, count)
+		}
 	}
 	return statsOpts, nil
 }
 
 func toPercentage(value, total int64) string {
+	if total == 0 {
+		return "0"
+	}
 	return strconv.FormatFloat(math.Ceil((float64(value)/float64(total))*100), 'f', 0, 64)
 }
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/components"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+func (h *Handler) AddTag(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse form", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if _, err = jobapplication.AddTag(r.Context(), h.Database.Queries(), userID, r.FormValue("name"), types.TagColor(r.FormValue("color"))); err != nil {
+		h.tagError(w, r, userID, 0, err)
+		return
+	}
+
+	h.tagsSection(w, r, userID)
+}
+
+func (h *Handler) UpdateTag(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	tagID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse form", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = jobapplication.UpdateTag(r.Context(), h.Database.Queries(), userID, tagID, r.FormValue("name"), types.TagColor(r.FormValue("color"))); err != nil {
+		h.tagError(w, r, userID, tagID, err)
+		return
+	}
+
+	h.tagsSection(w, r, userID)
+}
+
+func (h *Handler) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	tagID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = jobapplication.DeleteTag(r.Context(), h.Database, userID, tagID); err != nil {
+		h.tagError(w, r, userID, tagID, err)
+		return
+	}
+
+	h.tagsSection(w, r, userID)
+}
+
+func (h *Handler) SetJobTags(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	jobID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse form", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	tagIDs := make([]int64, len(r.Form["tags"]))
+	for i, idStr := range r.Form["tags"] {
+		tagIDs[i], err = strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			h.Logger.WarnContext(r.Context(), "invalid tag id", "error", err)
+			h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Bad request."))
+			return
+		}
+	}
+
+	if err = jobapplication.SetJobApplicationTags(r.Context(), h.Database, userID, jobID, tagIDs); err != nil {
+		h.tagError(w, r, userID, 0, err)
+		return
+	}
+
+	opts, err := h.getTagsOpts(r.Context(), userID, jobID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get tags", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	h.html(r.Context(), w, http.StatusOK, components.JobTagsUpdated(opts))
+}
+
+func (h *Handler) tagError(w http.ResponseWriter, r *http.Request, userID int64, tagID int64, err error) {
+	switch {
+	case errors.Is(err, jobapplication.ErrInvalidTagName):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid tag name", "Tag names must be between 1 and 30 characters without commas."))
+	case errors.Is(err, jobapplication.ErrInvalidTagColor):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid tag color", "Please select a valid color."))
+	case errors.Is(err, jobapplication.ErrDuplicateTag):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Tag already exists", "Please enter a different name."))
+	case errors.Is(err, jobapplication.ErrTagNotFound):
+		h.Logger.WarnContext(r.Context(), "tag not found", "userID", userID, "tagID", tagID)
+		h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Tag not found", "Try again later."))
+	case errors.Is(err, jobapplication.ErrNotFound):
+		h.Logger.WarnContext(r.Context(), "job application not found", "userID", userID)
+		h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "Job application not found", "Try again later."))
+	default:
+		h.Logger.ErrorContext(r.Context(), "failed to change tags", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+	}
+}
+
+func (h *Handler) tagsSection(w http.ResponseWriter, r *http.Request, userID int64) {
+	tags, err := jobapplication.GetTags(r.Context(), h.Database.Queries(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get tags", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	h.html(r.Context(), w, http.StatusOK, components.TagsSection(tags))
+}
+
+func (h *Handler) getTagsOpts(ctx context.Context, userID int64, jobID int64) (types.TagsOpts, error) {
+	tags, err := jobapplication.GetTags(ctx, h.Database.Queries(), userID)
+	if err != nil {
+		return types.TagsOpts{}, err
+	}
+	selected, err := jobapplication.GetJobApplicationTags(ctx, h.Database.Queries(), jobID)
+	if err != nil {
+		return types.TagsOpts{}, err
+	}
+	return types.TagsOpts{JobApplicationID: jobID, Tags: tags, Selected: selected}, nil
+}
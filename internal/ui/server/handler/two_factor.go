@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Piszmog/pathwise/internal/auth"
+	"github.com/Piszmog/pathwise/internal/ui/components"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+	"github.com/Piszmog/pathwise/internal/ui/utils"
+)
+
+func (h *Handler) SigninTwoFactor(w http.ResponseWriter, r *http.Request) {
+	cookie, err := r.Cookie("two_factor")
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	ok, err := auth.HasChallenge(r.Context(), h.Database.Queries(), cookie.Value, h.now())
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get two-factor challenge", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	if !ok {
+		utils.ClearTwoFactorCookie(w)
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	h.html(r.Context(), w, http.StatusOK, components.SigninTwoFactor())
+}
+
+func (h *Handler) VerifyTwoFactor(w http.ResponseWriter, r *http.Request) {
+	cookie, err := r.Cookie("two_factor")
+	if err != nil {
+		h.Logger.DebugContext(r.Context(), "missing two-factor cookie")
+		w.Header().Set("HX-Redirect", "/signin")
+		return
+	}
+	code := r.FormValue("code")
+	if code == "" {
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Missing code", "Please enter the code from your authenticator app."))
+		return
+	}
+
+	userID, err := auth.CompleteChallenge(r.Context(), h.Database.Queries(), cookie.Value, code, h.now())
+	if err != nil {
+		if errors.Is(err, auth.ErrChallengeNotFound) || errors.Is(err, auth.ErrTooManyAttempts) || errors.Is(err, auth.ErrTwoFactorNotEnabled) {
+			utils.ClearTwoFactorCookie(w)
+		}
+		h.twoFactorError(w, r, err)
+		return
+	}
+	utils.ClearTwoFactorCookie(w)
+
+	if err = h.startSession(w, r, userID); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to create session", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeWarning, "Something went wrong", "Try again later."))
+		return
+	}
+
+	w.Header().Set("HX-Redirect", "/")
+}
+
+func (h *Handler) StartTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	user, err := h.Database.Queries().GetUserByID(r.Context(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get user", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	enrollment, err := auth.EnrollTwoFactor(r.Context(), h.Database.Queries(), userID, user.Email)
+	if err != nil {
+		h.twoFactorError(w, r, err)
+		return
+	}
+
+	h.html(r.Context(), w, http.StatusOK, components.TwoFactorEnrollSection(enrollment))
+}
+
+func (h *Handler) EnableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	codes, err := auth.EnableTwoFactor(r.Context(), h.Database, userID, r.FormValue("code"), h.now())
+	if err != nil {
+		h.twoFactorError(w, r, err)
+		return
+	}
+
+	h.twoFactorSection(w, r, userID, codes)
+}
+
+func (h *Handler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	codes, err := auth.RegenerateRecoveryCodes(r.Context(), h.Database, userID, r.FormValue("code"), h.now())
+	if err != nil {
+		h.twoFactorError(w, r, err)
+		return
+	}
+
+	h.twoFactorSection(w, r, userID, codes)
+}
+
+func (h *Handler) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	user, err := h.Database.Queries().GetUserByID(r.Context(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get user", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = utils.CheckPasswordHash([]byte(user.Password), []byte(r.FormValue("password"))); err != nil {
+		h.Logger.DebugContext(r.Context(), "failed to compare password and hash", "error", err)
+		h.html(r.Context(), w, http.StatusForbidden, components.Alert(types.AlertTypeError, "Incorrect password", "Double check your password and try again."))
+		return
+	}
+
+	if err = auth.DisableTwoFactor(r.Context(), h.Database, userID, r.FormValue("code"), h.now()); err != nil {
+		h.twoFactorError(w, r, err)
+		return
+	}
+
+	h.twoFactorSection(w, r, userID, nil)
+}
+
+func (h *Handler) twoFactorError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, auth.ErrInvalidCode):
+		h.html(r.Context(), w, http.StatusUnauthorized, components.Alert(types.AlertTypeError, "Incorrect code", "Enter the current code from your authenticator app or one of your recovery codes."))
+	case errors.Is(err, auth.ErrTooManyAttempts):
+		h.Logger.WarnContext(r.Context(), "too many two-factor attempts", "error", err)
+		h.html(r.Context(), w, http.StatusTooManyRequests, components.Alert(types.AlertTypeError, "Too many attempts", "Wait 15 minutes before trying again."))
+	case errors.Is(err, auth.ErrChallengeNotFound):
+		h.html(r.Context(), w, http.StatusUnauthorized, components.Alert(types.AlertTypeError, "Sign in expired", "Please sign in again."))
+	case errors.Is(err, auth.ErrTwoFactorEnabled):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Two-factor authentication already enabled", "Disable it first to set it up again."))
+	case errors.Is(err, auth.ErrTwoFactorNotEnabled):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Two-factor authentication not enabled", "Set it up again from settings."))
+	default:
+		h.Logger.ErrorContext(r.Context(), "failed to check two-factor authentication", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+	}
+}
+
+func (h *Handler) twoFactorSection(w http.ResponseWriter, r *http.Request, userID int64, recoveryCodes []string) {
+	tf, err := auth.GetTwoFactor(r.Context(), h.Database.Queries(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get two-factor authentication", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	h.html(r.Context(), w, http.StatusOK, components.TwoFactorSection(tf, recoveryCodes))
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Piszmog/pathwise/internal/jobapplication"
+	"github.com/Piszmog/pathwise/internal/ui/components"
+	"github.com/Piszmog/pathwise/internal/ui/types"
+)
+
+// jobsChangedEvent is triggered on the page whenever the job applications may have changed, so the
+// counts of the saved views are reloaded.
+const jobsChangedEvent = "jobsChanged"
+
+func (h *Handler) Views(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	h.savedViews(w, r, userID)
+}
+
+func (h *Handler) SaveView(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = r.ParseForm(); err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse form", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	filterOpts, err := types.ParseFilterOpts(r.PostForm)
+	if err != nil {
+		h.Logger.WarnContext(r.Context(), "invalid filters", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid filters", "Check the filters and try again."))
+		return
+	}
+
+	if _, err = jobapplication.SaveView(r.Context(), h.Database.Queries(), userID, r.PostForm.Get("name"), filterOpts); err != nil {
+		h.viewError(w, r, userID, 0, err)
+		return
+	}
+
+	h.savedViews(w, r, userID)
+}
+
+func (h *Handler) DeleteView(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse user id", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	viewID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to parse id", "error", err)
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+
+	if err = jobapplication.DeleteView(r.Context(), h.Database.Queries(), userID, viewID); err != nil {
+		h.viewError(w, r, userID, viewID, err)
+		return
+	}
+
+	h.savedViews(w, r, userID)
+}
+
+func (h *Handler) viewError(w http.ResponseWriter, r *http.Request, userID int64, viewID int64, err error) {
+	switch {
+	case errors.Is(err, jobapplication.ErrInvalidViewName):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "Invalid view name", "View names must be between 1 and 50 characters."))
+	case errors.Is(err, jobapplication.ErrDuplicateView):
+		h.html(r.Context(), w, http.StatusBadRequest, components.Alert(types.AlertTypeError, "View already exists", "Please enter a different name."))
+	case errors.Is(err, jobapplication.ErrViewNotFound):
+		h.Logger.WarnContext(r.Context(), "view not found", "userID", userID, "viewID", viewID)
+		h.html(r.Context(), w, http.StatusNotFound, components.Alert(types.AlertTypeError, "View not found", "Try again later."))
+	default:
+		h.Logger.ErrorContext(r.Context(), "failed to change views", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+	}
+}
+
+func (h *Handler) savedViews(w http.ResponseWriter, r *http.Request, userID int64) {
+	views, err := jobapplication.GetViews(r.Context(), h.Database.Queries(), userID)
+	if err != nil {
+		h.Logger.ErrorContext(r.Context(), "failed to get views", "error", err)
+		h.html(r.Context(), w, http.StatusInternalServerError, components.Alert(types.AlertTypeError, "Something went wrong", "Try again later."))
+		return
+	}
+	h.html(r.Context(), w, http.StatusOK, components.SavedViews(views))
+}
+
+// triggerJobsChanged tells the page that the job applications may have changed.
+func triggerJobsChanged(w http.ResponseWriter) {
+	w.Header().Set("HX-Trigger", jobsChangedEvent)
+}
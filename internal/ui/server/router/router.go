@@ -3,22 +3,35 @@ package router
 import (
 	"log/slog"
 	"net/http"
+	"net/netip"
+	"time"
 
+	"github.com/Piszmog/pathwise/internal/auth"
 	"github.com/Piszmog/pathwise/internal/db"
+	"github.com/Piszmog/pathwise/internal/mail"
 	"github.com/Piszmog/pathwise/internal/search"
 	"github.com/Piszmog/pathwise/internal/server/health"
 	mw "github.com/Piszmog/pathwise/internal/server/middleware"
 	"github.com/Piszmog/pathwise/internal/server/mux"
+	"github.com/Piszmog/pathwise/internal/storage"
 	"github.com/Piszmog/pathwise/internal/ui/dist"
 	"github.com/Piszmog/pathwise/internal/ui/server/handler"
 	"github.com/Piszmog/pathwise/internal/ui/server/middleware"
+	"github.com/go-webauthn/webauthn/webauthn"
 )
 
-func New(logger *slog.Logger, database db.Database, searchClient *search.Client) http.Handler {
+func New(logger *slog.Logger, database db.Database, searchClient *search.Client, store storage.Storage, wa *webauthn.WebAuthn, mailer mail.Mailer, appURL string, trustedProxies []netip.Prefix, lockout auth.LockoutPolicy, clock func() time.Time) http.Handler {
 	h := &handler.Handler{
-		Logger:       logger,
-		Database:     database,
-		SearchClient: searchClient,
+		Logger:         logger,
+		Database:       database,
+		SearchClient:   searchClient,
+		Storage:        store,
+		WebAuthn:       wa,
+		Mailer:         mailer,
+		AppURL:         appURL,
+		TrustedProxies: trustedProxies,
+		Lockout:        lockout,
+		Clock:          clock,
 	}
 	authMiddleware := middleware.AuthMiddleware{
 		Logger:   logger,
@@ -38,11 +51,26 @@ func New(logger *slog.Logger, database db.Database, searchClient *search.Client)
 			mux.WithHandleFunc(http.MethodPost, "/signup", h.Register),
 			mux.WithHandleFunc(http.MethodGet, "/signin", h.Signin),
 			mux.WithHandleFunc(http.MethodPost, "/signin", h.Authenticate),
+			mux.WithHandleFunc(http.MethodGet, "/signin/two-factor", h.SigninTwoFactor),
+			mux.WithHandleFunc(http.MethodPost, "/signin/two-factor", h.VerifyTwoFactor),
+			mux.WithHandleFunc(http.MethodPost, "/signin/passkey/begin", h.BeginPasskeyLogin),
+			mux.WithHandleFunc(http.MethodPost, "/signin/passkey/finish", h.FinishPasskeyLogin),
+			mux.WithHandleFunc(http.MethodGet, "/forgot-password", h.ForgotPassword),
+			mux.WithHandleFunc(http.MethodPost, "/forgot-password", h.RequestPasswordReset),
+			mux.WithHandleFunc(http.MethodGet, "/reset-password", h.ResetPasswordPage),
+			mux.WithHandleFunc(http.MethodPost, "/reset-password", h.ResetPassword),
+			mux.WithHandleFunc(http.MethodGet, "/verify-email", h.VerifyEmail),
+			mux.WithHandleFunc(http.MethodGet, "/calendar/{file}", h.CalendarFeed),
 			mux.WithGeneralHandle(
 				"/",
 				authMiddleware.Middleware(
 					mux.NewMux(
 						mux.WithHandleFunc(http.MethodGet, "/", h.Main),
+						mux.WithHandleFunc(http.MethodGet, "/search", h.Search),
+						mux.WithHandleFunc(http.MethodGet, "/search/results", h.SearchResults),
+						mux.WithHandleFunc(http.MethodGet, "/board", h.Board),
+						mux.WithHandleFunc(http.MethodGet, "/board/columns/{id}", h.BoardColumn),
+						mux.WithHandleFunc(http.MethodPatch, "/board/jobs/{id}", h.MoveBoardJob),
 						mux.WithHandleFunc(http.MethodGet, "/job-listings", h.GetJobListingsPage),
 						mux.WithHandleFunc(http.MethodGet, "/job-listings/content", h.GetJobListings),
 						mux.WithHandleFunc(http.MethodGet, "/job-listings/{id}", h.GetJobListingDetails),
@@ -51,23 +79,88 @@ func New(logger *slog.Logger, database db.Database, searchClient *search.Client)
 						mux.WithHandleFunc(http.MethodPost, "/jobs", h.AddJob),
 						mux.WithHandleFunc(http.MethodGet, "/archives", h.Archives),
 						mux.WithHandleFunc(http.MethodPatch, "/jobs/archive", h.ArchiveJobs),
+						mux.WithHandleFunc(http.MethodPatch, "/jobs/bulk/status", h.BulkUpdateStatus),
+						mux.WithHandleFunc(http.MethodPost, "/jobs/bulk/notes", h.BulkAddNote),
+						mux.WithHandleFunc(http.MethodPost, "/jobs/bulk/tags", h.BulkAddTag),
+						mux.WithHandleFunc(http.MethodDelete, "/jobs/bulk", h.BulkDelete),
+						mux.WithHandleFunc(http.MethodPost, "/jobs/bulk/undo/{id}", h.UndoBulkAction),
 						mux.WithHandleFunc(http.MethodGet, "/jobs", h.GetJobs),
+						mux.WithHandleFunc(http.MethodGet, "/views", h.Views),
+						mux.WithHandleFunc(http.MethodPost, "/views", h.SaveView),
+						mux.WithHandleFunc(http.MethodDelete, "/views/{id}", h.DeleteView),
 						mux.WithHandleFunc(http.MethodGet, "/jobs/{id}", h.JobDetails),
 						mux.WithHandleFunc(http.MethodPatch, "/jobs/{id}", h.UpdateJob),
+						mux.WithHandleFunc(http.MethodDelete, "/jobs/{id}", h.DeleteJob),
 						mux.WithHandleFunc(http.MethodPatch, "/jobs/{id}/archive", h.ArchiveJob),
 						mux.WithHandleFunc(http.MethodPatch, "/jobs/{id}/unarchive", h.UnarchiveJob),
+						mux.WithHandleFunc(http.MethodPut, "/jobs/{id}/tags", h.SetJobTags),
+						mux.WithHandleFunc(http.MethodPut, "/jobs/{id}/resume", h.SetJobResumeVersion),
+						mux.WithHandleFunc(http.MethodPost, "/jobs/{id}/contacts", h.AddJobContact),
+						mux.WithHandleFunc(http.MethodDelete, "/jobs/{id}/contacts/{contactID}", h.RemoveJobContact),
 						mux.WithHandleFunc(http.MethodPost, "/jobs/{id}/notes", h.AddNote),
+						mux.WithHandleFunc(http.MethodPatch, "/jobs/{id}/notes/{noteID}", h.UpdateNote),
+						mux.WithHandleFunc(http.MethodDelete, "/jobs/{id}/notes/{noteID}", h.DeleteNote),
+						mux.WithHandleFunc(http.MethodPost, "/jobs/{id}/attachments", h.AddAttachment),
+						mux.WithHandleFunc(http.MethodGet, "/jobs/{id}/attachments/{attachmentID}", h.Attachment),
+						mux.WithHandleFunc(http.MethodDelete, "/jobs/{id}/attachments/{attachmentID}", h.DeleteAttachment),
+						mux.WithHandleFunc(http.MethodPost, "/jobs/{id}/reminders", h.AddReminder),
+						mux.WithHandleFunc(http.MethodPatch, "/jobs/{id}/reminders/{reminderID}/snooze", h.SnoozeReminder),
+						mux.WithHandleFunc(http.MethodPatch, "/jobs/{id}/reminders/{reminderID}/dismiss", h.DismissReminder),
+						mux.WithHandleFunc(http.MethodPost, "/jobs/{id}/interviews", h.AddInterview),
+						mux.WithHandleFunc(http.MethodPatch, "/jobs/{id}/interviews/{interviewID}", h.UpdateInterview),
+						mux.WithHandleFunc(http.MethodPut, "/jobs/{id}/offer", h.SaveOffer),
+						mux.WithHandleFunc(http.MethodDelete, "/jobs/{id}/offer", h.DeleteOffer),
+						mux.WithHandleFunc(http.MethodGet, "/reminders/due", h.DueReminders),
+						mux.WithHandleFunc(http.MethodGet, "/contacts", h.Contacts),
+						mux.WithHandleFunc(http.MethodPost, "/contacts", h.AddContact),
+						mux.WithHandleFunc(http.MethodPatch, "/contacts/{id}", h.UpdateContact),
+						mux.WithHandleFunc(http.MethodDelete, "/contacts/{id}", h.DeleteContact),
+						mux.WithHandleFunc(http.MethodGet, "/companies", h.Companies),
+						mux.WithHandleFunc(http.MethodGet, "/companies/{id}", h.Company),
+						mux.WithHandleFunc(http.MethodPost, "/companies/{id}/merge", h.MergeCompany),
+						mux.WithHandleFunc(http.MethodGet, "/offers", h.Offers),
+						mux.WithHandleFunc(http.MethodPut, "/offers/rates", h.SaveExchangeRates),
 						mux.WithHandleFunc(http.MethodGet, "/signout", h.Signout),
 						mux.WithHandleFunc(http.MethodGet, "/settings", h.Settings),
 						mux.WithHandleFunc(http.MethodPost, "/settings/changePassword", h.ChangePassword),
 						mux.WithHandleFunc(http.MethodPost, "/settings/logoutSessions", h.LogoutSessions),
 						mux.WithHandleFunc(http.MethodPost, "/settings/deleteAccount", h.DeleteAccount),
+						mux.WithHandleFunc(http.MethodPost, "/settings/verify-email", h.ResendEmailVerification),
 						mux.WithHandleFunc(http.MethodPost, "/settings/mcp/auth", h.CreateMcpAuth),
 						mux.WithHandleFunc(http.MethodPatch, "/settings/mcp/auth", h.RegenerateMcpAuth),
 						mux.WithHandleFunc(http.MethodDelete, "/settings/mcp/auth", h.DeleteMcpAuth),
+						mux.WithHandleFunc(http.MethodPost, "/settings/two-factor", h.StartTwoFactor),
+						mux.WithHandleFunc(http.MethodPost, "/settings/two-factor/enable", h.EnableTwoFactor),
+						mux.WithHandleFunc(http.MethodPost, "/settings/two-factor/recovery-codes", h.RegenerateRecoveryCodes),
+						mux.WithHandleFunc(http.MethodPost, "/settings/two-factor/disable", h.DisableTwoFactor),
+						mux.WithHandleFunc(http.MethodPost, "/settings/passkeys/begin", h.BeginPasskeyRegistration),
+						mux.WithHandleFunc(http.MethodPost, "/settings/passkeys/finish", h.FinishPasskeyRegistration),
+						mux.WithHandleFunc(http.MethodDelete, "/settings/passkeys/{id}", h.DeletePasskey),
+						mux.WithHandleFunc(http.MethodPost, "/settings/calendar", h.CreateCalendarFeed),
+						mux.WithHandleFunc(http.MethodPatch, "/settings/calendar", h.RegenerateCalendarFeed),
+						mux.WithHandleFunc(http.MethodDelete, "/settings/calendar", h.DeleteCalendarFeed),
+						mux.WithHandleFunc(http.MethodPost, "/settings/pipeline", h.AddPipelineStatus),
+						mux.WithHandleFunc(http.MethodPatch, "/settings/pipeline/{id}/move", h.MovePipelineStatus),
+						mux.WithHandleFunc(http.MethodPatch, "/settings/pipeline/{id}", h.UpdatePipelineStatus),
+						mux.WithHandleFunc(http.MethodDelete, "/settings/pipeline/{id}", h.DeletePipelineStatus),
+						mux.WithHandleFunc(http.MethodPost, "/settings/tags", h.AddTag),
+						mux.WithHandleFunc(http.MethodPatch, "/settings/tags/{id}", h.UpdateTag),
+						mux.WithHandleFunc(http.MethodDelete, "/settings/tags/{id}", h.DeleteTag),
+						mux.WithHandleFunc(http.MethodPost, "/settings/resumes", h.AddResumeVersion),
+						mux.WithHandleFunc(http.MethodGet, "/settings/resumes/{id}/file", h.ResumeVersionFile),
+						mux.WithHandleFunc(http.MethodDelete, "/settings/resumes/{id}", h.DeleteResumeVersion),
+						mux.WithHandleFunc(http.MethodPatch, "/settings/currency", h.UpdateDisplayCurrency),
+						mux.WithHandleFunc(http.MethodPost, "/settings/exchange-rates", h.ImportExchangeRates),
 						mux.WithHandleFunc(http.MethodGet, "/export/csv", h.ExportCSV),
+						mux.WithHandleFunc(http.MethodGet, "/export/json", h.ExportJSON),
+						mux.WithHandleFunc(http.MethodGet, "/import/csv", h.ImportCSVPage),
+						mux.WithHandleFunc(http.MethodPost, "/import/csv/preview", h.PreviewImportCSV),
+						mux.WithHandleFunc(http.MethodPost, "/import/csv", h.ImportCSV),
+						mux.WithHandleFunc(http.MethodPost, "/import/json", h.ImportJSON),
 						mux.WithHandleFunc(http.MethodGet, "/analytics", h.Analytics),
 						mux.WithHandleFunc(http.MethodGet, "/analytics/graph", h.AnalyticsGraph),
+						mux.WithHandleFunc(http.MethodGet, "/analytics/salary", h.AnalyticsSalary),
+						mux.WithHandleFunc(http.MethodGet, "/analytics/resumes", h.AnalyticsResumes),
 					),
 				),
 			),
@@ -29,3 +29,24 @@ type StatusCount struct {
 type AnalyticsData struct {
 	SankeyData SankeyData
 }
+
+// SalaryAnalytics is the median salary range asked for by job applications in each status,
+// converted to the display currency of the user.
+type SalaryAnalytics struct {
+	Currency string
+	Statuses []StatusSalary
+	// Unconverted is the number of job applications left out because their salary currency has no
+	// exchange rate.
+	Unconverted int64
+}
+
+// StatusSalary is the median salary range of the job applications in a status. A median is only
+// set when at least one job application has the bound.
+type StatusSalary struct {
+	Status    JobApplicationStatus
+	Count     int64
+	MedianMin int64
+	MedianMax int64
+	HasMin    bool
+	HasMax    bool
+}
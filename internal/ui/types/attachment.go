@@ -0,0 +1,101 @@
+package types
+
+import (
+	"strconv"
+	"time"
+)
+
+type AttachmentKind string
+
+const (
+	AttachmentKindResume      AttachmentKind = "resume"
+	AttachmentKindCoverLetter AttachmentKind = "cover_letter"
+	AttachmentKindOther       AttachmentKind = "other"
+)
+
+func (a AttachmentKind) String() string {
+	return string(a)
+}
+
+func (a AttachmentKind) PrettyString() string {
+	switch a {
+	case AttachmentKindResume:
+		return "Resume"
+	case AttachmentKindCoverLetter:
+		return "Cover letter"
+	case AttachmentKindOther:
+		return "File"
+	default:
+		return ""
+	}
+}
+
+func ToAttachmentKind(val string) AttachmentKind {
+	return attachmentKindMap[val]
+}
+
+var attachmentKindMap = map[string]AttachmentKind{
+	"resume":       AttachmentKindResume,
+	"cover_letter": AttachmentKindCoverLetter,
+	"other":        AttachmentKindOther,
+}
+
+var AttachmentKindSelectOptions = []SelectOption{
+	{
+		Label: "Resume",
+		Value: AttachmentKindResume.String(),
+	},
+	{
+		Label: "Cover letter",
+		Value: AttachmentKindCoverLetter.String(),
+	},
+	{
+		Label: "Other",
+		Value: AttachmentKindOther.String(),
+	},
+}
+
+type JobApplicationAttachment struct {
+	CreatedAt        time.Time
+	Name             string
+	Kind             AttachmentKind
+	ContentType      string
+	Size             int64
+	ID               int64
+	JobApplicationID int64
+	// ReadOnly is set when the job application is archived, so the attachment cannot be deleted.
+	ReadOnly bool
+}
+
+func (j JobApplicationAttachment) RecordID() int64 {
+	return j.ID
+}
+
+func (j JobApplicationAttachment) Type() JobApplicationTimelineType {
+	return JobApplicationTimelineTypeAttachment
+}
+
+func (j JobApplicationAttachment) Created() time.Time {
+	return j.CreatedAt
+}
+
+// URL is where the attachment is downloaded from.
+func (j JobApplicationAttachment) URL() string {
+	return "/jobs/" + strconv.FormatInt(j.JobApplicationID, 10) + "/attachments/" + strconv.FormatInt(j.ID, 10)
+}
+
+// PrettySize returns the size of the attachment in bytes, KB or MB.
+func (j JobApplicationAttachment) PrettySize() string {
+	return prettySize(j.Size)
+}
+
+func prettySize(size int64) string {
+	switch {
+	case size < 1<<10:
+		return strconv.FormatInt(size, 10) + " B"
+	case size < 1<<20:
+		return strconv.FormatFloat(float64(size)/(1<<10), 'f', 0, 64) + " KB"
+	default:
+		return strconv.FormatFloat(float64(size)/(1<<20), 'f', 1, 64) + " MB"
+	}
+}
@@ -0,0 +1,15 @@
+package types
+
+// BoardColumn is a column of the board, holding the job applications at a stage of the pipeline.
+type BoardColumn struct {
+	Status PipelineStatus
+	Count  int64
+}
+
+// BoardCardsOpts is a page of the cards of a board column.
+type BoardCardsOpts struct {
+	Jobs     []JobApplication
+	StatusID int64
+	NextPage int64
+	HasMore  bool
+}
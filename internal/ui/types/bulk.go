@@ -0,0 +1,10 @@
+package types
+
+import "time"
+
+// BulkUndoOpts describes the bulk action that can currently be undone.
+type BulkUndoOpts struct {
+	ActionID int64
+	Message  string
+	Window   time.Duration
+}
@@ -0,0 +1,37 @@
+package types
+
+import "time"
+
+// Company groups the job applications of a user whose company names only differ in case or whitespace.
+type Company struct {
+	Name               string
+	ID                 int64
+	Applications       int64
+	ActiveApplications int64
+}
+
+// CompanyDetails is the history of a company across every job application of the user.
+type CompanyDetails struct {
+	Company         Company
+	JobApplications []JobApplication
+	Notes           []CompanyNote
+	Listings        []CompanyListing
+	MergeTargets    []Company
+}
+
+// CompanyNote is a note on one of the job applications of a company.
+type CompanyNote struct {
+	CreatedAt        time.Time
+	Note             string
+	Title            string
+	ID               int64
+	JobApplicationID int64
+}
+
+// CompanyListing is a job listing posted by a company.
+type CompanyListing struct {
+	PostedAt time.Time
+	ID       string
+	Title    string
+	Location string
+}
@@ -0,0 +1,47 @@
+package types
+
+// Contact is a person met while applying, such as a recruiter or hiring manager.
+type Contact struct {
+	Name            string
+	Role            string
+	Email           string
+	LinkedInURL     string
+	Company         string
+	ID              int64
+	JobApplications []ContactJobApplication
+}
+
+// ContactJobApplication is a job application a contact is linked to.
+type ContactJobApplication struct {
+	Company  string
+	Title    string
+	Status   JobApplicationStatus
+	ID       int64
+	Archived bool
+}
+
+// ContactsOpts are the contacts of a job application together with every contact of the user to link.
+type ContactsOpts struct {
+	JobApplicationID int64
+	Company          string
+	Contacts         []Contact
+	Linked           []Contact
+}
+
+// Unlinked returns the contacts of the user that are not linked to the job application.
+func (o ContactsOpts) Unlinked() []Contact {
+	var unlinked []Contact
+	for _, contact := range o.Contacts {
+		linked := false
+		for _, l := range o.Linked {
+			if l.ID == contact.ID {
+				linked = true
+				break
+			}
+		}
+		if !linked {
+			unlinked = append(unlinked, contact)
+		}
+	}
+	return unlinked
+}
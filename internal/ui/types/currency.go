@@ -1,5 +1,10 @@
 package types
 
+import (
+	"strconv"
+	"strings"
+)
+
 // CurrencyInfo holds the code and symbol for a currency.
 type CurrencyInfo struct {
 	Code   string
@@ -16,3 +21,34 @@ var AvailableCurrencies = []CurrencyInfo{
 	{Code: "JPY", Symbol: "¥"},
 	{Code: "CHF", Symbol: "CHF"},
 }
+
+// BaseCurrency is the currency exchange rates are relative to.
+const BaseCurrency = "USD"
+
+// GetCurrency returns the available currency with the code.
+func GetCurrency(code string) (CurrencyInfo, bool) {
+	for _, c := range AvailableCurrencies {
+		if c.Code == code {
+			return c, true
+		}
+	}
+	return CurrencyInfo{}, false
+}
+
+// Format formats a whole amount in the currency, such as $120,000.
+func (c CurrencyInfo) Format(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	digits := strconv.FormatInt(amount, 10)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return sign + c.Symbol + b.String()
+}
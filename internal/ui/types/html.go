@@ -1,5 +1,13 @@
 package types
 
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+)
+
 type SelectOpts struct {
 	Name        string
 	Label       string
@@ -16,54 +24,11 @@ type SelectOption struct {
 	Value string
 }
 
-var JobApplicationStatusSelectOptions = []SelectOption{
-	{
-		Label: "Accepted",
-		Value: JobApplicationStatusAccepted.String(),
-	},
-	{
-		Label: "Applied",
-		Value: JobApplicationStatusApplied.String(),
-	},
-	{
-		Label: "Canceled",
-		Value: JobApplicationStatusCanceled.String(),
-	},
-	{
-		Label: "Closed",
-		Value: JobApplicationStatusClosed.String(),
-	},
-	{
-		Label: "Declined",
-		Value: JobApplicationStatusDeclined.String(),
-	},
-	{
-		Label: "Interviewing",
-		Value: JobApplicationStatusInterviewing.String(),
-	},
-	{
-		Label: "Offered",
-		Value: JobApplicationStatusOffered.String(),
-	},
-	{
-		Label: "Rejected",
-		Value: JobApplicationStatusRejected.String(),
-	},
-	{
-		Label: "Watching",
-		Value: JobApplicationStatusWatching.String(),
-	},
-	{
-		Label: "Withdrawn",
-		Value: JobApplicationStatusWithdrawn.String(),
-	},
-}
-
 type StatsOpts struct {
-	TotalInterviewingPercentage string
-	TotalRejectionsPercentage   string
+	Stages                      []PipelineStageStat
 	TotalApplications           int64
 	TotalCompanies              int64
+	TotalActive                 int64
 	AverageTimeToHearBackInDays int64
 }
 
@@ -72,10 +37,237 @@ type NewTimelineEntry struct {
 	SwapOOB string
 }
 
+type RemindersOpts struct {
+	Now              time.Time
+	Reminders        []JobApplicationReminder
+	Due              []DueReminder
+	JobApplicationID int64
+}
+
+type InterviewsOpts struct {
+	Interviews       []JobApplicationInterview
+	Status           JobApplicationStatus
+	JobApplicationID int64
+}
+
+// FilterOpts are the filters and sort order of the list of job applications. They round-trip
+// through the URL so a view can be bookmarked and shared.
 type FilterOpts struct {
-	Company    string
-	Status     JobApplicationStatus
-	IsArchived bool
+	Company string
+	Status  JobApplicationStatus
+	Sort    JobSort
+	// Order is "asc" or "desc". It is empty to use the default order of the sort.
+	Order string
+	// AppliedFrom and AppliedTo are the inclusive range of the applied date, formatted with
+	// FilterDateLayout.
+	AppliedFrom string
+	AppliedTo   string
+	Tag         int64
+	// SalaryMin and SalaryMax are in the display currency. Job applications match when their
+	// salary range overlaps.
+	SalaryMin int64
+	SalaryMax int64
+	// NoResponseDays matches job applications that have not changed status in the days since they
+	// were applied to.
+	NoResponseDays int64
+	HasURL         bool
+	IsArchived     bool
+}
+
+// FilterDateLayout is the layout of the applied date filters.
+const FilterDateLayout = "2006-01-02"
+
+// IsDescending reports whether the job applications are sorted in descending order.
+func (f FilterOpts) IsDescending() bool {
+	if f.Order == "" {
+		return f.Sort.DefaultDescending()
+	}
+	return f.Order == "desc"
+}
+
+// HasMoreFilters reports whether any filter besides the company, status and tag is set.
+func (f FilterOpts) HasMoreFilters() bool {
+	return f.AppliedFrom != "" || f.AppliedTo != "" || f.SalaryMin > 0 || f.SalaryMax > 0 || f.HasURL || f.NoResponseDays > 0
+}
+
+// Query returns the filters as URL query parameters, leaving out the ones that are not set.
+func (f FilterOpts) Query() url.Values {
+	values := url.Values{}
+	set := func(key string, value string) {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	setInt := func(key string, value int64) {
+		if value > 0 {
+			values.Set(key, strconv.FormatInt(value, 10))
+		}
+	}
+	set("company", f.Company)
+	set("status", f.Status.String())
+	setInt("tag", f.Tag)
+	set("sort", string(f.Sort))
+	set("order", f.Order)
+	set("applied_from", f.AppliedFrom)
+	set("applied_to", f.AppliedTo)
+	setInt("salary_min", f.SalaryMin)
+	setInt("salary_max", f.SalaryMax)
+	setInt("no_response_days", f.NoResponseDays)
+	if f.HasURL {
+		values.Set("has_url", "true")
+	}
+	if f.IsArchived {
+		values.Set("archived", "true")
+	}
+	return values
+}
+
+// PageURL returns the URL of the page listing the job applications matching the filters.
+func (f FilterOpts) PageURL(page int64) string {
+	path := "/"
+	if f.IsArchived {
+		path = "/archives"
+	}
+	query := f.Query()
+	query.Del("archived")
+	if page > 0 {
+		query.Set("page", strconv.FormatInt(page, 10))
+	}
+	if len(query) == 0 {
+		return path
+	}
+	return path + "?" + query.Encode()
+}
+
+// ParseFilterOpts parses the filters from URL query parameters, the inverse of FilterOpts.Query.
+func ParseFilterOpts(values url.Values) (FilterOpts, error) {
+	archivedStr := values.Get("archived")
+	archived := false
+	var err error
+	if archivedStr != "" {
+		archived, err = strconv.ParseBool(archivedStr)
+		if err != nil {
+			return FilterOpts{}, fmt.Errorf("failed to parse archive query: %w", err)
+		}
+	}
+	hasURL := false
+	if hasURLStr := values.Get("has_url"); hasURLStr != "" {
+		hasURL, err = strconv.ParseBool(hasURLStr)
+		if err != nil {
+			return FilterOpts{}, fmt.Errorf("failed to parse has url query: %w", err)
+		}
+	}
+	var tag int64
+	if tagStr := values.Get("tag"); tagStr != "" {
+		tag, err = strconv.ParseInt(tagStr, 10, 64)
+		if err != nil {
+			return FilterOpts{}, fmt.Errorf("failed to parse tag query: %w", err)
+		}
+	}
+	ints := []struct {
+		name  string
+		value int64
+	}{
+		{name: "salary_min"},
+		{name: "salary_max"},
+		{name: "no_response_days"},
+	}
+	for i, n := range ints {
+		val := values.Get(n.name)
+		if val == "" {
+			continue
+		}
+		ints[i].value, err = strconv.ParseInt(val, 10, 64)
+		if err != nil || ints[i].value < 0 {
+			return FilterOpts{}, fmt.Errorf("invalid %s query: %q", n.name, val)
+		}
+	}
+	for _, name := range []string{"applied_from", "applied_to"} {
+		if val := values.Get(name); val != "" {
+			if _, err = time.Parse(FilterDateLayout, val); err != nil {
+				return FilterOpts{}, fmt.Errorf("failed to parse %s query: %w", name, err)
+			}
+		}
+	}
+	sort := JobSort(values.Get("sort"))
+	if !sort.IsValid() {
+		return FilterOpts{}, fmt.Errorf("invalid sort query: %q", sort)
+	}
+	order := values.Get("order")
+	if order != "" && order != "asc" && order != "desc" {
+		return FilterOpts{}, fmt.Errorf("invalid order query: %q", order)
+	}
+	return FilterOpts{
+		Company:        values.Get("company"),
+		Status:         JobApplicationStatus(values.Get("status")),
+		Sort:           sort,
+		Order:          order,
+		AppliedFrom:    values.Get("applied_from"),
+		AppliedTo:      values.Get("applied_to"),
+		Tag:            tag,
+		SalaryMin:      ints[0].value,
+		SalaryMax:      ints[1].value,
+		NoResponseDays: ints[2].value,
+		HasURL:         hasURL,
+		IsArchived:     archived,
+	}, nil
+}
+
+// JobSort is what the list of job applications is sorted by.
+type JobSort string
+
+const (
+	JobSortUpdated JobSort = "updated"
+	JobSortApplied JobSort = "applied"
+	JobSortCompany JobSort = "company"
+	JobSortTitle   JobSort = "title"
+	JobSortStatus  JobSort = "status"
+	JobSortSalary  JobSort = "salary"
+)
+
+// JobSorts are the sorts in the order they are offered.
+var JobSorts = []JobSort{JobSortUpdated, JobSortApplied, JobSortCompany, JobSortTitle, JobSortStatus, JobSortSalary}
+
+// IsValid reports whether the sort is known. An empty sort is the default sort.
+func (s JobSort) IsValid() bool {
+	return s == "" || slices.Contains(JobSorts, s)
+}
+
+// DefaultDescending reports whether the sort is descending unless told otherwise. Dates and
+// salaries start with the latest and highest, names and statuses start from the top.
+func (s JobSort) DefaultDescending() bool {
+	switch s {
+	case JobSortCompany, JobSortTitle, JobSortStatus:
+		return false
+	default:
+		return true
+	}
+}
+
+func (s JobSort) PrettyString() string {
+	switch s {
+	case JobSortApplied:
+		return "Applied date"
+	case JobSortCompany:
+		return "Company"
+	case JobSortTitle:
+		return "Title"
+	case JobSortStatus:
+		return "Status"
+	case JobSortSalary:
+		return "Salary"
+	default:
+		return "Last updated"
+	}
+}
+
+// JobSortSelectOptions returns the sorts as options, leaving the default sort to the placeholder.
+func JobSortSelectOptions() []SelectOption {
+	options := make([]SelectOption, 0, len(JobSorts)-1)
+	for _, s := range JobSorts[1:] {
+		options = append(options, SelectOption{Label: s.PrettyString(), Value: string(s)})
+	}
+	return options
 }
 
 type PaginationOpts struct {
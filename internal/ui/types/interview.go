@@ -0,0 +1,147 @@
+package types
+
+type InterviewType string
+
+const (
+	InterviewTypePhone     InterviewType = "phone"
+	InterviewTypeTechnical InterviewType = "technical"
+	InterviewTypeOnsite    InterviewType = "onsite"
+)
+
+func (i InterviewType) String() string {
+	return string(i)
+}
+
+func (i InterviewType) PrettyString() string {
+	switch i {
+	case InterviewTypePhone:
+		return "Phone"
+	case InterviewTypeTechnical:
+		return "Technical"
+	case InterviewTypeOnsite:
+		return "Onsite"
+	default:
+		return ""
+	}
+}
+
+func ToInterviewType(val string) InterviewType {
+	return interviewTypeMap[val]
+}
+
+var interviewTypeMap = map[string]InterviewType{
+	"phone":     InterviewTypePhone,
+	"technical": InterviewTypeTechnical,
+	"onsite":    InterviewTypeOnsite,
+}
+
+var InterviewTypeSelectOptions = []SelectOption{
+	{
+		Label: "Phone",
+		Value: InterviewTypePhone.String(),
+	},
+	{
+		Label: "Technical",
+		Value: InterviewTypeTechnical.String(),
+	},
+	{
+		Label: "Onsite",
+		Value: InterviewTypeOnsite.String(),
+	},
+}
+
+type InterviewOutcome string
+
+const (
+	InterviewOutcomePending  InterviewOutcome = "pending"
+	InterviewOutcomePassed   InterviewOutcome = "passed"
+	InterviewOutcomeFailed   InterviewOutcome = "failed"
+	InterviewOutcomeCanceled InterviewOutcome = "canceled"
+)
+
+func (i InterviewOutcome) String() string {
+	return string(i)
+}
+
+func (i InterviewOutcome) PrettyString() string {
+	switch i {
+	case InterviewOutcomePending:
+		return "Pending"
+	case InterviewOutcomePassed:
+		return "Passed"
+	case InterviewOutcomeFailed:
+		return "Failed"
+	case InterviewOutcomeCanceled:
+		return "Canceled"
+	default:
+		return ""
+	}
+}
+
+func ToInterviewOutcome(val string) InterviewOutcome {
+	return interviewOutcomeMap[val]
+}
+
+var interviewOutcomeMap = map[string]InterviewOutcome{
+	"pending":  InterviewOutcomePending,
+	"passed":   InterviewOutcomePassed,
+	"failed":   InterviewOutcomeFailed,
+	"canceled": InterviewOutcomeCanceled,
+}
+
+var InterviewOutcomeSelectOptions = []SelectOption{
+	{
+		Label: "Pending",
+		Value: InterviewOutcomePending.String(),
+	},
+	{
+		Label: "Passed",
+		Value: InterviewOutcomePassed.String(),
+	},
+	{
+		Label: "Failed",
+		Value: InterviewOutcomeFailed.String(),
+	},
+	{
+		Label: "Canceled",
+		Value: InterviewOutcomeCanceled.String(),
+	},
+}
+
+// AvailableTimezones is a list of common timezones for the dropdown.
+var AvailableTimezones = []string{
+	"UTC",
+	"America/Los_Angeles",
+	"America/Denver",
+	"America/Phoenix",
+	"America/Chicago",
+	"America/New_York",
+	"America/Halifax",
+	"America/Sao_Paulo",
+	"Europe/London",
+	"Europe/Dublin",
+	"Europe/Lisbon",
+	"Europe/Paris",
+	"Europe/Berlin",
+	"Europe/Amsterdam",
+	"Europe/Madrid",
+	"Europe/Warsaw",
+	"Europe/Helsinki",
+	"Asia/Dubai",
+	"Asia/Kolkata",
+	"Asia/Singapore",
+	"Asia/Shanghai",
+	"Asia/Tokyo",
+	"Australia/Sydney",
+	"Pacific/Auckland",
+}
+
+var TimezoneSelectOptions = timezoneSelectOptions()
+
+func timezoneSelectOptions() []SelectOption {
+	options := make([]SelectOption, len(AvailableTimezones))
+	for i, tz := range AvailableTimezones {
+		options[i] = SelectOption{Label: tz, Value: tz}
+	}
+	return options
+}
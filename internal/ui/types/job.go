@@ -20,6 +20,7 @@ type JobApplication struct {
 	SalaryMin      sql.NullInt64
 	SalaryMax      sql.NullInt64
 	SalaryCurrency sql.NullString
+	Tags           []Tag
 }
 
 func (j JobApplication) RecordID() int64 {
@@ -35,8 +36,11 @@ type JobApplicationTimelineEntry interface {
 type JobApplicationTimelineType string
 
 const (
-	JobApplicationTimelineTypeNote   JobApplicationTimelineType = "note"
-	JobApplicationTimelineTypeStatus JobApplicationTimelineType = "status"
+	JobApplicationTimelineTypeNote       JobApplicationTimelineType = "note"
+	JobApplicationTimelineTypeStatus     JobApplicationTimelineType = "status"
+	JobApplicationTimelineTypeReminder   JobApplicationTimelineType = "reminder"
+	JobApplicationTimelineTypeInterview  JobApplicationTimelineType = "interview"
+	JobApplicationTimelineTypeAttachment JobApplicationTimelineType = "attachment"
 )
 
 func (t JobApplicationTimelineType) String() string {
@@ -48,8 +52,11 @@ func ToJobApplicationTimelineType(val string) JobApplicationTimelineType {
 }
 
 var jobApplicationTimelineTypeMap = map[string]JobApplicationTimelineType{
-	"note":   JobApplicationTimelineTypeNote,
-	"status": JobApplicationTimelineTypeStatus,
+	"note":       JobApplicationTimelineTypeNote,
+	"status":     JobApplicationTimelineTypeStatus,
+	"reminder":   JobApplicationTimelineTypeReminder,
+	"interview":  JobApplicationTimelineTypeInterview,
+	"attachment": JobApplicationTimelineTypeAttachment,
 }
 
 type JobApplicationStatusHistory struct {
@@ -73,11 +80,20 @@ func (j JobApplicationStatusHistory) Created() time.Time {
 }
 
 type JobApplicationNote struct {
-	CreatedAt        time.Time
-	UpdatedAt        time.Time
+	CreatedAt time.Time
+	// UpdatedAt is when the note was last edited. It is zero when the note was never edited.
+	UpdatedAt time.Time
+	// Note is the Markdown text of the note.
 	Note             string
 	ID               int64
 	JobApplicationID int64
+	// ReadOnly is set when the job application is archived, so the note cannot be edited or deleted.
+	ReadOnly bool
+}
+
+// IsEdited reports whether the note was changed after it was added.
+func (j JobApplicationNote) IsEdited() bool {
+	return !j.UpdatedAt.IsZero()
 }
 
 func (j JobApplicationNote) RecordID() int64 {
@@ -92,6 +108,72 @@ func (j JobApplicationNote) Created() time.Time {
 	return j.CreatedAt
 }
 
+type JobApplicationReminder struct {
+	CreatedAt        time.Time
+	RemindAt         time.Time
+	DismissedAt      sql.NullTime
+	Note             string
+	ID               int64
+	JobApplicationID int64
+}
+
+func (j JobApplicationReminder) RecordID() int64 {
+	return j.ID
+}
+
+func (j JobApplicationReminder) Type() JobApplicationTimelineType {
+	return JobApplicationTimelineTypeReminder
+}
+
+func (j JobApplicationReminder) Created() time.Time {
+	return j.CreatedAt
+}
+
+// IsOverdue reports whether the reminder was due before the day of now.
+func (j JobApplicationReminder) IsOverdue(now time.Time) bool {
+	return !j.DismissedAt.Valid && j.RemindAt.Before(now.UTC().Truncate(24*time.Hour))
+}
+
+type DueReminder struct {
+	Company  string
+	Title    string
+	Status   JobApplicationStatus
+	Reminder JobApplicationReminder
+}
+
+type JobApplicationInterview struct {
+	CreatedAt        time.Time
+	ScheduledAt      time.Time
+	Timezone         string
+	InterviewType    InterviewType
+	Outcome          InterviewOutcome
+	Interviewers     string
+	DurationMinutes  int64
+	ID               int64
+	JobApplicationID int64
+}
+
+func (j JobApplicationInterview) RecordID() int64 {
+	return j.ID
+}
+
+func (j JobApplicationInterview) Type() JobApplicationTimelineType {
+	return JobApplicationTimelineTypeInterview
+}
+
+func (j JobApplicationInterview) Created() time.Time {
+	return j.CreatedAt
+}
+
+// LocalScheduledAt returns when the interview is scheduled in the timezone it was scheduled in.
+func (j JobApplicationInterview) LocalScheduledAt() time.Time {
+	loc, err := time.LoadLocation(j.Timezone)
+	if err != nil {
+		return j.ScheduledAt.UTC()
+	}
+	return j.ScheduledAt.In(loc)
+}
+
 type JobApplicationStatus string
 
 const (
@@ -121,19 +203,7 @@ func (j JobApplicationStatus) PrettyString() string {
 	return string(r)
 }
 
-func ToJobApplicationStatus(val string) JobApplicationStatus {
-	return jobApplicationStatusMap[val]
-}
-
-var jobApplicationStatusMap = map[string]JobApplicationStatus{
-	"accepted":     JobApplicationStatusAccepted,
-	"applied":      JobApplicationStatusApplied,
-	"canceled":     JobApplicationStatusCanceled,
-	"closed":       JobApplicationStatusClosed,
-	"declined":     JobApplicationStatusDeclined,
-	"interviewing": JobApplicationStatusInterviewing,
-	"offered":      JobApplicationStatusOffered,
-	"rejected":     JobApplicationStatusRejected,
-	"watching":     JobApplicationStatusWatching,
-	"withdrawn":    JobApplicationStatusWithdrawn,
+// IsDefault reports whether the status is one of the statuses of the default pipeline.
+func (j JobApplicationStatus) IsDefault() bool {
+	return DefaultPipeline.Contains(j)
 }
@@ -26,6 +26,7 @@ type JobListingDetails struct {
 
 	TechStacks []string
 	HasAdded   bool
+	CompanyID  int64
 }
 
 type JobListingFilterOpts struct {
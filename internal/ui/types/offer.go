@@ -0,0 +1,61 @@
+package types
+
+// Offer is the offer of a job application. Amounts are whole units of the currency of the offer
+// and the equity prices are per share.
+type Offer struct {
+	Company            string
+	Title              string
+	Currency           string
+	Benefits           string
+	Deadline           string
+	Status             JobApplicationStatus
+	BaseSalary         int64
+	Bonus              int64
+	SignOnBonus        int64
+	EquityShares       int64
+	EquityStrikePrice  float64
+	EquitySharePrice   float64
+	VestingYears       int64
+	VestingCliffMonths int64
+	FirstYear          int64
+	FourYear           int64
+	ID                 int64
+	JobApplicationID   int64
+}
+
+// OfferOpts is the offer section of a job application.
+type OfferOpts struct {
+	Offer            Offer
+	Status           JobApplicationStatus
+	JobApplicationID int64
+	HasOffer         bool
+}
+
+// ComparedOffer is an offer with its total compensation converted to the currency offers are
+// compared in. Converted is false when there is no exchange rate for the currency of the offer.
+type ComparedOffer struct {
+	Offer
+	ConvertedFirstYear int64
+	ConvertedFourYear  int64
+	Converted          bool
+}
+
+// OfferComparison compares the offers of a user side by side.
+type OfferComparison struct {
+	Currency string
+	Offers   []ComparedOffer
+	Rates    []ExchangeRate
+}
+
+// ExchangeRate is how much one unit of a currency is worth in the BaseCurrency.
+type ExchangeRate struct {
+	Currency string
+	Rate     float64
+}
+
+// CurrencySettings is the display currency of a user and the exchange rates used to convert
+// amounts to it.
+type CurrencySettings struct {
+	DisplayCurrency string
+	Rates           []ExchangeRate
+}
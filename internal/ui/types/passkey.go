@@ -0,0 +1,11 @@
+package types
+
+import "time"
+
+// Passkey is a passkey the user can sign in with. LastUsedAt is zero until it is first used.
+type Passkey struct {
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	Name       string
+	ID         int64
+}
@@ -0,0 +1,68 @@
+package types
+
+// PipelineStatus is a stage of the status pipeline of a user. Job applications end at terminal
+// stages, such as being rejected or accepting an offer.
+type PipelineStatus struct {
+	Name     JobApplicationStatus
+	ID       int64
+	Position int64
+	Terminal bool
+}
+
+// Pipeline is the ordered list of statuses a user tracks job applications through.
+type Pipeline []PipelineStatus
+
+// Get returns the stage of the status.
+func (p Pipeline) Get(status JobApplicationStatus) (PipelineStatus, bool) {
+	for _, s := range p {
+		if s.Name == status {
+			return s, true
+		}
+	}
+	return PipelineStatus{}, false
+}
+
+// GetByID returns the stage with the ID.
+func (p Pipeline) GetByID(id int64) (PipelineStatus, bool) {
+	for _, s := range p {
+		if s.ID == id {
+			return s, true
+		}
+	}
+	return PipelineStatus{}, false
+}
+
+// Contains reports whether the status is a stage of the pipeline.
+func (p Pipeline) Contains(status JobApplicationStatus) bool {
+	_, ok := p.Get(status)
+	return ok
+}
+
+func (p Pipeline) SelectOptions() []SelectOption {
+	options := make([]SelectOption, len(p))
+	for i, s := range p {
+		options[i] = SelectOption{Label: s.Name.PrettyString(), Value: s.Name.String()}
+	}
+	return options
+}
+
+// DefaultPipeline is the pipeline users start with.
+var DefaultPipeline = Pipeline{
+	{Name: JobApplicationStatusWatching, Position: 1},
+	{Name: JobApplicationStatusApplied, Position: 2},
+	{Name: JobApplicationStatusInterviewing, Position: 3},
+	{Name: JobApplicationStatusOffered, Position: 4},
+	{Name: JobApplicationStatusAccepted, Position: 5, Terminal: true},
+	{Name: JobApplicationStatusDeclined, Position: 6, Terminal: true},
+	{Name: JobApplicationStatusRejected, Position: 7, Terminal: true},
+	{Name: JobApplicationStatusWithdrawn, Position: 8, Terminal: true},
+	{Name: JobApplicationStatusCanceled, Position: 9, Terminal: true},
+	{Name: JobApplicationStatusClosed, Position: 10, Terminal: true},
+}
+
+// PipelineStageStat is the number of job applications currently at a stage of the pipeline.
+type PipelineStageStat struct {
+	Status     PipelineStatus
+	Count      int64
+	Percentage string
+}
@@ -0,0 +1,77 @@
+package types
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// ResumeVersion is a named version of the resume of a user, optionally with the file that was sent.
+type ResumeVersion struct {
+	CreatedAt time.Time
+	Label     string
+	FileName  string
+	Size      int64
+	ID        int64
+}
+
+// HasFile reports whether a file was uploaded for the version.
+func (r ResumeVersion) HasFile() bool {
+	return r.FileName != ""
+}
+
+// URL is where the file of the version is downloaded from.
+func (r ResumeVersion) URL() string {
+	return "/settings/resumes/" + strconv.FormatInt(r.ID, 10) + "/file"
+}
+
+// PrettySize returns the size of the file in bytes, KB or MB.
+func (r ResumeVersion) PrettySize() string {
+	return prettySize(r.Size)
+}
+
+// ResumeVersionOpts is the resume version of a job application together with every version of the
+// user to choose from. Selected is 0 when the job application has no version.
+type ResumeVersionOpts struct {
+	JobApplicationID int64
+	Versions         []ResumeVersion
+	Selected         int64
+}
+
+// SelectedLabel returns the label of the selected version.
+func (o ResumeVersionOpts) SelectedLabel() string {
+	for _, v := range o.Versions {
+		if v.ID == o.Selected {
+			return v.Label
+		}
+	}
+	return ""
+}
+
+// ResumeVersionStats is how the job applications sent with a resume version fared. A job
+// application counts as responded to once it moved on from applied, and as interviewed once it
+// reached interviewing.
+type ResumeVersionStats struct {
+	Label       string
+	ID          int64
+	Applied     int64
+	Responded   int64
+	Interviewed int64
+}
+
+// ResponseRate returns the share of job applications that were responded to as a percentage.
+func (s ResumeVersionStats) ResponseRate() string {
+	return rate(s.Responded, s.Applied)
+}
+
+// InterviewRate returns the share of job applications that led to an interview as a percentage.
+func (s ResumeVersionStats) InterviewRate() string {
+	return rate(s.Interviewed, s.Applied)
+}
+
+func rate(value int64, total int64) string {
+	if total == 0 {
+		return "—"
+	}
+	return strconv.FormatFloat(math.Round(float64(value)/float64(total)*100), 'f', 0, 64) + "%"
+}
@@ -0,0 +1,47 @@
+package types
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// SearchResult is a job application matching a full-text search, with the matched terms highlighted.
+type SearchResult struct {
+	Job          JobApplication
+	Company      HighlightedText
+	Title        HighlightedText
+	NotesSnippet HighlightedText
+}
+
+// HighlightedText is text split into the parts that matched a search and the parts that did not.
+type HighlightedText []HighlightedPart
+
+type HighlightedPart struct {
+	Text  string
+	Match bool
+}
+
+type SearchOpts struct {
+	Query    string
+	Results  []SearchResult
+	Total    int64
+	Page     int64
+	PerPage  int64
+	Archived bool
+}
+
+// PageURL is the URL of the page of results for the same search.
+func (o SearchOpts) PageURL(page int64) string {
+	values := url.Values{
+		"q":        {o.Query},
+		"archived": {strconv.FormatBool(o.Archived)},
+		"page":     {strconv.FormatInt(page, 10)},
+		"per_page": {strconv.FormatInt(o.PerPage, 10)},
+	}
+	return "/search/results?" + values.Encode()
+}
+
+// HasNext reports whether there are results after the current page.
+func (o SearchOpts) HasNext() bool {
+	return (o.Page+1)*o.PerPage < o.Total
+}
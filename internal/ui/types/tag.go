@@ -0,0 +1,86 @@
+package types
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Tag is a user defined label job applications can be grouped by, such as "referral" or "contract".
+type Tag struct {
+	Name  string
+	Color TagColor
+	ID    int64
+}
+
+// TagColor is the color a tag is shown in.
+type TagColor string
+
+const (
+	TagColorGray   TagColor = "gray"
+	TagColorRed    TagColor = "red"
+	TagColorYellow TagColor = "yellow"
+	TagColorGreen  TagColor = "green"
+	TagColorBlue   TagColor = "blue"
+	TagColorIndigo TagColor = "indigo"
+	TagColorPurple TagColor = "purple"
+	TagColorPink   TagColor = "pink"
+)
+
+// TagColors are the colors a tag can be shown in.
+var TagColors = []TagColor{
+	TagColorGray,
+	TagColorRed,
+	TagColorYellow,
+	TagColorGreen,
+	TagColorBlue,
+	TagColorIndigo,
+	TagColorPurple,
+	TagColorPink,
+}
+
+// IsValid reports whether the color is one of the colors a tag can be shown in.
+func (c TagColor) IsValid() bool {
+	return slices.Contains(TagColors, c)
+}
+
+func (c TagColor) String() string {
+	return string(c)
+}
+
+// TagsOpts are the tags of a job application together with every tag of the user to choose from.
+type TagsOpts struct {
+	JobApplicationID int64
+	Tags             []Tag
+	Selected         []Tag
+}
+
+// IsSelected reports whether the job application has the tag.
+func (o TagsOpts) IsSelected(id int64) bool {
+	for _, tag := range o.Selected {
+		if tag.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// TagSelectOptions returns the tags as options of a select, valued by their ID.
+func TagSelectOptions(tags []Tag) []SelectOption {
+	options := make([]SelectOption, len(tags))
+	for i, tag := range tags {
+		options[i] = SelectOption{Label: tag.Name, Value: strconv.FormatInt(tag.ID, 10)}
+	}
+	return options
+}
+
+// TagColorSelectOptions are the colors a tag can be shown in as options of a select.
+var TagColorSelectOptions = tagColorSelectOptions()
+
+func tagColorSelectOptions() []SelectOption {
+	options := make([]SelectOption, len(TagColors))
+	for i, color := range TagColors {
+		options[i] = SelectOption{Label: strings.ToUpper(color.String()[:1]) + color.String()[1:], Value: color.String()}
+	}
+	return options
+}
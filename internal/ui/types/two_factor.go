@@ -0,0 +1,17 @@
+package types
+
+import "time"
+
+// TwoFactor is the state of two-factor authentication of a user.
+type TwoFactor struct {
+	EnabledAt         time.Time
+	RecoveryCodesLeft int64
+	Enabled           bool
+}
+
+// TwoFactorEnrollment is the secret of an authenticator app being set up, both as text and as a QR
+// code image.
+type TwoFactorEnrollment struct {
+	Secret string
+	QRCode string
+}
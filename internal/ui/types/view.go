@@ -0,0 +1,10 @@
+package types
+
+// SavedView is a named combination of filters of the list of job applications.
+type SavedView struct {
+	Name    string
+	Filters FilterOpts
+	// Count is the number of job applications currently matching the filters.
+	Count int64
+	ID    int64
+}
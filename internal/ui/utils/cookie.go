@@ -26,3 +26,39 @@ func SetSessionCookie(w http.ResponseWriter, value string, expires time.Time) {
 func ClearSessionCookie(w http.ResponseWriter) {
 	SetSessionCookie(w, "", time.Now().Add(-1*time.Hour))
 }
+
+// SetTwoFactorCookie stores the challenge of the second sign-in step. It uses a max age rather than
+// an expiry so it does not depend on the clock of the server matching the clock of the browser.
+func SetTwoFactorCookie(w http.ResponseWriter, value string, maxAge time.Duration) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     "two_factor",
+		Value:    value,
+		MaxAge:   int(maxAge.Seconds()),
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+		Path:     "/signin",
+		Secure:   IsProduction(),
+	})
+}
+
+func ClearTwoFactorCookie(w http.ResponseWriter) {
+	SetTwoFactorCookie(w, "", -1*time.Second)
+}
+
+// SetPasskeyCookie stores the ceremony of a passkey being added or used to sign in until the
+// browser returns the answer of the authenticator.
+func SetPasskeyCookie(w http.ResponseWriter, value string, maxAge time.Duration) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     "passkey",
+		Value:    value,
+		MaxAge:   int(maxAge.Seconds()),
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+		Path:     "/",
+		Secure:   IsProduction(),
+	})
+}
+
+func ClearPasskeyCookie(w http.ResponseWriter) {
+	SetPasskeyCookie(w, "", -1*time.Second)
+}
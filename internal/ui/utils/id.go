@@ -10,6 +10,14 @@ func JobRowMetadata(id int64) string {
 	return "job-" + strconv.FormatInt(id, 10) + "-row-metadata"
 }
 
+func JobSelectID(id int64) string {
+	return "job-" + strconv.FormatInt(id, 10) + "-select"
+}
+
+func JobTagsID(id int64) string {
+	return "job-" + strconv.FormatInt(id, 10) + "-tags"
+}
+
 func TimelineStatusRowID(id int64) string {
 	return "timeline-status-" + strconv.FormatInt(id, 10) + "-row"
 }
@@ -25,3 +33,47 @@ func TimelineNoteRowID(id int64) string {
 func TimelineNoteRowStringID(id string) string {
 	return "timeline-note-" + id + "-row"
 }
+
+func TimelineNoteContentID(id int64) string {
+	return "timeline-note-" + strconv.FormatInt(id, 10) + "-content"
+}
+
+func TimelineReminderRowID(id int64) string {
+	return "timeline-reminder-" + strconv.FormatInt(id, 10) + "-row"
+}
+
+func TimelineReminderRowStringID(id string) string {
+	return "timeline-reminder-" + id + "-row"
+}
+
+func TimelineInterviewRowID(id int64) string {
+	return "timeline-interview-" + strconv.FormatInt(id, 10) + "-row"
+}
+
+func TimelineInterviewRowStringID(id string) string {
+	return "timeline-interview-" + id + "-row"
+}
+
+func TimelineAttachmentRowID(id int64) string {
+	return "timeline-attachment-" + strconv.FormatInt(id, 10) + "-row"
+}
+
+func BoardColumnID(statusID int64) string {
+	return "board-column-" + strconv.FormatInt(statusID, 10)
+}
+
+func BoardColumnCountID(statusID int64) string {
+	return "board-column-" + strconv.FormatInt(statusID, 10) + "-count"
+}
+
+func BoardCardsID(statusID int64) string {
+	return "board-column-" + strconv.FormatInt(statusID, 10) + "-cards"
+}
+
+func BoardCardID(jobID int64) string {
+	return "board-job-" + strconv.FormatInt(jobID, 10)
+}
+
+func SearchResultID(jobID int64) string {
+	return "search-result-" + strconv.FormatInt(jobID, 10)
+}
@@ -224,3 +224,297 @@ func TestTimelineNoteRowStringID(t *testing.T) {
 		})
 	}
 }
+
+func TestTimelineNoteContentID(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		id       int64
+		expected string
+	}{
+		{
+			name:     "positive id",
+			id:       456,
+			expected: "timeline-note-456-content",
+		},
+		{
+			name:     "zero id",
+			id:       0,
+			expected: "timeline-note-0-content",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := utils.TimelineNoteContentID(tt.id)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestTimelineReminderRowID(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		id       int64
+		expected string
+	}{
+		{
+			name:     "positive id",
+			id:       321,
+			expected: "timeline-reminder-321-row",
+		},
+		{
+			name:     "zero id",
+			id:       0,
+			expected: "timeline-reminder-0-row",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := utils.TimelineReminderRowID(tt.id)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestTimelineReminderRowStringID(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		id       string
+		expected string
+	}{
+		{
+			name:     "numeric string",
+			id:       "654",
+			expected: "timeline-reminder-654-row",
+		},
+		{
+			name:     "empty string",
+			id:       "",
+			expected: "timeline-reminder--row",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := utils.TimelineReminderRowStringID(tt.id)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestTimelineInterviewRowID(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		id       int64
+		expected string
+	}{
+		{
+			name:     "positive id",
+			id:       987,
+			expected: "timeline-interview-987-row",
+		},
+		{
+			name:     "zero id",
+			id:       0,
+			expected: "timeline-interview-0-row",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := utils.TimelineInterviewRowID(tt.id)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestTimelineInterviewRowStringID(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		id       string
+		expected string
+	}{
+		{
+			name:     "numeric string",
+			id:       "246",
+			expected: "timeline-interview-246-row",
+		},
+		{
+			name:     "empty string",
+			id:       "",
+			expected: "timeline-interview--row",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := utils.TimelineInterviewRowStringID(tt.id)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestTimelineAttachmentRowID(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		id       int64
+		expected string
+	}{
+		{
+			name:     "positive id",
+			id:       987,
+			expected: "timeline-attachment-987-row",
+		},
+		{
+			name:     "zero id",
+			id:       0,
+			expected: "timeline-attachment-0-row",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := utils.TimelineAttachmentRowID(tt.id)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestBoardIDs(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		fn       func(int64) string
+		id       int64
+		expected string
+	}{
+		{
+			name:     "column",
+			fn:       utils.BoardColumnID,
+			id:       12,
+			expected: "board-column-12",
+		},
+		{
+			name:     "column count",
+			fn:       utils.BoardColumnCountID,
+			id:       12,
+			expected: "board-column-12-count",
+		},
+		{
+			name:     "column cards",
+			fn:       utils.BoardCardsID,
+			id:       12,
+			expected: "board-column-12-cards",
+		},
+		{
+			name:     "card",
+			fn:       utils.BoardCardID,
+			id:       345,
+			expected: "board-job-345",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, tt.fn(tt.id))
+		})
+	}
+}
+
+func TestSearchResultID(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		id       int64
+		expected string
+	}{
+		{
+			name:     "positive id",
+			id:       42,
+			expected: "search-result-42",
+		},
+		{
+			name:     "zero id",
+			id:       0,
+			expected: "search-result-0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := utils.SearchResultID(tt.id)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestJobSelectID(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		id       int64
+		expected string
+	}{
+		{
+			name:     "positive id",
+			id:       42,
+			expected: "job-42-select",
+		},
+		{
+			name:     "zero id",
+			id:       0,
+			expected: "job-0-select",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := utils.JobSelectID(tt.id)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestJobTagsID(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		id       int64
+		expected string
+	}{
+		{
+			name:     "positive id",
+			id:       42,
+			expected: "job-42-tags",
+		},
+		{
+			name:     "zero id",
+			id:       0,
+			expected: "job-0-tags",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := utils.JobTagsID(tt.id)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}